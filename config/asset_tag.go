@@ -0,0 +1,24 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+func GetAssetTagPrefix() string {
+	prefix := os.Getenv("ASSET_TAG_PREFIX")
+	if prefix == "" {
+		return "PLT"
+	}
+
+	return prefix
+}
+
+func GetAssetTagSequenceLength() int {
+	length, err := strconv.Atoi(os.Getenv("ASSET_TAG_SEQUENCE_LENGTH"))
+	if err != nil || length <= 0 {
+		return 6
+	}
+
+	return length
+}
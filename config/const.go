@@ -1,8 +1,20 @@
 package config
 
+import "time"
+
 type Config struct {
 	MaxSizeFile     int64
 	AllowedFileType []string
+	ThumbnailSize   int // Longest Side In Pixel
+	MaxImagePixel   int // Width x Height, Checked Before The Image Is Decoded
+	MaxAssetImage   int
+}
+type LoginAttemptConfig struct {
+	MaxAttemptPerEmail int64
+	MaxAttemptPerIP    int64 // Higher Than Per Email, So A Shared IP Address Is Not Locked By A Few Mistyped Password
+	AttemptWindow      time.Duration
+	BaseLockDuration   time.Duration
+	MaxLockDuration    time.Duration
 }
 
 var ResponseMessages = map[string]string{
@@ -14,13 +26,60 @@ var ResponseMessages = map[string]string{
 	"get":         "fetched",
 	"login":       "login",
 	"sign out":    "signed out",
+	"refresh":     "refreshed",
+	"revoke":      "revoked",
+	"request":     "requested",
+	"unlock":      "unlocked",
+	"reset":       "reset",
+	"deactivate":  "deactivated",
+	"activate":    "activated",
+	"unlink":      "unlinked",
+	"handle":      "handled",
+	"approve":     "approved",
+	"reject":      "rejected",
+	"check out":   "checked out",
+	"return":      "returned",
+	"import":      "imported",
+	"validate":    "validated",
+	"merge":       "merged",
 }
 var Departments = []string{"IT", "Human Resource", "Finance & Risk Management", "Marketing", "Sales", "Planning & Transformation", "Network"}
 var Floors = []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13"}
-var AssetStatus = []string{"available", "in-use", "maintenance"}
+var AssetStatus = []string{"procured", "available", "in-use", "maintenance", "retired", "disposed"}
+var AssetInitialStatus = []string{"procured", "available"}
+var AssetStatusTransitions = map[string][]string{
+	"procured":    {"available"},
+	"available":   {"in-use", "maintenance", "retired"},
+	"in-use":      {"available", "maintenance"},
+	"maintenance": {"available", "retired"},
+	"retired":     {"disposed"},
+	"disposed":    {},
+}
+var Currencies = []string{"IDR", "USD", "EUR", "SGD", "JPY"}
+var DepreciationMethods = []string{"straight-line", "declining-balance"}
+var AssetImportColumns = []string{"asset_name", "asset_desc", "asset_merk", "asset_category", "asset_price", "asset_currency", "asset_status", "purchase_date", "useful_life", "salvage_value", "depreciation_method", "floor", "room_name", "asset_qty", "owner_email"}
+var AssetImportFileType = []string{"csv", "xlsx"}
+var AssetCategoryFieldTypes = []string{"string", "number", "date", "boolean", "enum"}
+var AssetDuplicateSimilarity = 0.85 // Minimum Name Similarity, Between 0 And 1, For An Asset To Be Reported As Duplicate
+var WarrantyTypes = []string{"warranty", "support-contract"}
+var WarrantyReminderDays = 30
+var LoanStatus = []string{"requested", "approved", "rejected", "checked-out", "returned"}
+var TransferStatus = []string{"requested", "approved", "rejected"}
+var LoanReturnConditions = []string{"good", "damaged"}
+var UnitConditions = []string{"good", "fair", "damaged", "broken"}
 var FindingCategories = []string{"broken", "missing", "upgrade", "feedback"}
 var Days = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
 var ConfigFile = Config{
 	MaxSizeFile:     10000000, // 10 MB
-	AllowedFileType: []string{"jpg", "jpeg"},
+	AllowedFileType: []string{"jpg", "jpeg", "png", "webp"},
+	ThumbnailSize:   320,
+	MaxImagePixel:   40000000, // 40 MP
+	MaxAssetImage:   10,
+}
+var LoginAttempt = LoginAttemptConfig{
+	MaxAttemptPerEmail: 5,
+	MaxAttemptPerIP:    100,
+	AttemptWindow:      15 * time.Minute,
+	BaseLockDuration:   1 * time.Minute, // Doubled on every next lockout
+	MaxLockDuration:    24 * time.Hour,
 }
@@ -13,7 +13,47 @@ func GetJWTExpirationDuration() time.Duration {
 	duration, err := time.ParseDuration(os.Getenv("JWT_EXPIRES_IN"))
 
 	if err != nil {
-		return time.Hour * 24
+		return time.Minute * 15
+	}
+
+	return duration
+}
+
+func GetJWTRefreshExpirationDuration() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("JWT_REFRESH_EXPIRES_IN"))
+
+	if err != nil {
+		return time.Hour * 24 * 7
+	}
+
+	return duration
+}
+
+func GetPasswordResetExpirationDuration() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("PASSWORD_RESET_EXPIRES_IN"))
+
+	if err != nil {
+		return time.Minute * 15
+	}
+
+	return duration
+}
+
+func GetDeleteConfirmationExpirationDuration() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("DELETE_CONFIRMATION_EXPIRES_IN"))
+
+	if err != nil {
+		return time.Minute * 10
+	}
+
+	return duration
+}
+
+func GetEmailChangeExpirationDuration() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("EMAIL_CHANGE_EXPIRES_IN"))
+
+	if err != nil {
+		return time.Hour * 1
 	}
 
 	return duration
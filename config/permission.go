@@ -0,0 +1,147 @@
+package config
+
+// Permission Registry
+const (
+	PermissionAssetRead              = "asset:read"
+	PermissionAssetReadDeleted       = "asset:read_deleted"
+	PermissionAssetCreate            = "asset:create"
+	PermissionAssetUpdate            = "asset:update"
+	PermissionAssetDelete            = "asset:delete"
+	PermissionAssetDestroy           = "asset:destroy"
+	PermissionAssetRecover           = "asset:recover"
+	PermissionAssetMerge             = "asset:merge"
+	PermissionAssetValue             = "asset:value"
+	PermissionAssetImport            = "asset:import"
+	PermissionAssetExport            = "asset:export"
+	PermissionAssetStats             = "asset:stats"
+	PermissionPlacementRead          = "placement:read"
+	PermissionPlacementCreate        = "placement:create"
+	PermissionPlacementUpdate        = "placement:update"
+	PermissionPlacementDelete        = "placement:delete"
+	PermissionPlacementReadDeleted   = "placement:read_deleted"
+	PermissionPlacementDestroy       = "placement:destroy"
+	PermissionPlacementRecover       = "placement:recover"
+	PermissionAssetTagRead           = "asset_tag:read"
+	PermissionUnitRead               = "unit:read"
+	PermissionUnitCreate             = "unit:create"
+	PermissionUnitUpdate             = "unit:update"
+	PermissionUnitMove               = "unit:move"
+	PermissionUnitDelete             = "unit:delete"
+	PermissionMaintenanceRead        = "maintenance:read"
+	PermissionMaintenanceCreate      = "maintenance:create"
+	PermissionMaintenanceUpdate      = "maintenance:update"
+	PermissionMaintenanceDelete      = "maintenance:delete"
+	PermissionMaintenanceReadDeleted = "maintenance:read_deleted"
+	PermissionMaintenanceDestroy     = "maintenance:destroy"
+	PermissionMaintenanceRecover     = "maintenance:recover"
+	PermissionMaintenanceStats       = "maintenance:stats"
+	PermissionFindingRead            = "finding:read"
+	PermissionFindingCreate          = "finding:create"
+	PermissionFindingDelete          = "finding:delete"
+	PermissionFindingReadDeleted     = "finding:read_deleted"
+	PermissionFindingDestroy         = "finding:destroy"
+	PermissionFindingRecover         = "finding:recover"
+	PermissionFindingStats           = "finding:stats"
+	PermissionRoomRead               = "room:read"
+	PermissionRoomReadAsset          = "room:read_asset"
+	PermissionRoomCreate             = "room:create"
+	PermissionRoomUpdate             = "room:update"
+	PermissionRoomDelete             = "room:delete"
+	PermissionRoomReadDeleted        = "room:read_deleted"
+	PermissionRoomDestroy            = "room:destroy"
+	PermissionRoomRecover            = "room:recover"
+	PermissionRoomStats              = "room:stats"
+	PermissionTechnicianRead         = "technician:read"
+	PermissionTechnicianCreate       = "technician:create"
+	PermissionTechnicianUpdate       = "technician:update"
+	PermissionTechnicianDelete       = "technician:delete"
+	PermissionTechnicianReadDeleted  = "technician:read_deleted"
+	PermissionTechnicianDestroy      = "technician:destroy"
+	PermissionTechnicianRecover      = "technician:recover"
+	PermissionHistoryRead            = "history:read"
+	PermissionHistoryStats           = "history:stats"
+	PermissionAdminRead              = "admin:read"
+	PermissionAdminCreate            = "admin:create"
+	PermissionAdminUpdate            = "admin:update"
+	PermissionAdminDelete            = "admin:delete"
+	PermissionAccountUnlock          = "account:unlock"
+	PermissionRoleRead               = "role:read"
+	PermissionRoleManage             = "role:manage"
+	PermissionLoanRead               = "loan:read"
+	PermissionLoanRequest            = "loan:request"
+	PermissionLoanApprove            = "loan:approve"
+	PermissionLoanCheckout           = "loan:checkout"
+	PermissionTransferRead           = "transfer:read"
+	PermissionTransferRequest        = "transfer:request"
+	PermissionTransferApprove        = "transfer:approve"
+	PermissionCategoryRead           = "category:read"
+	PermissionCategoryManage         = "category:manage"
+	PermissionVendorRead             = "vendor:read"
+	PermissionVendorManage           = "vendor:manage"
+	PermissionWarrantyRead           = "warranty:read"
+	PermissionWarrantyManage         = "warranty:manage"
+	PermissionApiKeyRead             = "api_key:read"
+	PermissionApiKeyManage           = "api_key:manage"
+)
+
+// System Role (Account Type)
+const (
+	RoleAdmin      = "admin"
+	RoleTechnician = "technician"
+	RoleGuest      = "guest"
+	RoleApiKey     = "api_key" // Machine To Machine Caller, Not An Account Type
+)
+
+var Permissions = []string{
+	PermissionAssetRead, PermissionAssetReadDeleted, PermissionAssetCreate, PermissionAssetUpdate, PermissionAssetDelete, PermissionAssetDestroy, PermissionAssetRecover, PermissionAssetMerge, PermissionAssetStats, PermissionAssetValue, PermissionAssetImport, PermissionAssetExport,
+	PermissionPlacementRead, PermissionPlacementCreate, PermissionPlacementUpdate, PermissionPlacementDelete, PermissionPlacementReadDeleted, PermissionPlacementDestroy, PermissionPlacementRecover,
+	PermissionAssetTagRead,
+	PermissionUnitRead, PermissionUnitCreate, PermissionUnitUpdate, PermissionUnitMove, PermissionUnitDelete,
+	PermissionMaintenanceRead, PermissionMaintenanceCreate, PermissionMaintenanceUpdate, PermissionMaintenanceDelete, PermissionMaintenanceReadDeleted, PermissionMaintenanceDestroy, PermissionMaintenanceRecover, PermissionMaintenanceStats,
+	PermissionFindingRead, PermissionFindingCreate, PermissionFindingDelete, PermissionFindingReadDeleted, PermissionFindingDestroy, PermissionFindingRecover, PermissionFindingStats,
+	PermissionLoanRead, PermissionLoanRequest, PermissionLoanApprove, PermissionLoanCheckout,
+	PermissionTransferRead, PermissionTransferRequest, PermissionTransferApprove,
+	PermissionCategoryRead, PermissionCategoryManage,
+	PermissionVendorRead, PermissionVendorManage,
+	PermissionWarrantyRead, PermissionWarrantyManage,
+	PermissionRoomRead, PermissionRoomReadAsset, PermissionRoomCreate, PermissionRoomUpdate, PermissionRoomDelete, PermissionRoomReadDeleted, PermissionRoomDestroy, PermissionRoomRecover, PermissionRoomStats,
+	PermissionTechnicianRead, PermissionTechnicianCreate, PermissionTechnicianUpdate, PermissionTechnicianDelete, PermissionTechnicianReadDeleted, PermissionTechnicianDestroy, PermissionTechnicianRecover,
+	PermissionHistoryRead, PermissionHistoryStats,
+	PermissionAdminRead, PermissionAdminCreate, PermissionAdminUpdate, PermissionAdminDelete,
+	PermissionAccountUnlock,
+	PermissionRoleRead, PermissionRoleManage,
+	PermissionApiKeyRead, PermissionApiKeyManage,
+}
+var SystemRoles = []string{RoleAdmin, RoleTechnician, RoleGuest}
+
+// Scope That Can Be Granted To Api Key, Only Asset And Room Routes Accept Api Key
+var ApiKeyScopes = []string{
+	PermissionAssetRead, PermissionAssetStats, PermissionAssetValue, PermissionAssetExport,
+	PermissionPlacementRead, PermissionCategoryRead,
+	PermissionAssetTagRead, PermissionUnitRead,
+	PermissionMaintenanceRead, PermissionMaintenanceStats,
+	PermissionFindingRead, PermissionFindingCreate, PermissionFindingStats,
+	PermissionRoomRead, PermissionRoomReadAsset, PermissionRoomStats,
+}
+
+// Default Role Permission, Only Used When The System Role Is Seeded For The First Time. Admin Always Get Every Permission
+var DefaultRolePermissions = map[string][]string{
+	RoleAdmin: Permissions,
+	RoleTechnician: {
+		PermissionAssetReadDeleted,
+		PermissionPlacementRead, PermissionPlacementUpdate,
+		PermissionAssetTagRead, PermissionUnitRead, PermissionUnitUpdate,
+		PermissionMaintenanceRead,
+		PermissionFindingRead, PermissionFindingCreate,
+		PermissionLoanRead, PermissionLoanRequest, PermissionLoanCheckout,
+		PermissionTransferRead, PermissionTransferRequest,
+		PermissionCategoryRead, PermissionVendorRead, PermissionWarrantyRead,
+		PermissionRoomRead, PermissionRoomReadAsset,
+		PermissionTechnicianRead,
+	},
+	RoleGuest: {
+		PermissionFindingCreate,
+		PermissionLoanRequest,
+		PermissionRoomRead,
+	},
+}
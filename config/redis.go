@@ -11,6 +11,21 @@ var (
 	RedisClient *redis.Client
 )
 
+// Redis Key Format
+const (
+	RedisKeySession        = "session:%s"
+	RedisKeyAccountSession = "account_session:%s"
+	RedisKeyRefreshToken   = "refresh_token:%s"
+	RedisKeyPasswordReset  = "password_reset:%s"
+	RedisKeyRevokedAccount = "revoked_account:%s"
+	RedisKeyLoginAttempt   = "login_attempt:%s"
+	RedisKeyLoginLock      = "login_lock:%s"
+	RedisKeyLoginLockCount = "login_lock_count:%s"
+	RedisKeyTelegramLink   = "telegram_link:%s"
+	RedisKeyEmailChange    = "email_change:%s"
+	RedisKeyDeleteConfirm  = "delete_confirmation:%s"
+)
+
 func InitRedis() *redis.Client {
 	RedisClient = redis.NewClient(&redis.Options{
 		Addr:     "localhost:6379",
@@ -0,0 +1,17 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// Soft Deleted Row Older Than This Is Permanently Removed By The Purge Scheduler
+func GetSoftDeleteRetentionDuration() time.Duration {
+	day, err := strconv.Atoi(os.Getenv("SOFT_DELETE_RETENTION_DAY"))
+	if err != nil || day <= 0 {
+		return 30 * 24 * time.Hour
+	}
+
+	return time.Duration(day) * 24 * time.Hour
+}
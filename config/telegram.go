@@ -0,0 +1,34 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+func GetTelegramBotToken() string {
+	return os.Getenv("TELEGRAM_BOT_TOKEN")
+}
+
+func GetTelegramWebhookSecret() string {
+	return os.Getenv("TELEGRAM_WEBHOOK_SECRET")
+}
+
+func GetTelegramLinkExpirationDuration() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("TELEGRAM_LINK_EXPIRES_IN"))
+
+	if err != nil {
+		return time.Minute * 10
+	}
+
+	return duration
+}
+
+// Either "webhook" Or "polling"
+func GetTelegramUpdateMode() string {
+	mode := os.Getenv("TELEGRAM_UPDATE_MODE")
+	if mode == "" {
+		return "webhook"
+	}
+
+	return mode
+}
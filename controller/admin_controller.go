@@ -0,0 +1,205 @@
+package controller
+
+import (
+	"math"
+	"net/http"
+	"pelita/entity"
+	"pelita/service"
+	"pelita/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AdminController struct {
+	AdminService service.AdminService
+}
+
+func NewAdminController(adminService service.AdminService) *AdminController {
+	return &AdminController{AdminService: adminService}
+}
+
+// @Summary      Get All Admin
+// @Description  Returns a paginated list of admin
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetAllAdmin
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/admins [get]
+func (rc *AdminController) GetAllAdmin(c *gin.Context) {
+	// Pagination
+	pagination := utils.GetPagination(c)
+
+	// Service: Get All Admin
+	admin, total, err := rc.AdminService.GetAllAdmin(pagination)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	totalPages := int(math.Ceil(float64(total) / float64(pagination.Limit)))
+	metadata := gin.H{
+		"total":       total,
+		"page":        pagination.Page,
+		"limit":       pagination.Limit,
+		"total_pages": totalPages,
+	}
+	utils.BuildResponseMessage(c, "success", "admin", "get", http.StatusOK, admin, metadata)
+}
+
+// @Summary      Post Create Admin
+// @Description  Create an admin
+// @Tags         Admin
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPostCreateAdmin  true  "Post Admin Request Body"
+// @Success      201  {object}  entity.ResponsePostCreateAdmin
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/admins [post]
+func (rc *AdminController) Create(c *gin.Context) {
+	// Model
+	var req entity.RequestPostCreateAdmin
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Create Admin
+	admin := entity.Admin{
+		Username:       req.Username,
+		Password:       req.Password,
+		Email:          req.Email,
+		TelegramUserId: req.TelegramUserId,
+	}
+	if err := rc.AdminService.Create(&admin); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "admin", "post", http.StatusCreated, &admin, nil)
+}
+
+// @Summary      Put Update Admin By Id
+// @Description  Update an admin by Id
+// @Tags         Admin
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPutUpdateAdmin  true  "Update Admin Request Body"
+// @Success      200  {object}  entity.ResponsePutUpdateAdmin
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/admins/{id} [put]
+// @Param        id  path  string  true  "Id of admin"
+func (rc *AdminController) UpdateById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Model
+	var req entity.RequestPutUpdateAdmin
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Parse Id
+	adminID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Update Admin
+	admin := entity.Admin{
+		Username:       req.Username,
+		Email:          req.Email,
+		TelegramUserId: req.TelegramUserId,
+	}
+	if err := rc.AdminService.UpdateById(&admin, adminID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "admin", "put", http.StatusOK, nil, nil)
+}
+
+// @Summary      Put Deactivate Admin By Id
+// @Description  Deactivate an admin by Id. Deactivated admin can't login and all of its session is revoked. The last active admin can't be deactivated
+// @Tags         Admin
+// @Success      200  {object}  entity.ResponsePutDeactivateAdmin
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/admins/deactivate/{id} [put]
+// @Param        id  path  string  true  "Id of admin"
+func (rc *AdminController) DeactivateById(c *gin.Context) {
+	rc.updateIsActiveById(c, false)
+}
+
+// @Summary      Put Activate Admin By Id
+// @Description  Activate a deactivated admin by Id
+// @Tags         Admin
+// @Success      200  {object}  entity.ResponsePutDeactivateAdmin
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/admins/activate/{id} [put]
+// @Param        id  path  string  true  "Id of admin"
+func (rc *AdminController) ActivateById(c *gin.Context) {
+	rc.updateIsActiveById(c, true)
+}
+
+// @Summary      Delete Admin By Id
+// @Description  Delete admin by id. The admin is deactivated and hidden, but kept so the record it created stay intact. The last active admin can't be deleted
+// @Tags         Admin
+// @Success      200  {object}  entity.ResponseDeleteAdminById
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/admins/{id} [delete]
+// @Param        id  path  string  true  "Id of admin"
+func (rc *AdminController) DeleteById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	adminID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Delete Admin By Id
+	if err := rc.AdminService.DeleteById(adminID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "admin", "soft delete", http.StatusOK, nil, nil)
+}
+
+func (rc *AdminController) updateIsActiveById(c *gin.Context, isActive bool) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	adminID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Update Is Active By Id
+	if err := rc.AdminService.UpdateIsActiveById(isActive, adminID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	method := "deactivate"
+	if isActive {
+		method = "activate"
+	}
+	utils.BuildResponseMessage(c, "success", "admin", method, http.StatusOK, nil, nil)
+}
@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"math"
+	"net/http"
+	"pelita/entity"
+	"pelita/service"
+	"pelita/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ApiKeyController struct {
+	ApiKeyService service.ApiKeyService
+}
+
+func NewApiKeyController(apiKeyService service.ApiKeyService) *ApiKeyController {
+	return &ApiKeyController{ApiKeyService: apiKeyService}
+}
+
+// @Summary      Get All Api Key
+// @Description  Returns a paginated list of api key. The key itself is never returned
+// @Tags         Api Key
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetAllApiKey
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/api-keys [get]
+func (rc *ApiKeyController) GetAllApiKey(c *gin.Context) {
+	// Pagination
+	pagination := utils.GetPagination(c)
+
+	// Service: Get All Api Key
+	apiKeys, total, err := rc.ApiKeyService.GetAllApiKey(pagination)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Response
+	totalPages := int(math.Ceil(float64(total) / float64(pagination.Limit)))
+	metadata := gin.H{
+		"total":       total,
+		"page":        pagination.Page,
+		"limit":       pagination.Limit,
+		"total_pages": totalPages,
+	}
+	utils.BuildResponseMessage(c, "success", "api key", "get", http.StatusOK, apiKeys, metadata)
+}
+
+// @Summary      Post Create Api Key
+// @Description  Create an api key for machine to machine integration. The key is only shown once, send it as X-API-Key header
+// @Tags         Api Key
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPostCreateApiKey  true  "Post Api Key Request Body"
+// @Success      201  {object}  entity.ResponsePostCreateApiKey
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/api-keys [post]
+func (rc *ApiKeyController) Create(c *gin.Context) {
+	// Model
+	var req entity.RequestPostCreateApiKey
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get User Id
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service : Create Api Key
+	apiKey, err := rc.ApiKeyService.Create(&req, userID)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "api key", "post", http.StatusCreated, apiKey, nil)
+}
+
+// @Summary      Revoke Api Key By Id
+// @Description  Revoke api key by id, the key is kept so its history stay attributed
+// @Tags         Api Key
+// @Success      200  {object}  entity.ResponseRevokeApiKeyById
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/api-keys/{id} [delete]
+// @Param        id  path  string  true  "Id of api key"
+func (rc *ApiKeyController) RevokeById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	apiKeyID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Revoke Api Key By Id
+	if err := rc.ApiKeyService.RevokeById(apiKeyID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "api key", "revoke", http.StatusOK, nil, nil)
+}
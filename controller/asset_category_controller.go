@@ -0,0 +1,190 @@
+package controller
+
+import (
+	"net/http"
+	"pelita/entity"
+	"pelita/service"
+	"pelita/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AssetCategoryController struct {
+	AssetCategoryService service.AssetCategoryService
+}
+
+func NewAssetCategoryController(assetCategoryService service.AssetCategoryService) *AssetCategoryController {
+	return &AssetCategoryController{AssetCategoryService: assetCategoryService}
+}
+
+// @Summary      Get All Asset Category
+// @Description  Returns the asset category tree, child category is nested under its parent
+// @Tags         Asset Category
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetAllAssetCategory
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/categories [get]
+func (rc *AssetCategoryController) GetAllAssetCategory(c *gin.Context) {
+	// Service: Get All Asset Category
+	assetCategory, err := rc.AssetCategoryService.GetAllAssetCategory()
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset category", "get", http.StatusOK, assetCategory, nil)
+}
+
+// @Summary      Get Asset Category By Id
+// @Description  Returns an asset category with its field definition and child category
+// @Tags         Asset Category
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetAssetCategory
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/categories/{id} [get]
+// @Param        id  path  string  true  "Id of asset category"
+func (rc *AssetCategoryController) GetById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetCategoryID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service: Get Asset Category By Id
+	assetCategory, err := rc.AssetCategoryService.GetById(assetCategoryID)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset category", "get", http.StatusOK, assetCategory, nil)
+}
+
+// @Summary      Post Create Asset Category
+// @Description  Create an asset category with its custom field definition
+// @Tags         Asset Category
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPostCreateUpdateAssetCategory  true  "Post Create Asset Category Request Body"
+// @Success      201  {object}  entity.ResponsePostCreateAssetCategory
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/categories [post]
+func (rc *AssetCategoryController) Create(c *gin.Context) {
+	// Model
+	var req entity.RequestPostCreateUpdateAssetCategory
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Create Asset Category
+	assetCategory := buildAssetCategory(req)
+	if err := rc.AssetCategoryService.Create(&assetCategory); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset category", "post", http.StatusCreated, &assetCategory, nil)
+}
+
+// @Summary      Put Update Asset Category By Id
+// @Description  Update an asset category by Id. Renaming a category also rename it on every linked asset, and field definition left out of the request is removed
+// @Tags         Asset Category
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPostCreateUpdateAssetCategory  true  "Put Update Asset Category Request Body"
+// @Success      200  {object}  entity.ResponsePutUpdateAssetCategory
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/categories/{id} [put]
+// @Param        id  path  string  true  "Id of asset category"
+func (rc *AssetCategoryController) UpdateById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Model
+	var req entity.RequestPostCreateUpdateAssetCategory
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Parse Id
+	assetCategoryID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Update Asset Category By Id
+	assetCategory := buildAssetCategory(req)
+	if err := rc.AssetCategoryService.UpdateById(&assetCategory, assetCategoryID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset category", "put", http.StatusOK, &assetCategory, nil)
+}
+
+// @Summary      Delete Asset Category By Id
+// @Description  Permanentally delete asset category by Id. Category that still has child category or asset can't be deleted
+// @Tags         Asset Category
+// @Success      200  {object}  entity.ResponseDeleteAssetCategoryById
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/categories/{id} [delete]
+// @Param        id  path  string  true  "Id of asset category"
+func (rc *AssetCategoryController) DeleteById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetCategoryID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Delete Asset Category By Id
+	if err := rc.AssetCategoryService.DeleteById(assetCategoryID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset category", "hard delete", http.StatusOK, nil, nil)
+}
+
+func buildAssetCategory(req entity.RequestPostCreateUpdateAssetCategory) entity.AssetCategory {
+	assetCategory := entity.AssetCategory{
+		CategoryName:           req.CategoryName,
+		CategoryDesc:           req.CategoryDesc,
+		MaintenanceIntervalDay: req.MaintenanceIntervalDay,
+		ParentId:               req.ParentId,
+		FieldDefinitions:       []entity.AssetCategoryField{},
+	}
+	for _, dt := range req.FieldDefinitions {
+		assetCategory.FieldDefinitions = append(assetCategory.FieldDefinitions, entity.AssetCategoryField{
+			FieldName:    dt.FieldName,
+			FieldLabel:   dt.FieldLabel,
+			FieldType:    dt.FieldType,
+			FieldOptions: dt.FieldOptions,
+			IsRequired:   dt.IsRequired,
+		})
+	}
+
+	return assetCategory
+}
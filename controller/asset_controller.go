@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"mime/multipart"
@@ -10,7 +12,9 @@ import (
 	"pelita/entity"
 	"pelita/service"
 	"pelita/utils"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -32,12 +36,30 @@ func NewAssetRepository(assetService service.AssetService) *AssetController {
 // @Success      200  {object}  entity.ResponseGetAllAsset
 // @Failure      404  {object}  entity.ResponseNotFound
 // @Router       /api/v1/assets [get]
+// @Param        search  query  string  false  "Search Term"
+// @Param        sort_by  query  string  false  "Sort Column (asset_name, asset_category, asset_status, asset_merk, asset_price, purchase_date, created_at, updated_at)"
+// @Param        sort_dir  query  string  false  "Sort Direction (asc or desc), Default desc"
+// @Param        category  query  string  false  "Asset Category"
+// @Param        category_id  query  string  false  "Id of asset category"
+// @Param        status  query  string  false  "Asset Status"
+// @Param        merk  query  string  false  "Asset Merk"
+// @Param        price_min  query  number  false  "Minimum Asset Price"
+// @Param        price_max  query  number  false  "Maximum Asset Price"
+// @Param        created_from  query  string  false  "Created From (YYYY-MM-DD)"
+// @Param        created_to  query  string  false  "Created To (YYYY-MM-DD)"
+// @Param        room_id  query  string  false  "Id of room"
+// @Param        floor  query  string  false  "Floor"
+// @Param        department  query  string  false  "Department"
+// @Param        attr.{field_name}  query  string  false  "Custom Attribute Value, Ranged By attr.{field_name}.min And attr.{field_name}.max"
 func (rc *AssetController) GetAllAsset(c *gin.Context) {
 	// Pagination
 	pagination := utils.GetPagination(c)
 
+	// Filter
+	filter := utils.GetFilter(c)
+
 	// Service: Get All Asset
-	asset, total, err := rc.AssetService.GetAllAsset(pagination)
+	asset, total, err := rc.AssetService.GetAllAsset(pagination, filter)
 	if err != nil {
 		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
 		return
@@ -82,12 +104,21 @@ func (rc *AssetController) GetDeletedAsset(c *gin.Context) {
 // @Param        asset_name     formData  string  true  "Asset Name"
 // @Param        asset_desc     formData  string  true  "Asset Description"
 // @Param        asset_merk     formData  string  true  "Asset Merk"
-// @Param        asset_category formData  string  true  "Asset Category"
+// @Param        asset_category formData  string  false  "Asset Category Name, Used When Asset Category Id Is Empty"
+// @Param        asset_category_id formData  string  false  "Id of asset category"
 // @Param        asset_price    formData  number  true  "Asset Price"
+// @Param        asset_currency formData  string  false  "Asset Currency (such as: IDR or USD)"
 // @Param        asset_status   formData  string  true  "Asset Status"
-// @Param        asset_image    formData  file    true  "Asset Image (JPG,PNG,JPEG)"
+// @Param        purchase_date  formData  string  false  "Purchase Date (YYYY-MM-DD)"
+// @Param        useful_life    formData  integer  false  "Useful Life In Year"
+// @Param        salvage_value  formData  number  false  "Salvage Value"
+// @Param        depreciation_method  formData  string  false  "Depreciation Method (straight-line or declining-balance)"
+// @Param        attributes     formData  string  false  "Custom Attribute As JSON Object, Keyed By Field Name Of The Asset Category"
+// @Param        asset_image    formData  file    true  "Asset Image (JPG,JPEG,PNG,WEBP)"
+// @Param        force  query  bool  false  "Save the asset even when a similar asset already exist"
 // @Success      201  {object}  entity.ResponseCreateAsset
 // @Failure      400  {object}  entity.ResponseBadRequest
+// @Failure      409  {object}  entity.ResponseAssetDuplicate
 // @Router       /api/v1/assets [post]
 func (rc *AssetController) Create(c *gin.Context) {
 	// Model
@@ -104,8 +135,55 @@ func (rc *AssetController) Create(c *gin.Context) {
 	req.AssetDesc = utils.OptionalString(c.PostForm("asset_desc"))
 	req.AssetMerk = utils.OptionalString(c.PostForm("asset_merk"))
 	req.AssetCategory = c.PostForm("asset_category")
-	req.AssetPrice = utils.OptionalString(c.PostForm("asset_price"))
+	if assetCategoryId := c.PostForm("asset_category_id"); assetCategoryId != "" {
+		id, err := uuid.Parse(assetCategoryId)
+		if err != nil {
+			utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+			return
+		}
+		req.AssetCategoryId = &id
+	}
+	req.AssetCurrency = strings.ToUpper(c.PostForm("asset_currency"))
 	req.AssetStatus = c.PostForm("asset_status")
+	req.DepreciationMethod = c.PostForm("depreciation_method")
+	if assetPrice := c.PostForm("asset_price"); assetPrice != "" {
+		price, err := utils.ParseMoney(assetPrice)
+		if err != nil {
+			utils.BuildErrorMessage(c, http.StatusBadRequest, "asset price must be a number")
+			return
+		}
+		req.AssetPrice = &price
+	}
+	if salvageValue := c.PostForm("salvage_value"); salvageValue != "" {
+		value, err := utils.ParseMoney(salvageValue)
+		if err != nil {
+			utils.BuildErrorMessage(c, http.StatusBadRequest, "salvage value must be a number")
+			return
+		}
+		req.SalvageValue = &value
+	}
+	if usefulLife := c.PostForm("useful_life"); usefulLife != "" {
+		life, err := strconv.Atoi(usefulLife)
+		if err != nil {
+			utils.BuildErrorMessage(c, http.StatusBadRequest, "useful life must be a number")
+			return
+		}
+		req.UsefulLife = &life
+	}
+	if purchaseDate := c.PostForm("purchase_date"); purchaseDate != "" {
+		date, err := time.Parse("2006-01-02", purchaseDate)
+		if err != nil {
+			utils.BuildErrorMessage(c, http.StatusBadRequest, "purchase date must be in YYYY-MM-DD format")
+			return
+		}
+		req.PurchaseDate = &date
+	}
+	if attributes := c.PostForm("attributes"); attributes != "" {
+		if err := json.Unmarshal([]byte(attributes), &req.Attributes); err != nil {
+			utils.BuildErrorMessage(c, http.StatusBadRequest, "attributes must be a JSON object of field name and value")
+			return
+		}
+	}
 
 	// Get User Id
 	adminId, err := utils.GetCurrentUserID(c)
@@ -130,6 +208,12 @@ func (rc *AssetController) Create(c *gin.Context) {
 		fileSize = file.Size
 		fileHeader = file
 
+		// Validate file type
+		if !utils.Contains(config.ConfigFile.AllowedFileType, fileExt) {
+			utils.BuildErrorMessage(c, http.StatusBadRequest, fmt.Sprintf("file type must be one of %s", strings.Join(config.ConfigFile.AllowedFileType, ", ")))
+			return
+		}
+
 		// Validate file size
 		if fileSize > config.ConfigFile.MaxSizeFile {
 			utils.BuildErrorMessage(c, http.StatusBadRequest, fmt.Sprintf("The file size must be under %.2f MB", float64(config.ConfigFile.MaxSizeFile)/1000000))
@@ -150,7 +234,7 @@ func (rc *AssetController) Create(c *gin.Context) {
 		utils.BuildErrorMessage(c, http.StatusBadRequest, "asset name is required")
 		return
 	}
-	if req.AssetCategory == "" {
+	if req.AssetCategory == "" && req.AssetCategoryId == nil {
 		utils.BuildErrorMessage(c, http.StatusBadRequest, "asset category is required")
 		return
 	}
@@ -158,14 +242,21 @@ func (rc *AssetController) Create(c *gin.Context) {
 		utils.BuildErrorMessage(c, http.StatusBadRequest, "asset status is required")
 		return
 	}
-	// Validator Contain : Asset Status
-	if !utils.Contains(config.AssetStatus, req.AssetStatus) {
-		utils.BuildErrorMessage(c, http.StatusBadRequest, "asset status is not valid")
+	// Validator Contain : Asset Status, New Asset Must Start Its Lifecycle
+	if !utils.Contains(config.AssetInitialStatus, req.AssetStatus) {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "asset status must be procured or available")
 		return
 	}
+	if statusReason := c.PostForm("status_reason"); statusReason != "" {
+		req.StatusReason = &statusReason
+	}
 
 	// Service : Create Asset
-	if err := rc.AssetService.Create(&req, adminId, fileHeader, fileExt, fileSize); err != nil {
+	force := c.Query("force") == "true"
+	if err := rc.AssetService.Create(&req, adminId, fileHeader, fileExt, fileSize, force); err != nil {
+		if buildAssetDuplicateMessage(c, err) {
+			return
+		}
 		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
 		return
 	}
@@ -182,8 +273,10 @@ func (rc *AssetController) Create(c *gin.Context) {
 // @Param        request  body  entity.RequestUpdateAssetById  true  "Update Asset Request Body"
 // @Success      200  {object}  entity.ResponseUpdateAssetById
 // @Failure      400  {object}  entity.ResponseBadRequest
+// @Failure      409  {object}  entity.ResponseConflict
 // @Router       /api/v1/assets/{id} [put]
 // @Param        id  path  string  true  "Id of asset"
+// @Param        force  query  bool  false  "Save the asset even when a similar asset already exist"
 func (rc *AssetController) UpdateById(c *gin.Context) {
 	// Param
 	id := c.Param("id")
@@ -209,7 +302,7 @@ func (rc *AssetController) UpdateById(c *gin.Context) {
 		utils.BuildErrorMessage(c, http.StatusBadRequest, "asset name is required")
 		return
 	}
-	if req.AssetCategory == "" {
+	if req.AssetCategory == "" && req.AssetCategoryId == nil {
 		utils.BuildErrorMessage(c, http.StatusBadRequest, "asset category is required")
 		return
 	}
@@ -223,9 +316,25 @@ func (rc *AssetController) UpdateById(c *gin.Context) {
 		return
 	}
 
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
 	// Service : Update Asset
-	if err := rc.AssetService.UpdateById(&req, assetID); err != nil {
-		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+	force := c.Query("force") == "true"
+	if err := rc.AssetService.UpdateById(&req, assetID, userID, role, force); err != nil {
+		if buildAssetDuplicateMessage(c, err) {
+			return
+		}
+		utils.BuildErrorMessage(c, assetStatusErrorCode(err), err.Error())
 		return
 	}
 
@@ -233,16 +342,197 @@ func (rc *AssetController) UpdateById(c *gin.Context) {
 	utils.BuildResponseMessage(c, "success", "asset", "put", http.StatusOK, &req, nil)
 }
 
+// @Summary      Get Asset Status History By Id
+// @Description  Returns every status transition of an asset ordered from the oldest
+// @Tags         Asset
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetAssetStatusHistory
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/{id}/status-history [get]
+// @Param        id  path  string  true  "Id of asset"
+func (rc *AssetController) GetStatusHistoryById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Get Asset Status History By Id
+	history, err := rc.AssetService.GetStatusHistoryById(assetID)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset status history", "get", http.StatusOK, history, nil)
+}
+
+// @Summary      Get All Asset Book Value
+// @Description  Returns a paginated list of asset book value. Only asset with price, purchase date, and useful life are listed
+// @Tags         Asset
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetAllAssetBookValue
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/book-values [get]
+// @Param        as_of  query  string  false  "Valuation Date (YYYY-MM-DD), Default Today"
+func (rc *AssetController) GetAllBookValue(c *gin.Context) {
+	// Pagination
+	pagination := utils.GetPagination(c)
+
+	// Query Param
+	at, err := getValuationDate(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Get All Asset Book Value
+	bookValue, total, err := rc.AssetService.GetAllBookValue(pagination, at)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Response
+	totalPages := int(math.Ceil(float64(total) / float64(pagination.Limit)))
+	metadata := gin.H{
+		"total":       total,
+		"page":        pagination.Page,
+		"limit":       pagination.Limit,
+		"total_pages": totalPages,
+	}
+	utils.BuildResponseMessage(c, "success", "asset book value", "get", http.StatusOK, bookValue, metadata)
+}
+
+// @Summary      Get Asset Book Value By Id
+// @Description  Returns the current book value and accumulated depreciation of an asset
+// @Tags         Asset
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetAssetBookValue
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/{id}/book-value [get]
+// @Param        id  path  string  true  "Id of asset"
+// @Param        as_of  query  string  false  "Valuation Date (YYYY-MM-DD), Default Today"
+func (rc *AssetController) GetBookValueById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Query Param
+	at, err := getValuationDate(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Get Asset Book Value By Id
+	bookValue, err := rc.AssetService.GetBookValueById(assetID, at)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset book value", "get", http.StatusOK, bookValue, nil)
+}
+
+// @Summary      Get Asset Book Value Summary
+// @Description  Returns the total price and book value of every placed asset per department or per floor, grouped by currency
+// @Tags         Asset
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetAssetBookValueSummary
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/book-values/summary/{context} [get]
+// @Param        context  path  string  true  "Summary Context (dept or floor)"
+// @Param        as_of  query  string  false  "Valuation Date (YYYY-MM-DD), Default Today"
+func (rc *AssetController) GetBookValueSummary(c *gin.Context) {
+	// Param
+	context := c.Param("context")
+
+	// Validator : Context Validator
+	if context != "dept" && context != "floor" {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "context is not valid")
+		return
+	}
+
+	// Query Param
+	at, err := getValuationDate(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Get Asset Book Value Summary
+	summary, err := rc.AssetService.GetBookValueSummary(context, at)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset book value", "get", http.StatusOK, summary, nil)
+}
+
+// @Summary      Get Asset Delete Preview By Id
+// @Description  List every row that is removed along with a deleted asset when it is permanently deleted. The returned confirmation token is required to permanently delete it
+// @Tags         Asset
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetDeletePreview
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/destroy/{id}/delete-preview [get]
+// @Param        id  path  string  true  "Id of asset"
+func (rc *AssetController) GetDeletePreviewById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Get User Id
+	accountId, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service : Get Asset Delete Preview By Id
+	deletePreview, err := rc.AssetService.GetDeletePreviewById(assetID, accountId)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "delete preview", "get", http.StatusOK, deletePreview, nil)
+}
+
 // @Summary      Hard Delete Asset By Id
-// @Description  Permanentally Delete Asset By Id
+// @Description  Permanentally Delete Asset By Id. Every row removed along with it is archived
 // @Tags         Asset
 // @Success      200  {object}  entity.ResponseHardDeleteAssetById
 // @Failure      400  {object}  entity.ResponseBadRequest
 // @Router       /api/v1/assets/destroy/{id} [delete]
 // @Param        id  path  string  true  "Id of asset"
+// @Param        confirmation_token  query  string  true  "Confirmation token from the delete preview"
 func (rc *AssetController) HardDeleteById(c *gin.Context) {
 	// Param
 	id := c.Param("id")
+	confirmationToken := c.Query("confirmation_token")
 
 	// Parse Id
 	assetID, err := uuid.Parse(id)
@@ -251,8 +541,15 @@ func (rc *AssetController) HardDeleteById(c *gin.Context) {
 		return
 	}
 
+	// Get User Id
+	accountId, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
 	// Service : Hard Delete Asset By Id
-	if err := rc.AssetService.HardDeleteById(assetID); err != nil {
+	if err := rc.AssetService.HardDeleteById(assetID, accountId, confirmationToken); err != nil {
 		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
 		return
 	}
@@ -289,6 +586,46 @@ func (rc *AssetController) SoftDeleteById(c *gin.Context) {
 	utils.BuildResponseMessage(c, "success", "asset", "soft delete", http.StatusOK, nil, nil)
 }
 
+// @Summary      Post Merge Asset By Id
+// @Description  Merge a duplicate asset into the asset by Id. Placement, maintenance, and finding of the duplicate are moved to the asset, then the duplicate is deleted
+// @Tags         Asset
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestMergeAssetById  true  "Merge Asset Request Body"
+// @Success      200  {object}  entity.ResponseMergeAssetById
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/{id}/merge [post]
+// @Param        id  path  string  true  "Id of the surviving asset"
+func (rc *AssetController) MergeById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Model
+	var req entity.RequestMergeAssetById
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Parse Id
+	assetID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Merge Asset By Id
+	if err := rc.AssetService.MergeById(req.DuplicateAssetId, assetID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset", "merge", http.StatusOK, nil, nil)
+}
+
 // @Summary      Recover Put Deleted Asset By Id
 // @Description  Recover Deleted Asset By Id
 // @Tags         Asset
@@ -347,3 +684,42 @@ func (rc *AssetController) GetMostContext(c *gin.Context) {
 	// Response
 	utils.BuildResponseMessage(c, "success", "asset", "get", http.StatusOK, asset, nil)
 }
+
+// Possible Duplicate Is Sent Back Along With The Conflict, So The Caller Can Merge Or Force It
+func buildAssetDuplicateMessage(c *gin.Context, err error) bool {
+	var duplicateErr *service.AssetDuplicateError
+	if !errors.As(err, &duplicateErr) {
+		return false
+	}
+
+	c.JSON(http.StatusConflict, gin.H{
+		"message": duplicateErr.Error(),
+		"status":  "failed",
+		"data":    duplicateErr.Duplicates,
+	})
+	return true
+}
+
+// Illegal Asset Status Transition Is A Conflict With The Current State Of The Asset
+func assetStatusErrorCode(err error) int {
+	if errors.Is(err, service.ErrIllegalAssetStatusTransition) {
+		return http.StatusConflict
+	}
+
+	return http.StatusBadRequest
+}
+
+// Book Value Is Calculated For Today Unless The As Of Date Is Given
+func getValuationDate(c *gin.Context) (time.Time, error) {
+	asOf := c.Query("as_of")
+	if asOf == "" {
+		return time.Now(), nil
+	}
+
+	at, err := time.Parse("2006-01-02", asOf)
+	if err != nil {
+		return time.Time{}, errors.New("as_of must be in YYYY-MM-DD format")
+	}
+
+	return at, nil
+}
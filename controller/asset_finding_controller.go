@@ -32,12 +32,38 @@ func NewAssetFindingRepository(assetFindingService service.AssetFindingService)
 // @Success      200  {object}  entity.ResponseGetAllAssetFinding
 // @Failure      404  {object}  entity.ResponseNotFound
 // @Router       /api/v1/assets/findings [get]
+// @Param        search  query  string  false  "Search Term"
+// @Param        sort_by  query  string  false  "Sort Column (finding_category, created_at)"
+// @Param        sort_dir  query  string  false  "Sort Direction (asc or desc), Default desc"
+// @Param        finding_category  query  string  false  "Finding Category"
+// @Param        asset_placement_id  query  string  false  "Id of asset placement"
+// @Param        asset_id  query  string  false  "Id of asset"
+// @Param        room_id  query  string  false  "Id of room"
+// @Param        floor  query  string  false  "Floor"
+// @Param        department  query  string  false  "Department"
+// @Param        created_from  query  string  false  "Created From (YYYY-MM-DD)"
+// @Param        created_to  query  string  false  "Created To (YYYY-MM-DD)"
 func (rc *AssetFindingController) GetAllAssetFinding(c *gin.Context) {
 	// Pagination
 	pagination := utils.GetPagination(c)
 
+	// Filter
+	filter := utils.GetFilter(c)
+
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Service: Get All Asset Finding
-	assetFinding, total, err := rc.AssetFindingService.GetAllAssetFinding(pagination)
+	assetFinding, total, err := rc.AssetFindingService.GetAllAssetFinding(pagination, filter, userID, role)
 	if err != nil {
 		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
 		return
@@ -111,8 +137,9 @@ func (rc *AssetFindingController) GetMostContext(c *gin.Context) {
 // @Produce      json
 // @Param        finding_category     	formData  string  true  "Finding Category"
 // @Param        finding_notes     		formData  string  true  "Finding Notes"
-// @Param        finding_image     		formData  file    true  "Finding Image (JPG,PNG,JPEG)"
+// @Param        finding_image     		formData  file    true  "Finding Image (JPG,JPEG,PNG,WEBP)"
 // @Param        asset_placement_id 	formData  string  true  "Asset Placement Id"
+// @Param        asset_unit_id 			formData  string  false  "Asset Unit Id"
 // @Success      201  {object}  entity.ResponseCreateAssetFinding
 // @Failure      400  {object}  entity.ResponseBadRequest
 // @Router       /api/v1/assets/findings [post]
@@ -142,12 +169,14 @@ func (rc *AssetFindingController) Create(c *gin.Context) {
 
 	// Define The Role Id
 	var technicianId, userId uuid.UUID
-	if role == "technician" {
+	req.FindingByApiKey = nil
+	switch role {
+	case "technician":
 		technicianId = technicianOrUserId
-		userId = uuid.Nil
-	} else {
+	case config.RoleApiKey:
+		req.FindingByApiKey = &technicianOrUserId
+	default:
 		userId = technicianOrUserId
-		technicianId = uuid.Nil
 	}
 
 	// Validator Field
@@ -155,7 +184,7 @@ func (rc *AssetFindingController) Create(c *gin.Context) {
 		utils.BuildErrorMessage(c, http.StatusBadRequest, "asset placement id is required")
 		return
 	}
-	if technicianId == uuid.Nil && userId == uuid.Nil {
+	if technicianId == uuid.Nil && userId == uuid.Nil && req.FindingByApiKey == nil {
 		utils.BuildErrorMessage(c, http.StatusUnauthorized, "technician id and user id is required")
 		return
 	}
@@ -181,6 +210,12 @@ func (rc *AssetFindingController) Create(c *gin.Context) {
 		fileSize = file.Size
 		fileHeader = file
 
+		// Validate file type
+		if !utils.Contains(config.ConfigFile.AllowedFileType, fileExt) {
+			utils.BuildErrorMessage(c, http.StatusBadRequest, fmt.Sprintf("file type must be one of %s", strings.Join(config.ConfigFile.AllowedFileType, ", ")))
+			return
+		}
+
 		// Validate file size
 		if fileSize > config.ConfigFile.MaxSizeFile {
 			utils.BuildErrorMessage(c, http.StatusBadRequest, fmt.Sprintf("The file size must be under %.2f MB", float64(config.ConfigFile.MaxSizeFile)/1000000))
@@ -207,14 +242,108 @@ func (rc *AssetFindingController) Create(c *gin.Context) {
 	utils.BuildResponseMessage(c, "success", "asset finding", "post", http.StatusCreated, cleanedData, nil)
 }
 
-// @Summary      Delete Asset Finding By Id
-// @Description  Permanentally delete asset finding by id
+// @Summary      Get Deleted Asset Finding
+// @Description  Returns a list of deleted asset findings
+// @Tags         Asset
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetDeletedAssetFinding
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/findings/deleted [get]
+func (rc *AssetFindingController) GetDeleted(c *gin.Context) {
+	// Service: Get All Deleted Asset Finding
+	assetFinding, err := rc.AssetFindingService.GetDeleted()
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "deleted asset finding", "get", http.StatusOK, assetFinding, nil)
+}
+
+// @Summary      Get Asset Finding Delete Preview By Id
+// @Description  List every row that is removed along with a deleted asset finding when it is permanently deleted. The returned confirmation token is required to permanently delete it
+// @Tags         Asset
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetDeletePreview
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/findings/destroy/{id}/delete-preview [get]
+// @Param        id  path  string  true  "Id of asset finding"
+func (rc *AssetFindingController) GetDeletePreviewById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetFindingID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Get User Id
+	accountId, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service : Get Asset Finding Delete Preview By Id
+	deletePreview, err := rc.AssetFindingService.GetDeletePreviewById(assetFindingID, accountId)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "delete preview", "get", http.StatusOK, deletePreview, nil)
+}
+
+// @Summary      Hard Delete Asset Finding By Id
+// @Description  Permanentally delete asset finding by id. Every row removed along with it is archived
+// @Tags         Asset
+// @Success      200  {object}  entity.ResponseHardDeleteAssetFindingById
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/findings/destroy/{id} [delete]
+// @Param        id  path  string  true  "Id of asset finding"
+// @Param        confirmation_token  query  string  true  "Confirmation token from the delete preview"
+func (rc *AssetFindingController) HardDeleteById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+	confirmationToken := c.Query("confirmation_token")
+
+	// Parse Id
+	assetFindingID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Get User Id
+	accountId, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service : Hard Delete Asset Finding By Id
+	if err := rc.AssetFindingService.HardDeleteById(assetFindingID, accountId, confirmationToken); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset finding", "hard delete", http.StatusOK, nil, nil)
+}
+
+// @Summary      Soft Delete Asset Finding By Id
+// @Description  Delete asset finding by id. It can be recovered until it is permanently deleted
 // @Tags         Asset
 // @Success      200  {object}  entity.ResponseDeleteAssetFindingById
 // @Failure      400  {object}  entity.ResponseBadRequest
 // @Router       /api/v1/assets/findings/{id} [delete]
 // @Param        id  path  string  true  "Id of asset finding"
-func (rc *AssetFindingController) DeleteById(c *gin.Context) {
+func (rc *AssetFindingController) SoftDeleteById(c *gin.Context) {
 	// Param
 	id := c.Param("id")
 
@@ -225,8 +354,8 @@ func (rc *AssetFindingController) DeleteById(c *gin.Context) {
 		return
 	}
 
-	// Service : Delete Asset Finding By Id
-	if err := rc.AssetFindingService.DeleteById(assetFindingID); err != nil {
+	// Service : Soft Delete Asset Finding By Id
+	if err := rc.AssetFindingService.SoftDeleteById(assetFindingID); err != nil {
 		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
 		return
 	}
@@ -234,3 +363,31 @@ func (rc *AssetFindingController) DeleteById(c *gin.Context) {
 	// Response
 	utils.BuildResponseMessage(c, "success", "asset finding", "soft delete", http.StatusOK, nil, nil)
 }
+
+// @Summary      Recover Put Deleted Asset Finding By Id
+// @Description  Recover deleted asset finding by id
+// @Tags         Asset
+// @Success      200  {object}  entity.ResponseRecoverDeleteAssetFindingById
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/findings/recover/{id} [put]
+// @Param        id  path  string  true  "Id of asset finding"
+func (rc *AssetFindingController) RecoverDeletedById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetFindingID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Recover Deleted Asset Finding By Id
+	if err := rc.AssetFindingService.RecoverDeletedById(assetFindingID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset finding", "recover", http.StatusOK, nil, nil)
+}
@@ -0,0 +1,209 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"pelita/config"
+	"pelita/entity"
+	"pelita/service"
+	"pelita/utils"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AssetImageController struct {
+	AssetImageService service.AssetImageService
+}
+
+func NewAssetImageController(assetImageService service.AssetImageService) *AssetImageController {
+	return &AssetImageController{AssetImageService: assetImageService}
+}
+
+// @Summary      Get All Asset Image By Asset Id
+// @Description  Returns the image gallery of an asset in its display order
+// @Tags         Asset
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetAllAssetImage
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/{id}/images [get]
+// @Param        id  path  string  true  "Id of asset"
+func (rc *AssetImageController) GetAllByAssetId(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Get All Asset Image By Asset Id
+	assetImage, err := rc.AssetImageService.GetAllByAssetId(assetID)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset image", "get", http.StatusOK, assetImage, nil)
+}
+
+// @Summary      Post Create Asset Image
+// @Description  Add one or more images to the gallery of an asset. The first image of an empty gallery become the primary image
+// @Tags         Asset
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        asset_image  formData  file  true  "Asset Image (jpg, jpeg, png, or webp), can be sent more than once"
+// @Success      201  {object}  entity.ResponsePostCreateAssetImage
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/{id}/images [post]
+// @Param        id  path  string  true  "Id of asset"
+func (rc *AssetImageController) Create(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Get User Id
+	adminId, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Validator File
+	form, err := c.MultipartForm()
+	if err != nil || len(form.File["asset_image"]) == 0 {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "asset image is required")
+		return
+	}
+	files := form.File["asset_image"]
+	for _, file := range files {
+		fileExt := strings.ToLower(strings.TrimPrefix(filepath.Ext(file.Filename), "."))
+		if !utils.Contains(config.ConfigFile.AllowedFileType, fileExt) {
+			utils.BuildErrorMessage(c, http.StatusBadRequest, fmt.Sprintf("file type must be one of %s", strings.Join(config.ConfigFile.AllowedFileType, ", ")))
+			return
+		}
+		if file.Size > config.ConfigFile.MaxSizeFile {
+			utils.BuildErrorMessage(c, http.StatusBadRequest, fmt.Sprintf("The file size must be under %.2f MB", float64(config.ConfigFile.MaxSizeFile)/1000000))
+			return
+		}
+	}
+
+	// Service : Create Asset Image
+	assetImage, err := rc.AssetImageService.Create(assetID, adminId, files)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset image", "post", http.StatusCreated, assetImage, nil)
+}
+
+// @Summary      Put Update Asset Image Order
+// @Description  Reorder the image gallery of an asset, every image of the asset must be listed
+// @Tags         Asset
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPutAssetImageOrder  true  "Put Update Asset Image Order Request Body"
+// @Success      200  {object}  entity.ResponsePutUpdateAssetImage
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/{id}/images/order [put]
+// @Param        id  path  string  true  "Id of asset"
+func (rc *AssetImageController) UpdateOrderByAssetId(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Model
+	var req entity.RequestPutAssetImageOrder
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Parse Id
+	assetID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Update Asset Image Order
+	if err := rc.AssetImageService.UpdateOrderByAssetId(assetID, req.ImageIds); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset image", "put", http.StatusOK, nil, nil)
+}
+
+// @Summary      Put Update Asset Image Primary
+// @Description  Make an image the primary image of its asset
+// @Tags         Asset
+// @Produce      json
+// @Success      200  {object}  entity.ResponsePutUpdateAssetImage
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/images/primary/{id} [put]
+// @Param        id  path  string  true  "Id of asset image"
+func (rc *AssetImageController) UpdatePrimaryById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetImageID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Update Asset Image Primary
+	if err := rc.AssetImageService.UpdatePrimaryById(assetImageID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset image", "put", http.StatusOK, nil, nil)
+}
+
+// @Summary      Delete Asset Image By Id
+// @Description  Permanently delete an image and its stored file. The next image in order become the primary image
+// @Tags         Asset
+// @Produce      json
+// @Success      200  {object}  entity.ResponseDeleteAssetImageById
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/images/{id} [delete]
+// @Param        id  path  string  true  "Id of asset image"
+func (rc *AssetImageController) DeleteById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetImageID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Delete Asset Image By Id
+	if err := rc.AssetImageService.DeleteById(assetImageID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset image", "hard delete", http.StatusOK, nil, nil)
+}
@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"pelita/config"
+	"pelita/service"
+	"pelita/utils"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AssetImportController struct {
+	AssetImportService service.AssetImportService
+}
+
+func NewAssetImportController(assetImportService service.AssetImportService) *AssetImportController {
+	return &AssetImportController{AssetImportService: assetImportService}
+}
+
+// @Summary      Post Import Asset
+// @Description  Import assets and their optional placements from a CSV or XLSX file. Every row is validated first and nothing is saved when any row is invalid. Row similar to an existing asset is rejected unless its optional force column is true
+// @Tags         Asset
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file  formData  file  true  "CSV or XLSX file, the first row must be the column header"
+// @Param        dry_run  query  bool  false  "Only validate the file without saving"
+// @Success      200  {object}  entity.ResponsePostImportAsset
+// @Success      201  {object}  entity.ResponsePostImportAsset
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Failure      422  {object}  entity.ResponsePostImportAsset
+// @Router       /api/v1/assets/import [post]
+func (rc *AssetImportController) Import(c *gin.Context) {
+	// Query Param
+	dryRun := c.Query("dry_run") == "true"
+
+	// Get User Id
+	adminId, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Validator File
+	file, err := c.FormFile("file")
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "file is required")
+		return
+	}
+	fileExt := strings.ToLower(strings.TrimPrefix(filepath.Ext(file.Filename), "."))
+	if !utils.Contains(config.AssetImportFileType, fileExt) {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "file must be csv or xlsx")
+		return
+	}
+	if file.Size > config.ConfigFile.MaxSizeFile {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, fmt.Sprintf("The file size must be under %.2f MB", float64(config.ConfigFile.MaxSizeFile)/1000000))
+		return
+	}
+
+	fileReader, err := file.Open()
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Failed to open the file")
+		return
+	}
+	defer fileReader.Close()
+
+	rows, err := utils.ReadSpreadsheet(fileReader, fileExt)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Import Asset
+	report, err := rc.AssetImportService.Import(rows, adminId, dryRun)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	if len(report.Errors) > 0 && !dryRun {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"message": "Failed to import asset, fix the invalid row and try again",
+			"status":  "failed",
+			"data":    report,
+		})
+		return
+	}
+	if dryRun {
+		utils.BuildResponseMessage(c, "success", "asset import", "validate", http.StatusOK, report, nil)
+		return
+	}
+	utils.BuildResponseMessage(c, "success", "asset", "import", http.StatusCreated, report, nil)
+}
+
+// @Summary      Get Export Asset
+// @Description  Export every asset with its placements as a CSV or XLSX file, using the same column as the import
+// @Tags         Asset
+// @Produce      octet-stream
+// @Success      200  {file}  binary
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/export [get]
+// @Param        format  query  string  false  "csv or xlsx, default csv"
+func (rc *AssetImportController) Export(c *gin.Context) {
+	// Query Param
+	fileExt := strings.ToLower(c.DefaultQuery("format", "csv"))
+	if !utils.Contains(config.AssetImportFileType, fileExt) {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "format must be csv or xlsx")
+		return
+	}
+
+	// Service : Export Asset
+	rows, err := rc.AssetImportService.Export()
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := utils.WriteSpreadsheet(&buf, fileExt, rows); err != nil {
+		utils.BuildErrorMessage(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Response
+	contentType := "text/csv"
+	if fileExt == "xlsx" {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=asset_%s.%s", time.Now().Format("20060102150405"), fileExt))
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
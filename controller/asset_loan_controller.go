@@ -0,0 +1,323 @@
+package controller
+
+import (
+	"math"
+	"net/http"
+	"pelita/entity"
+	"pelita/service"
+	"pelita/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AssetLoanController struct {
+	AssetLoanService service.AssetLoanService
+}
+
+func NewAssetLoanController(assetLoanService service.AssetLoanService) *AssetLoanController {
+	return &AssetLoanController{AssetLoanService: assetLoanService}
+}
+
+// @Summary      Get All Asset Loan
+// @Description  Returns a paginated list of asset loan. Technician only see loan inside its access scope
+// @Tags         Asset
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetAllAssetLoan
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/loans [get]
+func (rc *AssetLoanController) GetAllAssetLoan(c *gin.Context) {
+	// Pagination
+	pagination := utils.GetPagination(c)
+
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service: Get All Asset Loan
+	assetLoan, total, err := rc.AssetLoanService.GetAllAssetLoan(pagination, userID, role)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Response
+	totalPages := int(math.Ceil(float64(total) / float64(pagination.Limit)))
+	metadata := gin.H{
+		"total":       total,
+		"page":        pagination.Page,
+		"limit":       pagination.Limit,
+		"total_pages": totalPages,
+	}
+	utils.BuildResponseMessage(c, "success", "asset loan", "get", http.StatusOK, assetLoan, metadata)
+}
+
+// @Summary      Get My Asset Loan
+// @Description  Returns a paginated list of asset loan borrowed by the current account
+// @Tags         Asset
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetAllAssetLoan
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/loans/my [get]
+func (rc *AssetLoanController) GetMyAssetLoan(c *gin.Context) {
+	// Pagination
+	pagination := utils.GetPagination(c)
+
+	// Get User ID
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service: Get My Asset Loan
+	assetLoan, total, err := rc.AssetLoanService.GetMyAssetLoan(pagination, userID)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Response
+	totalPages := int(math.Ceil(float64(total) / float64(pagination.Limit)))
+	metadata := gin.H{
+		"total":       total,
+		"page":        pagination.Page,
+		"limit":       pagination.Limit,
+		"total_pages": totalPages,
+	}
+	utils.BuildResponseMessage(c, "success", "asset loan", "get", http.StatusOK, assetLoan, metadata)
+}
+
+// @Summary      Get All Overdue Asset Loan
+// @Description  Returns every checked out asset loan past its due date
+// @Tags         Asset
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetAllAssetLoanOverdue
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/loans/overdue [get]
+func (rc *AssetLoanController) GetAllOverdueAssetLoan(c *gin.Context) {
+	// Service: Get All Overdue Asset Loan
+	assetLoan, err := rc.AssetLoanService.GetAllOverdueAssetLoan()
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "overdue asset loan", "get", http.StatusOK, assetLoan, nil)
+}
+
+// @Summary      Post Request Asset Loan
+// @Description  Request to borrow an asset from a placement. The loan must be approved before it can be checked out
+// @Tags         Asset
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPostCreateAssetLoan  true  "Post Asset Loan Request Body"
+// @Success      201  {object}  entity.ResponsePostCreateAssetLoan
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/loans [post]
+func (rc *AssetLoanController) Create(c *gin.Context) {
+	// Model
+	var req entity.RequestPostCreateAssetLoan
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Parse Id
+	assetPlacementID, err := uuid.Parse(req.AssetPlacementId)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Create Asset Loan
+	assetLoan := entity.AssetLoan{
+		AssetPlacementId: assetPlacementID,
+		DueAt:            req.DueAt,
+		LoanNotes:        req.LoanNotes,
+	}
+	if err := rc.AssetLoanService.Create(&assetLoan, userID, role); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset loan", "request", http.StatusCreated, &assetLoan, nil)
+}
+
+// @Summary      Put Approve Asset Loan By Id
+// @Description  Approve a requested asset loan by Id
+// @Tags         Asset
+// @Success      200  {object}  entity.ResponsePutUpdateAssetLoan
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/loans/approve/{id} [put]
+// @Param        id  path  string  true  "Id of asset loan"
+func (rc *AssetLoanController) ApproveById(c *gin.Context) {
+	rc.updateApprovalById(c, true)
+}
+
+// @Summary      Put Reject Asset Loan By Id
+// @Description  Reject a requested asset loan by Id
+// @Tags         Asset
+// @Success      200  {object}  entity.ResponsePutUpdateAssetLoan
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/loans/reject/{id} [put]
+// @Param        id  path  string  true  "Id of asset loan"
+func (rc *AssetLoanController) RejectById(c *gin.Context) {
+	rc.updateApprovalById(c, false)
+}
+
+// @Summary      Put Check Out Asset Loan By Id
+// @Description  Hand over an approved asset loan by Id. The asset status become in-use
+// @Tags         Asset
+// @Success      200  {object}  entity.ResponsePutUpdateAssetLoan
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Failure      409  {object}  entity.ResponseConflict
+// @Router       /api/v1/assets/loans/checkout/{id} [put]
+// @Param        id  path  string  true  "Id of asset loan"
+func (rc *AssetLoanController) CheckOutById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetLoanID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service : Check Out Asset Loan By Id
+	if err := rc.AssetLoanService.CheckOutById(assetLoanID, userID, role); err != nil {
+		utils.BuildErrorMessage(c, assetStatusErrorCode(err), err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset loan", "check out", http.StatusOK, nil, nil)
+}
+
+// @Summary      Put Return Asset Loan By Id
+// @Description  Return a checked out asset loan by Id. The asset status become available, or maintenance when returned damaged
+// @Tags         Asset
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPutReturnAssetLoan  true  "Put Return Asset Loan Request Body"
+// @Success      200  {object}  entity.ResponsePutUpdateAssetLoan
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Failure      409  {object}  entity.ResponseConflict
+// @Router       /api/v1/assets/loans/return/{id} [put]
+// @Param        id  path  string  true  "Id of asset loan"
+func (rc *AssetLoanController) ReturnById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Model
+	var req entity.RequestPutReturnAssetLoan
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Parse Id
+	assetLoanID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service : Return Asset Loan By Id
+	if err := rc.AssetLoanService.ReturnById(req.ReturnCondition, req.ReturnNotes, assetLoanID, userID, role); err != nil {
+		utils.BuildErrorMessage(c, assetStatusErrorCode(err), err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset loan", "return", http.StatusOK, nil, nil)
+}
+
+func (rc *AssetLoanController) updateApprovalById(c *gin.Context, isApproved bool) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetLoanID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Get User Id
+	adminID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service : Approve Or Reject Asset Loan By Id
+	method := "approve"
+	if isApproved {
+		err = rc.AssetLoanService.ApproveById(assetLoanID, adminID)
+	} else {
+		method = "reject"
+		err = rc.AssetLoanService.RejectById(assetLoanID, adminID)
+	}
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset loan", method, http.StatusOK, nil, nil)
+}
@@ -28,12 +28,39 @@ func NewAssetMaintenanceRepository(assetMaintenanceService service.AssetMaintena
 // @Success      200  {object}  entity.ResponseGetAllAssetMaintenance
 // @Failure      404  {object}  entity.ResponseNotFound
 // @Router       /api/v1/assets/maintenances [get]
+// @Param        search  query  string  false  "Search Term"
+// @Param        sort_by  query  string  false  "Sort Column (maintenance_day, maintenance_hour_start, created_at, updated_at)"
+// @Param        sort_dir  query  string  false  "Sort Direction (asc or desc), Default desc"
+// @Param        asset_placement_id  query  string  false  "Id of asset placement"
+// @Param        asset_id  query  string  false  "Id of asset"
+// @Param        technician_id  query  string  false  "Id of technician"
+// @Param        maintenance_day  query  string  false  "Maintenance Day (such as: Mon)"
+// @Param        room_id  query  string  false  "Id of room"
+// @Param        floor  query  string  false  "Floor"
+// @Param        department  query  string  false  "Department"
+// @Param        created_from  query  string  false  "Created From (YYYY-MM-DD)"
+// @Param        created_to  query  string  false  "Created To (YYYY-MM-DD)"
 func (rc *AssetMaintenanceController) GetAllAssetMaintenance(c *gin.Context) {
 	// Pagination
 	pagination := utils.GetPagination(c)
 
+	// Filter
+	filter := utils.GetFilter(c)
+
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Service: Get All Asset Maintenance
-	assetMaintenance, total, err := rc.AssetMaintenanceService.GetAllAssetMaintenance(pagination)
+	assetMaintenance, total, err := rc.AssetMaintenanceService.GetAllAssetMaintenance(pagination, filter, userID, role)
 	if err != nil {
 		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
 		return
@@ -59,8 +86,20 @@ func (rc *AssetMaintenanceController) GetAllAssetMaintenance(c *gin.Context) {
 // @Failure      404  {object}  entity.ResponseNotFound
 // @Router       /api/v1/assets/maintenances/schedule [get]
 func (rc *AssetMaintenanceController) GetAllAssetMaintenanceSchedule(c *gin.Context) {
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Service: Get All Asset Maintenance
-	assetMaintenance, err := rc.AssetMaintenanceService.GetAllAssetMaintenanceSchedule()
+	assetMaintenance, err := rc.AssetMaintenanceService.GetAllAssetMaintenanceSchedule(userID, role)
 	if err != nil {
 		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
 		return
@@ -184,14 +223,108 @@ func (rc *AssetMaintenanceController) UpdateById(c *gin.Context) {
 	utils.BuildResponseMessage(c, "success", "asset maintenance", "put", http.StatusOK, &req, nil)
 }
 
-// @Summary      Delete Asset Maintenance By Id
-// @Description  Permanentally delete asset maintenance by id
+// @Summary      Get Deleted Asset Maintenance
+// @Description  Returns a list of deleted asset maintenances
+// @Tags         Asset
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetDeletedAssetMaintenance
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/maintenances/deleted [get]
+func (rc *AssetMaintenanceController) GetDeleted(c *gin.Context) {
+	// Service: Get All Deleted Asset Maintenance
+	assetMaintenance, err := rc.AssetMaintenanceService.GetDeleted()
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "deleted asset maintenance", "get", http.StatusOK, assetMaintenance, nil)
+}
+
+// @Summary      Get Asset Maintenance Delete Preview By Id
+// @Description  List every row that is removed along with a deleted asset maintenance when it is permanently deleted. The returned confirmation token is required to permanently delete it
+// @Tags         Asset
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetDeletePreview
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/maintenances/destroy/{id}/delete-preview [get]
+// @Param        id  path  string  true  "Id of asset maintenance"
+func (rc *AssetMaintenanceController) GetDeletePreviewById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetMaintenanceID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Get User Id
+	accountId, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service : Get Asset Maintenance Delete Preview By Id
+	deletePreview, err := rc.AssetMaintenanceService.GetDeletePreviewById(assetMaintenanceID, accountId)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "delete preview", "get", http.StatusOK, deletePreview, nil)
+}
+
+// @Summary      Hard Delete Asset Maintenance By Id
+// @Description  Permanentally delete asset maintenance by id. Every row removed along with it is archived
+// @Tags         Asset
+// @Success      200  {object}  entity.ResponseHardDeleteAssetMaintenanceById
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/maintenances/destroy/{id} [delete]
+// @Param        id  path  string  true  "Id of asset maintenance"
+// @Param        confirmation_token  query  string  true  "Confirmation token from the delete preview"
+func (rc *AssetMaintenanceController) HardDeleteById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+	confirmationToken := c.Query("confirmation_token")
+
+	// Parse Id
+	assetMaintenanceID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Get User Id
+	accountId, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service : Hard Delete Asset Maintenance By Id
+	if err := rc.AssetMaintenanceService.HardDeleteById(assetMaintenanceID, accountId, confirmationToken); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset maintenance", "hard delete", http.StatusOK, nil, nil)
+}
+
+// @Summary      Soft Delete Asset Maintenance By Id
+// @Description  Delete asset maintenance by id. It can be recovered until it is permanently deleted
 // @Tags         Asset
 // @Success      200  {object}  entity.ResponseDeleteAssetMaintenanceById
 // @Failure      400  {object}  entity.ResponseBadRequest
 // @Router       /api/v1/assets/maintenances/{id} [delete]
 // @Param        id  path  string  true  "Id of asset maintenance"
-func (rc *AssetMaintenanceController) DeleteById(c *gin.Context) {
+func (rc *AssetMaintenanceController) SoftDeleteById(c *gin.Context) {
 	// Param
 	id := c.Param("id")
 
@@ -202,8 +335,8 @@ func (rc *AssetMaintenanceController) DeleteById(c *gin.Context) {
 		return
 	}
 
-	// Service : Delete Asset Maintenance By Id
-	if err := rc.AssetMaintenanceService.DeleteById(assetMaintenanceID); err != nil {
+	// Service : Soft Delete Asset Maintenance By Id
+	if err := rc.AssetMaintenanceService.SoftDeleteById(assetMaintenanceID); err != nil {
 		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
 		return
 	}
@@ -212,6 +345,34 @@ func (rc *AssetMaintenanceController) DeleteById(c *gin.Context) {
 	utils.BuildResponseMessage(c, "success", "asset maintenance", "soft delete", http.StatusOK, nil, nil)
 }
 
+// @Summary      Recover Put Deleted Asset Maintenance By Id
+// @Description  Recover deleted asset maintenance by id
+// @Tags         Asset
+// @Success      200  {object}  entity.ResponseRecoverDeleteAssetMaintenanceById
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/maintenances/recover/{id} [put]
+// @Param        id  path  string  true  "Id of asset maintenance"
+func (rc *AssetMaintenanceController) RecoverDeletedById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetMaintenanceID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Recover Deleted Asset Maintenance By Id
+	if err := rc.AssetMaintenanceService.RecoverDeletedById(assetMaintenanceID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset maintenance", "recover", http.StatusOK, nil, nil)
+}
+
 // @Summary      Get Most Context Asset Maintenance
 // @Description  Returns a list of most appear item in asset maintenance by given field
 // @Tags         Asset
@@ -27,12 +27,40 @@ func NewAssetPlacementRepository(assetPlacementService service.AssetPlacementSer
 // @Success      200  {object}  entity.ResponseGetAllAssetPlacement
 // @Failure      404  {object}  entity.ResponseNotFound
 // @Router       /api/v1/assets/placements [get]
+// @Param        search  query  string  false  "Search Term"
+// @Param        sort_by  query  string  false  "Sort Column (asset_qty, created_at, updated_at)"
+// @Param        sort_dir  query  string  false  "Sort Direction (asc or desc), Default desc"
+// @Param        asset_id  query  string  false  "Id of asset"
+// @Param        category  query  string  false  "Asset Category"
+// @Param        room_id  query  string  false  "Id of room"
+// @Param        floor  query  string  false  "Floor"
+// @Param        department  query  string  false  "Department"
+// @Param        owner_id  query  string  false  "Id of technician owning the asset"
+// @Param        qty_min  query  integer  false  "Minimum Asset Qty"
+// @Param        qty_max  query  integer  false  "Maximum Asset Qty"
+// @Param        created_from  query  string  false  "Created From (YYYY-MM-DD)"
+// @Param        created_to  query  string  false  "Created To (YYYY-MM-DD)"
 func (rc *AssetPlacementController) GetAllAssetPlacement(c *gin.Context) {
 	// Pagination
 	pagination := utils.GetPagination(c)
 
+	// Filter
+	filter := utils.GetFilter(c)
+
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Service: Get All Asset Placement
-	assetPlacement, total, err := rc.AssetPlacementService.GetAllAssetPlacement(pagination)
+	assetPlacement, total, err := rc.AssetPlacementService.GetAllAssetPlacement(pagination, filter, userID, role)
 	if err != nil {
 		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
 		return
@@ -135,8 +163,20 @@ func (rc *AssetPlacementController) UpdateById(c *gin.Context) {
 		return
 	}
 
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Service : Update Asset Placement
-	if err := rc.AssetPlacementService.UpdateById(&req, assetPlacementID); err != nil {
+	if err := rc.AssetPlacementService.UpdateById(&req, assetPlacementID, userID, role); err != nil {
 		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
 		return
 	}
@@ -145,14 +185,108 @@ func (rc *AssetPlacementController) UpdateById(c *gin.Context) {
 	utils.BuildResponseMessage(c, "success", "asset placement", "put", http.StatusOK, &req, nil)
 }
 
-// @Summary      Delete Asset Placement By Id
-// @Description  Permanentally delete asset placement by id
+// @Summary      Get Deleted Asset Placement
+// @Description  Returns a list of deleted asset placements
+// @Tags         Asset
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetDeletedAssetPlacement
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/placements/deleted [get]
+func (rc *AssetPlacementController) GetDeleted(c *gin.Context) {
+	// Service: Get All Deleted Asset Placement
+	assetPlacement, err := rc.AssetPlacementService.GetDeleted()
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "deleted asset placement", "get", http.StatusOK, assetPlacement, nil)
+}
+
+// @Summary      Get Asset Placement Delete Preview By Id
+// @Description  List every row that is removed along with a deleted asset placement when it is permanently deleted. The returned confirmation token is required to permanently delete it
+// @Tags         Asset
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetDeletePreview
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/placements/destroy/{id}/delete-preview [get]
+// @Param        id  path  string  true  "Id of asset placement"
+func (rc *AssetPlacementController) GetDeletePreviewById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetPlacementID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Get User Id
+	accountId, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service : Get Asset Placement Delete Preview By Id
+	deletePreview, err := rc.AssetPlacementService.GetDeletePreviewById(assetPlacementID, accountId)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "delete preview", "get", http.StatusOK, deletePreview, nil)
+}
+
+// @Summary      Hard Delete Asset Placement By Id
+// @Description  Permanentally delete asset placement by id. Every row removed along with it is archived
+// @Tags         Asset
+// @Success      200  {object}  entity.ResponseHardDeleteAssetPlacementById
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/placements/destroy/{id} [delete]
+// @Param        id  path  string  true  "Id of asset placement"
+// @Param        confirmation_token  query  string  true  "Confirmation token from the delete preview"
+func (rc *AssetPlacementController) HardDeleteById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+	confirmationToken := c.Query("confirmation_token")
+
+	// Parse Id
+	assetPlacementID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Get User Id
+	accountId, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service : Hard Delete Asset Placement By Id
+	if err := rc.AssetPlacementService.HardDeleteById(assetPlacementID, accountId, confirmationToken); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset placement", "hard delete", http.StatusOK, nil, nil)
+}
+
+// @Summary      Soft Delete Asset Placement By Id
+// @Description  Delete asset placement by id. It can be recovered until it is permanently deleted
 // @Tags         Asset
 // @Success      200  {object}  entity.ResponseDeleteAssetPlacementById
 // @Failure      400  {object}  entity.ResponseBadRequest
 // @Router       /api/v1/assets/placements/{id} [delete]
 // @Param        id  path  string  true  "Id of asset placement"
-func (rc *AssetPlacementController) DeleteById(c *gin.Context) {
+func (rc *AssetPlacementController) SoftDeleteById(c *gin.Context) {
 	// Param
 	id := c.Param("id")
 
@@ -163,8 +297,8 @@ func (rc *AssetPlacementController) DeleteById(c *gin.Context) {
 		return
 	}
 
-	// Service : Delete Asset Placement By Id
-	if err := rc.AssetPlacementService.DeleteById(assetPlacementID); err != nil {
+	// Service : Soft Delete Asset Placement By Id
+	if err := rc.AssetPlacementService.SoftDeleteById(assetPlacementID); err != nil {
 		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
 		return
 	}
@@ -172,3 +306,31 @@ func (rc *AssetPlacementController) DeleteById(c *gin.Context) {
 	// Response
 	utils.BuildResponseMessage(c, "success", "asset placement", "soft delete", http.StatusOK, nil, nil)
 }
+
+// @Summary      Recover Put Deleted Asset Placement By Id
+// @Description  Recover deleted asset placement by id
+// @Tags         Asset
+// @Success      200  {object}  entity.ResponseRecoverDeleteAssetPlacementById
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/placements/recover/{id} [put]
+// @Param        id  path  string  true  "Id of asset placement"
+func (rc *AssetPlacementController) RecoverDeletedById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetPlacementID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Recover Deleted Asset Placement By Id
+	if err := rc.AssetPlacementService.RecoverDeletedById(assetPlacementID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset placement", "recover", http.StatusOK, nil, nil)
+}
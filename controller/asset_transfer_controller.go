@@ -0,0 +1,247 @@
+package controller
+
+import (
+	"math"
+	"net/http"
+	"pelita/entity"
+	"pelita/service"
+	"pelita/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AssetTransferController struct {
+	AssetTransferService service.AssetTransferService
+}
+
+func NewAssetTransferController(assetTransferService service.AssetTransferService) *AssetTransferController {
+	return &AssetTransferController{AssetTransferService: assetTransferService}
+}
+
+// @Summary      Get All Asset Transfer
+// @Description  Returns a paginated list of asset transfer. Technician only see transfer inside its access scope
+// @Tags         Asset
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetAllAssetTransfer
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/transfers [get]
+func (rc *AssetTransferController) GetAllAssetTransfer(c *gin.Context) {
+	// Pagination
+	pagination := utils.GetPagination(c)
+
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service: Get All Asset Transfer
+	assetTransfer, total, err := rc.AssetTransferService.GetAllAssetTransfer(pagination, userID, role)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Response
+	totalPages := int(math.Ceil(float64(total) / float64(pagination.Limit)))
+	metadata := gin.H{
+		"total":       total,
+		"page":        pagination.Page,
+		"limit":       pagination.Limit,
+		"total_pages": totalPages,
+	}
+	utils.BuildResponseMessage(c, "success", "asset transfer", "get", http.StatusOK, assetTransfer, metadata)
+}
+
+// @Summary      Get All Asset Movement By Asset Id
+// @Description  Returns a paginated movement log of an asset
+// @Tags         Asset
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetAllAssetMovement
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/{id}/movements [get]
+// @Param        id  path  string  true  "Id of asset"
+func (rc *AssetTransferController) GetAllMovementByAssetId(c *gin.Context) {
+	rc.getAllMovementById(c, false)
+}
+
+// @Summary      Get All Asset Movement By Room Id
+// @Description  Returns a paginated movement log of every asset moved in or out of a room
+// @Tags         Asset
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetAllAssetMovement
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/movements/rooms/{id} [get]
+// @Param        id  path  string  true  "Id of room"
+func (rc *AssetTransferController) GetAllMovementByRoomId(c *gin.Context) {
+	rc.getAllMovementById(c, true)
+}
+
+// @Summary      Post Request Asset Transfer
+// @Description  Request to move some quantity of an asset placement into another room. The transfer must be approved before the asset is moved
+// @Tags         Asset
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPostCreateAssetTransfer  true  "Post Asset Transfer Request Body"
+// @Success      201  {object}  entity.ResponsePostCreateAssetTransfer
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/transfers [post]
+func (rc *AssetTransferController) Create(c *gin.Context) {
+	// Model
+	var req entity.RequestPostCreateAssetTransfer
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service : Create Asset Transfer
+	assetTransfer := entity.AssetTransfer{
+		SourcePlacementId: req.SourcePlacementId,
+		TargetRoomId:      req.TargetRoomId,
+		TransferQty:       req.TransferQty,
+		TransferNotes:     req.TransferNotes,
+	}
+	if err := rc.AssetTransferService.Create(&assetTransfer, userID, role); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset transfer", "request", http.StatusCreated, &assetTransfer, nil)
+}
+
+// @Summary      Put Approve Asset Transfer By Id
+// @Description  Approve a requested asset transfer by Id. The quantity and its unit are moved from the source placement into the target room
+// @Tags         Asset
+// @Success      200  {object}  entity.ResponsePutUpdateAssetTransfer
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/transfers/approve/{id} [put]
+// @Param        id  path  string  true  "Id of asset transfer"
+func (rc *AssetTransferController) ApproveById(c *gin.Context) {
+	rc.updateApprovalById(c, true)
+}
+
+// @Summary      Put Reject Asset Transfer By Id
+// @Description  Reject a requested asset transfer by Id
+// @Tags         Asset
+// @Success      200  {object}  entity.ResponsePutUpdateAssetTransfer
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/transfers/reject/{id} [put]
+// @Param        id  path  string  true  "Id of asset transfer"
+func (rc *AssetTransferController) RejectById(c *gin.Context) {
+	rc.updateApprovalById(c, false)
+}
+
+func (rc *AssetTransferController) getAllMovementById(c *gin.Context, isRoom bool) {
+	// Param
+	id := c.Param("id")
+
+	// Pagination
+	pagination := utils.GetPagination(c)
+
+	// Parse Id
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service: Get All Asset Movement By Asset Or Room Id
+	var assetMovement []entity.AssetMovement
+	var total int64
+	if isRoom {
+		assetMovement, total, err = rc.AssetTransferService.GetAllMovementByRoomId(pagination, parsedID, userID, role)
+	} else {
+		assetMovement, total, err = rc.AssetTransferService.GetAllMovementByAssetId(pagination, parsedID, userID, role)
+	}
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Response
+	totalPages := int(math.Ceil(float64(total) / float64(pagination.Limit)))
+	metadata := gin.H{
+		"total":       total,
+		"page":        pagination.Page,
+		"limit":       pagination.Limit,
+		"total_pages": totalPages,
+	}
+	utils.BuildResponseMessage(c, "success", "asset movement", "get", http.StatusOK, assetMovement, metadata)
+}
+
+func (rc *AssetTransferController) updateApprovalById(c *gin.Context, isApproved bool) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetTransferID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service : Approve Or Reject Asset Transfer By Id
+	method := "approve"
+	if isApproved {
+		err = rc.AssetTransferService.ApproveById(assetTransferID, userID, role)
+	} else {
+		method = "reject"
+		err = rc.AssetTransferService.RejectById(assetTransferID, userID)
+	}
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset transfer", method, http.StatusOK, nil, nil)
+}
@@ -0,0 +1,387 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"pelita/entity"
+	"pelita/service"
+	"pelita/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AssetUnitController struct {
+	AssetUnitService service.AssetUnitService
+}
+
+func NewAssetUnitController(assetUnitService service.AssetUnitService) *AssetUnitController {
+	return &AssetUnitController{AssetUnitService: assetUnitService}
+}
+
+// @Summary      Get All Asset Unit By Asset Placement Id
+// @Description  Returns every unit of an asset placement
+// @Tags         Asset
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetAllAssetUnit
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/placements/{id}/units [get]
+// @Param        id  path  string  true  "Id of asset placement"
+func (rc *AssetUnitController) GetAllByAssetPlacementId(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetPlacementID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Get All Asset Unit By Asset Placement Id
+	assetUnit, err := rc.AssetUnitService.GetAllByAssetPlacementId(assetPlacementID, userID, role)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset unit", "get", http.StatusOK, assetUnit, nil)
+}
+
+// @Summary      Post Generate Asset Unit By Asset Placement Id
+// @Description  Generate missing unit so the total unit match the asset placement quantity
+// @Tags         Asset
+// @Produce      json
+// @Success      201  {object}  entity.ResponsePostGenerateAssetUnit
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/placements/{id}/units [post]
+// @Param        id  path  string  true  "Id of asset placement"
+func (rc *AssetUnitController) GenerateByAssetPlacementId(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetPlacementID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Generate Asset Unit By Asset Placement Id
+	assetUnit, err := rc.AssetUnitService.GenerateByAssetPlacementId(assetPlacementID)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset unit", "post", http.StatusCreated, assetUnit, nil)
+}
+
+// @Summary      Put Update Asset Unit By Id
+// @Description  Update serial number and condition of an asset unit by Id
+// @Tags         Asset
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPutUpdateAssetUnit  true  "Put Update Asset Unit Request Body"
+// @Success      200  {object}  entity.ResponsePutUpdateAssetUnit
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/units/{id} [put]
+// @Param        id  path  string  true  "Id of asset unit"
+func (rc *AssetUnitController) UpdateById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Model
+	var req entity.RequestPutUpdateAssetUnit
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Parse Id
+	assetUnitID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Update Asset Unit By Id
+	assetUnit := entity.AssetUnit{
+		SerialNumber:  req.SerialNumber,
+		UnitCondition: req.UnitCondition,
+	}
+	if err := rc.AssetUnitService.UpdateById(&assetUnit, assetUnitID, userID, role); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset unit", "put", http.StatusOK, nil, nil)
+}
+
+// @Summary      Put Move Asset Unit By Id
+// @Description  Move an asset unit to another placement of the same asset. Quantity of both placement is adjusted and the move is logged
+// @Tags         Asset
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPutMoveAssetUnit  true  "Put Move Asset Unit Request Body"
+// @Success      200  {object}  entity.ResponsePutUpdateAssetUnit
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/units/move/{id} [put]
+// @Param        id  path  string  true  "Id of asset unit"
+func (rc *AssetUnitController) MoveById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Model
+	var req entity.RequestPutMoveAssetUnit
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Parse Id
+	assetUnitID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service : Move Asset Unit By Id
+	if err := rc.AssetUnitService.MoveById(req.AssetPlacementId, assetUnitID, userID, role); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset unit", "put", http.StatusOK, nil, nil)
+}
+
+// @Summary      Delete Asset Unit By Id
+// @Description  Delete an asset unit by Id. Quantity of its placement is reduced
+// @Tags         Asset
+// @Success      200  {object}  entity.ResponseDeleteAssetUnit
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/units/{id} [delete]
+// @Param        id  path  string  true  "Id of asset unit"
+func (rc *AssetUnitController) DeleteById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetUnitID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Delete Asset Unit By Id
+	if err := rc.AssetUnitService.DeleteById(assetUnitID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset unit", "hard delete", http.StatusOK, nil, nil)
+}
+
+// @Summary      Get Asset Tag Lookup
+// @Description  Resolve a scanned asset tag to its unit, asset, placement, and room
+// @Tags         Asset
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetAssetTagLookup
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/tags/{tag} [get]
+// @Param        tag  path  string  true  "Asset tag"
+func (rc *AssetUnitController) GetLookupByTag(c *gin.Context) {
+	// Param
+	tag := c.Param("tag")
+
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Get Asset Tag Lookup
+	lookup, err := rc.AssetUnitService.GetLookupByTag(tag, userID, role)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "asset tag", "get", http.StatusOK, lookup, nil)
+}
+
+// @Summary      Get Asset Tag QR Code
+// @Description  Returns the QR code image of an asset tag
+// @Tags         Asset
+// @Produce      png
+// @Success      200  {file}  binary
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/tags/{tag}/qr [get]
+// @Param        tag  path  string  true  "Asset tag"
+func (rc *AssetUnitController) GetQRCodeByTag(c *gin.Context) {
+	// Param
+	tag := c.Param("tag")
+
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Get Asset Tag QR Code
+	image, err := rc.AssetUnitService.GetQRCodeByTag(tag, userID, role)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Response
+	c.Data(http.StatusOK, "image/png", image)
+}
+
+// @Summary      Get Asset Tag Barcode
+// @Description  Returns the Code128 barcode image of an asset tag
+// @Tags         Asset
+// @Produce      png
+// @Success      200  {file}  binary
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/tags/{tag}/barcode [get]
+// @Param        tag  path  string  true  "Asset tag"
+func (rc *AssetUnitController) GetBarcodeByTag(c *gin.Context) {
+	// Param
+	tag := c.Param("tag")
+
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Get Asset Tag Barcode
+	image, err := rc.AssetUnitService.GetBarcodeByTag(tag, userID, role)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Response
+	c.Data(http.StatusOK, "image/png", image)
+}
+
+// @Summary      Get Asset Tag Label Sheet
+// @Description  Returns a printable PDF label sheet of every asset tag in a room or an asset placement
+// @Tags         Asset
+// @Produce      application/pdf
+// @Success      200  {file}  binary
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/tags/labels [get]
+// @Param        room_id  query  string  false  "Id of room"
+// @Param        asset_placement_id  query  string  false  "Id of asset placement"
+func (rc *AssetUnitController) GetLabelSheet(c *gin.Context) {
+	// Query Param
+	var roomID, assetPlacementID *uuid.UUID
+	if id := c.Query("room_id"); id != "" {
+		parsedID, err := uuid.Parse(id)
+		if err != nil {
+			utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+			return
+		}
+		roomID = &parsedID
+	}
+	if id := c.Query("asset_placement_id"); id != "" {
+		parsedID, err := uuid.Parse(id)
+		if err != nil {
+			utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+			return
+		}
+		assetPlacementID = &parsedID
+	}
+
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Get Asset Tag Label Sheet
+	sheet, err := rc.AssetUnitService.GetLabelSheet(roomID, assetPlacementID, userID, role)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=asset_tag_label_%s.pdf", time.Now().Format("20060102150405")))
+	c.Data(http.StatusOK, "application/pdf", sheet)
+}
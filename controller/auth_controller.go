@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 	"pelita/entity"
 	"pelita/service"
@@ -8,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type AuthController struct {
@@ -38,18 +40,14 @@ func (ac *AuthController) Register(c *gin.Context) {
 	}
 
 	// Register Token
-	token, err := ac.AuthService.Register(&req)
+	loginData, err := ac.AuthService.Register(&req, c.GetHeader("User-Agent"), c.ClientIP())
 	if err != nil {
 		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	// Response
-	utils.BuildResponseMessage(c, "success", "user", "register", http.StatusCreated, gin.H{
-		"data": gin.H{
-			"access_token": token,
-		},
-	}, nil)
+	utils.BuildResponseMessage(c, "success", "user", "register", http.StatusCreated, loginData, nil)
 }
 
 // @Summary      Post Login
@@ -60,6 +58,7 @@ func (ac *AuthController) Register(c *gin.Context) {
 // @Param        request  body  entity.UserAuth true  "Post Login Request Body"
 // @Success      200  {object}  entity.ResponsePostLogin
 // @Failure      400  {object}  entity.ResponseBadRequest
+// @Failure      429  {object}  entity.ResponseBadRequest
 // @Router       /api/v1/auths/login [post]
 func (ac *AuthController) Login(c *gin.Context) {
 	// Model
@@ -72,17 +71,48 @@ func (ac *AuthController) Login(c *gin.Context) {
 	}
 
 	// Token Generate
-	token, role, err := ac.AuthService.Login(req.Email, req.Password)
+	loginData, err := ac.AuthService.Login(req.Email, req.Password, c.GetHeader("User-Agent"), c.ClientIP())
 	if err != nil {
+		if errors.Is(err, service.ErrAccountLocked) {
+			utils.BuildErrorMessage(c, http.StatusTooManyRequests, err.Error())
+			return
+		}
 		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	// Response
-	utils.BuildResponseMessage(c, "success", "user", "login", http.StatusOK, gin.H{
-		"role":         role,
-		"access_token": token,
-	}, nil)
+	utils.BuildResponseMessage(c, "success", "user", "login", http.StatusOK, loginData, nil)
+}
+
+// @Summary      Post Refresh Token
+// @Description  Exchange a refresh token for a new access token and refresh token
+// @Tags         Auth
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPostRefreshToken true  "Post Refresh Token Request Body"
+// @Success      200  {object}  entity.ResponsePostRefreshToken
+// @Failure      401  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/auths/refresh [post]
+func (ac *AuthController) RefreshToken(c *gin.Context) {
+	// Model
+	var req entity.RequestPostRefreshToken
+
+	// Validator
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Rotate Refresh Token
+	loginData, err := ac.AuthService.RefreshToken(req.RefreshToken)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "token", "refresh", http.StatusOK, loginData, nil)
 }
 
 // @Summary      Post Sign Out
@@ -119,3 +149,205 @@ func (ac *AuthController) SignOut(c *gin.Context) {
 	// Response
 	utils.BuildResponseMessage(c, "success", "user", "sign out", http.StatusOK, nil, nil)
 }
+
+// @Summary      Get My Session
+// @Description  Returns a list of active sessions (devices) of the current account
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetMySession
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/auths/sessions [get]
+func (ac *AuthController) GetMySession(c *gin.Context) {
+	// Get User Id
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Get Session Id (Token Issued Before Session Exist Has No Session)
+	sessionID, _ := utils.GetCurrentSessionID(c)
+
+	// Service : Get My Session
+	sessions, err := ac.AuthService.GetMySession(userID, sessionID)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "session", "get", http.StatusOK, sessions, nil)
+}
+
+// @Summary      Delete Session By Id
+// @Description  Revoke a session (device). Admin can revoke session of any account
+// @Tags         Auth
+// @Success      200  {object}  entity.ResponseDeleteSessionById
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/auths/sessions/{id} [delete]
+// @Param        id  path  string  true  "Id of session"
+func (ac *AuthController) RevokeSessionById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	sessionID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Get User Id
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Get Role
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service : Revoke Session By Id
+	if err := ac.AuthService.RevokeSessionById(sessionID, userID, role); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "session", "revoke", http.StatusOK, nil, nil)
+}
+
+// @Summary      Put Change Password
+// @Description  Change password of the current account. Every session, including the current one, will be revoked, so login again with the new password
+// @Tags         Auth
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPutChangePassword true  "Put Change Password Request Body"
+// @Success      200  {object}  entity.ResponsePutChangePassword
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/auths/password [put]
+func (ac *AuthController) ChangePassword(c *gin.Context) {
+	// Model
+	var req entity.RequestPutChangePassword
+
+	// Validator
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get User Id
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Get Role
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service : Change Password
+	if err := ac.AuthService.ChangePassword(userID, role, req.OldPassword, req.NewPassword); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "password", "put", http.StatusOK, nil, nil)
+}
+
+// @Summary      Post Forgot Password
+// @Description  Request a one-time password reset token. The token is delivered through the configured notifier
+// @Tags         Auth
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPostForgotPassword true  "Post Forgot Password Request Body"
+// @Success      200  {object}  entity.ResponsePostForgotPassword
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/auths/password/forgot [post]
+func (ac *AuthController) ForgotPassword(c *gin.Context) {
+	// Model
+	var req entity.RequestPostForgotPassword
+
+	// Validator
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Forgot Password
+	if err := ac.AuthService.ForgotPassword(req.Email); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "password reset", "request", http.StatusOK, nil, nil)
+}
+
+// @Summary      Post Reset Password
+// @Description  Reset password using the one-time reset token. Every existing token of the account will be revoked
+// @Tags         Auth
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPostResetPassword true  "Post Reset Password Request Body"
+// @Success      200  {object}  entity.ResponsePostResetPassword
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/auths/password/reset [post]
+func (ac *AuthController) ResetPassword(c *gin.Context) {
+	// Model
+	var req entity.RequestPostResetPassword
+
+	// Validator
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Reset Password
+	if err := ac.AuthService.ResetPassword(req.Token, req.NewPassword); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "password", "reset", http.StatusOK, nil, nil)
+}
+
+// @Summary      Put Unlock Account
+// @Description  Clear failed login attempts and lockout of an account. Only admin can use this feature
+// @Tags         Auth
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPutUnlockAccount true  "Put Unlock Account Request Body"
+// @Success      200  {object}  entity.ResponsePutUnlockAccount
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/auths/unlock [put]
+func (ac *AuthController) UnlockAccount(c *gin.Context) {
+	// Model
+	var req entity.RequestPutUnlockAccount
+
+	// Validator
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Unlock Account
+	if err := ac.AuthService.UnlockAccount(req.Email, req.IPAddress); err != nil {
+		utils.BuildErrorMessage(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "account", "unlock", http.StatusOK, nil, nil)
+}
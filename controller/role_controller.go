@@ -0,0 +1,245 @@
+package controller
+
+import (
+	"math"
+	"net/http"
+	"pelita/entity"
+	"pelita/service"
+	"pelita/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RoleController struct {
+	RoleService service.RoleService
+}
+
+func NewRoleController(roleService service.RoleService) *RoleController {
+	return &RoleController{RoleService: roleService}
+}
+
+// @Summary      Get All Role
+// @Description  Returns a paginated list of role with its permission
+// @Tags         Role
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetAllRole
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/roles [get]
+func (rc *RoleController) GetAllRole(c *gin.Context) {
+	// Pagination
+	pagination := utils.GetPagination(c)
+
+	// Service: Get All Role
+	roles, total, err := rc.RoleService.GetAllRole(pagination)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	totalPages := int(math.Ceil(float64(total) / float64(pagination.Limit)))
+	metadata := gin.H{
+		"total":       total,
+		"page":        pagination.Page,
+		"limit":       pagination.Limit,
+		"total_pages": totalPages,
+	}
+	utils.BuildResponseMessage(c, "success", "role", "get", http.StatusOK, roles, metadata)
+}
+
+// @Summary      Get All Permission
+// @Description  Returns a list of permission that can be granted to a role
+// @Tags         Role
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetAllPermission
+// @Router       /api/v1/roles/permissions [get]
+func (rc *RoleController) GetAllPermission(c *gin.Context) {
+	// Service: Get All Permission
+	permissions := rc.RoleService.GetAllPermission()
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "permission", "get", http.StatusOK, permissions, nil)
+}
+
+// @Summary      Post Create Role
+// @Description  Create a custom role with its permission
+// @Tags         Role
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPostCreateUpdateRole true  "Post Create Role Request Body"
+// @Success      201  {object}  entity.ResponsePostCreateRole
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/roles [post]
+func (rc *RoleController) Create(c *gin.Context) {
+	// Model
+	var req entity.RequestPostCreateUpdateRole
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get User ID
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Create Role
+	role := entity.Role{
+		RoleName: req.RoleName,
+		RoleDesc: req.RoleDesc,
+	}
+	if err := rc.RoleService.Create(&role, req.Permissions, userID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "role", "post", http.StatusCreated, &role, nil)
+}
+
+// @Summary      Put Update Role
+// @Description  Update a role and replace its permission by id
+// @Tags         Role
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPostCreateUpdateRole true  "Put Update Role Request Body"
+// @Success      200  {object}  entity.ResponsePutUpdateRole
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/roles/{id} [put]
+// @Param        id  path  string  true  "Id of role"
+func (rc *RoleController) UpdateById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Model
+	var req entity.RequestPostCreateUpdateRole
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Parse Id
+	roleID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID Format")
+		return
+	}
+
+	// Service : Update Role
+	role := entity.Role{
+		RoleName: req.RoleName,
+		RoleDesc: req.RoleDesc,
+	}
+	if err := rc.RoleService.UpdateById(&role, req.Permissions, roleID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "role", "put", http.StatusOK, &role, nil)
+}
+
+// @Summary      Delete Role By Id
+// @Description  Permanentally delete custom role by id. Account that use this role will fallback to its system role
+// @Tags         Role
+// @Success      200  {object}  entity.ResponseDeleteRoleById
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/roles/{id} [delete]
+// @Param        id  path  string  true  "Id of role"
+func (rc *RoleController) DeleteById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	roleID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Delete Role By Id
+	if err := rc.RoleService.DeleteById(roleID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "role", "hard delete", http.StatusOK, nil, nil)
+}
+
+// @Summary      Put Assign Role
+// @Description  Assign a custom role to an account. The account will use the custom role permission instead of its system role
+// @Tags         Role
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPutAssignRole true  "Put Assign Role Request Body"
+// @Success      200  {object}  entity.ResponsePutAssignRole
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/roles/{id}/accounts [put]
+// @Param        id  path  string  true  "Id of role"
+func (rc *RoleController) AssignRoleToAccount(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Model
+	var req entity.RequestPutAssignRole
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Parse Id
+	roleID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID Format")
+		return
+	}
+
+	// Service : Assign Role To Account
+	if err := rc.RoleService.AssignRoleToAccount(roleID, req.AccountId, req.AccountType); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "account role", "put", http.StatusOK, nil, nil)
+}
+
+// @Summary      Delete Account Role
+// @Description  Remove custom role from an account. The account will fallback to its system role
+// @Tags         Role
+// @Success      200  {object}  entity.ResponseDeleteRoleById
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/roles/accounts/{accountId} [delete]
+// @Param        accountId  path  string  true  "Id of account"
+func (rc *RoleController) RemoveRoleFromAccount(c *gin.Context) {
+	// Param
+	id := c.Param("accountId")
+
+	// Parse Id
+	accountID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Remove Role From Account
+	if err := rc.RoleService.RemoveRoleFromAccount(accountID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "account role", "hard delete", http.StatusOK, nil, nil)
+}
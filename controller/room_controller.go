@@ -65,8 +65,20 @@ func (rc *RoomController) GetRoomAssetByFloorAndRoomName(c *gin.Context) {
 	roomName := c.Param("roomName")
 	floor := c.Param("floor")
 
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Service: Get Find Room Asset By Floor And Room Name
-	room, err := rc.RoomService.GetRoomAssetByFloorAndRoomName(floor, roomName)
+	room, err := rc.RoomService.GetRoomAssetByFloorAndRoomName(floor, roomName, userID, role)
 	if err != nil {
 		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
 		return
@@ -91,8 +103,20 @@ func (rc *RoomController) GetRoomAssetShortByFloorAndRoomName(c *gin.Context) {
 	roomName := c.Param("roomName")
 	floor := c.Param("floor")
 
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Service: Get Find Room Asset Short By Floor And Room Name
-	room, err := rc.RoomService.GetRoomAssetShortByFloorAndRoomName(floor, roomName)
+	room, err := rc.RoomService.GetRoomAssetShortByFloorAndRoomName(floor, roomName, userID, role)
 	if err != nil {
 		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
 		return
@@ -220,14 +244,136 @@ func (rc *RoomController) UpdateById(c *gin.Context) {
 	utils.BuildResponseMessage(c, "success", "room", "put", http.StatusOK, &req, nil)
 }
 
-// @Summary      Delete Room By Id
-// @Description  Permanentally delete room by id
+// @Summary      Get Deleted Room
+// @Description  Returns a list of deleted rooms
+// @Tags         Room
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetDeletedRoom
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/rooms/deleted [get]
+func (rc *RoomController) GetDeleted(c *gin.Context) {
+	// Service: Get All Deleted Room
+	room, err := rc.RoomService.GetDeleted()
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "deleted room", "get", http.StatusOK, room, nil)
+}
+
+// @Summary      Get Room Delete Preview By Id
+// @Description  List every row that is removed along with a deleted room when it is permanently deleted. The returned confirmation token is required to permanently delete it
+// @Tags         Room
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetDeletePreview
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/rooms/destroy/{id}/delete-preview [get]
+// @Param        id  path  string  true  "Id of room"
+func (rc *RoomController) GetDeletePreviewById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	roomID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Get User Id
+	accountId, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service : Get Room Delete Preview By Id
+	deletePreview, err := rc.RoomService.GetDeletePreviewById(roomID, accountId)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "delete preview", "get", http.StatusOK, deletePreview, nil)
+}
+
+// @Summary      Hard Delete Room By Id
+// @Description  Permanentally delete room by id. Every row removed along with it is archived
+// @Tags         Room
+// @Success      200  {object}  entity.ResponseHardDeleteRoomById
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/rooms/destroy/{id} [delete]
+// @Param        id  path  string  true  "Id of room"
+// @Param        confirmation_token  query  string  true  "Confirmation token from the delete preview"
+func (rc *RoomController) HardDeleteById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+	confirmationToken := c.Query("confirmation_token")
+
+	// Parse Id
+	roomID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Get User Id
+	accountId, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service : Hard Delete Room By Id
+	if err := rc.RoomService.HardDeleteById(roomID, accountId, confirmationToken); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "room", "hard delete", http.StatusOK, nil, nil)
+}
+
+// @Summary      Soft Delete Room By Id
+// @Description  Delete room by id. It can be recovered until it is permanently deleted
 // @Tags         Room
 // @Success      200  {object}  entity.ResponseDeleteRoomById
 // @Failure      400  {object}  entity.ResponseBadRequest
 // @Router       /api/v1/rooms/{id} [delete]
 // @Param        id  path  string  true  "Id of room"
-func (rc *RoomController) DeleteById(c *gin.Context) {
+func (rc *RoomController) SoftDeleteById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	roomID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Soft Delete Room By Id
+	if err := rc.RoomService.SoftDeleteById(roomID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "room", "soft delete", http.StatusOK, nil, nil)
+}
+
+// @Summary      Recover Put Deleted Room By Id
+// @Description  Recover deleted room by id
+// @Tags         Room
+// @Success      200  {object}  entity.ResponseRecoverDeleteRoomById
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/rooms/recover/{id} [put]
+// @Param        id  path  string  true  "Id of room"
+func (rc *RoomController) RecoverDeletedById(c *gin.Context) {
 	// Param
 	id := c.Param("id")
 
@@ -238,14 +384,14 @@ func (rc *RoomController) DeleteById(c *gin.Context) {
 		return
 	}
 
-	// Service : Delete Room By Id
-	if err := rc.RoomService.DeleteById(roomID); err != nil {
+	// Service : Recover Deleted Room By Id
+	if err := rc.RoomService.RecoverDeletedById(roomID); err != nil {
 		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	// Response
-	utils.BuildResponseMessage(c, "success", "room", "delete", http.StatusOK, nil, nil)
+	utils.BuildResponseMessage(c, "success", "room", "recover", http.StatusOK, nil, nil)
 }
 
 // @Summary      Get Most Context Room
@@ -156,14 +156,108 @@ func (rc *TechnicianController) UpdateById(c *gin.Context) {
 	utils.BuildResponseMessage(c, "success", "technician", "put", http.StatusOK, nil, nil)
 }
 
-// @Summary      Delete Technician By Id
-// @Description  Permanentally delete technician by id
+// @Summary      Get Deleted Technician
+// @Description  Returns a list of deleted technicians
+// @Tags         Technician
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetDeletedTechnician
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/technicians/deleted [get]
+func (rc *TechnicianController) GetDeleted(c *gin.Context) {
+	// Service: Get All Deleted Technician
+	technician, err := rc.TechnicianService.GetDeleted()
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "deleted technician", "get", http.StatusOK, technician, nil)
+}
+
+// @Summary      Get Technician Delete Preview By Id
+// @Description  List every row that is removed along with a deleted technician when it is permanently deleted. The returned confirmation token is required to permanently delete it
+// @Tags         Technician
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetDeletePreview
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/technicians/destroy/{id}/delete-preview [get]
+// @Param        id  path  string  true  "Id of technician"
+func (rc *TechnicianController) GetDeletePreviewById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	technicianID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Get User Id
+	accountId, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service : Get Technician Delete Preview By Id
+	deletePreview, err := rc.TechnicianService.GetDeletePreviewById(technicianID, accountId)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "delete preview", "get", http.StatusOK, deletePreview, nil)
+}
+
+// @Summary      Hard Delete Technician By Id
+// @Description  Permanentally delete technician by id. Every row removed along with it is archived
+// @Tags         Technician
+// @Success      200  {object}  entity.ResponseHardDeleteTechnicianById
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/technicians/destroy/{id} [delete]
+// @Param        id  path  string  true  "Id of technician"
+// @Param        confirmation_token  query  string  true  "Confirmation token from the delete preview"
+func (rc *TechnicianController) HardDeleteById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+	confirmationToken := c.Query("confirmation_token")
+
+	// Parse Id
+	technicianID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Get User Id
+	accountId, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service : Hard Delete Technician By Id
+	if err := rc.TechnicianService.HardDeleteById(technicianID, accountId, confirmationToken); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "technician", "hard delete", http.StatusOK, nil, nil)
+}
+
+// @Summary      Soft Delete Technician By Id
+// @Description  Delete technician by id. It can be recovered until it is permanently deleted
 // @Tags         Technician
 // @Success      200  {object}  entity.ResponseDeleteTechnicianById
 // @Failure      400  {object}  entity.ResponseBadRequest
 // @Router       /api/v1/technicians/{id} [delete]
 // @Param        id  path  string  true  "Id of technician"
-func (rc *TechnicianController) DeleteById(c *gin.Context) {
+func (rc *TechnicianController) SoftDeleteById(c *gin.Context) {
 	// Param
 	id := c.Param("id")
 
@@ -174,8 +268,8 @@ func (rc *TechnicianController) DeleteById(c *gin.Context) {
 		return
 	}
 
-	// Service : Delete Technician By Id
-	if err := rc.TechnicianService.DeleteById(technicianID); err != nil {
+	// Service : Soft Delete Technician By Id
+	if err := rc.TechnicianService.SoftDeleteById(technicianID); err != nil {
 		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
 		return
 	}
@@ -183,3 +277,106 @@ func (rc *TechnicianController) DeleteById(c *gin.Context) {
 	// Response
 	utils.BuildResponseMessage(c, "success", "technician", "soft delete", http.StatusOK, nil, nil)
 }
+
+// @Summary      Recover Put Deleted Technician By Id
+// @Description  Recover deleted technician by id
+// @Tags         Technician
+// @Success      200  {object}  entity.ResponseRecoverDeleteTechnicianById
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/technicians/recover/{id} [put]
+// @Param        id  path  string  true  "Id of technician"
+func (rc *TechnicianController) RecoverDeletedById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	technicianID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Recover Deleted Technician By Id
+	if err := rc.TechnicianService.RecoverDeletedById(technicianID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "technician", "recover", http.StatusOK, nil, nil)
+}
+
+// @Summary      Get Technician Scope By Id
+// @Description  Returns the department and floor scope of a technician. Empty scope means the technician can see nothing until a department or floor is assigned
+// @Tags         Technician
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetTechnicianScope
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/technicians/{id}/scopes [get]
+// @Param        id  path  string  true  "Id of technician"
+func (rc *TechnicianController) GetScopeById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	technicianID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Get Scope By Id
+	scope, err := rc.TechnicianService.GetScopeById(technicianID)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "technician scope", "get", http.StatusOK, scope, nil)
+}
+
+// @Summary      Put Update Technician Scope By Id
+// @Description  Replace the department and floor scope of a technician
+// @Tags         Technician
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPutTechnicianScope  true  "Update Technician Scope Request Body"
+// @Success      200  {object}  entity.ResponsePutTechnicianScope
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/technicians/{id}/scopes [put]
+// @Param        id  path  string  true  "Id of technician"
+func (rc *TechnicianController) UpdateScopeById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Model
+	var req entity.RequestPutTechnicianScope
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Parse Id
+	technicianID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Update Scope By Id
+	scope := entity.AccessScope{
+		Departments: req.Departments,
+		Floors:      req.Floors,
+	}
+	if err := rc.TechnicianService.UpdateScopeById(&scope, technicianID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "technician scope", "put", http.StatusOK, &scope, nil)
+}
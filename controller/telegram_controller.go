@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"log"
+	"net/http"
+	"pelita/config"
+	"pelita/service"
+	"pelita/utils"
+
+	"github.com/gin-gonic/gin"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+type TelegramController struct {
+	TelegramService service.TelegramService
+}
+
+func NewTelegramController(telegramService service.TelegramService) *TelegramController {
+	return &TelegramController{TelegramService: telegramService}
+}
+
+// @Summary      Post Create Telegram Link Code
+// @Description  Generate a one-time code. Send the code to the Telegram bot to link and verify the Telegram account
+// @Tags         Auth
+// @Produce      json
+// @Success      201  {object}  entity.ResponsePostTelegramLink
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/auths/telegram [post]
+func (rc *TelegramController) CreateLinkCode(c *gin.Context) {
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Create Link Code
+	linkCode, err := rc.TelegramService.CreateLinkCode(userID, role)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "telegram link code", "post", http.StatusCreated, linkCode, nil)
+}
+
+// @Summary      Delete Telegram Link
+// @Description  Unlink Telegram account from the current account
+// @Tags         Auth
+// @Produce      json
+// @Success      200  {object}  entity.ResponseDeleteTelegramLink
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/auths/telegram [delete]
+func (rc *TelegramController) Unlink(c *gin.Context) {
+	// Get User ID & Role
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Unlink
+	if err := rc.TelegramService.Unlink(userID, role); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "telegram", "unlink", http.StatusOK, nil, nil)
+}
+
+// @Summary      Post Telegram Webhook
+// @Description  Receive update from Telegram Bot API. Request must contain X-Telegram-Bot-Api-Secret-Token header
+// @Tags         Telegram
+// @Accept       application/json
+// @Produce      json
+// @Success      200  {object}  entity.ResponseBadRequest
+// @Failure      401  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/telegram/webhook [post]
+func (rc *TelegramController) Webhook(c *gin.Context) {
+	// Validator : Secret Token
+	secret := config.GetTelegramWebhookSecret()
+	if secret == "" || c.GetHeader("X-Telegram-Bot-Api-Secret-Token") != secret {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, "invalid webhook secret token")
+		return
+	}
+
+	// Model
+	var req tgbotapi.Update
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Handle Update, Telegram Retry Non 2xx Response So Only Log The Error
+	if err := rc.TelegramService.HandleUpdate(req); err != nil {
+		log.Println("Failed to handle telegram update:", err)
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "telegram update", "handle", http.StatusOK, nil, nil)
+}
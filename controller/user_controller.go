@@ -2,6 +2,7 @@ package controller
 
 import (
 	"net/http"
+	"pelita/entity"
 	"pelita/service"
 	"pelita/utils"
 
@@ -49,3 +50,76 @@ func (ac *UserController) GetMyProfile(c *gin.Context) {
 	// Response
 	utils.BuildResponseMessage(c, "success", "user", "get", http.StatusOK, user, nil)
 }
+
+// @Summary      Put My Profile
+// @Description  Update username, email, and telegram ID of the current account. Changed email must be confirmed with the token sent to the new email before it is applied
+// @Tags         User
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPutUpdateProfile true  "Put My Profile Request Body"
+// @Success      200  {object}  entity.ResponsePutUpdateProfile
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/profile [put]
+func (ac *UserController) UpdateMyProfile(c *gin.Context) {
+	// Model
+	var req entity.RequestPutUpdateProfile
+
+	// Validator
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get User Id
+	userID, err := utils.GetCurrentUserID(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Get Role
+	role, err := utils.GetCurrentRole(c)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Service : Update My Profile
+	result, err := ac.UserService.UpdateMyProfile(userID, role, &req)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "profile", "put", http.StatusOK, result, nil)
+}
+
+// @Summary      Post Confirm Email
+// @Description  Confirm the changed email using the one-time confirmation token. Every existing token of the account will be revoked
+// @Tags         User
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPostConfirmEmail true  "Post Confirm Email Request Body"
+// @Success      200  {object}  entity.ResponsePostConfirmEmail
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/profile/email/confirm [post]
+func (ac *UserController) ConfirmEmail(c *gin.Context) {
+	// Model
+	var req entity.RequestPostConfirmEmail
+
+	// Validator
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Confirm Email
+	if err := ac.UserService.ConfirmEmail(req.Token); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "email", "put", http.StatusOK, nil, nil)
+}
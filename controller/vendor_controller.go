@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"math"
+	"net/http"
+	"pelita/entity"
+	"pelita/service"
+	"pelita/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type VendorController struct {
+	VendorService service.VendorService
+}
+
+func NewVendorController(vendorService service.VendorService) *VendorController {
+	return &VendorController{VendorService: vendorService}
+}
+
+// @Summary      Get All Vendor
+// @Description  Returns a paginated list of vendor
+// @Tags         Vendor
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetAllVendor
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/vendors [get]
+func (rc *VendorController) GetAllVendor(c *gin.Context) {
+	// Pagination
+	pagination := utils.GetPagination(c)
+
+	// Service: Get All Vendor
+	vendor, total, err := rc.VendorService.GetAllVendor(pagination)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Response
+	totalPages := int(math.Ceil(float64(total) / float64(pagination.Limit)))
+	metadata := gin.H{
+		"total":       total,
+		"page":        pagination.Page,
+		"limit":       pagination.Limit,
+		"total_pages": totalPages,
+	}
+	utils.BuildResponseMessage(c, "success", "vendor", "get", http.StatusOK, vendor, metadata)
+}
+
+// @Summary      Post Create Vendor
+// @Description  Create a vendor
+// @Tags         Vendor
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPostCreateUpdateVendor  true  "Post Create Vendor Request Body"
+// @Success      201  {object}  entity.ResponsePostCreateVendor
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/vendors [post]
+func (rc *VendorController) Create(c *gin.Context) {
+	// Model
+	var req entity.RequestPostCreateUpdateVendor
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Create Vendor
+	vendor := entity.Vendor{
+		VendorName:    req.VendorName,
+		VendorAddress: req.VendorAddress,
+		ContactName:   req.ContactName,
+		ContactEmail:  req.ContactEmail,
+		ContactPhone:  req.ContactPhone,
+	}
+	if err := rc.VendorService.Create(&vendor); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "vendor", "post", http.StatusCreated, &vendor, nil)
+}
+
+// @Summary      Put Update Vendor By Id
+// @Description  Update a vendor by Id
+// @Tags         Vendor
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPostCreateUpdateVendor  true  "Put Update Vendor Request Body"
+// @Success      200  {object}  entity.ResponsePutUpdateVendor
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/vendors/{id} [put]
+// @Param        id  path  string  true  "Id of vendor"
+func (rc *VendorController) UpdateById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Model
+	var req entity.RequestPostCreateUpdateVendor
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Parse Id
+	vendorID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Update Vendor By Id
+	vendor := entity.Vendor{
+		VendorName:    req.VendorName,
+		VendorAddress: req.VendorAddress,
+		ContactName:   req.ContactName,
+		ContactEmail:  req.ContactEmail,
+		ContactPhone:  req.ContactPhone,
+	}
+	if err := rc.VendorService.UpdateById(&vendor, vendorID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "vendor", "put", http.StatusOK, &vendor, nil)
+}
+
+// @Summary      Delete Vendor By Id
+// @Description  Permanentally delete vendor by Id. Its warranty is kept without vendor
+// @Tags         Vendor
+// @Success      200  {object}  entity.ResponseDeleteVendorById
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/vendors/{id} [delete]
+// @Param        id  path  string  true  "Id of vendor"
+func (rc *VendorController) DeleteById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	vendorID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Delete Vendor By Id
+	if err := rc.VendorService.DeleteById(vendorID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "vendor", "hard delete", http.StatusOK, nil, nil)
+}
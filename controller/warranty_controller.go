@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"net/http"
+	"pelita/entity"
+	"pelita/service"
+	"pelita/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WarrantyController struct {
+	WarrantyService service.WarrantyService
+}
+
+func NewWarrantyController(warrantyService service.WarrantyService) *WarrantyController {
+	return &WarrantyController{WarrantyService: warrantyService}
+}
+
+// @Summary      Get All Warranty By Asset Id
+// @Description  Returns every warranty and support contract of an asset, the latest ending first
+// @Tags         Asset
+// @Produce      json
+// @Success      200  {object}  entity.ResponseGetAllWarranty
+// @Failure      404  {object}  entity.ResponseNotFound
+// @Router       /api/v1/assets/{id}/warranties [get]
+// @Param        id  path  string  true  "Id of asset"
+func (rc *WarrantyController) GetAllByAssetId(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	assetID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Get All Warranty By Asset Id
+	warranty, err := rc.WarrantyService.GetAllByAssetId(assetID)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "warranty", "get", http.StatusOK, warranty, nil)
+}
+
+// @Summary      Post Create Warranty
+// @Description  Create a warranty or support contract of an asset
+// @Tags         Asset
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPostCreateUpdateWarranty  true  "Post Create Warranty Request Body"
+// @Success      201  {object}  entity.ResponsePostCreateWarranty
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/warranties [post]
+func (rc *WarrantyController) Create(c *gin.Context) {
+	// Model
+	var req entity.RequestPostCreateUpdateWarranty
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Create Warranty
+	warranty := buildWarranty(&req)
+	if err := rc.WarrantyService.Create(&warranty); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "warranty", "post", http.StatusCreated, &warranty, nil)
+}
+
+// @Summary      Put Update Warranty By Id
+// @Description  Update a warranty or support contract by Id
+// @Tags         Asset
+// @Accept       application/json
+// @Produce      json
+// @Param        request  body  entity.RequestPostCreateUpdateWarranty  true  "Put Update Warranty Request Body"
+// @Success      200  {object}  entity.ResponsePutUpdateWarranty
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/warranties/{id} [put]
+// @Param        id  path  string  true  "Id of warranty"
+func (rc *WarrantyController) UpdateById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Model
+	var req entity.RequestPostCreateUpdateWarranty
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Parse Id
+	warrantyID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Update Warranty By Id
+	warranty := buildWarranty(&req)
+	if err := rc.WarrantyService.UpdateById(&warranty, warrantyID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "warranty", "put", http.StatusOK, &warranty, nil)
+}
+
+// @Summary      Delete Warranty By Id
+// @Description  Permanentally delete warranty by Id
+// @Tags         Asset
+// @Success      200  {object}  entity.ResponseDeleteWarrantyById
+// @Failure      400  {object}  entity.ResponseBadRequest
+// @Router       /api/v1/assets/warranties/{id} [delete]
+// @Param        id  path  string  true  "Id of warranty"
+func (rc *WarrantyController) DeleteById(c *gin.Context) {
+	// Param
+	id := c.Param("id")
+
+	// Parse Id
+	warrantyID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Service : Delete Warranty By Id
+	if err := rc.WarrantyService.DeleteById(warrantyID); err != nil {
+		utils.BuildErrorMessage(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response
+	utils.BuildResponseMessage(c, "success", "warranty", "hard delete", http.StatusOK, nil, nil)
+}
+
+func buildWarranty(req *entity.RequestPostCreateUpdateWarranty) entity.Warranty {
+	return entity.Warranty{
+		WarrantyType:  req.WarrantyType,
+		StartDate:     req.StartDate,
+		EndDate:       req.EndDate,
+		CoverageNotes: req.CoverageNotes,
+		ContactName:   req.ContactName,
+		ContactEmail:  req.ContactEmail,
+		ContactPhone:  req.ContactPhone,
+		AssetId:       req.AssetId,
+		VendorId:      req.VendorId,
+	}
+}
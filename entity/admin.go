@@ -8,13 +8,16 @@ import (
 
 type (
 	Admin struct {
-		ID              uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
-		Username        string    `json:"username" gorm:"type:varchar(36);not null"`
-		Password        string    `json:"password" gorm:"type:varchar(500);not null"`
-		Email           string    `json:"email" gorm:"type:varchar(500);not null"`
-		TelegramUserId  *string   `json:"telegram_user_id" gorm:"type:varchar(36);null"`
-		TelegramIsValid bool      `json:"telegram_is_valid"`
-		CreatedAt       time.Time `json:"created_at" gorm:"type:timestamp;not null"`
+		ID              uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+		Username        string     `json:"username" gorm:"type:varchar(36);not null"`
+		Password        string     `json:"password" gorm:"type:varchar(500);not null"`
+		Email           string     `json:"email" gorm:"type:varchar(500);not null"`
+		TelegramUserId  *string    `json:"telegram_user_id" gorm:"type:varchar(36);null"`
+		TelegramIsValid bool       `json:"telegram_is_valid"`
+		IsActive        bool       `json:"is_active" gorm:"not null;default:true"`
+		CreatedAt       time.Time  `json:"created_at" gorm:"type:timestamp;not null"`
+		DeactivatedAt   *time.Time `json:"deactivated_at" gorm:"type:timestamp;null"`
+		DeletedAt       *time.Time `json:"deleted_at" gorm:"type:datetime;default:null"`
 	}
 	AdminContact struct {
 		Username        string  `json:"username"`
@@ -22,6 +25,41 @@ type (
 		TelegramUserId  *string `json:"telegram_user_id"`
 		TelegramIsValid bool    `json:"telegram_is_valid"`
 	}
+	RequestPostCreateAdmin struct {
+		Username       string  `json:"username" binding:"required"`
+		Password       string  `json:"password" binding:"required"`
+		Email          string  `json:"email" binding:"required,email"`
+		TelegramUserId *string `json:"telegram_user_id"`
+	}
+	RequestPutUpdateAdmin struct {
+		Username       string  `json:"username" binding:"required"`
+		Email          string  `json:"email" binding:"required,email"`
+		TelegramUserId *string `json:"telegram_user_id"`
+	}
+	// For Response Only
+	ResponseGetAllAdmin struct {
+		Message  string   `json:"message" example:"admin fetched"`
+		Status   string   `json:"status" example:"success"`
+		Data     []Admin  `json:"data"`
+		Metadata Metadata `json:"metadata"`
+	}
+	ResponsePostCreateAdmin struct {
+		Message string `json:"message" example:"admin created"`
+		Status  string `json:"status" example:"success"`
+		Data    Admin  `json:"data"`
+	}
+	ResponsePutUpdateAdmin struct {
+		Message string `json:"message" example:"admin updated"`
+		Status  string `json:"status" example:"success"`
+	}
+	ResponsePutDeactivateAdmin struct {
+		Message string `json:"message" example:"admin deactivated"`
+		Status  string `json:"status" example:"success"`
+	}
+	ResponseDeleteAdminById struct {
+		Message string `json:"message" example:"admin deleted"`
+		Status  string `json:"status" example:"success"`
+	}
 )
 
 // For Generic Interface
@@ -31,3 +69,11 @@ func (a *Admin) GetID() uuid.UUID {
 func (a *Admin) GetPassword() string {
 	return a.Password
 }
+func (a *Admin) GetContact() AccountContact {
+	return AccountContact{
+		Username:        a.Username,
+		Email:           a.Email,
+		TelegramUserId:  a.TelegramUserId,
+		TelegramIsValid: a.TelegramIsValid,
+	}
+}
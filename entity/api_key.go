@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	ApiKey struct {
+		ID         uuid.UUID     `json:"id" gorm:"type:varchar(36);primaryKey"`
+		Name       string        `json:"name" gorm:"type:varchar(75);not null"`
+		KeyPrefix  string        `json:"key_prefix" gorm:"type:varchar(16);not null"`
+		KeyHash    string        `json:"-" gorm:"type:varchar(64);not null;unique"`
+		ExpiredAt  time.Time     `json:"expired_at" gorm:"type:timestamp;not null"`
+		LastUsedAt *time.Time    `json:"last_used_at" gorm:"type:timestamp;null"`
+		RevokedAt  *time.Time    `json:"revoked_at" gorm:"type:timestamp;null"`
+		CreatedAt  time.Time     `json:"created_at" gorm:"type:timestamp;not null"`
+		Scopes     []ApiKeyScope `json:"scopes" gorm:"foreignKey:ApiKeyId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+		// FK - Admin
+		CreatedBy uuid.UUID `json:"created_by" gorm:"type:varchar(36);not null"`
+		Admin     Admin     `json:"-" gorm:"foreignKey:CreatedBy;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	}
+	ApiKeyScope struct {
+		ID         uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
+		ApiKeyId   uuid.UUID `json:"api_key_id" gorm:"type:varchar(36);not null;uniqueIndex:idx_api_key_scope"`
+		Permission string    `json:"permission" gorm:"type:varchar(75);not null;uniqueIndex:idx_api_key_scope"`
+	}
+	RequestPostCreateApiKey struct {
+		Name      string    `json:"name" binding:"required,max=75"`
+		Scopes    []string  `json:"scopes" binding:"required,min=1"`
+		ExpiredAt time.Time `json:"expired_at" binding:"required"`
+	}
+	ApiKeyCreated struct {
+		ApiKey
+		Key string `json:"key" example:"pelita_8f14e45fceea167a5a36dedd4bea2543"`
+	}
+	// For Response Only
+	ResponseGetAllApiKey struct {
+		Message  string   `json:"message" example:"api key fetched"`
+		Status   string   `json:"status" example:"success"`
+		Data     []ApiKey `json:"data"`
+		Metadata Metadata `json:"metadata"`
+	}
+	ResponsePostCreateApiKey struct {
+		Message string        `json:"message" example:"api key created"`
+		Status  string        `json:"status" example:"success"`
+		Data    ApiKeyCreated `json:"data"`
+	}
+	ResponseRevokeApiKeyById struct {
+		Message string `json:"message" example:"api key revoked"`
+		Status  string `json:"status" example:"success"`
+	}
+)
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	Archive struct {
+		ID          uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
+		RecordTable string    `json:"record_table" gorm:"type:varchar(64);not null;index:idx_archive_record"`
+		RecordId    string    `json:"record_id" gorm:"type:varchar(36);not null;index:idx_archive_record"`
+		RecordData  string    `json:"record_data" gorm:"type:longtext;not null"` // Whole Row As JSON
+		// Deleted Row That Cascaded Into This Record, The Same As The Record For The Deleted Row Itself
+		DeletedTable string    `json:"deleted_table" gorm:"type:varchar(64);not null;index:idx_archive_deleted"`
+		DeletedId    string    `json:"deleted_id" gorm:"type:varchar(36);not null;index:idx_archive_deleted"`
+		ArchivedAt   time.Time `json:"archived_at" gorm:"type:datetime;not null"`
+		ArchivedBy   uuid.UUID `json:"archived_by" gorm:"type:varchar(36);not null"`
+	}
+	DeleteDependency struct {
+		RecordTable string   `json:"record_table"`
+		Total       int      `json:"total"`
+		RecordIds   []string `json:"record_ids"`
+	}
+	DeletePreview struct {
+		RecordTable       string             `json:"record_table"`
+		RecordId          uuid.UUID          `json:"record_id"`
+		Dependencies      []DeleteDependency `json:"dependencies"`
+		ConfirmationToken string             `json:"confirmation_token"`
+		ExpiredAt         time.Time          `json:"expired_at"`
+	}
+	DeleteConfirmation struct {
+		RecordTable string    `json:"record_table"`
+		RecordId    uuid.UUID `json:"record_id"`
+		Fingerprint string    `json:"fingerprint"` // Hash Of Every Dependency Shown On The Preview
+		RequestedBy uuid.UUID `json:"requested_by"`
+	}
+	// For Response Only
+	ResponseGetDeletePreview struct {
+		Message string        `json:"message" example:"delete preview fetched"`
+		Status  string        `json:"status" example:"success"`
+		Data    DeletePreview `json:"data"`
+	}
+)
@@ -13,16 +13,57 @@ type (
 		AssetDesc     *string    `json:"asset_desc" gorm:"type:varchar(500);null"`
 		AssetMerk     *string    `json:"asset_merk" gorm:"type:varchar(75);null"`
 		AssetCategory string     `json:"asset_category" gorm:"type:varchar(36);not null"`
-		AssetPrice    *string    `json:"asset_price" gorm:"type:varchar(9);null"`
+		AssetPrice    *Money     `json:"asset_price" gorm:"type:decimal(15,2);null"`
+		AssetCurrency string     `json:"asset_currency" gorm:"type:varchar(3);not null;default:IDR"`
 		AssetStatus   string     `json:"asset_status" gorm:"type:varchar(36);not null"`
-		AssetImageURL *string    `json:"asset_image_url" gorm:"type:varchar(1000);null"`
+		AssetImageURL *string    `json:"asset_image_url" gorm:"type:varchar(1000);null"` // Primary Image Of The Gallery
 		CreatedAt     time.Time  `json:"created_at" gorm:"type:datetime;not null"`
 		UpdatedAt     *time.Time `json:"updated_at" gorm:"type:datetime;default:null"`
 		DeletedAt     *time.Time `json:"deleted_at" gorm:"type:datetime;default:null"`
+		StatusReason  *string    `json:"status_reason,omitempty" gorm:"-"`
+		// Depreciation, Book Value Is Only Known When Price, Purchase Date, And Useful Life Are Filled
+		PurchaseDate       *time.Time `json:"purchase_date" gorm:"type:date;null"`
+		UsefulLife         *int       `json:"useful_life" gorm:"type:int;null"` // In Year
+		SalvageValue       *Money     `json:"salvage_value" gorm:"type:decimal(15,2);null"`
+		DepreciationMethod string     `json:"depreciation_method" gorm:"type:varchar(36);not null;default:straight-line"`
+		// FK - Asset Category, Asset Category Above Always Hold The Category Name
+		AssetCategoryId *uuid.UUID      `json:"asset_category_id" gorm:"type:varchar(36);null;index"`
+		Category        *AssetCategory  `json:"-" gorm:"foreignKey:AssetCategoryId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+		Attributes      AssetAttributes `json:"attributes" gorm:"-"` // Custom Attribute Keyed By Field Name Of The Category
 		// FK - Admin
 		CreatedBy uuid.UUID `json:"created_by" gorm:"not null"`
 		Admin     Admin     `json:"-" gorm:"foreignKey:CreatedBy;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	}
+	AssetBookValue struct {
+		AssetId                 uuid.UUID `json:"asset_id"`
+		AssetName               string    `json:"asset_name"`
+		AssetCategory           string    `json:"asset_category"`
+		AssetCurrency           string    `json:"asset_currency"`
+		AssetPrice              Money     `json:"asset_price"`
+		SalvageValue            Money     `json:"salvage_value"`
+		PurchaseDate            time.Time `json:"purchase_date"`
+		UsefulLife              int       `json:"useful_life"`
+		DepreciationMethod      string    `json:"depreciation_method"`
+		AccumulatedDepreciation Money     `json:"accumulated_depreciation"`
+		BookValue               Money     `json:"book_value"`
+	}
+	AssetPlacedValuation struct {
+		Asset
+		AssetQty int    `json:"asset_qty"`
+		RoomDept string `json:"room_dept"`
+		Floor    string `json:"floor"`
+	}
+	AssetDuplicate struct {
+		Asset
+		Similarity float64 `json:"similarity"`
+	}
+	AssetBookValueSummary struct {
+		Context        string `json:"context"`
+		AssetCurrency  string `json:"asset_currency"`
+		TotalQty       int    `json:"total_qty"`
+		TotalPrice     Money  `json:"total_price"`
+		TotalBookValue Money  `json:"total_book_value"`
+	}
 	// For Response Only
 	ResponseGetAllAsset struct {
 		Message  string   `json:"message" example:"asset fetched"`
@@ -55,12 +96,50 @@ type (
 		Message string `json:"message" example:"asset recovered"`
 		Status  string `json:"status" example:"success"`
 	}
+	ResponseAssetDuplicate struct {
+		Message string           `json:"message" example:"asset may already exist, set force to true to save it anyway"`
+		Status  string           `json:"status" example:"failed"`
+		Data    []AssetDuplicate `json:"data"`
+	}
+	ResponseMergeAssetById struct {
+		Message string `json:"message" example:"asset merged"`
+		Status  string `json:"status" example:"success"`
+	}
+	ResponseGetAllAssetBookValue struct {
+		Message  string           `json:"message" example:"asset book value fetched"`
+		Status   string           `json:"status" example:"success"`
+		Data     []AssetBookValue `json:"data"`
+		Metadata Metadata         `json:"metadata"`
+	}
+	ResponseGetAssetBookValue struct {
+		Message string         `json:"message" example:"asset book value fetched"`
+		Status  string         `json:"status" example:"success"`
+		Data    AssetBookValue `json:"data"`
+	}
+	ResponseGetAssetBookValueSummary struct {
+		Message string                  `json:"message" example:"asset book value fetched"`
+		Status  string                  `json:"status" example:"success"`
+		Data    []AssetBookValueSummary `json:"data"`
+	}
 	RequestUpdateAssetById struct {
 		AssetName     string `json:"asset_name" binding:"required"`
 		AssetDesc     string `json:"asset_desc" binding:"required"`
 		AssetMerk     string `json:"asset_merk" binding:"required"`
-		AssetCategory string `json:"asset_category" binding:"required"`
-		AssetPrice    string `json:"asset_price" binding:"required"`
+		AssetCategory string `json:"asset_category"` // Category Name, Used When Asset Category Id Is Empty
+		AssetPrice    Money  `json:"asset_price" binding:"required"`
+		AssetCurrency string `json:"asset_currency"`
 		AssetStatus   string `json:"asset_status" binding:"required"`
+		StatusReason  string `json:"status_reason"`
+		// Depreciation
+		PurchaseDate       time.Time `json:"purchase_date"`
+		UsefulLife         int       `json:"useful_life"`
+		SalvageValue       Money     `json:"salvage_value"`
+		DepreciationMethod string    `json:"depreciation_method"`
+		// FK - Asset Category
+		AssetCategoryId *uuid.UUID      `json:"asset_category_id"`
+		Attributes      AssetAttributes `json:"attributes"` // Left Empty To Keep The Current Attribute
+	}
+	RequestMergeAssetById struct {
+		DuplicateAssetId uuid.UUID `json:"duplicate_asset_id" binding:"required"`
 	}
 )
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// Custom Attribute Keyed By Field Name, Number And Boolean Value Is Accepted And Kept As Text
+type AssetAttributes map[string]string
+
+type (
+	AssetAttribute struct {
+		ID             uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
+		AttributeValue string    `json:"attribute_value" gorm:"type:varchar(255);not null"` // Stored In Its Normalized Text Form
+		// FK - Asset
+		AssetId uuid.UUID `json:"asset_id" gorm:"type:varchar(36);not null;uniqueIndex:idx_asset_attribute_field"`
+		Asset   Asset     `json:"-" gorm:"foreignKey:AssetId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+		// FK - Asset Category Field
+		AssetCategoryFieldId uuid.UUID          `json:"asset_category_field_id" gorm:"type:varchar(36);not null;uniqueIndex:idx_asset_attribute_field"`
+		AssetCategoryField   AssetCategoryField `json:"-" gorm:"foreignKey:AssetCategoryFieldId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	}
+	AssetAttributeValue struct {
+		AssetId        uuid.UUID `json:"asset_id"`
+		FieldName      string    `json:"field_name"`
+		AttributeValue string    `json:"attribute_value"`
+	}
+)
+
+func (a *AssetAttributes) UnmarshalJSON(data []byte) error {
+	// Null Leave The Attribute Untouched
+	var attributes map[string]interface{}
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return err
+	}
+	if attributes == nil {
+		return nil
+	}
+
+	result := AssetAttributes{}
+	for key, value := range attributes {
+		switch v := value.(type) {
+		case nil:
+			result[key] = ""
+		case string:
+			result[key] = v
+		case float64:
+			result[key] = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			result[key] = strconv.FormatBool(v)
+		default:
+			return fmt.Errorf("attribute %s must be a string, number, or boolean", key)
+		}
+	}
+	*a = result
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	AssetCategory struct {
+		ID                     uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+		CategoryName           string     `json:"category_name" gorm:"type:varchar(36);unique;not null"`
+		CategoryDesc           *string    `json:"category_desc" gorm:"type:varchar(255);null"`
+		MaintenanceIntervalDay *int       `json:"maintenance_interval_day" gorm:"type:int;null"` // Default Interval Between Maintenance
+		CreatedAt              time.Time  `json:"created_at" gorm:"type:datetime;not null"`
+		UpdatedAt              *time.Time `json:"updated_at" gorm:"type:datetime;default:null"`
+		// FK - Parent Category
+		ParentId *uuid.UUID     `json:"parent_id" gorm:"type:varchar(36);null;index"`
+		Parent   *AssetCategory `json:"-" gorm:"foreignKey:ParentId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+		// Custom Field Definition
+		FieldDefinitions []AssetCategoryField `json:"field_definitions" gorm:"foreignKey:AssetCategoryId"`
+		Children         []AssetCategory      `json:"children,omitempty" gorm:"-"`
+		// Attribute Value Deleted Along With Its Removed Field, Only Filled On Update
+		RemovedAttributes []AssetAttributeValue `json:"removed_attributes,omitempty" gorm:"-"`
+	}
+	AssetCategoryField struct {
+		ID           uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
+		FieldName    string    `json:"field_name" gorm:"type:varchar(36);not null;uniqueIndex:idx_category_field_name"`
+		FieldLabel   string    `json:"field_label" gorm:"type:varchar(75);not null"`
+		FieldType    string    `json:"field_type" gorm:"type:varchar(16);not null"`
+		FieldOptions []string  `json:"field_options" gorm:"type:text;serializer:json"` // Only For Enum Type
+		IsRequired   bool      `json:"is_required" gorm:"not null;default:false"`
+		FieldOrder   int       `json:"field_order" gorm:"type:int;not null"`
+		// FK - Asset Category
+		AssetCategoryId uuid.UUID     `json:"asset_category_id" gorm:"type:varchar(36);not null;uniqueIndex:idx_category_field_name"`
+		AssetCategory   AssetCategory `json:"-" gorm:"foreignKey:AssetCategoryId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	}
+	RequestPostCreateUpdateAssetCategory struct {
+		CategoryName           string                      `json:"category_name" binding:"required,max=36"`
+		CategoryDesc           *string                     `json:"category_desc" binding:"omitempty,max=255"`
+		MaintenanceIntervalDay *int                        `json:"maintenance_interval_day" binding:"omitempty,min=1"`
+		ParentId               *uuid.UUID                  `json:"parent_id"`
+		FieldDefinitions       []RequestAssetCategoryField `json:"field_definitions" binding:"omitempty,dive"`
+	}
+	RequestAssetCategoryField struct {
+		FieldName    string   `json:"field_name" binding:"required,max=36"`
+		FieldLabel   string   `json:"field_label" binding:"required,max=75"`
+		FieldType    string   `json:"field_type" binding:"required"`
+		FieldOptions []string `json:"field_options"`
+		IsRequired   bool     `json:"is_required"`
+	}
+	// For Response Only
+	ResponseGetAllAssetCategory struct {
+		Message string          `json:"message" example:"asset category fetched"`
+		Status  string          `json:"status" example:"success"`
+		Data    []AssetCategory `json:"data"`
+	}
+	ResponseGetAssetCategory struct {
+		Message string        `json:"message" example:"asset category fetched"`
+		Status  string        `json:"status" example:"success"`
+		Data    AssetCategory `json:"data"`
+	}
+	ResponsePostCreateAssetCategory struct {
+		Message string        `json:"message" example:"asset category created"`
+		Status  string        `json:"status" example:"success"`
+		Data    AssetCategory `json:"data"`
+	}
+	ResponsePutUpdateAssetCategory struct {
+		Message string        `json:"message" example:"asset category updated"`
+		Status  string        `json:"status" example:"success"`
+		Data    AssetCategory `json:"data"`
+	}
+	ResponseDeleteAssetCategoryById struct {
+		Message string `json:"message" example:"asset category permanentally deleted"`
+		Status  string `json:"status" example:"success"`
+	}
+)
@@ -8,20 +8,29 @@ import (
 
 type (
 	AssetFinding struct {
-		ID              uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
-		FindingCategory string    `json:"finding_category" gorm:"type:varchar(36);not null"`
-		FindingNotes    string    `json:"finding_notes" gorm:"type:varchar(255);not null"`
-		FindingImage    *string   `json:"finding_image" gorm:"type:varchar(500);null"`
-		CreatedAt       time.Time `json:"created_at" gorm:"type:datetime;not null"`
+		ID              uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+		FindingCategory string     `json:"finding_category" gorm:"type:varchar(36);not null"`
+		FindingNotes    string     `json:"finding_notes" gorm:"type:varchar(255);not null"`
+		FindingImage    *string    `json:"finding_image" gorm:"type:varchar(500);null"`
+		CreatedAt       time.Time  `json:"created_at" gorm:"type:datetime;not null"`
+		DeletedAt       *time.Time `json:"deleted_at" gorm:"type:datetime;default:null"`
+		// Only Filled When A Broken Asset Is Reported
+		WarrantyStatus *AssetWarrantyStatus `json:"warranty_status,omitempty" gorm:"-"`
 		// FK - Asset Placement
 		AssetPlacementId uuid.UUID      `json:"asset_placement_id" gorm:"not null"`
 		AssetPlacement   AssetPlacement `json:"asset_placements" gorm:"foreignKey:AssetPlacementId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+		// FK - Asset Unit (Optional)
+		AssetUnitId *uuid.UUID `json:"asset_unit_id" gorm:"type:varchar(36);null"`
+		AssetUnit   *AssetUnit `json:"-" gorm:"foreignKey:AssetUnitId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
 		// FK - Technician
 		FindingByTechnician *uuid.UUID `json:"finding_by_technician" gorm:"null"`
 		Technician          Technician `json:"technicians" gorm:"foreignKey:FindingByTechnician;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 		// FK - User / Guest
 		FindingByUser *uuid.UUID `json:"finding_by_user" gorm:"null"`
 		User          User       `json:"users" gorm:"foreignKey:FindingByUser;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+		// FK - Api Key (Integration)
+		FindingByApiKey *uuid.UUID `json:"finding_by_api_key" gorm:"type:varchar(36);null"`
+		ApiKey          *ApiKey    `json:"-" gorm:"foreignKey:FindingByApiKey;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
 	}
 	AssetFindingReport struct {
 		AssetName       string    `json:"asset_name"`
@@ -51,6 +60,19 @@ type (
 		Message string `json:"message" example:"asset finding deleted"`
 		Status  string `json:"status" example:"success"`
 	}
+	ResponseGetDeletedAssetFinding struct {
+		Message string         `json:"message" example:"deleted asset finding fetched"`
+		Status  string         `json:"status" example:"success"`
+		Data    []AssetFinding `json:"data"`
+	}
+	ResponseHardDeleteAssetFindingById struct {
+		Message string `json:"message" example:"asset finding permanentally deleted"`
+		Status  string `json:"status" example:"success"`
+	}
+	ResponseRecoverDeleteAssetFindingById struct {
+		Message string `json:"message" example:"asset finding recovered"`
+		Status  string `json:"status" example:"success"`
+	}
 	ResponseCreateAssetFinding struct {
 		Message string `json:"message" example:"asset finding created"`
 		Status  string `json:"status" example:"success"`
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	AssetImage struct {
+		ID           uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
+		ImageURL     string    `json:"image_url" gorm:"type:varchar(1000);not null"`
+		ThumbnailURL *string   `json:"thumbnail_url" gorm:"type:varchar(1000);null"`
+		ImageOrder   int       `json:"image_order" gorm:"type:int;not null"`
+		IsPrimary    bool      `json:"is_primary" gorm:"not null;default:false"`
+		CreatedAt    time.Time `json:"created_at" gorm:"type:datetime;not null"`
+		// FK - Asset
+		AssetId uuid.UUID `json:"asset_id" gorm:"type:varchar(36);not null;index"`
+		Asset   Asset     `json:"-" gorm:"foreignKey:AssetId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+		// FK - Admin
+		CreatedBy uuid.UUID `json:"created_by" gorm:"not null"`
+		Admin     Admin     `json:"-" gorm:"foreignKey:CreatedBy;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	}
+	RequestPutAssetImageOrder struct {
+		ImageIds []uuid.UUID `json:"image_ids" binding:"required,min=1"`
+	}
+	// For Response Only
+	ResponseGetAllAssetImage struct {
+		Message string       `json:"message" example:"asset image fetched"`
+		Status  string       `json:"status" example:"success"`
+		Data    []AssetImage `json:"data"`
+	}
+	ResponsePostCreateAssetImage struct {
+		Message string       `json:"message" example:"asset image created"`
+		Status  string       `json:"status" example:"success"`
+		Data    []AssetImage `json:"data"`
+	}
+	ResponsePutUpdateAssetImage struct {
+		Message string `json:"message" example:"asset image updated"`
+		Status  string `json:"status" example:"success"`
+	}
+	ResponseDeleteAssetImageById struct {
+		Message string `json:"message" example:"asset image permanentally deleted"`
+		Status  string `json:"status" example:"success"`
+	}
+)
@@ -0,0 +1,35 @@
+package entity
+
+type (
+	AssetImport struct {
+		Row            int
+		Asset          Asset
+		AssetPlacement []AssetPlacement
+	}
+	AssetImportError struct {
+		Row     int    `json:"row"`
+		Column  string `json:"column"`
+		Message string `json:"message"`
+	}
+	AssetImportReport struct {
+		DryRun              bool               `json:"dry_run"`
+		IsCommitted         bool               `json:"is_committed"`
+		TotalRow            int                `json:"total_row"`
+		TotalAsset          int                `json:"total_asset"`
+		TotalAssetPlacement int                `json:"total_asset_placement"`
+		Errors              []AssetImportError `json:"errors"`
+	}
+	AssetExport struct {
+		Asset
+		Floor      *string `json:"floor"`
+		RoomName   *string `json:"room_name"`
+		AssetQty   *int    `json:"asset_qty"`
+		OwnerEmail *string `json:"owner_email"`
+	}
+	// For Response Only
+	ResponsePostImportAsset struct {
+		Message string            `json:"message" example:"asset imported"`
+		Status  string            `json:"status" example:"success"`
+		Data    AssetImportReport `json:"data"`
+	}
+)
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	AssetLoan struct {
+		ID              uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+		LoanStatus      string     `json:"loan_status" gorm:"type:varchar(36);not null"`
+		LoanNotes       *string    `json:"loan_notes" gorm:"type:varchar(255);null"`
+		DueAt           time.Time  `json:"due_at" gorm:"type:datetime;not null"`
+		ReturnCondition *string    `json:"return_condition" gorm:"type:varchar(36);null"`
+		ReturnNotes     *string    `json:"return_notes" gorm:"type:varchar(255);null"`
+		CreatedAt       time.Time  `json:"created_at" gorm:"type:datetime;not null"`
+		ApprovedAt      *time.Time `json:"approved_at" gorm:"type:datetime;null"`
+		CheckedOutAt    *time.Time `json:"checked_out_at" gorm:"type:datetime;null"`
+		ReturnedAt      *time.Time `json:"returned_at" gorm:"type:datetime;null"`
+		// Borrower Can Be Any Account Type
+		BorrowerId   uuid.UUID `json:"borrower_id" gorm:"type:varchar(36);not null;index"`
+		BorrowerType string    `json:"borrower_type" gorm:"type:varchar(36);not null"`
+		// FK - Asset Placement
+		AssetPlacementId uuid.UUID      `json:"asset_placement_id" gorm:"not null"`
+		AssetPlacement   AssetPlacement `json:"asset_placements" gorm:"foreignKey:AssetPlacementId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+		// Approver Can Be Any Account Granted With Loan Approval Permission
+		ApprovedBy *uuid.UUID `json:"approved_by" gorm:"type:varchar(36);null"`
+	}
+	AssetLoanOverdue struct {
+		ID           uuid.UUID `json:"id"`
+		AssetName    string    `json:"asset_name"`
+		Floor        string    `json:"floor"`
+		RoomName     string    `json:"room_name"`
+		BorrowerId   uuid.UUID `json:"borrower_id"`
+		BorrowerType string    `json:"borrower_type"`
+		BorrowerName string    `json:"borrower_name"`
+		DueAt        time.Time `json:"due_at"`
+	}
+	RequestPostCreateAssetLoan struct {
+		AssetPlacementId string    `json:"asset_placement_id" binding:"required,uuid"`
+		DueAt            time.Time `json:"due_at" binding:"required"`
+		LoanNotes        *string   `json:"loan_notes" binding:"omitempty,max=255"`
+	}
+	RequestPutReturnAssetLoan struct {
+		ReturnCondition string  `json:"return_condition" binding:"required"`
+		ReturnNotes     *string `json:"return_notes" binding:"omitempty,max=255"`
+	}
+	// For Response Only
+	ResponseGetAllAssetLoan struct {
+		Message  string      `json:"message" example:"asset loan fetched"`
+		Status   string      `json:"status" example:"success"`
+		Data     []AssetLoan `json:"data"`
+		Metadata Metadata    `json:"metadata"`
+	}
+	ResponseGetAllAssetLoanOverdue struct {
+		Message string             `json:"message" example:"overdue asset loan fetched"`
+		Status  string             `json:"status" example:"success"`
+		Data    []AssetLoanOverdue `json:"data"`
+	}
+	ResponsePostCreateAssetLoan struct {
+		Message string    `json:"message" example:"asset loan requested"`
+		Status  string    `json:"status" example:"success"`
+		Data    AssetLoan `json:"data"`
+	}
+	ResponsePutUpdateAssetLoan struct {
+		Message string `json:"message" example:"asset loan updated"`
+		Status  string `json:"status" example:"success"`
+	}
+)
@@ -15,9 +15,13 @@ type (
 		MaintenanceNotes     *string    `json:"maintenance_notes" gorm:"type:varchar(144);null"`
 		CreatedAt            time.Time  `json:"created_at" gorm:"type:datetime;not null"`
 		UpdatedAt            *time.Time `json:"updated_at" gorm:"type:datetime;null"`
+		DeletedAt            *time.Time `json:"deleted_at" gorm:"type:datetime;default:null"`
 		// FK - Asset Placement
 		AssetPlacementId uuid.UUID      `json:"asset_placement_id" gorm:"not null"`
 		AssetPlacement   AssetPlacement `json:"-" gorm:"foreignKey:AssetPlacementId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+		// FK - Asset Unit (Optional)
+		AssetUnitId *uuid.UUID `json:"asset_unit_id" gorm:"type:varchar(36);null"`
+		AssetUnit   *AssetUnit `json:"-" gorm:"foreignKey:AssetUnitId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
 		// FK - Admin
 		CreatedBy uuid.UUID `json:"created_by" gorm:"not null"`
 		Admin     Admin     `json:"-" gorm:"foreignKey:CreatedBy;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
@@ -57,6 +61,19 @@ type (
 		Message string `json:"message" example:"asset maintenance deleted"`
 		Status  string `json:"status" example:"success"`
 	}
+	ResponseGetDeletedAssetMaintenance struct {
+		Message string             `json:"message" example:"deleted asset maintenance fetched"`
+		Status  string             `json:"status" example:"success"`
+		Data    []AssetMaintenance `json:"data"`
+	}
+	ResponseHardDeleteAssetMaintenanceById struct {
+		Message string `json:"message" example:"asset maintenance permanentally deleted"`
+		Status  string `json:"status" example:"success"`
+	}
+	ResponseRecoverDeleteAssetMaintenanceById struct {
+		Message string `json:"message" example:"asset maintenance recovered"`
+		Status  string `json:"status" example:"success"`
+	}
 	ResponseCreateAssetMaintenance struct {
 		Message string `json:"message" example:"asset maintenance created"`
 		Status  string `json:"status" example:"success"`
@@ -71,6 +88,7 @@ type (
 		MaintenanceHourEnd   string  `json:"maintenance_hour_end" binding:"required"`
 		MaintenanceNotes     *string `json:"maintenance_notes" binding:"omitempty"`
 		AssetPlacementId     string  `json:"asset_placement_id" binding:"required"`
+		AssetUnitId          *string `json:"asset_unit_id" binding:"omitempty"`
 		MaintenanceBy        string  `json:"maintenance_by" binding:"required"`
 	}
 )
@@ -13,6 +13,7 @@ type (
 		AssetDesc *string    `json:"asset_desc" gorm:"type:varchar(144)"`
 		CreatedAt time.Time  `json:"created_at" gorm:"type:datetime;not null"`
 		UpdatedAt *time.Time `json:"updated_at" gorm:"type:datetime;null"`
+		DeletedAt *time.Time `json:"deleted_at" gorm:"type:datetime;default:null"`
 		// FK - Asset
 		AssetId uuid.UUID `json:"asset_id" gorm:"not null"`
 		Asset   Asset     `json:"-" gorm:"foreignKey:AssetId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
@@ -37,6 +38,19 @@ type (
 		Message string `json:"message" example:"asset placement deleted"`
 		Status  string `json:"status" example:"success"`
 	}
+	ResponseGetDeletedAssetPlacement struct {
+		Message string           `json:"message" example:"deleted asset placement fetched"`
+		Status  string           `json:"status" example:"success"`
+		Data    []AssetPlacement `json:"data"`
+	}
+	ResponseHardDeleteAssetPlacementById struct {
+		Message string `json:"message" example:"asset placement permanentally deleted"`
+		Status  string `json:"status" example:"success"`
+	}
+	ResponseRecoverDeleteAssetPlacementById struct {
+		Message string `json:"message" example:"asset placement recovered"`
+		Status  string `json:"status" example:"success"`
+	}
 	ResponseCreateAssetPlacement struct {
 		Message string `json:"message" example:"asset placement created"`
 		Status  string `json:"status" example:"success"`
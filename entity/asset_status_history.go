@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	AssetStatusHistory struct {
+		ID         uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
+		FromStatus *string   `json:"from_status" gorm:"type:varchar(36);null"`
+		ToStatus   string    `json:"to_status" gorm:"type:varchar(36);not null"`
+		Reason     *string   `json:"reason" gorm:"type:varchar(255);null"`
+		CreatedAt  time.Time `json:"created_at" gorm:"type:datetime;not null"`
+		// Actor Can Be Any Account Type
+		ChangedBy     uuid.UUID `json:"changed_by" gorm:"type:varchar(36);not null"`
+		ChangedByType string    `json:"changed_by_type" gorm:"type:varchar(36);not null"`
+		// FK - Asset
+		AssetId uuid.UUID `json:"asset_id" gorm:"type:varchar(36);not null;index"`
+		Asset   Asset     `json:"-" gorm:"foreignKey:AssetId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	}
+	// For Response Only
+	ResponseGetAssetStatusHistory struct {
+		Message string               `json:"message" example:"asset status history fetched"`
+		Status  string               `json:"status" example:"success"`
+		Data    []AssetStatusHistory `json:"data"`
+	}
+)
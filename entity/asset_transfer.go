@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	AssetTransfer struct {
+		ID             uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+		TransferStatus string     `json:"transfer_status" gorm:"type:varchar(36);not null"`
+		TransferQty    int        `json:"transfer_qty" gorm:"type:int;not null"`
+		TransferNotes  *string    `json:"transfer_notes" gorm:"type:varchar(255);null"`
+		CreatedAt      time.Time  `json:"created_at" gorm:"type:datetime;not null"`
+		ApprovedAt     *time.Time `json:"approved_at" gorm:"type:datetime;null"`
+		// Requester Can Be Any Account Type
+		RequestedBy     uuid.UUID `json:"requested_by" gorm:"type:varchar(36);not null;index"`
+		RequestedByType string    `json:"requested_by_type" gorm:"type:varchar(36);not null"`
+		// FK - Asset
+		AssetId uuid.UUID `json:"asset_id" gorm:"type:varchar(36);not null"`
+		Asset   Asset     `json:"-" gorm:"foreignKey:AssetId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+		// FK - Source Asset Placement
+		SourcePlacementId uuid.UUID      `json:"source_placement_id" gorm:"type:varchar(36);not null"`
+		SourcePlacement   AssetPlacement `json:"-" gorm:"foreignKey:SourcePlacementId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+		// FK - Target Room
+		TargetRoomId uuid.UUID `json:"target_room_id" gorm:"type:varchar(36);not null"`
+		TargetRoom   Room      `json:"-" gorm:"foreignKey:TargetRoomId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+		// Target Asset Placement Is Only Known Once Approved
+		TargetPlacementId *uuid.UUID `json:"target_placement_id" gorm:"type:varchar(36);null"`
+		// Approver Can Be Any Account Granted With Transfer Approval Permission
+		ApprovedBy *uuid.UUID `json:"approved_by" gorm:"type:varchar(36);null"`
+	}
+	AssetMovement struct {
+		ID        uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
+		AssetQty  int       `json:"asset_qty" gorm:"type:int;not null"`
+		CreatedAt time.Time `json:"created_at" gorm:"type:datetime;not null"`
+		// Actor Can Be Any Account Type
+		MovedBy     uuid.UUID `json:"moved_by" gorm:"type:varchar(36);not null"`
+		MovedByType string    `json:"moved_by_type" gorm:"type:varchar(36);not null"`
+		// FK - Asset
+		AssetId uuid.UUID `json:"asset_id" gorm:"type:varchar(36);not null;index"`
+		Asset   Asset     `json:"-" gorm:"foreignKey:AssetId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+		// FK - Room
+		FromRoomId uuid.UUID `json:"from_room_id" gorm:"type:varchar(36);not null;index"`
+		FromRoom   Room      `json:"-" gorm:"foreignKey:FromRoomId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+		ToRoomId   uuid.UUID `json:"to_room_id" gorm:"type:varchar(36);not null;index"`
+		ToRoom     Room      `json:"-" gorm:"foreignKey:ToRoomId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+		// Log Is Kept Even When The Placement Is Removed
+		FromPlacementId uuid.UUID `json:"from_placement_id" gorm:"type:varchar(36);not null"`
+		ToPlacementId   uuid.UUID `json:"to_placement_id" gorm:"type:varchar(36);not null"`
+		// FK - Asset Transfer, Empty When A Single Unit Is Moved Directly
+		AssetTransferId *uuid.UUID     `json:"asset_transfer_id" gorm:"type:varchar(36);null"`
+		AssetTransfer   *AssetTransfer `json:"-" gorm:"foreignKey:AssetTransferId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+		// FK - Asset Unit, Empty When Moved By Quantity
+		AssetUnitId *uuid.UUID `json:"asset_unit_id" gorm:"type:varchar(36);null"`
+		AssetUnit   *AssetUnit `json:"-" gorm:"foreignKey:AssetUnitId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	}
+	RequestPostCreateAssetTransfer struct {
+		SourcePlacementId uuid.UUID `json:"source_placement_id" binding:"required"`
+		TargetRoomId      uuid.UUID `json:"target_room_id" binding:"required"`
+		TransferQty       int       `json:"transfer_qty" binding:"required,min=1"`
+		TransferNotes     *string   `json:"transfer_notes" binding:"omitempty,max=255"`
+	}
+	// For Response Only
+	ResponseGetAllAssetTransfer struct {
+		Message  string          `json:"message" example:"asset transfer fetched"`
+		Status   string          `json:"status" example:"success"`
+		Data     []AssetTransfer `json:"data"`
+		Metadata Metadata        `json:"metadata"`
+	}
+	ResponsePostCreateAssetTransfer struct {
+		Message string        `json:"message" example:"asset transfer requested"`
+		Status  string        `json:"status" example:"success"`
+		Data    AssetTransfer `json:"data"`
+	}
+	ResponsePutUpdateAssetTransfer struct {
+		Message string `json:"message" example:"asset transfer approved"`
+		Status  string `json:"status" example:"success"`
+	}
+	ResponseGetAllAssetMovement struct {
+		Message  string          `json:"message" example:"asset movement fetched"`
+		Status   string          `json:"status" example:"success"`
+		Data     []AssetMovement `json:"data"`
+		Metadata Metadata        `json:"metadata"`
+	}
+)
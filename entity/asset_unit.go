@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	AssetUnit struct {
+		ID            uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+		Tag           string     `json:"tag" gorm:"type:varchar(36);not null;uniqueIndex"`
+		Sequence      int        `json:"sequence" gorm:"type:int;not null;uniqueIndex"`
+		SerialNumber  *string    `json:"serial_number" gorm:"type:varchar(75);null;uniqueIndex"`
+		UnitCondition string     `json:"unit_condition" gorm:"type:varchar(36);not null;default:good"`
+		CreatedAt     time.Time  `json:"created_at" gorm:"type:datetime;not null"`
+		UpdatedAt     *time.Time `json:"updated_at" gorm:"type:datetime;null"`
+		// FK - Asset Placement
+		AssetPlacementId uuid.UUID      `json:"asset_placement_id" gorm:"type:varchar(36);not null;index"`
+		AssetPlacement   AssetPlacement `json:"-" gorm:"foreignKey:AssetPlacementId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	}
+	AssetTagLookup struct {
+		Tag              string    `json:"tag"`
+		AssetUnitId      uuid.UUID `json:"asset_unit_id"`
+		SerialNumber     *string   `json:"serial_number"`
+		UnitCondition    string    `json:"unit_condition"`
+		AssetId          uuid.UUID `json:"asset_id"`
+		AssetName        string    `json:"asset_name"`
+		AssetCategory    string    `json:"asset_category"`
+		AssetMerk        *string   `json:"asset_merk"`
+		AssetStatus      string    `json:"asset_status"`
+		AssetPlacementId uuid.UUID `json:"asset_placement_id"`
+		AssetQty         int       `json:"asset_qty"`
+		RoomId           uuid.UUID `json:"room_id"`
+		RoomName         string    `json:"room_name"`
+		RoomDept         string    `json:"room_dept"`
+		Floor            string    `json:"floor"`
+	}
+	AssetTagLabel struct {
+		Tag           string `json:"tag"`
+		AssetName     string `json:"asset_name"`
+		AssetCategory string `json:"asset_category"`
+		RoomName      string `json:"room_name"`
+		Floor         string `json:"floor"`
+	}
+	RequestPutUpdateAssetUnit struct {
+		SerialNumber  *string `json:"serial_number" binding:"omitempty,max=75"`
+		UnitCondition string  `json:"unit_condition" binding:"required"`
+	}
+	RequestPutMoveAssetUnit struct {
+		AssetPlacementId uuid.UUID `json:"asset_placement_id" binding:"required"`
+	}
+	// For Response Only
+	ResponseGetAllAssetUnit struct {
+		Message string      `json:"message" example:"asset unit fetched"`
+		Status  string      `json:"status" example:"success"`
+		Data    []AssetUnit `json:"data"`
+	}
+	ResponsePostGenerateAssetUnit struct {
+		Message string      `json:"message" example:"asset unit created"`
+		Status  string      `json:"status" example:"success"`
+		Data    []AssetUnit `json:"data"`
+	}
+	ResponsePutUpdateAssetUnit struct {
+		Message string `json:"message" example:"asset unit updated"`
+		Status  string `json:"status" example:"success"`
+	}
+	ResponseDeleteAssetUnit struct {
+		Message string `json:"message" example:"asset unit permanentally deleted"`
+		Status  string `json:"status" example:"success"`
+	}
+	ResponseGetAssetTagLookup struct {
+		Message string         `json:"message" example:"asset tag fetched"`
+		Status  string         `json:"status" example:"success"`
+		Data    AssetTagLookup `json:"data"`
+	}
+)
@@ -0,0 +1,19 @@
+package entity
+
+import "github.com/google/uuid"
+
+type (
+	EmailChange struct {
+		AccountId uuid.UUID `json:"account_id"`
+		Role      string    `json:"role"`
+		Email     string    `json:"email"`
+	}
+	RequestPostConfirmEmail struct {
+		Token string `json:"token" binding:"required"`
+	}
+	// For Response Only
+	ResponsePostConfirmEmail struct {
+		Message string `json:"message" example:"email updated"`
+		Status  string `json:"status" example:"success"`
+	}
+)
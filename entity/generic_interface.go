@@ -17,6 +17,13 @@ type (
 	Account interface {
 		GetID() uuid.UUID
 		GetPassword() string
+		GetContact() AccountContact
+	}
+	AccountContact struct {
+		Username        string  `json:"username"`
+		Email           string  `json:"email"`
+		TelegramUserId  *string `json:"telegram_user_id"`
+		TelegramIsValid bool    `json:"telegram_is_valid"`
 	}
 	MyProfile struct {
 		Username        string    `json:"username" gorm:"type:varchar(36);not null"`
@@ -34,4 +41,8 @@ type (
 		Message string `json:"message" example:"asset not found"`
 		Status  string `json:"status" example:"failed"`
 	}
+	ResponseConflict struct {
+		Message string `json:"message" example:"illegal asset status transition: retired to in-use"`
+		Status  string `json:"status" example:"failed"`
+	}
 )
@@ -12,6 +12,7 @@ type (
 		AdminID      *uuid.UUID `json:"admin_id" gorm:"type:varchar(36);null"`
 		TechnicianID *uuid.UUID `json:"technician_id" gorm:"type:varchar(36);null"`
 		UserID       *uuid.UUID `json:"user_id" gorm:"type:varchar(36);null"`
+		ApiKeyID     *uuid.UUID `json:"api_key_id" gorm:"type:varchar(36);null"`
 		TypeUser     string     `json:"type_user" gorm:"type:varchar(36);not null"`
 		TypeHistory  string     `json:"type_history" gorm:"type:varchar(255);not null"`
 		CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
@@ -30,6 +31,9 @@ type (
 		// FK - Technician
 		TechnicianID *uuid.UUID  `json:"technician_id"`
 		Technician   *Technician `json:"technicians" gorm:"foreignKey:TechnicianID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+		// FK - Api Key
+		ApiKeyID *uuid.UUID `json:"api_key_id"`
+		ApiKey   *ApiKey    `json:"api_keys" gorm:"foreignKey:ApiKeyID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
 	}
 	// For Response Only
 	ResponseGetAllHistory struct {
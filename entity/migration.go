@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+type (
+	// Data Migration That Must Only Run Once, Recorded After It Succeed
+	Migration struct {
+		Name       string    `json:"name" gorm:"type:varchar(75);primaryKey"`
+		MigratedAt time.Time `json:"migrated_at" gorm:"type:datetime;not null"`
+	}
+)
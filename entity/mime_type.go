@@ -6,6 +6,8 @@ func MimeType(fileExt string) string {
 		return "image/jpeg"
 	case "png":
 		return "image/png"
+	case "webp":
+		return "image/webp"
 	default:
 		return "application/octet-stream"
 	}
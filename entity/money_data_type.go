@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Money Is Kept In Minor Unit (1/100), So It Is Never Rounded By Float Arithmetic
+type Money int64
+
+const moneyScale = 100
+
+var moneyPattern = regexp.MustCompile(`^(-?)([0-9]{1,13})(?:\.([0-9]{1,2}))?$`)
+
+// Only Plain Decimal With At Most 2 Fraction Digit Is Accepted, Such As 1500000 Or 1500000.50
+func ParseMoney(value string) (Money, error) {
+	match := moneyPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0, errors.New("money must be a decimal with at most 2 fraction digit")
+	}
+
+	major, err := strconv.ParseInt(match[2], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	minor := int64(0)
+	if match[3] != "" {
+		minor, _ = strconv.ParseInt((match[3] + "0")[:2], 10, 64)
+	}
+	money := Money(major*moneyScale + minor)
+	if match[1] == "-" {
+		money = -money
+	}
+
+	return money, nil
+}
+
+// Float Is Only Used For Fractional Calculation Such As Depreciation, Rounded Back To The Nearest Minor Unit
+func MoneyFromFloat(value float64) Money {
+	return Money(math.Round(value * moneyScale))
+}
+
+func (m Money) Float64() float64 {
+	return float64(m) / moneyScale
+}
+
+func (m Money) String() string {
+	sign := ""
+	value := int64(m)
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, value/moneyScale, value%moneyScale)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalJSON(b []byte) error {
+	parsed, err := ParseMoney(strings.Trim(string(b), `"`))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func (m *Money) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	switch v := value.(type) {
+	case []byte:
+		parsed, err := ParseMoney(string(v))
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	case string:
+		parsed, err := ParseMoney(v)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	case int64:
+		*m = Money(v * moneyScale)
+		return nil
+	case float64:
+		*m = MoneyFromFloat(v)
+		return nil
+	}
+	return fmt.Errorf("cannot convert %T to Money", value)
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+func (Money) GormDataType() string {
+	return "decimal"
+}
+func (Money) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return "DECIMAL(15,2)"
+}
@@ -0,0 +1,42 @@
+package entity
+
+import "github.com/google/uuid"
+
+type (
+	PasswordReset struct {
+		AccountId uuid.UUID `json:"account_id"`
+		Role      string    `json:"role"`
+	}
+	RequestPostForgotPassword struct {
+		Email string `json:"email" binding:"required,email"`
+	}
+	RequestPostResetPassword struct {
+		Token       string `json:"token" binding:"required"`
+		NewPassword string `json:"new_password" binding:"required"`
+	}
+	RequestPutChangePassword struct {
+		OldPassword string `json:"old_password" binding:"required"`
+		NewPassword string `json:"new_password" binding:"required"`
+	}
+	RequestPutUnlockAccount struct {
+		Email     string `json:"email" binding:"required,email"`
+		IPAddress string `json:"ip_address"`
+	}
+	// For Response Only
+	ResponsePostForgotPassword struct {
+		Message string `json:"message" example:"password reset requested"`
+		Status  string `json:"status" example:"success"`
+	}
+	ResponsePostResetPassword struct {
+		Message string `json:"message" example:"password reset"`
+		Status  string `json:"status" example:"success"`
+	}
+	ResponsePutChangePassword struct {
+		Message string `json:"message" example:"password updated"`
+		Status  string `json:"status" example:"success"`
+	}
+	ResponsePutUnlockAccount struct {
+		Message string `json:"message" example:"account unlocked"`
+		Status  string `json:"status" example:"success"`
+	}
+)
@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	Role struct {
+		ID          uuid.UUID        `json:"id" gorm:"type:varchar(36);primaryKey"`
+		RoleName    string           `json:"role_name" gorm:"type:varchar(75);not null;unique"`
+		RoleDesc    *string          `json:"role_desc" gorm:"type:varchar(255);null"`
+		IsSystem    bool             `json:"is_system"`
+		CreatedAt   time.Time        `json:"created_at" gorm:"type:timestamp;not null"`
+		CreatedBy   *uuid.UUID       `json:"created_by" gorm:"type:varchar(36);null"`
+		Permissions []RolePermission `json:"permissions" gorm:"foreignKey:RoleId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	}
+	RolePermission struct {
+		ID         uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
+		RoleId     uuid.UUID `json:"role_id" gorm:"type:varchar(36);not null;uniqueIndex:idx_role_permission"`
+		Permission string    `json:"permission" gorm:"type:varchar(75);not null;uniqueIndex:idx_role_permission"`
+	}
+	// Default Permission Already Given To A System Role, So A Permission Removed By Admin Is Not Given Back On The Next Seed
+	RoleSeededPermission struct {
+		ID         uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
+		RoleId     uuid.UUID `json:"role_id" gorm:"type:varchar(36);not null;uniqueIndex:idx_role_seeded_permission"`
+		Role       Role      `json:"-" gorm:"foreignKey:RoleId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+		Permission string    `json:"permission" gorm:"type:varchar(75);not null;uniqueIndex:idx_role_seeded_permission"`
+	}
+	AccountRole struct {
+		AccountId   uuid.UUID `json:"account_id" gorm:"type:varchar(36);primaryKey"`
+		AccountType string    `json:"account_type" gorm:"type:varchar(36);not null"`
+		CreatedAt   time.Time `json:"created_at" gorm:"type:timestamp;not null"`
+		// FK - Role
+		RoleId uuid.UUID `json:"role_id" gorm:"type:varchar(36);not null"`
+		Role   Role      `json:"-" gorm:"foreignKey:RoleId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	}
+	RequestPostCreateUpdateRole struct {
+		RoleName    string   `json:"role_name" binding:"required"`
+		RoleDesc    *string  `json:"role_desc"`
+		Permissions []string `json:"permissions" binding:"required"`
+	}
+	RequestPutAssignRole struct {
+		AccountId   uuid.UUID `json:"account_id" binding:"required"`
+		AccountType string    `json:"account_type" binding:"required"`
+	}
+	// For Response Only
+	ResponseGetAllRole struct {
+		Message  string   `json:"message" example:"role fetched"`
+		Status   string   `json:"status" example:"success"`
+		Data     []Role   `json:"data"`
+		Metadata Metadata `json:"metadata"`
+	}
+	ResponseGetAllPermission struct {
+		Message string   `json:"message" example:"permission fetched"`
+		Status  string   `json:"status" example:"success"`
+		Data    []string `json:"data"`
+	}
+	ResponsePostCreateRole struct {
+		Message string `json:"message" example:"role created"`
+		Status  string `json:"status" example:"success"`
+		Data    Role   `json:"data"`
+	}
+	ResponsePutUpdateRole struct {
+		Message string `json:"message" example:"role updated"`
+		Status  string `json:"status" example:"success"`
+		Data    Role   `json:"data"`
+	}
+	ResponseDeleteRoleById struct {
+		Message string `json:"message" example:"role deleted"`
+		Status  string `json:"status" example:"success"`
+	}
+	ResponsePutAssignRole struct {
+		Message string `json:"message" example:"account role updated"`
+		Status  string `json:"status" example:"success"`
+	}
+)
@@ -8,11 +8,12 @@ import (
 
 type (
 	Room struct {
-		ID        uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
-		Floor     string    `json:"floor" gorm:"type:varchar(2);not null"`
-		RoomName  string    `json:"room_name" gorm:"type:varchar(36);not null"`
-		RoomDept  string    `json:"room_dept" gorm:"type:varchar(75);not null"`
-		CreatedAt time.Time `json:"created_at" gorm:"type:timestamp;not null"`
+		ID        uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+		Floor     string     `json:"floor" gorm:"type:varchar(2);not null"`
+		RoomName  string     `json:"room_name" gorm:"type:varchar(36);not null"`
+		RoomDept  string     `json:"room_dept" gorm:"type:varchar(75);not null"`
+		CreatedAt time.Time  `json:"created_at" gorm:"type:timestamp;not null"`
+		DeletedAt *time.Time `json:"deleted_at" gorm:"type:datetime;default:null"`
 	}
 	RoomAsset struct {
 		Floor         string  `json:"floor"`
@@ -54,6 +55,19 @@ type (
 		Message string `json:"message" example:"room deleted"`
 		Status  string `json:"status" example:"success"`
 	}
+	ResponseGetDeletedRoom struct {
+		Message string `json:"message" example:"deleted room fetched"`
+		Status  string `json:"status" example:"success"`
+		Data    []Room `json:"data"`
+	}
+	ResponseHardDeleteRoomById struct {
+		Message string `json:"message" example:"room permanentally deleted"`
+		Status  string `json:"status" example:"success"`
+	}
+	ResponseRecoverDeleteRoomById struct {
+		Message string `json:"message" example:"room recovered"`
+		Status  string `json:"status" example:"success"`
+	}
 	ResponseCreateAssetRoom struct {
 		Message string `json:"message" example:"room created"`
 		Status  string `json:"status" example:"success"`
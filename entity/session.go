@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	Session struct {
+		ID         uuid.UUID `json:"id"`
+		AccountId  uuid.UUID `json:"account_id"`
+		Role       string    `json:"role"`
+		Device     string    `json:"device"`
+		IPAddress  string    `json:"ip_address"`
+		IsCurrent  bool      `json:"is_current"`
+		CreatedAt  time.Time `json:"created_at"`
+		LastUsedAt time.Time `json:"last_used_at"`
+		ExpiredAt  time.Time `json:"expired_at"`
+	}
+	RequestPostRefreshToken struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+	// For Response Only
+	ResponsePostRefreshToken struct {
+		Message string    `json:"message" example:"token refreshed"`
+		Status  string    `json:"status" example:"success"`
+		Data    LoginData `json:"data"`
+	}
+	ResponseGetMySession struct {
+		Message string    `json:"message" example:"session fetched"`
+		Status  string    `json:"status" example:"success"`
+		Data    []Session `json:"data"`
+	}
+	ResponseDeleteSessionById struct {
+		Message string `json:"message" example:"session revoked"`
+		Status  string `json:"status" example:"success"`
+	}
+)
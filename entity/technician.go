@@ -8,18 +8,46 @@ import (
 
 type (
 	Technician struct {
-		ID              uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
-		Username        string    `json:"username" gorm:"type:varchar(36);not null"`
-		Password        string    `json:"password" gorm:"type:varchar(500);not null"`
-		Email           string    `json:"email" gorm:"type:varchar(500);not null"`
-		TelegramUserId  *string   `json:"telegram_user_id" gorm:"type:varchar(36);null"`
-		TelegramIsValid bool      `json:"telegram_is_valid"`
-		CreatedAt       time.Time `json:"created_at" gorm:"type:timestamp;not null"`
+		ID              uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+		Username        string     `json:"username" gorm:"type:varchar(36);not null"`
+		Password        string     `json:"password" gorm:"type:varchar(500);not null"`
+		Email           string     `json:"email" gorm:"type:varchar(500);not null"`
+		TelegramUserId  *string    `json:"telegram_user_id" gorm:"type:varchar(36);null"`
+		TelegramIsValid bool       `json:"telegram_is_valid"`
+		CreatedAt       time.Time  `json:"created_at" gorm:"type:timestamp;not null"`
+		DeletedAt       *time.Time `json:"deleted_at" gorm:"type:datetime;default:null"`
 		// FK - User
 		CreatedBy uuid.UUID `json:"created_by" gorm:"not null"`
 		Admin     Admin     `json:"-" gorm:"foreignKey:CreatedBy;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	}
+	TechnicianScope struct {
+		ID         uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
+		ScopeType  string    `json:"scope_type" gorm:"type:varchar(36);not null"`
+		ScopeValue string    `json:"scope_value" gorm:"type:varchar(75);not null"`
+		CreatedAt  time.Time `json:"created_at" gorm:"type:timestamp;not null"`
+		// FK - Technician
+		TechnicianId uuid.UUID  `json:"technician_id" gorm:"type:varchar(36);not null"`
+		Technician   Technician `json:"-" gorm:"foreignKey:TechnicianId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	}
+	AccessScope struct {
+		Departments []string `json:"departments"`
+		Floors      []string `json:"floors"`
+	}
+	RequestPutTechnicianScope struct {
+		Departments []string `json:"departments"`
+		Floors      []string `json:"floors"`
+	}
 	// For Response Only
+	ResponseGetTechnicianScope struct {
+		Message string      `json:"message" example:"technician scope fetched"`
+		Status  string      `json:"status" example:"success"`
+		Data    AccessScope `json:"data"`
+	}
+	ResponsePutTechnicianScope struct {
+		Message string      `json:"message" example:"technician scope updated"`
+		Status  string      `json:"status" example:"success"`
+		Data    AccessScope `json:"data"`
+	}
 	ResponseGetAllTechnician struct {
 		Message  string       `json:"message" example:"technician fetched"`
 		Status   string       `json:"status" example:"success"`
@@ -35,16 +63,28 @@ type (
 		Status  string `json:"status" example:"success"`
 	}
 	RequestPostUpdateTechnicianById struct {
-		Username        string  `json:"username"`
-		Password        string  `json:"password"`
-		Email           string  `json:"email"`
-		TelegramUserId  *string `json:"telegram_user_id"`
-		TelegramIsValid bool    `json:"telegram_is_valid"`
+		Username       string  `json:"username"`
+		Password       string  `json:"password"`
+		Email          string  `json:"email"`
+		TelegramUserId *string `json:"telegram_user_id"`
 	}
 	ResponseDeleteTechnicianById struct {
 		Message string `json:"message" example:"technician deleted"`
 		Status  string `json:"status" example:"success"`
 	}
+	ResponseGetDeletedTechnician struct {
+		Message string       `json:"message" example:"deleted technician fetched"`
+		Status  string       `json:"status" example:"success"`
+		Data    []Technician `json:"data"`
+	}
+	ResponseHardDeleteTechnicianById struct {
+		Message string `json:"message" example:"technician permanentally deleted"`
+		Status  string `json:"status" example:"success"`
+	}
+	ResponseRecoverDeleteTechnicianById struct {
+		Message string `json:"message" example:"technician recovered"`
+		Status  string `json:"status" example:"success"`
+	}
 )
 
 // For Generic Interface
@@ -54,3 +94,16 @@ func (a *Technician) GetID() uuid.UUID {
 func (a *Technician) GetPassword() string {
 	return a.Password
 }
+func (a *Technician) GetContact() AccountContact {
+	return AccountContact{
+		Username:        a.Username,
+		Email:           a.Email,
+		TelegramUserId:  a.TelegramUserId,
+		TelegramIsValid: a.TelegramIsValid,
+	}
+}
+
+// Technician Without Any Department Or Floor Scope Reach Nothing, Only Nil Scope (Admin) Is Unrestricted
+func (s *AccessScope) IsEmpty() bool {
+	return s == nil || (len(s.Departments) == 0 && len(s.Floors) == 0)
+}
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	TelegramLink struct {
+		AccountId uuid.UUID `json:"account_id"`
+		Role      string    `json:"role"`
+	}
+	TelegramLinkCode struct {
+		Code      string    `json:"code"`
+		Command   string    `json:"command"`
+		ExpiredAt time.Time `json:"expired_at"`
+	}
+	// For Response Only
+	ResponsePostTelegramLink struct {
+		Message string           `json:"message" example:"telegram link code created"`
+		Status  string           `json:"status" example:"success"`
+		Data    TelegramLinkCode `json:"data"`
+	}
+	ResponseDeleteTelegramLink struct {
+		Message string `json:"message" example:"telegram unlinked"`
+		Status  string `json:"status" example:"success"`
+	}
+)
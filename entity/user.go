@@ -16,10 +16,32 @@ type (
 		TelegramIsValid bool      `json:"telegram_is_valid"`
 		CreatedAt       time.Time `json:"created_at" gorm:"type:timestamp;not null"`
 	}
+	EmailCollision struct {
+		Email     string    `json:"email"`
+		Role      string    `json:"role"`
+		AccountId uuid.UUID `json:"account_id"`
+		Username  string    `json:"username"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+	// Normalized Email Of Every Account, Its Primary Key Keep Email Unique Across Admin, Technician, and User
+	AccountEmail struct {
+		Email     string    `json:"email" gorm:"type:varchar(255);primaryKey"`
+		AccountId uuid.UUID `json:"account_id" gorm:"type:varchar(36);not null;uniqueIndex"`
+		Role      string    `json:"role" gorm:"type:varchar(36);not null"`
+	}
 	UserAuth struct {
 		Email    string `json:"email" binding:"required,email"`
 		Password string `json:"password" binding:"required"`
 	}
+	RequestPutUpdateProfile struct {
+		Username       string  `json:"username" binding:"required,max=36"`
+		Email          string  `json:"email" binding:"required,email"`
+		TelegramUserId *string `json:"telegram_user_id"`
+	}
+	UpdateProfileData struct {
+		EmailConfirmationRequired bool    `json:"email_confirmation_required" example:"true"`
+		PendingEmail              *string `json:"pending_email" example:"new@gmail.com"`
+	}
 	// For Response Only
 	ResponseGetAllUser struct {
 		Message  string   `json:"message" example:"user fetched"`
@@ -32,14 +54,20 @@ type (
 		Status  string `json:"status" example:"success"`
 		Data    []User `json:"data"`
 	}
+	ResponsePutUpdateProfile struct {
+		Message string            `json:"message" example:"profile updated"`
+		Status  string            `json:"status" example:"success"`
+		Data    UpdateProfileData `json:"data"`
+	}
 	ResponsePostLogin struct {
 		Message string    `json:"message" example:"user login successfully"`
 		Status  string    `json:"status" example:"success"`
 		Data    LoginData `json:"data"`
 	}
 	LoginData struct {
-		AccessToken string `json:"access_token" example:"<your_access_token>"`
-		Role        string `json:"role" example:"admin"`
+		AccessToken  string `json:"access_token" example:"<your_access_token>"`
+		RefreshToken string `json:"refresh_token" example:"<your_refresh_token>"`
+		Role         string `json:"role" example:"admin"`
 	}
 	ResponsePostSignOut struct {
 		Message string `json:"message" example:"User signed out"`
@@ -61,3 +89,11 @@ func (a *User) GetID() uuid.UUID {
 func (a *User) GetPassword() string {
 	return a.Password
 }
+func (a *User) GetContact() AccountContact {
+	return AccountContact{
+		Username:        a.Username,
+		Email:           a.Email,
+		TelegramUserId:  a.TelegramUserId,
+		TelegramIsValid: a.TelegramIsValid,
+	}
+}
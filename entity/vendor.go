@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	Vendor struct {
+		ID            uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+		VendorName    string     `json:"vendor_name" gorm:"type:varchar(75);unique;not null"`
+		VendorAddress *string    `json:"vendor_address" gorm:"type:varchar(255);null"`
+		ContactName   *string    `json:"contact_name" gorm:"type:varchar(75);null"`
+		ContactEmail  *string    `json:"contact_email" gorm:"type:varchar(255);null"`
+		ContactPhone  *string    `json:"contact_phone" gorm:"type:varchar(16);null"`
+		CreatedAt     time.Time  `json:"created_at" gorm:"type:datetime;not null"`
+		UpdatedAt     *time.Time `json:"updated_at" gorm:"type:datetime;default:null"`
+	}
+	RequestPostCreateUpdateVendor struct {
+		VendorName    string  `json:"vendor_name" binding:"required,max=75"`
+		VendorAddress *string `json:"vendor_address" binding:"omitempty,max=255"`
+		ContactName   *string `json:"contact_name" binding:"omitempty,max=75"`
+		ContactEmail  *string `json:"contact_email" binding:"omitempty,email"`
+		ContactPhone  *string `json:"contact_phone" binding:"omitempty,max=16"`
+	}
+	// For Response Only
+	ResponseGetAllVendor struct {
+		Message  string   `json:"message" example:"vendor fetched"`
+		Status   string   `json:"status" example:"success"`
+		Data     []Vendor `json:"data"`
+		Metadata Metadata `json:"metadata"`
+	}
+	ResponsePostCreateVendor struct {
+		Message string `json:"message" example:"vendor created"`
+		Status  string `json:"status" example:"success"`
+		Data    Vendor `json:"data"`
+	}
+	ResponsePutUpdateVendor struct {
+		Message string `json:"message" example:"vendor updated"`
+		Status  string `json:"status" example:"success"`
+		Data    Vendor `json:"data"`
+	}
+	ResponseDeleteVendorById struct {
+		Message string `json:"message" example:"vendor permanentally deleted"`
+		Status  string `json:"status" example:"success"`
+	}
+)
@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	Warranty struct {
+		ID            uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+		WarrantyType  string     `json:"warranty_type" gorm:"type:varchar(36);not null"`
+		StartDate     time.Time  `json:"start_date" gorm:"type:date;not null"`
+		EndDate       time.Time  `json:"end_date" gorm:"type:date;not null;index"`
+		CoverageNotes *string    `json:"coverage_notes" gorm:"type:varchar(500);null"`
+		ContactName   *string    `json:"contact_name" gorm:"type:varchar(75);null"`
+		ContactEmail  *string    `json:"contact_email" gorm:"type:varchar(255);null"`
+		ContactPhone  *string    `json:"contact_phone" gorm:"type:varchar(16);null"`
+		RemindedAt    *time.Time `json:"reminded_at" gorm:"type:datetime;null"` // Reset When The End Date Is Changed
+		CreatedAt     time.Time  `json:"created_at" gorm:"type:datetime;not null"`
+		UpdatedAt     *time.Time `json:"updated_at" gorm:"type:datetime;default:null"`
+		// FK - Asset
+		AssetId uuid.UUID `json:"asset_id" gorm:"type:varchar(36);not null;index"`
+		Asset   Asset     `json:"-" gorm:"foreignKey:AssetId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+		// FK - Vendor, Warranty Is Kept When The Vendor Is Removed
+		VendorId *uuid.UUID `json:"vendor_id" gorm:"type:varchar(36);null"`
+		Vendor   *Vendor    `json:"vendor,omitempty" gorm:"foreignKey:VendorId;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	}
+	AssetWarrantyStatus struct {
+		IsUnderWarranty bool       `json:"is_under_warranty"`
+		WarrantyId      *uuid.UUID `json:"warranty_id"`
+		WarrantyType    *string    `json:"warranty_type"`
+		EndDate         *time.Time `json:"end_date"`
+		VendorName      *string    `json:"vendor_name"`
+		ContactName     *string    `json:"contact_name"`
+		ContactEmail    *string    `json:"contact_email"`
+		ContactPhone    *string    `json:"contact_phone"`
+	}
+	WarrantyReminder struct {
+		ID           uuid.UUID `json:"id"`
+		AssetName    string    `json:"asset_name"`
+		WarrantyType string    `json:"warranty_type"`
+		EndDate      time.Time `json:"end_date"`
+		VendorName   *string   `json:"vendor_name"`
+		ContactName  *string   `json:"contact_name"`
+		ContactPhone *string   `json:"contact_phone"`
+	}
+	RequestPostCreateUpdateWarranty struct {
+		WarrantyType  string     `json:"warranty_type" binding:"required"`
+		StartDate     time.Time  `json:"start_date" binding:"required"`
+		EndDate       time.Time  `json:"end_date" binding:"required"`
+		CoverageNotes *string    `json:"coverage_notes" binding:"omitempty,max=500"`
+		ContactName   *string    `json:"contact_name" binding:"omitempty,max=75"`
+		ContactEmail  *string    `json:"contact_email" binding:"omitempty,email"`
+		ContactPhone  *string    `json:"contact_phone" binding:"omitempty,max=16"`
+		AssetId       uuid.UUID  `json:"asset_id" binding:"required"`
+		VendorId      *uuid.UUID `json:"vendor_id"`
+	}
+	// For Response Only
+	ResponseGetAllWarranty struct {
+		Message string     `json:"message" example:"warranty fetched"`
+		Status  string     `json:"status" example:"success"`
+		Data    []Warranty `json:"data"`
+	}
+	ResponsePostCreateWarranty struct {
+		Message string   `json:"message" example:"warranty created"`
+		Status  string   `json:"status" example:"success"`
+		Data    Warranty `json:"data"`
+	}
+	ResponsePutUpdateWarranty struct {
+		Message string   `json:"message" example:"warranty updated"`
+		Status  string   `json:"status" example:"success"`
+		Data    Warranty `json:"data"`
+	}
+	ResponseDeleteWarrantyById struct {
+		Message string `json:"message" example:"warranty permanentally deleted"`
+		Status  string `json:"status" example:"success"`
+	}
+)
@@ -16,6 +16,7 @@ func GenerateAdmin() entity.Admin {
 		Password:        string(hashedPass),
 		TelegramUserId:  nil,
 		TelegramIsValid: false,
+		IsActive:        true,
 		Email:           gofakeit.Email(),
 	}
 }
@@ -0,0 +1,18 @@
+package factory
+
+import (
+	"pelita/entity"
+
+	"github.com/brianvoe/gofakeit/v6"
+)
+
+func GenerateAssetCategory() entity.AssetCategory {
+	desc := gofakeit.Sentence(8)
+	maintenanceIntervalDay := gofakeit.Number(3, 12) * 30
+
+	return entity.AssetCategory{
+		CategoryName:           gofakeit.ProductCategory(),
+		CategoryDesc:           &desc,
+		MaintenanceIntervalDay: &maintenanceIntervalDay,
+	}
+}
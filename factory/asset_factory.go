@@ -1,10 +1,10 @@
 package factory
 
 import (
-	"fmt"
 	"pelita/config"
 	"pelita/entity"
 	"pelita/utils"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
 )
@@ -12,7 +12,9 @@ import (
 func GenerateAsset() entity.Asset {
 	desc := gofakeit.ProductDescription()
 	merk := gofakeit.Company()
-	price := fmt.Sprintf("%d", gofakeit.Number(1, 1000)*10000)
+	price := entity.Money(gofakeit.Number(1, 1000) * 10000 * 100)
+	purchaseDate := gofakeit.DateRange(time.Now().AddDate(-5, 0, 0), time.Now()).Truncate(24 * time.Hour)
+	usefulLife := gofakeit.Number(2, 8)
 
 	return entity.Asset{
 		AssetName:     gofakeit.ProductName(),
@@ -20,6 +22,11 @@ func GenerateAsset() entity.Asset {
 		AssetMerk:     &merk,
 		AssetCategory: gofakeit.ProductCategory(),
 		AssetPrice:    &price,
+		AssetCurrency: config.Currencies[0],
 		AssetStatus:   utils.RandomPicker(config.AssetStatus),
+		// Depreciation
+		PurchaseDate:       &purchaseDate,
+		UsefulLife:         &usefulLife,
+		DepreciationMethod: utils.RandomPicker(config.DepreciationMethods),
 	}
 }
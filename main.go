@@ -6,7 +6,10 @@ import (
 	"os"
 	"pelita/config"
 	"pelita/entity"
+	"pelita/repository"
 	"pelita/routes"
+	"pelita/utils"
+	"strings"
 
 	_ "pelita/docs"
 
@@ -41,7 +44,13 @@ func main() {
 
 	// Connect DB
 	db := config.ConnectDatabase()
+	MigrateAssetPrice(db)
 	MigrateAll(db)
+	MigrateAssetUnit(db)
+	MigrateAssetImage(db)
+	MigrateAssetCategory(db)
+	MigrateAssetCategoryFieldType(db)
+	ReportEmailCollision(db)
 
 	// Setup Gin & Redis
 	router := gin.Default()
@@ -65,12 +74,33 @@ func MigrateAll(db *gorm.DB) {
 		&entity.User{},
 		&entity.Admin{},
 		&entity.Technician{},
+		&entity.AccountEmail{},
+		&entity.TechnicianScope{},
 		&entity.Room{},
+		&entity.AssetCategory{},
+		&entity.AssetCategoryField{},
 		&entity.Asset{},
+		&entity.AssetAttribute{},
+		&entity.AssetImage{},
+		&entity.Vendor{},
+		&entity.Warranty{},
+		&entity.AssetStatusHistory{},
 		&entity.AssetPlacement{},
+		&entity.AssetUnit{},
+		&entity.AssetTransfer{},
+		&entity.AssetMovement{},
 		&entity.AssetMaintenance{},
 		&entity.AssetFinding{},
+		&entity.AssetLoan{},
 		&entity.History{},
+		&entity.Archive{},
+		&entity.Role{},
+		&entity.RolePermission{},
+		&entity.RoleSeededPermission{},
+		&entity.AccountRole{},
+		&entity.ApiKey{},
+		&entity.ApiKeyScope{},
+		&entity.Migration{},
 	)
 
 	if err != nil {
@@ -79,3 +109,199 @@ func MigrateAll(db *gorm.DB) {
 
 	fmt.Println("Migrate Success!")
 }
+
+func MigrateAssetPrice(db *gorm.DB) {
+	// Asset Price Used To Be A Free Text, Normalize Every Value Before It Become A Decimal
+	if !db.Migrator().HasColumn(&entity.Asset{}, "asset_price") {
+		return
+	}
+	columnTypes, err := db.Migrator().ColumnTypes(&entity.Asset{})
+	if err != nil {
+		panic(err.Error())
+	}
+	for _, columnType := range columnTypes {
+		if columnType.Name() != "asset_price" || !strings.EqualFold(columnType.DatabaseTypeName(), "varchar") {
+			continue
+		}
+
+		var rows []struct {
+			ID         string
+			AssetPrice string
+		}
+		if err := db.Table("assets").Select("id, asset_price").Where("asset_price is not null").Find(&rows).Error; err != nil {
+			panic(err.Error())
+		}
+
+		// Nothing Is Changed While Any Price Can't Be Understood, So It Can Be Fixed By Hand First
+		prices := make(map[string]entity.Money, len(rows))
+		invalid := 0
+		for _, row := range rows {
+			if strings.TrimSpace(row.AssetPrice) == "" {
+				continue
+			}
+			price, err := utils.ParseMoney(row.AssetPrice)
+			if err != nil {
+				log.Printf("Migrate Asset Price : asset %s has invalid price %q : %s\n", row.ID, row.AssetPrice, err.Error())
+				invalid++
+				continue
+			}
+			prices[row.ID] = price
+		}
+		if invalid > 0 {
+			panic(fmt.Sprintf("migrate asset price aborted, %d asset price can't be converted", invalid))
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Table("assets").Where("asset_price is not null and trim(asset_price) = ''").Update("asset_price", nil).Error; err != nil {
+				return err
+			}
+			for id, price := range prices {
+				if err := tx.Table("assets").Where("id = ?", id).Update("asset_price", price.String()).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			panic(err.Error())
+		}
+
+		fmt.Printf("Migrate Asset Price Success! %d Asset Price Normalized\n", len(prices))
+	}
+}
+
+func MigrateAssetUnit(db *gorm.DB) {
+	var tags []string
+	total := 0
+	migrated, err := repository.NewMigrationRepository(db).RunOnce("asset_unit", func(tx *gorm.DB) error {
+		// Asset Tag Is Now Owned By Asset Unit, Carry Over The Printed Tag Before Exploding The Remaining Quantity
+		if tx.Migrator().HasTable("asset_tags") {
+			err := tx.Exec(`INSERT INTO asset_units (id, tag, sequence, unit_condition, created_at, asset_placement_id)
+				SELECT id, tag, sequence, 'good', created_at, asset_placement_id FROM asset_tags`).Error
+			if err != nil {
+				return err
+			}
+
+			// Tag Was Kept When The Quantity Was Reduced, Unit Beyond The Quantity Is Removed
+			tags, err = repository.NewAssetUnitRepository(tx).DeleteSurplusForAllAssetPlacement()
+			if err != nil {
+				return err
+			}
+
+			// Asset Tag Generate Permission Is Replaced By Unit Create
+			err = tx.Exec(`INSERT INTO role_permissions (id, role_id, permission)
+				SELECT UUID(), role_id, ? FROM role_permissions
+				WHERE permission = 'asset_tag:generate' AND role_id NOT IN (SELECT role_id FROM (SELECT role_id FROM role_permissions WHERE permission = ?) AS granted)`, config.PermissionUnitCreate, config.PermissionUnitCreate).Error
+			if err != nil {
+				return err
+			}
+			if err := tx.Exec(`DELETE FROM role_permissions WHERE permission = 'asset_tag:generate'`).Error; err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropTable("asset_tags"); err != nil {
+				return err
+			}
+		}
+
+		// Every Asset Placement Quantity Is Exploded Into Asset Unit
+		var err error
+		total, err = repository.NewAssetUnitRepository(tx).CreateMissingForAllAssetPlacement(config.GetAssetTagPrefix(), config.GetAssetTagSequenceLength())
+		return err
+	})
+	if err != nil {
+		panic(err.Error())
+	}
+	if !migrated {
+		return
+	}
+	for _, tag := range tags {
+		log.Printf("asset tag %s is beyond its placement quantity and has been removed\n", tag)
+	}
+
+	fmt.Printf("Migrate Asset Unit Success! %d Surplus Tag Removed, %d Unit Created\n", len(tags), total)
+}
+
+func MigrateAssetImage(db *gorm.DB) {
+	var total int64
+	migrated, err := repository.NewMigrationRepository(db).RunOnce("asset_image", func(tx *gorm.DB) error {
+		// Asset Image Used To Be A Single URL, Carry It Over As The Primary Image Of An Empty Gallery
+		result := tx.Exec(`INSERT INTO asset_images (id, image_url, image_order, is_primary, created_at, asset_id, created_by)
+			SELECT UUID(), asset_image_url, 1, true, created_at, id, created_by FROM assets
+			WHERE asset_image_url is not null AND id NOT IN (SELECT asset_id FROM asset_images)`)
+		total = result.RowsAffected
+
+		return result.Error
+	})
+	if err != nil {
+		panic(err.Error())
+	}
+	if !migrated {
+		return
+	}
+
+	fmt.Printf("Migrate Asset Image Success! %d Image Carried Over\n", total)
+}
+
+func MigrateAssetCategory(db *gorm.DB) {
+	total := 0
+	migrated, err := repository.NewMigrationRepository(db).RunOnce("asset_category", func(tx *gorm.DB) error {
+		// Asset Category Used To Be A Free Text, Every Distinct Value Become A Category And Plural Is Folded Into Its Singular
+		var err error
+		total, err = repository.NewAssetCategoryRepository(tx).CreateMissingFromAsset()
+		return err
+	})
+	if err != nil {
+		panic(err.Error())
+	}
+	if !migrated {
+		return
+	}
+
+	fmt.Printf("Migrate Asset Category Success! %d Category Created\n", total)
+}
+
+func MigrateAssetCategoryFieldType(db *gorm.DB) {
+	var total int64
+	migrated, err := repository.NewMigrationRepository(db).RunOnce("asset_category_field_type", func(tx *gorm.DB) error {
+		// Field Type Text And Option Has Been Renamed To String And Enum
+		result := tx.Exec(`UPDATE asset_category_fields SET field_type = CASE field_type WHEN 'text' THEN 'string' ELSE 'enum' END
+			WHERE field_type IN ('text', 'option')`)
+		total = result.RowsAffected
+
+		return result.Error
+	})
+	if err != nil {
+		panic(err.Error())
+	}
+	if !migrated {
+		return
+	}
+
+	fmt.Printf("Migrate Asset Category Field Type Success! %d Field Renamed\n", total)
+}
+
+func ReportEmailCollision(db *gorm.DB) {
+	// Email Must Be Unique Across Admin, Technician, and User. Collision Created Before It Was Enforced Must Be Fixed Manually
+	userRepo := repository.NewUserRepository(db)
+	if err := userRepo.SyncAccountEmail(); err != nil {
+		log.Printf("failed to sync account email: %v\n", err)
+		return
+	}
+
+	collisions, err := userRepo.FindAllEmailCollision()
+	if err != nil {
+		log.Printf("failed to report email collision: %v\n", err)
+		return
+	}
+	if len(collisions) == 0 {
+		fmt.Println("No Email Collision Found!")
+		return
+	}
+
+	fmt.Printf("Found %d Account With Colliding Email :\n", len(collisions))
+	for _, collision := range collisions {
+		report := fmt.Sprintf("- %s | %s | %s | %s", collision.Email, collision.Role, collision.AccountId, collision.Username)
+		fmt.Println(report)
+		log.Printf("email collision: %s\n", report)
+	}
+}
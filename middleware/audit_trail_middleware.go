@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"log"
+	"pelita/config"
 	"pelita/entity"
 	"pelita/utils"
 	"time"
@@ -42,8 +43,10 @@ func AuditTrailMiddleware(db *gorm.DB, activityName string) gin.HandlerFunc {
 			history.AdminID = &userID
 		case "technician":
 			history.TechnicianID = &userID
-		case "user":
+		case "guest":
 			history.UserID = &userID
+		case config.RoleApiKey:
+			history.ApiKeyID = &userID
 		default:
 			log.Println("unknown user type:")
 			c.Next()
@@ -3,11 +3,17 @@ package middleware
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"math"
 	"net/http"
 	"strings"
 	"time"
 
 	"pelita/config"
+	"pelita/entity"
+	"pelita/repository"
+	"pelita/utils"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt"
@@ -52,8 +58,23 @@ func ValidateToken(tokenString string) (uuid.UUID, error) {
 	return userID, nil
 }
 
-func AuthMiddleware(redisClient *redis.Client, allowedRoles ...string) gin.HandlerFunc {
+// Api Key (X-API-Key Header) Only Accepted When apiKeyRepo Is Given
+func AuthMiddleware(redisClient *redis.Client, apiKeyRepo repository.ApiKeyRepository, allowedRoles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Api Key
+		if key := strings.TrimSpace(c.GetHeader("X-API-Key")); key != "" {
+			if apiKeyRepo == nil {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "api key is not accepted on this route"})
+				return
+			}
+			if !isRoleAllowed(config.RoleApiKey, allowedRoles) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "access forbidden for this role"})
+				return
+			}
+			authenticateApiKey(c, apiKeyRepo, key)
+			return
+		}
+
 		// Header
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -106,15 +127,28 @@ func AuthMiddleware(redisClient *redis.Client, allowedRoles ...string) gin.Handl
 			return
 		}
 
-		// Check If Role Is Allowed
-		isAllowed := false
-		for _, r := range allowedRoles {
-			if role == r {
-				isAllowed = true
-				break
+		// Check If Every Token Of The Account Has Been Revoked
+		revokedAt, err := redisClient.Get(context.Background(), fmt.Sprintf(config.RedisKeyRevokedAccount, userID)).Int64()
+		if err == nil {
+			issuedAt, ok := claims["iat"].(float64)
+			if !ok || int64(math.Round(issuedAt*1000)) <= revokedAt {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "token has been revoked"})
+				return
 			}
 		}
-		if !isAllowed {
+
+		// Check If Session Is Still Active
+		sessionID, hasSession := claims["session_id"].(string)
+		if hasSession {
+			exists, err := redisClient.Exists(context.Background(), fmt.Sprintf(config.RedisKeySession, sessionID)).Result()
+			if err != nil || exists == 0 {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "session has been revoked"})
+				return
+			}
+		}
+
+		// Check If Role Is Allowed, Any Role Is Allowed When Not Specified
+		if !isRoleAllowed(role, allowedRoles) {
 			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "access forbidden for this role"})
 			return
 		}
@@ -122,7 +156,72 @@ func AuthMiddleware(redisClient *redis.Client, allowedRoles ...string) gin.Handl
 		// Set Context
 		c.Set("userID", userID)
 		c.Set("role", role)
+		if hasSession {
+			c.Set("sessionID", sessionID)
+		}
 
 		c.Next()
 	}
 }
+
+func authenticateApiKey(c *gin.Context, apiKeyRepo repository.ApiKeyRepository, key string) {
+	// Repo : Find Api Key By Hash
+	apiKey, err := apiKeyRepo.FindByKeyHash(utils.HashToken(key))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": "failed to check api key"})
+		return
+	}
+	if apiKey == nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "invalid api key"})
+		return
+	}
+	if apiKey.RevokedAt != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "api key revoked"})
+		return
+	}
+	now := time.Now()
+	if now.After(apiKey.ExpiredAt) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "api key expired"})
+		return
+	}
+
+	// Repo : Update Last Used At
+	if err := apiKeyRepo.UpdateLastUsedAtById(now, apiKey.ID); err != nil {
+		log.Printf("failed to update api key last used: %v\n", err)
+	}
+
+	// Set Context
+	c.Set("userID", apiKey.ID.String())
+	c.Set("role", config.RoleApiKey)
+	c.Set("scopes", buildApiKeyScopes(apiKey.Scopes))
+
+	c.Next()
+
+	// Repo : Create History For Read Request, Write Request Is Already Audited By AuditTrailMiddleware
+	if isReadMethod(c.Request.Method) && !c.IsAborted() {
+		if err := apiKeyRepo.CreateHistoryById(fmt.Sprintf("api_key_read %s", c.FullPath()), apiKey.ID); err != nil {
+			log.Printf("failed to write api key audit log: %v\n", err)
+		}
+	}
+}
+
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+func buildApiKeyScopes(apiKeyScopes []entity.ApiKeyScope) []string {
+	scopes := make([]string, 0, len(apiKeyScopes))
+	for _, scope := range apiKeyScopes {
+		scopes = append(scopes, scope.Permission)
+	}
+
+	return scopes
+}
+
+func isRoleAllowed(role string, allowedRoles []string) bool {
+	if len(allowedRoles) == 0 {
+		return true
+	}
+
+	return utils.Contains(allowedRoles, role)
+}
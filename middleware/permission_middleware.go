@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"pelita/config"
+	"pelita/repository"
+	"pelita/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func PermissionMiddleware(roleRepo repository.RoleRepository, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Get Context User Id & Role
+		userID, err := utils.GetCurrentUserID(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": err.Error()})
+			return
+		}
+		role, err := utils.GetCurrentRole(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": err.Error()})
+			return
+		}
+
+		// Repo : Find Permission By Account, Api Key Use Its Own Scopes
+		var permissions []string
+		if role == config.RoleApiKey {
+			permissions, err = utils.GetCurrentScopes(c)
+		} else {
+			permissions, err = roleRepo.FindPermissionByAccount(userID, role)
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": "failed to check permission"})
+			return
+		}
+
+		// Check If Permission Is Granted
+		if !utils.Contains(permissions, permission) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "access forbidden, missing permission " + permission})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"pelita/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Subquery Of Room Id Inside The Given Department And Floor Scope. Technician Without Any Scope Reach No Room
+func findScopedRoomId(db *gorm.DB, scope *entity.AccessScope) *gorm.DB {
+	query := db.Model(&entity.Room{}).Select("id")
+	if scope.IsEmpty() {
+		return query.Where("1 = 0")
+	}
+	if len(scope.Departments) > 0 {
+		query = query.Where("room_dept IN ?", scope.Departments)
+	}
+	if len(scope.Floors) > 0 {
+		query = query.Where("floor IN ?", scope.Floors)
+	}
+
+	return query
+}
+
+// Subquery Of Asset Placement Id Inside The Given Department And Floor Scope
+func findScopedAssetPlacementId(db *gorm.DB, scope *entity.AccessScope) *gorm.DB {
+	return db.Model(&entity.AssetPlacement{}).
+		Select("id").
+		Where("room_id IN (?)", findScopedRoomId(db, scope))
+}
+
+// Nil Scope Is Unrestricted, Otherwise The Row Must Be Inside The Scope
+func isInScope(db *gorm.DB, scope *entity.AccessScope, model interface{}, condition string, id uuid.UUID) (bool, error) {
+	if scope == nil {
+		return true, nil
+	}
+
+	// Query
+	var total int64
+	err := db.Model(model).Where("id = ?", id).Where(condition, findScopedRoomId(db, scope)).Count(&total).Error
+	if err != nil {
+		return false, err
+	}
+
+	return total > 0, nil
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+
+	"pelita/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Email Is Compared Case Insensitive And Without Surrounding Space
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// Reserve The Email For The Account, Concurrent Account With The Same Email Is Rejected By The Primary Key
+func reserveAccountEmail(tx *gorm.DB, accountId uuid.UUID, role, email string) error {
+	// Query : Release Previous Email Of The Account
+	if err := releaseAccountEmail(tx, accountId); err != nil {
+		return err
+	}
+
+	// Query : Reserve Email
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.AccountEmail{
+		Email:     normalizeEmail(email),
+		AccountId: accountId,
+		Role:      role,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("email already used")
+	}
+
+	return nil
+}
+
+func releaseAccountEmail(tx *gorm.DB, accountId uuid.UUID) error {
+	return tx.Where("account_id = ?", accountId).Delete(&entity.AccountEmail{}).Error
+}
@@ -2,20 +2,30 @@ package repository
 
 import (
 	"errors"
+	"time"
 
 	"pelita/entity"
+	"pelita/utils"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Admin Interface
 type AdminRepository interface {
+	FindAll(pagination utils.Pagination) ([]entity.Admin, int64, error)
+	FindById(id uuid.UUID) (*entity.Admin, error)
 	FindByEmail(email string) (*entity.Admin, error)
+	FindByEmailAndId(email string, id uuid.UUID) (*entity.Admin, error)
 	FindAllContact() ([]entity.AdminContact, error)
+	CountActive() (int64, error)
+	Create(admin *entity.Admin) error
+	UpdateById(admin *entity.Admin, id uuid.UUID) error
+	UpdateIsActiveById(isActive bool, id uuid.UUID) error
+	DeleteById(id uuid.UUID) error
 
 	// For Seeder
-	Create(room *entity.Admin) error
 	DeleteAll() error
 	FindOneRandom() (*entity.Admin, error)
 }
@@ -30,6 +40,45 @@ func NewAdminRepository(db *gorm.DB) AdminRepository {
 	return &adminRepository{db: db}
 }
 
+func (r *adminRepository) FindAll(pagination utils.Pagination) ([]entity.Admin, int64, error) {
+	var total int64
+
+	// Models
+	var admin []entity.Admin
+
+	// Pagination
+	offset := (pagination.Page - 1) * pagination.Limit
+	r.db.Model(&entity.Admin{}).Where("deleted_at is null").Count(&total)
+
+	// Query
+	err := r.db.Omit("password").
+		Where("deleted_at is null").
+		Order("is_active DESC").
+		Order("username ASC").
+		Limit(pagination.Limit).
+		Offset(offset).
+		Find(&admin).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, 0, err
+	}
+
+	return admin, total, nil
+}
+
+func (r *adminRepository) FindById(id uuid.UUID) (*entity.Admin, error) {
+	// Models
+	var admin entity.Admin
+
+	// Query
+	err := r.db.Where("id = ? AND deleted_at is null", id).First(&admin).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &admin, err
+}
+
 func (r *adminRepository) FindAllContact() ([]entity.AdminContact, error) {
 	// Models
 	var admin []entity.AdminContact
@@ -39,6 +88,7 @@ func (r *adminRepository) FindAllContact() ([]entity.AdminContact, error) {
 		Select("username, email, telegram_is_valid, telegram_user_id").
 		Where("telegram_is_valid = ?", true).
 		Where("telegram_user_id IS NOT NULL").
+		Where("is_active = ? AND deleted_at is null", true).
 		Order("username ASC").
 		Find(&admin).Error
 
@@ -49,6 +99,7 @@ func (r *adminRepository) FindAllContact() ([]entity.AdminContact, error) {
 	return admin, err
 }
 
+// Deleted Admin Is Still Found, So Its Email Stay Reserved And Login Is Rejected As Inactive
 func (r *adminRepository) FindByEmail(email string) (*entity.Admin, error) {
 	// Models
 	var admin entity.Admin
@@ -62,15 +113,146 @@ func (r *adminRepository) FindByEmail(email string) (*entity.Admin, error) {
 	return &admin, err
 }
 
-// For Seeder
-func (r *adminRepository) DeleteAll() error {
-	return r.db.Where("1 = 1").Delete(&entity.Admin{}).Error
+func (r *adminRepository) FindByEmailAndId(email string, id uuid.UUID) (*entity.Admin, error) {
+	// Models
+	var admin entity.Admin
+
+	// Query
+	err := r.db.Where("email = ? AND id != ?", email, id).First(&admin).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &admin, err
 }
+
+func (r *adminRepository) CountActive() (int64, error) {
+	var total int64
+
+	// Query
+	err := r.db.Model(&entity.Admin{}).Where("is_active = ? AND deleted_at is null", true).Count(&total).Error
+
+	return total, err
+}
+
 func (r *adminRepository) Create(admin *entity.Admin) error {
 	admin.ID = uuid.New()
+	admin.IsActive = true
 
-	// Query
-	return r.db.Create(admin).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Reserve Email
+		if err := reserveAccountEmail(tx, admin.ID, "admin", admin.Email); err != nil {
+			return err
+		}
+
+		// Query
+		return tx.Create(admin).Error
+	})
+}
+
+func (r *adminRepository) UpdateById(admin *entity.Admin, id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query
+		result := tx.Model(&entity.Admin{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"username":          admin.Username,
+			"email":             admin.Email,
+			"telegram_user_id":  admin.TelegramUserId,
+			"telegram_is_valid": admin.TelegramIsValid,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("admin not found")
+		}
+
+		// Query : Reserve Email
+		return reserveAccountEmail(tx, id, "admin", admin.Email)
+	})
+}
+
+func (r *adminRepository) UpdateIsActiveById(isActive bool, id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var deactivatedAt *time.Time
+		if !isActive {
+			// Query : Guard Last Active Admin
+			if err := checkLastActiveAdmin(tx, id); err != nil {
+				return err
+			}
+
+			now := time.Now()
+			deactivatedAt = &now
+		}
+
+		// Query
+		result := tx.Model(&entity.Admin{}).Where("id = ? AND deleted_at is null", id).Updates(map[string]interface{}{
+			"is_active":      isActive,
+			"deactivated_at": deactivatedAt,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("admin not found")
+		}
+
+		return nil
+	})
+}
+
+// Admin Is Referenced As Creator By Many Table With Cascade Delete, So It Is Deactivated And Marked As Deleted Instead
+func (r *adminRepository) DeleteById(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Guard Last Active Admin
+		if err := checkLastActiveAdmin(tx, id); err != nil {
+			return err
+		}
+
+		// Query
+		now := time.Now()
+		result := tx.Model(&entity.Admin{}).Where("id = ? AND deleted_at is null", id).Updates(map[string]interface{}{
+			"is_active":      false,
+			"deactivated_at": gorm.Expr("COALESCE(deactivated_at, ?)", now),
+			"deleted_at":     now,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("admin not found")
+		}
+
+		return nil
+	})
+}
+
+// Lock Every Active Admin, So Two Concurrent Removal Can't Both Pass The Guard
+func checkLastActiveAdmin(tx *gorm.DB, id uuid.UUID) error {
+	var activeIds []uuid.UUID
+	err := tx.Model(&entity.Admin{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("is_active = ? AND deleted_at is null", true).
+		Pluck("id", &activeIds).Error
+	if err != nil {
+		return err
+	}
+
+	for _, activeId := range activeIds {
+		if activeId == id && len(activeIds) <= 1 {
+			return errors.New("can't remove the last active admin")
+		}
+	}
+
+	return nil
+}
+
+// For Seeder
+func (r *adminRepository) DeleteAll() error {
+	if err := r.db.Where("role = ?", "admin").Delete(&entity.AccountEmail{}).Error; err != nil {
+		return err
+	}
+
+	return r.db.Where("1 = 1").Delete(&entity.Admin{}).Error
 }
 func (r *adminRepository) FindOneRandom() (*entity.Admin, error) {
 	var admin entity.Admin
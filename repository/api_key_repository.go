@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"errors"
+	"pelita/config"
+	"pelita/entity"
+	"pelita/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Api Key Interface
+type ApiKeyRepository interface {
+	FindAll(pagination utils.Pagination) ([]entity.ApiKey, int64, error)
+	FindByKeyHash(keyHash string) (*entity.ApiKey, error)
+	Create(apiKey *entity.ApiKey, scopes []string) error
+	UpdateLastUsedAtById(lastUsedAt time.Time, id uuid.UUID) error
+	CreateHistoryById(typeHistory string, id uuid.UUID) error
+	RevokeById(id uuid.UUID) error
+}
+
+// Api Key Struct
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+// Api Key Constructor
+func NewApiKeyRepository(db *gorm.DB) ApiKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+func (r *apiKeyRepository) FindAll(pagination utils.Pagination) ([]entity.ApiKey, int64, error) {
+	var total int64
+
+	// Models
+	var apiKeys []entity.ApiKey
+
+	// Pagination
+	offset := (pagination.Page - 1) * pagination.Limit
+	r.db.Model(&entity.ApiKey{}).Count(&total)
+
+	// Query
+	err := r.db.Preload("Scopes").
+		Order("created_at DESC").
+		Limit(pagination.Limit).
+		Offset(offset).
+		Find(&apiKeys).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, 0, err
+	}
+
+	return apiKeys, total, nil
+}
+
+func (r *apiKeyRepository) FindByKeyHash(keyHash string) (*entity.ApiKey, error) {
+	// Models
+	var apiKey entity.ApiKey
+
+	// Query
+	err := r.db.Preload("Scopes").Where("key_hash = ?", keyHash).First(&apiKey).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &apiKey, err
+}
+
+func (r *apiKeyRepository) Create(apiKey *entity.ApiKey, scopes []string) error {
+	apiKey.ID = uuid.New()
+	apiKey.CreatedAt = time.Now()
+	apiKey.Scopes = make([]entity.ApiKeyScope, 0, len(scopes))
+	for _, scope := range scopes {
+		apiKey.Scopes = append(apiKey.Scopes, entity.ApiKeyScope{
+			ID:         uuid.New(),
+			ApiKeyId:   apiKey.ID,
+			Permission: scope,
+		})
+	}
+
+	// Query
+	return r.db.Create(apiKey).Error
+}
+
+func (r *apiKeyRepository) UpdateLastUsedAtById(lastUsedAt time.Time, id uuid.UUID) error {
+	// Query
+	return r.db.Model(&entity.ApiKey{}).Where("id = ?", id).Update("last_used_at", lastUsedAt).Error
+}
+
+func (r *apiKeyRepository) CreateHistoryById(typeHistory string, id uuid.UUID) error {
+	history := entity.History{
+		ID:          uuid.New(),
+		ApiKeyID:    &id,
+		TypeUser:    config.RoleApiKey,
+		TypeHistory: typeHistory,
+		CreatedAt:   time.Now(),
+	}
+
+	// Query
+	return r.db.Create(&history).Error
+}
+
+// Revoked Api Key Is Kept, So Every History It Produced Is Still Attributed To It
+func (r *apiKeyRepository) RevokeById(id uuid.UUID) error {
+	// Query : Only Active Api Key Can Be Revoked
+	result := r.db.Model(&entity.ApiKey{}).Where("id = ? AND revoked_at is null", id).Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("api key not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"pelita/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Table That Is Removed By FK OnDelete:CASCADE, Its Children Are Removed Along With It
+type deleteDependency struct {
+	table    string
+	column   string
+	children []deleteDependency
+}
+
+// Row That Would Be Removed By A Hard Delete
+type deleteRecord struct {
+	table string
+	id    string
+	data  map[string]interface{}
+}
+
+// Child Of Asset Placement Is Shared By Asset, Room, And Technician
+var assetPlacementDeleteDependency = deleteDependency{
+	table:  "asset_placements",
+	column: "id",
+	children: []deleteDependency{
+		{table: "asset_units", column: "asset_placement_id"},
+		{table: "asset_maintenances", column: "asset_placement_id"},
+		{table: "asset_findings", column: "asset_placement_id"},
+		{table: "asset_loans", column: "asset_placement_id"},
+		{table: "asset_transfers", column: "source_placement_id"},
+	},
+}
+
+var assetMaintenanceDeleteDependency = deleteDependency{table: "asset_maintenances", column: "id"}
+
+var assetFindingDeleteDependency = deleteDependency{table: "asset_findings", column: "id"}
+
+var assetDeleteDependency = deleteDependency{
+	table:  "assets",
+	column: "id",
+	children: []deleteDependency{
+		{table: "asset_images", column: "asset_id"},
+		{table: "asset_attributes", column: "asset_id"},
+		{table: "asset_status_histories", column: "asset_id"},
+		{table: "asset_placements", column: "asset_id", children: assetPlacementDeleteDependency.children},
+		{table: "warranties", column: "asset_id"},
+		{table: "asset_transfers", column: "asset_id"},
+		{table: "asset_movements", column: "asset_id"},
+	},
+}
+
+var roomDeleteDependency = deleteDependency{
+	table:  "rooms",
+	column: "id",
+	children: []deleteDependency{
+		{table: "asset_placements", column: "room_id", children: assetPlacementDeleteDependency.children},
+		{table: "asset_transfers", column: "target_room_id"},
+		{table: "asset_movements", column: "from_room_id"},
+		{table: "asset_movements", column: "to_room_id"},
+	},
+}
+
+var technicianDeleteDependency = deleteDependency{
+	table:  "technicians",
+	column: "id",
+	children: []deleteDependency{
+		{table: "technician_scopes", column: "technician_id"},
+		{table: "asset_placements", column: "asset_owner", children: assetPlacementDeleteDependency.children},
+		{table: "asset_maintenances", column: "maintenance_by"},
+		{table: "asset_findings", column: "finding_by_technician"},
+		{table: "histories", column: "technician_id"},
+	},
+}
+
+// Every Row That Would Be Removed When The Soft Deleted Root Row Is Hard Deleted, The Root Row Come First
+func findDeleteRecord(db *gorm.DB, root deleteDependency, id uuid.UUID) ([]deleteRecord, error) {
+	// Query : Only Soft Deleted Row Can Be Permanently Deleted
+	var total int64
+	if err := db.Table(root.table).Where("id = ? AND deleted_at is not null", id).Count(&total).Error; err != nil {
+		return nil, err
+	}
+	if total == 0 {
+		return nil, nil
+	}
+
+	var records []deleteRecord
+	visited := map[string]bool{}
+
+	var walk func(dependency deleteDependency, parentIds []string) error
+	walk = func(dependency deleteDependency, parentIds []string) error {
+		// Query
+		var rows []map[string]interface{}
+		err := db.Table(dependency.table).Where(dependency.column+" IN ?", parentIds).Order("id").Find(&rows).Error
+		if err != nil {
+			return err
+		}
+
+		var ids []string
+		for _, row := range rows {
+			for column, value := range row {
+				if b, ok := value.([]byte); ok {
+					row[column] = string(b)
+				}
+			}
+			rowId := fmt.Sprint(row["id"])
+			ids = append(ids, rowId)
+
+			// A Row Can Be Reached From More Than One Parent, Such As A Transfer Of The Asset Out Of Its Own Placement
+			key := dependency.table + ":" + rowId
+			if visited[key] {
+				continue
+			}
+			visited[key] = true
+			records = append(records, deleteRecord{table: dependency.table, id: rowId, data: row})
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		for _, child := range dependency.children {
+			if err := walk(child, ids); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := walk(root, []string{id.String()}); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// Id Of Soft Deleted Row That Has Passed The Retention
+func findDeletedIdBefore(db *gorm.DB, table string, before time.Time) ([]uuid.UUID, error) {
+	// Models
+	var ids []uuid.UUID
+
+	// Query
+	err := db.Table(table).Where("deleted_at is not null AND deleted_at < ?", before).Order("deleted_at ASC").Pluck("id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Dependency Grouped By Table, Without The Root Row. Nil When The Root Row Is Not Soft Deleted
+func findDeleteDependency(db *gorm.DB, root deleteDependency, id uuid.UUID) ([]entity.DeleteDependency, error) {
+	records, err := findDeleteRecord(db, root, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	dependencies := []entity.DeleteDependency{}
+	index := map[string]int{}
+	for _, record := range records[1:] {
+		idx, ok := index[record.table]
+		if !ok {
+			idx = len(dependencies)
+			index[record.table] = idx
+			dependencies = append(dependencies, entity.DeleteDependency{RecordTable: record.table, RecordIds: []string{}})
+		}
+		dependencies[idx].Total++
+		dependencies[idx].RecordIds = append(dependencies[idx].RecordIds, record.id)
+	}
+
+	return dependencies, nil
+}
+
+// Finding Image Of Every Asset Finding That Is Cascaded Along With The Soft Deleted Root Row
+func findDeletedFindingImage(db *gorm.DB, root deleteDependency, id uuid.UUID) ([]string, error) {
+	records, err := findDeleteRecord(db, root, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, record := range records {
+		if record.table != "asset_findings" || record.data["finding_image"] == nil {
+			continue
+		}
+		if file := fmt.Sprint(record.data["finding_image"]); file != "" {
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
+
+// Copy The Root Row And Every Cascaded Row Into Archive, Then Hard Delete The Root Row
+func deleteWithArchive(tx *gorm.DB, root deleteDependency, id, archivedBy uuid.UUID) error {
+	records, err := findDeleteRecord(tx, root, id)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	archives := make([]entity.Archive, 0, len(records))
+	for _, record := range records {
+		data, err := json.Marshal(record.data)
+		if err != nil {
+			return err
+		}
+
+		archives = append(archives, entity.Archive{
+			ID:           uuid.New(),
+			RecordTable:  record.table,
+			RecordId:     record.id,
+			RecordData:   string(data),
+			DeletedTable: root.table,
+			DeletedId:    id.String(),
+			ArchivedAt:   now,
+			ArchivedBy:   archivedBy,
+		})
+	}
+
+	// Query : Archive
+	if err := tx.CreateInBatches(&archives, 500).Error; err != nil {
+		return err
+	}
+
+	// Query : Delete The Root Row, The Rest Is Removed By FK OnDelete:CASCADE
+	return tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s = ?", root.table, root.column), id).Error
+}
@@ -0,0 +1,351 @@
+package repository
+
+import (
+	"errors"
+	"pelita/entity"
+	"pelita/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Asset Category Interface
+type AssetCategoryRepository interface {
+	FindAll() ([]entity.AssetCategory, error)
+	FindById(id uuid.UUID) (*entity.AssetCategory, error)
+	FindByCategoryName(categoryName string) (*entity.AssetCategory, error)
+	FindByCategoryNameAndId(categoryName string, id uuid.UUID) (*entity.AssetCategory, error)
+	CountAssetById(id uuid.UUID) (int64, error)
+	CountChildrenById(id uuid.UUID) (int64, error)
+	Create(assetCategory *entity.AssetCategory) error
+	UpdateById(assetCategory *entity.AssetCategory, id uuid.UUID, validateParent func(parentOf map[uuid.UUID]*uuid.UUID) error, validateAttribute func(assetIds []uuid.UUID, attributes []entity.AssetAttributeValue) ([]entity.AssetAttributeValue, error)) error
+	DeleteById(id uuid.UUID) error
+
+	// For Migration
+	CreateMissingFromAsset() (int, error)
+}
+
+// Asset Category Struct
+type assetCategoryRepository struct {
+	db *gorm.DB
+}
+
+// Asset Category Constructor
+func NewAssetCategoryRepository(db *gorm.DB) AssetCategoryRepository {
+	return &assetCategoryRepository{db: db}
+}
+
+func preloadAssetCategoryField(db *gorm.DB) *gorm.DB {
+	return db.Order("field_order ASC")
+}
+
+func (r *assetCategoryRepository) FindAll() ([]entity.AssetCategory, error) {
+	// Models
+	var assetCategory []entity.AssetCategory
+
+	// Query
+	err := r.db.Preload("FieldDefinitions", preloadAssetCategoryField).
+		Order("category_name ASC").
+		Find(&assetCategory).Error
+
+	return assetCategory, err
+}
+
+func (r *assetCategoryRepository) FindById(id uuid.UUID) (*entity.AssetCategory, error) {
+	// Models
+	var assetCategory entity.AssetCategory
+
+	// Query
+	err := r.db.Preload("FieldDefinitions", preloadAssetCategoryField).
+		Where("id = ?", id).
+		First(&assetCategory).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &assetCategory, err
+}
+
+func (r *assetCategoryRepository) FindByCategoryName(categoryName string) (*entity.AssetCategory, error) {
+	// Models
+	var assetCategory entity.AssetCategory
+
+	// Query
+	err := r.db.Preload("FieldDefinitions", preloadAssetCategoryField).
+		Where("LOWER(category_name) = ?", utils.NormalizeCategoryKey(categoryName)).
+		First(&assetCategory).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &assetCategory, err
+}
+
+func (r *assetCategoryRepository) FindByCategoryNameAndId(categoryName string, id uuid.UUID) (*entity.AssetCategory, error) {
+	// Models
+	var assetCategory entity.AssetCategory
+
+	// Query
+	err := r.db.Where("LOWER(category_name) = ? AND id != ?", utils.NormalizeCategoryKey(categoryName), id).First(&assetCategory).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &assetCategory, err
+}
+
+func (r *assetCategoryRepository) CountAssetById(id uuid.UUID) (int64, error) {
+	var total int64
+
+	// Query : Soft Deleted Asset Still Hold Its Category
+	err := r.db.Model(&entity.Asset{}).Where("asset_category_id = ?", id).Count(&total).Error
+
+	return total, err
+}
+
+func (r *assetCategoryRepository) CountChildrenById(id uuid.UUID) (int64, error) {
+	var total int64
+
+	// Query
+	err := r.db.Model(&entity.AssetCategory{}).Where("parent_id = ?", id).Count(&total).Error
+
+	return total, err
+}
+
+func (r *assetCategoryRepository) Create(assetCategory *entity.AssetCategory) error {
+	assetCategory.ID = uuid.New()
+	assetCategory.CreatedAt = time.Now()
+	assetCategory.UpdatedAt = nil
+	for i := range assetCategory.FieldDefinitions {
+		assetCategory.FieldDefinitions[i].ID = uuid.New()
+		assetCategory.FieldDefinitions[i].FieldOrder = i + 1
+	}
+
+	// Query : Create Asset Category Along With Its Field Definition
+	return r.db.Create(assetCategory).Error
+}
+
+func (r *assetCategoryRepository) UpdateById(assetCategory *entity.AssetCategory, id uuid.UUID, validateParent func(parentOf map[uuid.UUID]*uuid.UUID) error, validateAttribute func(assetIds []uuid.UUID, attributes []entity.AssetAttributeValue) ([]entity.AssetAttributeValue, error)) error {
+	now := time.Now()
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Every Category Is Locked, So Concurrent Re-Parent Is Checked One After Another
+		var allCategory []entity.AssetCategory
+		err := tx.Model(&entity.AssetCategory{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "parent_id").
+			Find(&allCategory).Error
+		if err != nil {
+			return err
+		}
+		parentOf := map[uuid.UUID]*uuid.UUID{}
+		for _, dt := range allCategory {
+			parentOf[dt.ID] = dt.ParentId
+		}
+		if err := validateParent(parentOf); err != nil {
+			return err
+		}
+
+		// Query : Update Asset Category
+		result := tx.Model(&entity.AssetCategory{}).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"category_name":            assetCategory.CategoryName,
+				"category_desc":            assetCategory.CategoryDesc,
+				"maintenance_interval_day": assetCategory.MaintenanceIntervalDay,
+				"parent_id":                assetCategory.ParentId,
+				"updated_at":               now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		// Query : Existing Attribute Is Locked Along With Its Asset And Checked Against The New Field Definition
+		var assetIds []uuid.UUID
+		err = tx.Model(&entity.Asset{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("asset_category_id = ? AND deleted_at is null", id).
+			Pluck("id", &assetIds).Error
+		if err != nil {
+			return err
+		}
+		var attributeValue []entity.AssetAttributeValue
+		err = tx.Table("asset_attributes").
+			Select("asset_attributes.asset_id, asset_category_fields.field_name, asset_attributes.attribute_value").
+			Joins("JOIN asset_category_fields ON asset_category_fields.id = asset_attributes.asset_category_field_id").
+			Where("asset_category_fields.asset_category_id = ?", id).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Scan(&attributeValue).Error
+		if err != nil {
+			return err
+		}
+		changedAttribute, err := validateAttribute(assetIds, attributeValue)
+		if err != nil {
+			return err
+		}
+
+		// Query : Field Definition Is Matched By Its Name, So The Field Keep Its Id
+		var existingField []entity.AssetCategoryField
+		if err := tx.Where("asset_category_id = ?", id).Find(&existingField).Error; err != nil {
+			return err
+		}
+		existingId := map[string]uuid.UUID{}
+		for _, dt := range existingField {
+			existingId[dt.FieldName] = dt.ID
+		}
+
+		keptId := []uuid.UUID{}
+		for i := range assetCategory.FieldDefinitions {
+			field := &assetCategory.FieldDefinitions[i]
+			field.AssetCategoryId = id
+			field.FieldOrder = i + 1
+			if fieldId, ok := existingId[field.FieldName]; ok {
+				field.ID = fieldId
+				if err := tx.Select("*").Omit("AssetCategory").Save(field).Error; err != nil {
+					return err
+				}
+			} else {
+				field.ID = uuid.New()
+				if err := tx.Omit("AssetCategory").Create(field).Error; err != nil {
+					return err
+				}
+			}
+			keptId = append(keptId, field.ID)
+		}
+
+		// Query : Attribute Value Is Rewritten Into Its Normalized Form Of The New Field Type
+		fieldId := map[string]uuid.UUID{}
+		for _, field := range assetCategory.FieldDefinitions {
+			fieldId[field.FieldName] = field.ID
+		}
+		for _, dt := range changedAttribute {
+			err := tx.Model(&entity.AssetAttribute{}).
+				Where("asset_id = ? AND asset_category_field_id = ?", dt.AssetId, fieldId[dt.FieldName]).
+				Update("attribute_value", dt.AttributeValue).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		// Query : Remove Field That Is No Longer Defined, Its Attribute Value Is Cascaded And Reported Back
+		assetCategory.RemovedAttributes = nil
+		for _, dt := range attributeValue {
+			if _, ok := fieldId[dt.FieldName]; !ok {
+				assetCategory.RemovedAttributes = append(assetCategory.RemovedAttributes, dt)
+			}
+		}
+		query := tx.Where("asset_category_id = ?", id)
+		if len(keptId) > 0 {
+			query = query.Where("id NOT IN ?", keptId)
+		}
+		if err := query.Delete(&entity.AssetCategoryField{}).Error; err != nil {
+			return err
+		}
+
+		// Query : Asset Keep Holding The Latest Category Name
+		return tx.Model(&entity.Asset{}).
+			Where("asset_category_id = ?", id).
+			UpdateColumn("asset_category", assetCategory.CategoryName).Error
+	})
+}
+
+func (r *assetCategoryRepository) DeleteById(id uuid.UUID) error {
+	// Query
+	return r.db.Delete(&entity.AssetCategory{}, "id = ?", id).Error
+}
+
+func (r *assetCategoryRepository) CreateMissingFromAsset() (int, error) {
+	// Models
+	var usedCategory []struct {
+		AssetCategory string
+		Total         int
+	}
+	var existingCategory []entity.AssetCategory
+
+	// Query : Free Text Category That Is Not Linked Yet, The Most Used Spelling First
+	err := r.db.Table("assets").
+		Select("asset_category, COUNT(*) as total").
+		Where("asset_category_id is null").
+		Group("asset_category").
+		Order("total DESC").
+		Scan(&usedCategory).Error
+	if err != nil || len(usedCategory) == 0 {
+		return 0, err
+	}
+	if err := r.db.Find(&existingCategory).Error; err != nil {
+		return 0, err
+	}
+
+	// Existing Category Take Precedence, Otherwise The Most Used Spelling Become The Category Name
+	category := map[string]*entity.AssetCategory{}
+	for i := range existingCategory {
+		category[utils.NormalizeCategoryKey(existingCategory[i].CategoryName)] = &existingCategory[i]
+	}
+	categoryName := map[string]string{}
+	for _, dt := range usedCategory {
+		name := strings.Join(strings.Fields(dt.AssetCategory), " ")
+		if name == "" {
+			name = "Uncategorized"
+		}
+		if _, ok := categoryName[utils.NormalizeCategoryKey(name)]; !ok {
+			categoryName[utils.NormalizeCategoryKey(name)] = name
+		}
+	}
+
+	// Plural Is Folded Into Its Singular When Both Exist
+	resolveKey := func(key string) string {
+		for _, singular := range utils.SingularCategoryKey(key) {
+			if _, ok := category[singular]; ok {
+				return singular
+			}
+			if _, ok := categoryName[singular]; ok {
+				return singular
+			}
+		}
+		return key
+	}
+
+	total := 0
+	now := time.Now()
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		for _, dt := range usedCategory {
+			key := utils.NormalizeCategoryKey(dt.AssetCategory)
+			if key == "" {
+				key = utils.NormalizeCategoryKey("Uncategorized")
+			}
+			key = resolveKey(key)
+
+			// Query : Create Missing Asset Category
+			assetCategory, ok := category[key]
+			if !ok {
+				assetCategory = &entity.AssetCategory{ID: uuid.New(), CategoryName: categoryName[key], CreatedAt: now}
+				if err := tx.Create(assetCategory).Error; err != nil {
+					return err
+				}
+				category[key] = assetCategory
+				total++
+			}
+
+			// Query : Link Asset And Rewrite Its Category Name
+			err := tx.Model(&entity.Asset{}).
+				Where("asset_category = ? AND asset_category_id is null", dt.AssetCategory).
+				UpdateColumns(map[string]interface{}{
+					"asset_category_id": assetCategory.ID,
+					"asset_category":    assetCategory.CategoryName,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return total, err
+}
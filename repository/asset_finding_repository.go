@@ -12,11 +12,17 @@ import (
 
 // Asset Finding Interface
 type AssetFindingRepository interface {
-	FindAll(pagination utils.Pagination) ([]entity.AssetFinding, int64, error)
+	FindAll(pagination utils.Pagination, filter utils.Filter, scope *entity.AccessScope) ([]entity.AssetFinding, int64, error)
 	FindAllReport() ([]entity.AssetFindingReport, error)
 	FindAllFindingHourTotal() ([]entity.StatsContextTotal, error)
+	FindDeleted() ([]entity.AssetFinding, error)
+	FindAllDeletedIdBefore(before time.Time) ([]uuid.UUID, error)
+	FindAllStoredFileByDeletedId(id uuid.UUID) ([]string, error)
+	FindDeleteDependencyById(id uuid.UUID) ([]entity.DeleteDependency, error)
 	Create(assetFinding *entity.AssetFinding, technicianId, userId uuid.UUID) error
-	DeleteById(id uuid.UUID) error
+	HardDeleteById(id, archivedBy uuid.UUID) error
+	SoftDeleteById(id uuid.UUID) error
+	RecoverDeletedById(id uuid.UUID) error
 
 	// For Seeder
 	DeleteAll() error
@@ -32,21 +38,40 @@ func NewAssetFindingRepository(db *gorm.DB) AssetFindingRepository {
 	return &assetFindingRepository{db: db}
 }
 
-func (r *assetFindingRepository) FindAll(pagination utils.Pagination) ([]entity.AssetFinding, int64, error) {
+func (r *assetFindingRepository) FindAll(pagination utils.Pagination, filter utils.Filter, scope *entity.AccessScope) ([]entity.AssetFinding, int64, error) {
 	var total int64
 
 	// Models
 	var assetFinding []entity.AssetFinding
 
+	// Query : Filter By Access Scope
+	query := r.db.Model(&entity.AssetFinding{}).
+		Where("asset_findings.deleted_at is null").
+		Where("asset_findings.asset_placement_id IN (?)", r.db.Model(&entity.AssetPlacement{}).Select("id").Where("deleted_at is null"))
+	if scope != nil {
+		query = query.Where("asset_findings.asset_placement_id IN (?)", findScopedAssetPlacementId(r.db, scope))
+	}
+
+	// Query : Filter
+	query, err := applyFilter(query, assetFindingFilterSpec, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// Pagination
 	offset := (pagination.Page - 1) * pagination.Limit
-	r.db.Model(&entity.AssetFinding{}).Count(&total)
+	query.Session(&gorm.Session{}).Count(&total)
+
+	// Query : Sort
+	query, err = applySort(query, assetFindingFilterSpec, filter)
+	if err != nil {
+		return nil, 0, err
+	}
 
 	// Query
-	err := r.db.Preload("User").
+	err = query.Preload("User").
 		Preload("Technician").
 		Preload("AssetPlacement").
-		Order("created_at DESC").
 		Limit(pagination.Limit).
 		Offset(offset).
 		Find(&assetFinding).Error
@@ -70,6 +95,7 @@ func (r *assetFindingRepository) FindAllReport() ([]entity.AssetFindingReport, e
 		Joins("JOIN rooms ON rooms.id = asset_placements.room_id").
 		Joins("JOIN asset_maintenances ON asset_maintenances.asset_placement_id = asset_placements.id").
 		Joins("JOIN technicians ON technicians.id = asset_maintenances.maintenance_by").
+		Where("asset_findings.deleted_at is null AND asset_placements.deleted_at is null AND asset_maintenances.deleted_at is null AND technicians.deleted_at is null").
 		Order("asset_findings.created_at DESC").
 		Find(&assetFinding).Error
 
@@ -87,6 +113,7 @@ func (r *assetFindingRepository) FindAllFindingHourTotal() ([]entity.StatsContex
 	// Query
 	err := r.db.Table("asset_findings").
 		Select("HOUR(created_at) as context, COUNT(1) as total").
+		Where("deleted_at is null").
 		Group("HOUR(created_at)").
 		Order("total DESC").
 		Find(&asset).Error
@@ -98,6 +125,50 @@ func (r *assetFindingRepository) FindAllFindingHourTotal() ([]entity.StatsContex
 	return asset, err
 }
 
+func (r *assetFindingRepository) FindDeleted() ([]entity.AssetFinding, error) {
+	// Models
+	var assetFinding []entity.AssetFinding
+
+	// Query
+	result := r.db.Order("deleted_at DESC").
+		Where("deleted_at is not null").
+		Find(&assetFinding)
+
+	// Response
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) || len(assetFinding) == 0 {
+		return nil, errors.New("deleted asset finding not found")
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return assetFinding, nil
+}
+
+func (r *assetFindingRepository) FindAllDeletedIdBefore(before time.Time) ([]uuid.UUID, error) {
+	return findDeletedIdBefore(r.db, "asset_findings", before)
+}
+
+func (r *assetFindingRepository) FindAllStoredFileByDeletedId(id uuid.UUID) ([]string, error) {
+	// Query : Finding Image That Go Along With A Permanently Deleted Asset Finding
+	files, err := findDeletedFindingImage(r.db, assetFindingDeleteDependency, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func (r *assetFindingRepository) FindDeleteDependencyById(id uuid.UUID) ([]entity.DeleteDependency, error) {
+	// Query : Every Row That Is Cascaded Along With The Asset Finding
+	dependencies, err := findDeleteDependency(r.db, assetFindingDeleteDependency, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dependencies, nil
+}
+
 func (r *assetFindingRepository) Create(assetFinding *entity.AssetFinding, technicianId, userId uuid.UUID) error {
 	now := time.Now()
 
@@ -118,13 +189,51 @@ func (r *assetFindingRepository) Create(assetFinding *entity.AssetFinding, techn
 	return r.db.Create(assetFinding).Error
 }
 
-func (r *assetFindingRepository) DeleteById(id uuid.UUID) error {
-	// Models
-	var assetFinding entity.AssetFinding
+func (r *assetFindingRepository) HardDeleteById(id, archivedBy uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Archive And Delete
+		return deleteWithArchive(tx, assetFindingDeleteDependency, id, archivedBy)
+	})
+}
 
-	// Query
-	err := r.db.Unscoped().Where("id = ?", id).Delete(&assetFinding).Error
-	if err != nil {
+func (r *assetFindingRepository) SoftDeleteById(id uuid.UUID) error {
+	// Query : Check Old Asset Finding
+	var existingAssetFinding entity.AssetFinding
+	if err := r.db.First(&existingAssetFinding, "id = ? AND deleted_at is null", id).Error; err != nil {
+		return err
+	}
+	now := time.Now()
+
+	// Query : Update
+	existingAssetFinding.DeletedAt = &now
+
+	if err := r.db.Save(&existingAssetFinding).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *assetFindingRepository) RecoverDeletedById(id uuid.UUID) error {
+	// Query : Check Old Asset Finding
+	var existingAssetFinding entity.AssetFinding
+	if err := r.db.First(&existingAssetFinding, "id = ? AND deleted_at is not null", id).Error; err != nil {
+		return err
+	}
+
+	// Query : Asset Placement Must Not Be Deleted
+	var total int64
+	if err := r.db.Model(&entity.AssetPlacement{}).Where("id = ? AND deleted_at is null", existingAssetFinding.AssetPlacementId).Count(&total).Error; err != nil {
+		return err
+	}
+	if total == 0 {
+		return errors.New("asset placement of the asset finding is deleted, recover the asset placement first")
+	}
+
+	// Query : Update
+	existingAssetFinding.DeletedAt = nil
+
+	if err := r.db.Save(&existingAssetFinding).Error; err != nil {
 		return err
 	}
 
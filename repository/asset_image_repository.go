@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"errors"
+	"pelita/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Asset Image Interface
+type AssetImageRepository interface {
+	FindAllByAssetId(assetId uuid.UUID) ([]entity.AssetImage, error)
+	FindById(id uuid.UUID) (*entity.AssetImage, error)
+	Create(assetImage []entity.AssetImage, assetId, adminId uuid.UUID) error
+	UpdateOrderByAssetId(assetId uuid.UUID, ids []uuid.UUID) error
+	UpdatePrimaryById(assetImage *entity.AssetImage) error
+	DeleteById(assetImage *entity.AssetImage) error
+}
+
+// Asset Image Struct
+type assetImageRepository struct {
+	db *gorm.DB
+}
+
+// Asset Image Constructor
+func NewAssetImageRepository(db *gorm.DB) AssetImageRepository {
+	return &assetImageRepository{db: db}
+}
+
+func (r *assetImageRepository) FindAllByAssetId(assetId uuid.UUID) ([]entity.AssetImage, error) {
+	// Models
+	var assetImage []entity.AssetImage
+
+	// Query
+	err := r.db.Where("asset_id = ?", assetId).
+		Order("image_order ASC").
+		Find(&assetImage).Error
+
+	return assetImage, err
+}
+
+func (r *assetImageRepository) FindById(id uuid.UUID) (*entity.AssetImage, error) {
+	// Models
+	var assetImage entity.AssetImage
+
+	// Query
+	err := r.db.Where("id = ?", id).First(&assetImage).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &assetImage, err
+}
+
+func (r *assetImageRepository) Create(assetImage []entity.AssetImage, assetId, adminId uuid.UUID) error {
+	now := time.Now()
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : New Image Is Appended After The Last Order
+		var lastOrder *int
+		if err := tx.Model(&entity.AssetImage{}).Select("MAX(image_order)").Where("asset_id = ?", assetId).Scan(&lastOrder).Error; err != nil {
+			return err
+		}
+		nextOrder := 1
+		if lastOrder != nil {
+			nextOrder = *lastOrder + 1
+		}
+
+		for i := range assetImage {
+			assetImage[i].ID = uuid.New()
+			assetImage[i].AssetId = assetId
+			assetImage[i].CreatedBy = adminId
+			assetImage[i].CreatedAt = now
+			assetImage[i].ImageOrder = nextOrder + i
+			// The First Image Of The Gallery Become The Primary
+			assetImage[i].IsPrimary = lastOrder == nil && i == 0
+		}
+
+		// Query : Create Asset Image
+		if err := tx.Create(&assetImage).Error; err != nil {
+			return err
+		}
+		if lastOrder != nil {
+			return nil
+		}
+
+		return syncAssetImageURL(tx, assetId, &assetImage[0].ImageURL)
+	})
+}
+
+func (r *assetImageRepository) UpdateOrderByAssetId(assetId uuid.UUID, ids []uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			err := tx.Model(&entity.AssetImage{}).
+				Where("id = ? AND asset_id = ?", id, assetId).
+				Update("image_order", i+1).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *assetImageRepository) UpdatePrimaryById(assetImage *entity.AssetImage) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Only One Primary Image Per Asset
+		err := tx.Model(&entity.AssetImage{}).
+			Where("asset_id = ?", assetImage.AssetId).
+			Update("is_primary", gorm.Expr("id = ?", assetImage.ID)).Error
+		if err != nil {
+			return err
+		}
+
+		return syncAssetImageURL(tx, assetImage.AssetId, &assetImage.ImageURL)
+	})
+}
+
+func (r *assetImageRepository) DeleteById(assetImage *entity.AssetImage) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Delete Asset Image
+		if err := tx.Delete(&entity.AssetImage{}, "id = ?", assetImage.ID).Error; err != nil {
+			return err
+		}
+		if !assetImage.IsPrimary {
+			return nil
+		}
+
+		// Query : The Next Image In Order Take Over As The Primary
+		var nextImage entity.AssetImage
+		err := tx.Where("asset_id = ?", assetImage.AssetId).Order("image_order ASC").First(&nextImage).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return syncAssetImageURL(tx, assetImage.AssetId, nil)
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&nextImage).Update("is_primary", true).Error; err != nil {
+			return err
+		}
+
+		return syncAssetImageURL(tx, assetImage.AssetId, &nextImage.ImageURL)
+	})
+}
+
+// Asset Image URL Always Mirror The Primary Image Of The Gallery
+func syncAssetImageURL(tx *gorm.DB, assetId uuid.UUID, imageURL *string) error {
+	return tx.Model(&entity.Asset{}).
+		Where("id = ?", assetId).
+		Update("asset_image_url", imageURL).Error
+}
@@ -0,0 +1,351 @@
+package repository
+
+import (
+	"errors"
+	"pelita/entity"
+	"pelita/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Asset Loan Interface
+type AssetLoanRepository interface {
+	FindAll(pagination utils.Pagination, scope *entity.AccessScope) ([]entity.AssetLoan, int64, error)
+	FindAllByBorrowerId(pagination utils.Pagination, borrowerId uuid.UUID) ([]entity.AssetLoan, int64, error)
+	FindAllOverdue(now time.Time) ([]entity.AssetLoanOverdue, error)
+	FindById(id uuid.UUID) (*entity.AssetLoan, error)
+	CountActiveByAssetPlacementId(assetPlacementId uuid.UUID) (int64, error)
+	Create(assetLoan *entity.AssetLoan) error
+	ApproveById(adminId, id uuid.UUID) error
+	UpdateApprovalById(loanStatus string, adminId, id uuid.UUID) error
+	CheckOutById(id uuid.UUID, resolveHistory func(asset *entity.Asset) (*entity.AssetStatusHistory, error)) error
+	ReturnById(returnCondition string, returnNotes *string, id uuid.UUID, resolveHistory func(asset *entity.Asset, totalCheckedOut int64) (*entity.AssetStatusHistory, error)) error
+}
+
+// Asset Loan Struct
+type assetLoanRepository struct {
+	db *gorm.DB
+}
+
+// Asset Loan Constructor
+func NewAssetLoanRepository(db *gorm.DB) AssetLoanRepository {
+	return &assetLoanRepository{db: db}
+}
+
+func (r *assetLoanRepository) FindAll(pagination utils.Pagination, scope *entity.AccessScope) ([]entity.AssetLoan, int64, error) {
+	var total int64
+
+	// Models
+	var assetLoan []entity.AssetLoan
+
+	// Query : Filter By Access Scope
+	query := r.db.Model(&entity.AssetLoan{})
+	if scope != nil {
+		query = query.Where("asset_placement_id IN (?)", findScopedAssetPlacementId(r.db, scope))
+	}
+
+	// Pagination
+	offset := (pagination.Page - 1) * pagination.Limit
+	query.Session(&gorm.Session{}).Count(&total)
+
+	// Query
+	err := query.Preload("AssetPlacement").
+		Order("created_at DESC").
+		Limit(pagination.Limit).
+		Offset(offset).
+		Find(&assetLoan).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, 0, err
+	}
+
+	return assetLoan, total, nil
+}
+
+func (r *assetLoanRepository) FindAllByBorrowerId(pagination utils.Pagination, borrowerId uuid.UUID) ([]entity.AssetLoan, int64, error) {
+	var total int64
+
+	// Models
+	var assetLoan []entity.AssetLoan
+
+	// Pagination
+	offset := (pagination.Page - 1) * pagination.Limit
+	r.db.Model(&entity.AssetLoan{}).Where("borrower_id = ?", borrowerId).Count(&total)
+
+	// Query
+	err := r.db.Preload("AssetPlacement").
+		Where("borrower_id = ?", borrowerId).
+		Order("created_at DESC").
+		Limit(pagination.Limit).
+		Offset(offset).
+		Find(&assetLoan).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, 0, err
+	}
+
+	return assetLoan, total, nil
+}
+
+func (r *assetLoanRepository) FindAllOverdue(now time.Time) ([]entity.AssetLoanOverdue, error) {
+	// Models
+	var assetLoan []entity.AssetLoanOverdue
+
+	// Query
+	err := r.db.Table("asset_loans").
+		Select("asset_loans.id, asset_name, floor, room_name, borrower_id, borrower_type, COALESCE(users.username, technicians.username, admins.username) AS borrower_name, due_at").
+		Joins("JOIN asset_placements ON asset_loans.asset_placement_id = asset_placements.id").
+		Joins("JOIN assets ON asset_placements.asset_id = assets.id").
+		Joins("JOIN rooms ON rooms.id = asset_placements.room_id").
+		Joins("LEFT JOIN users ON users.id = asset_loans.borrower_id").
+		Joins("LEFT JOIN technicians ON technicians.id = asset_loans.borrower_id").
+		Joins("LEFT JOIN admins ON admins.id = asset_loans.borrower_id").
+		Where("loan_status = ? AND due_at < ?", "checked-out", now).
+		Order("due_at ASC").
+		Find(&assetLoan).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return assetLoan, err
+}
+
+func (r *assetLoanRepository) FindById(id uuid.UUID) (*entity.AssetLoan, error) {
+	// Models
+	var assetLoan entity.AssetLoan
+
+	// Query
+	err := r.db.Preload("AssetPlacement").Where("id = ?", id).First(&assetLoan).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &assetLoan, err
+}
+
+func (r *assetLoanRepository) CountActiveByAssetPlacementId(assetPlacementId uuid.UUID) (int64, error) {
+	var total int64
+
+	// Query : Approved Loan Already Reserve The Asset
+	err := r.db.Model(&entity.AssetLoan{}).
+		Where("asset_placement_id = ? AND loan_status IN ?", assetPlacementId, []string{"approved", "checked-out"}).
+		Count(&total).Error
+
+	return total, err
+}
+
+func (r *assetLoanRepository) Create(assetLoan *entity.AssetLoan) error {
+	assetLoan.ID = uuid.New()
+	assetLoan.LoanStatus = "requested"
+	assetLoan.CreatedAt = time.Now()
+
+	// Query
+	return r.db.Create(assetLoan).Error
+}
+
+func (r *assetLoanRepository) ApproveById(adminId, id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Lock Requested Loan
+		var assetLoan entity.AssetLoan
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND loan_status = ?", id, "requested").
+			First(&assetLoan).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("asset loan is not waiting for approval")
+		}
+		if err != nil {
+			return err
+		}
+
+		// Query : Lock Placement, So Concurrent Approval Of The Same Placement Wait For This One
+		var assetPlacement entity.AssetPlacement
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at is null", assetLoan.AssetPlacementId).
+			First(&assetPlacement).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("asset placement not found")
+		}
+		if err != nil {
+			return err
+		}
+
+		// Query : Check Remaining Quantity Of The Placement
+		var total int64
+		err = tx.Model(&entity.AssetLoan{}).
+			Where("asset_placement_id = ? AND loan_status IN ?", assetPlacement.ID, []string{"approved", "checked-out"}).
+			Count(&total).Error
+		if err != nil {
+			return err
+		}
+		if total >= int64(assetPlacement.AssetQty) {
+			return errors.New("no asset left to be loaned in this placement")
+		}
+
+		// Query : Approve Loan
+		return tx.Model(&entity.AssetLoan{}).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"loan_status": "approved",
+				"approved_by": adminId,
+				"approved_at": time.Now(),
+			}).Error
+	})
+}
+
+func (r *assetLoanRepository) UpdateApprovalById(loanStatus string, adminId, id uuid.UUID) error {
+	// Query : Only Requested Loan Can Be Approved Or Rejected
+	result := r.db.Model(&entity.AssetLoan{}).
+		Where("id = ? AND loan_status = ?", id, "requested").
+		Updates(map[string]interface{}{
+			"loan_status": loanStatus,
+			"approved_by": adminId,
+			"approved_at": time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("asset loan is not waiting for approval")
+	}
+
+	return nil
+}
+
+// Resolve History Is Called Under Asset Lock, So A Status Change Committed Meanwhile Is Not Overwritten
+func (r *assetLoanRepository) CheckOutById(id uuid.UUID, resolveHistory func(asset *entity.Asset) (*entity.AssetStatusHistory, error)) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		// Query : Lock Approved Loan
+		var assetLoan entity.AssetLoan
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND loan_status = ?", id, "approved").
+			First(&assetLoan).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("asset loan is not approved")
+		}
+		if err != nil {
+			return err
+		}
+
+		// Query : Lock Asset Of The Placement
+		var asset entity.Asset
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = (?)", tx.Model(&entity.AssetPlacement{}).Select("asset_id").Where("id = ?", assetLoan.AssetPlacementId)).
+			First(&asset).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("asset not found")
+		}
+		if err != nil {
+			return err
+		}
+
+		history, err := resolveHistory(&asset)
+		if err != nil {
+			return err
+		}
+
+		// Query : Check Out Loan
+		err = tx.Model(&entity.AssetLoan{}).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"loan_status":    "checked-out",
+				"checked_out_at": now,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		return updateAssetStatus(tx, history, asset.ID, now)
+	})
+}
+
+// Resolve History Is Called Under Asset Lock With The Other Loan Still Checked Out, So Concurrent Return Can't Both Keep The Asset In-Use
+func (r *assetLoanRepository) ReturnById(returnCondition string, returnNotes *string, id uuid.UUID, resolveHistory func(asset *entity.Asset, totalCheckedOut int64) (*entity.AssetStatusHistory, error)) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		// Query : Lock Checked Out Loan
+		var assetLoan entity.AssetLoan
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND loan_status = ?", id, "checked-out").
+			First(&assetLoan).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("asset loan is not checked out")
+		}
+		if err != nil {
+			return err
+		}
+
+		// Query : Lock Asset Of The Placement
+		var asset entity.Asset
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = (?)", tx.Model(&entity.AssetPlacement{}).Select("asset_id").Where("id = ?", assetLoan.AssetPlacementId)).
+			First(&asset).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("asset not found")
+		}
+		if err != nil {
+			return err
+		}
+
+		// Query : Count Other Loan Of The Asset Still Checked Out
+		var totalCheckedOut int64
+		err = tx.Model(&entity.AssetLoan{}).
+			Joins("JOIN asset_placements ON asset_loans.asset_placement_id = asset_placements.id").
+			Where("asset_placements.asset_id = ? AND asset_loans.loan_status = ? AND asset_loans.id != ?", asset.ID, "checked-out", id).
+			Count(&totalCheckedOut).Error
+		if err != nil {
+			return err
+		}
+
+		history, err := resolveHistory(&asset, totalCheckedOut)
+		if err != nil {
+			return err
+		}
+
+		// Query : Return Loan
+		err = tx.Model(&entity.AssetLoan{}).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"loan_status":      "returned",
+				"return_condition": returnCondition,
+				"return_notes":     returnNotes,
+				"returned_at":      now,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		return updateAssetStatus(tx, history, asset.ID, now)
+	})
+}
+
+// Nil History Means The Asset Status Is Unchanged
+func updateAssetStatus(tx *gorm.DB, history *entity.AssetStatusHistory, assetId uuid.UUID, now time.Time) error {
+	if history == nil {
+		return nil
+	}
+
+	// Query : Update Asset Status
+	err := tx.Model(&entity.Asset{}).
+		Where("id = ?", assetId).
+		Updates(map[string]interface{}{
+			"asset_status": history.ToStatus,
+			"updated_at":   now,
+		}).Error
+	if err != nil {
+		return err
+	}
+
+	// Query : Record Status Transition
+	history.ID = uuid.New()
+	history.AssetId = assetId
+	history.CreatedAt = now
+
+	return tx.Create(history).Error
+}
@@ -13,13 +13,19 @@ import (
 
 // Asset Maintenance Interface
 type AssetMaintenanceRepository interface {
-	FindAll(pagination utils.Pagination) ([]entity.AssetMaintenance, int64, error)
-	FindAllSchedule() ([]entity.AssetMaintenanceSchedule, error)
+	FindAll(pagination utils.Pagination, filter utils.Filter, scope *entity.AccessScope) ([]entity.AssetMaintenance, int64, error)
+	FindAllSchedule(scope *entity.AccessScope) ([]entity.AssetMaintenanceSchedule, error)
+	FindById(id uuid.UUID) (*entity.AssetMaintenance, error)
 	Create(assetMaintenance *entity.AssetMaintenance, adminId uuid.UUID) error
 	FindByAssetPlacementIdMaintenanceByAndMaintenanceDay(assetPlacementId, maintenanceBy uuid.UUID, maintenanceDay string, maintenanceHourStart, maintenanceHourEnd entity.Time) (*entity.AssetMaintenance, error)
 	FindByAssetPlacementIdMaintenanceByMaintenanceDayAndId(assetPlacementId, maintenanceBy uuid.UUID, maintenanceDay string, maintenanceHourStart, maintenanceHourEnd entity.Time, id uuid.UUID) (*entity.AssetMaintenance, error)
+	FindDeleted() ([]entity.AssetMaintenance, error)
+	FindAllDeletedIdBefore(before time.Time) ([]uuid.UUID, error)
+	FindDeleteDependencyById(id uuid.UUID) ([]entity.DeleteDependency, error)
 	UpdateById(assetMaintenance *entity.AssetMaintenance, id uuid.UUID) error
-	DeleteById(id uuid.UUID) error
+	HardDeleteById(id, archivedBy uuid.UUID) error
+	SoftDeleteById(id uuid.UUID) error
+	RecoverDeletedById(id uuid.UUID) error
 
 	// For Seeder
 	DeleteAll() error
@@ -35,19 +41,38 @@ func NewAssetMaintenanceRepository(db *gorm.DB) AssetMaintenanceRepository {
 	return &assetMaintenanceRepository{db: db}
 }
 
-func (r *assetMaintenanceRepository) FindAll(pagination utils.Pagination) ([]entity.AssetMaintenance, int64, error) {
+func (r *assetMaintenanceRepository) FindAll(pagination utils.Pagination, filter utils.Filter, scope *entity.AccessScope) ([]entity.AssetMaintenance, int64, error) {
 	var total int64
 
 	// Models
 	var assetMaintenance []entity.AssetMaintenance
 
+	// Query : Filter By Access Scope
+	query := r.db.Model(&entity.AssetMaintenance{}).
+		Where("asset_maintenances.deleted_at is null").
+		Where("asset_maintenances.asset_placement_id IN (?)", r.db.Model(&entity.AssetPlacement{}).Select("id").Where("deleted_at is null"))
+	if scope != nil {
+		query = query.Where("asset_maintenances.asset_placement_id IN (?)", findScopedAssetPlacementId(r.db, scope))
+	}
+
+	// Query : Filter
+	query, err := applyFilter(query, assetMaintenanceFilterSpec, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// Pagination
 	offset := (pagination.Page - 1) * pagination.Limit
-	r.db.Model(&entity.AssetMaintenance{}).Count(&total)
+	query.Session(&gorm.Session{}).Count(&total)
+
+	// Query : Sort
+	query, err = applySort(query, assetMaintenanceFilterSpec, filter)
+	if err != nil {
+		return nil, 0, err
+	}
 
 	// Query
-	err := r.db.Order("created_at DESC").
-		Limit(pagination.Limit).
+	err = query.Limit(pagination.Limit).
 		Offset(offset).
 		Find(&assetMaintenance).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -57,16 +82,22 @@ func (r *assetMaintenanceRepository) FindAll(pagination utils.Pagination) ([]ent
 	return assetMaintenance, total, nil
 }
 
-func (r *assetMaintenanceRepository) FindAllSchedule() ([]entity.AssetMaintenanceSchedule, error) {
+func (r *assetMaintenanceRepository) FindAllSchedule(scope *entity.AccessScope) ([]entity.AssetMaintenanceSchedule, error) {
 	// Models
 	var asset []entity.AssetMaintenanceSchedule
 
 	// Query
-	err := r.db.Table("asset_maintenances").
+	query := r.db.Table("asset_maintenances")
+	if scope != nil {
+		query = query.Where("asset_maintenances.asset_placement_id IN (?)", findScopedAssetPlacementId(r.db, scope))
+	}
+
+	err := query.
 		Select("maintenance_day, maintenance_hour_start, maintenance_hour_end, maintenance_notes, asset_qty, asset_name, asset_category, username, email, telegram_user_id, telegram_is_valid").
 		Joins("JOIN asset_placements ON asset_maintenances.asset_placement_id = asset_placements.id").
 		Joins("JOIN assets ON assets.id = asset_placements.asset_id").
 		Joins("JOIN technicians ON technicians.id = asset_maintenances.maintenance_by").
+		Where("asset_maintenances.deleted_at is null AND asset_placements.deleted_at is null AND technicians.deleted_at is null").
 		Order("FIELD(maintenance_day, 'Mon', 'Tue', 'Wed', 'Thu', 'Fri', 'Sat', 'Sun'), maintenance_hour_start ASC").
 		Find(&asset).Error
 
@@ -82,7 +113,7 @@ func (r *assetMaintenanceRepository) FindByAssetPlacementIdMaintenanceByAndMaint
 	var existingRecords []entity.AssetMaintenance
 
 	// Query
-	err := r.db.Where("asset_placement_id = ? AND maintenance_by = ? AND maintenance_day = ?", assetPlacementId, maintenanceBy, maintenanceDay).
+	err := r.db.Where("asset_placement_id = ? AND maintenance_by = ? AND maintenance_day = ? AND deleted_at is null", assetPlacementId, maintenanceBy, maintenanceDay).
 		Find(&existingRecords).Error
 
 	if err != nil {
@@ -111,7 +142,7 @@ func (r *assetMaintenanceRepository) FindByAssetPlacementIdMaintenanceByMaintena
 	var existingRecords []entity.AssetMaintenance
 
 	// Query
-	err := r.db.Where("asset_placement_id = ? AND maintenance_by = ? AND maintenance_day = ? AND id != ?", assetPlacementId, maintenanceBy, maintenanceDay, id).
+	err := r.db.Where("asset_placement_id = ? AND maintenance_by = ? AND maintenance_day = ? AND id != ? AND deleted_at is null", assetPlacementId, maintenanceBy, maintenanceDay, id).
 		Find(&existingRecords).Error
 
 	if err != nil {
@@ -146,12 +177,59 @@ func (r *assetMaintenanceRepository) Create(assetMaintenance *entity.AssetMainte
 	return r.db.Create(assetMaintenance).Error
 }
 
+func (r *assetMaintenanceRepository) FindById(id uuid.UUID) (*entity.AssetMaintenance, error) {
+	// Models
+	var assetMaintenance entity.AssetMaintenance
+
+	// Query
+	err := r.db.Where("id = ? AND deleted_at is null", id).First(&assetMaintenance).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &assetMaintenance, err
+}
+
+func (r *assetMaintenanceRepository) FindDeleted() ([]entity.AssetMaintenance, error) {
+	// Models
+	var assetMaintenance []entity.AssetMaintenance
+
+	// Query
+	result := r.db.Order("deleted_at DESC").
+		Where("deleted_at is not null").
+		Find(&assetMaintenance)
+
+	// Response
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) || len(assetMaintenance) == 0 {
+		return nil, errors.New("deleted asset maintenance not found")
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return assetMaintenance, nil
+}
+
+func (r *assetMaintenanceRepository) FindAllDeletedIdBefore(before time.Time) ([]uuid.UUID, error) {
+	return findDeletedIdBefore(r.db, "asset_maintenances", before)
+}
+
+func (r *assetMaintenanceRepository) FindDeleteDependencyById(id uuid.UUID) ([]entity.DeleteDependency, error) {
+	// Query : Every Row That Is Cascaded Along With The Asset Maintenance
+	dependencies, err := findDeleteDependency(r.db, assetMaintenanceDeleteDependency, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dependencies, nil
+}
+
 func (r *assetMaintenanceRepository) UpdateById(assetMaintenance *entity.AssetMaintenance, id uuid.UUID) error {
 	now := time.Now()
 
 	// Query : Check Old Asset Maintenance
 	var existingAssetMaintenance entity.AssetMaintenance
-	if err := r.db.First(&existingAssetMaintenance, "id = ?", id).Error; err != nil {
+	if err := r.db.First(&existingAssetMaintenance, "id = ? AND deleted_at is null", id).Error; err != nil {
 		return err
 	}
 
@@ -160,6 +238,7 @@ func (r *assetMaintenanceRepository) UpdateById(assetMaintenance *entity.AssetMa
 	existingAssetMaintenance.MaintenanceDay = assetMaintenance.MaintenanceDay
 	existingAssetMaintenance.MaintenanceHourStart = assetMaintenance.MaintenanceHourStart
 	existingAssetMaintenance.MaintenanceHourEnd = assetMaintenance.MaintenanceHourEnd
+	existingAssetMaintenance.AssetUnitId = assetMaintenance.AssetUnitId
 
 	if err := r.db.Save(&existingAssetMaintenance).Error; err != nil {
 		return err
@@ -168,13 +247,57 @@ func (r *assetMaintenanceRepository) UpdateById(assetMaintenance *entity.AssetMa
 	return nil
 }
 
-func (r *assetMaintenanceRepository) DeleteById(id uuid.UUID) error {
-	// Models
-	var assetMaintenance entity.AssetMaintenance
+func (r *assetMaintenanceRepository) HardDeleteById(id, archivedBy uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Archive And Delete
+		return deleteWithArchive(tx, assetMaintenanceDeleteDependency, id, archivedBy)
+	})
+}
 
-	// Query
-	err := r.db.Unscoped().Where("id = ?", id).Delete(&assetMaintenance).Error
-	if err != nil {
+func (r *assetMaintenanceRepository) SoftDeleteById(id uuid.UUID) error {
+	// Query : Check Old Asset Maintenance
+	var existingAssetMaintenance entity.AssetMaintenance
+	if err := r.db.First(&existingAssetMaintenance, "id = ? AND deleted_at is null", id).Error; err != nil {
+		return err
+	}
+	now := time.Now()
+
+	// Query : Update
+	existingAssetMaintenance.DeletedAt = &now
+
+	if err := r.db.Save(&existingAssetMaintenance).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *assetMaintenanceRepository) RecoverDeletedById(id uuid.UUID) error {
+	// Query : Check Old Asset Maintenance
+	var existingAssetMaintenance entity.AssetMaintenance
+	if err := r.db.First(&existingAssetMaintenance, "id = ? AND deleted_at is not null", id).Error; err != nil {
+		return err
+	}
+
+	// Query : Asset Placement And Technician Must Not Be Deleted
+	var total int64
+	if err := r.db.Model(&entity.AssetPlacement{}).Where("id = ? AND deleted_at is null", existingAssetMaintenance.AssetPlacementId).Count(&total).Error; err != nil {
+		return err
+	}
+	if total == 0 {
+		return errors.New("asset placement of the asset maintenance is deleted, recover the asset placement first")
+	}
+	if err := r.db.Model(&entity.Technician{}).Where("id = ? AND deleted_at is null", existingAssetMaintenance.MaintenanceBy).Count(&total).Error; err != nil {
+		return err
+	}
+	if total == 0 {
+		return errors.New("technician of the asset maintenance is deleted, recover the technician first")
+	}
+
+	// Query : Update
+	existingAssetMaintenance.DeletedAt = nil
+
+	if err := r.db.Save(&existingAssetMaintenance).Error; err != nil {
 		return err
 	}
 
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"errors"
+	"pelita/entity"
+	"pelita/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Asset Movement Interface
+type AssetMovementRepository interface {
+	FindAllByAssetId(pagination utils.Pagination, id uuid.UUID, scope *entity.AccessScope) ([]entity.AssetMovement, int64, error)
+	FindAllByRoomId(pagination utils.Pagination, id uuid.UUID, scope *entity.AccessScope) ([]entity.AssetMovement, int64, error)
+}
+
+// Asset Movement Struct
+type assetMovementRepository struct {
+	db *gorm.DB
+}
+
+// Asset Movement Constructor
+func NewAssetMovementRepository(db *gorm.DB) AssetMovementRepository {
+	return &assetMovementRepository{db: db}
+}
+
+func (r *assetMovementRepository) FindAllByAssetId(pagination utils.Pagination, id uuid.UUID, scope *entity.AccessScope) ([]entity.AssetMovement, int64, error) {
+	return r.findAll(pagination, r.db.Where("asset_id = ?", id), scope)
+}
+
+func (r *assetMovementRepository) FindAllByRoomId(pagination utils.Pagination, id uuid.UUID, scope *entity.AccessScope) ([]entity.AssetMovement, int64, error) {
+	return r.findAll(pagination, r.db.Where("from_room_id = ? OR to_room_id = ?", id, id), scope)
+}
+
+func (r *assetMovementRepository) findAll(pagination utils.Pagination, condition *gorm.DB, scope *entity.AccessScope) ([]entity.AssetMovement, int64, error) {
+	var total int64
+
+	// Models
+	var assetMovement []entity.AssetMovement
+
+	// Query : Filter By Asset Or Room
+	query := r.db.Model(&entity.AssetMovement{}).Where(condition)
+
+	// Query : Movement From Or To A Room Inside The Scope
+	if scope != nil {
+		scopedRoomId := findScopedRoomId(r.db, scope)
+		query = query.Where(r.db.Where("from_room_id IN (?)", scopedRoomId).Or("to_room_id IN (?)", scopedRoomId))
+	}
+
+	// Pagination
+	offset := (pagination.Page - 1) * pagination.Limit
+	query.Session(&gorm.Session{}).Count(&total)
+
+	// Query
+	err := query.Order("created_at DESC").
+		Limit(pagination.Limit).
+		Offset(offset).
+		Find(&assetMovement).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, 0, err
+	}
+
+	return assetMovement, total, err
+}
+
+// Every Move Between Placement Is Logged Inside The Same Transaction
+func createAssetMovement(tx *gorm.DB, assetMovement *entity.AssetMovement, source, target *entity.AssetPlacement, now time.Time) error {
+	assetMovement.ID = uuid.New()
+	assetMovement.AssetId = source.AssetId
+	assetMovement.FromRoomId = source.RoomId
+	assetMovement.ToRoomId = target.RoomId
+	assetMovement.FromPlacementId = source.ID
+	assetMovement.ToPlacementId = target.ID
+	assetMovement.CreatedAt = now
+
+	// Query
+	return tx.Create(assetMovement).Error
+}
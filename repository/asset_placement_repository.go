@@ -8,16 +8,25 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Asset Placement Interface
 type AssetPlacementRepository interface {
-	FindAll(pagination utils.Pagination) ([]entity.AssetPlacement, int64, error)
-	Create(assetPlacement *entity.AssetPlacement, adminId uuid.UUID) error
+	FindAll(pagination utils.Pagination, filter utils.Filter, scope *entity.AccessScope) ([]entity.AssetPlacement, int64, error)
+	Create(assetPlacement *entity.AssetPlacement, adminId uuid.UUID, prefix string, sequenceLength int) error
+	FindById(id uuid.UUID) (*entity.AssetPlacement, error)
 	FindByAssetIdAndRoomId(assetId, assetPlacementId uuid.UUID) (*entity.AssetPlacement, error)
 	FindByAssetIdRoomIdAndId(assetId, assetPlacementId uuid.UUID, id uuid.UUID) (*entity.AssetPlacement, error)
-	UpdateById(assetPlacement *entity.AssetPlacement, id uuid.UUID) error
-	DeleteById(id uuid.UUID) error
+	FindDeleted() ([]entity.AssetPlacement, error)
+	FindAllDeletedIdBefore(before time.Time) ([]uuid.UUID, error)
+	FindAllStoredFileByDeletedId(id uuid.UUID) ([]string, error)
+	FindDeleteDependencyById(id uuid.UUID) ([]entity.DeleteDependency, error)
+	UpdateById(assetPlacement *entity.AssetPlacement, id uuid.UUID, prefix string, sequenceLength int) error
+	HardDeleteById(id, archivedBy uuid.UUID) error
+	SoftDeleteById(id uuid.UUID) error
+	RecoverDeletedById(id uuid.UUID) error
+	IsInScopeById(id uuid.UUID, scope *entity.AccessScope) (bool, error)
 
 	// For Seeder
 	DeleteAll() error
@@ -34,19 +43,36 @@ func NewAssetPlacementRepository(db *gorm.DB) AssetPlacementRepository {
 	return &assetPlacementRepository{db: db}
 }
 
-func (r *assetPlacementRepository) FindAll(pagination utils.Pagination) ([]entity.AssetPlacement, int64, error) {
+func (r *assetPlacementRepository) FindAll(pagination utils.Pagination, filter utils.Filter, scope *entity.AccessScope) ([]entity.AssetPlacement, int64, error) {
 	var total int64
 
 	// Models
 	var assetPlacement []entity.AssetPlacement
 
+	// Query : Filter By Access Scope
+	query := r.db.Model(&entity.AssetPlacement{}).Where("asset_placements.deleted_at is null")
+	if scope != nil {
+		query = query.Where("asset_placements.room_id IN (?)", findScopedRoomId(r.db, scope))
+	}
+
+	// Query : Filter
+	query, err := applyFilter(query, assetPlacementFilterSpec, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// Pagination
 	offset := (pagination.Page - 1) * pagination.Limit
-	r.db.Model(&entity.AssetPlacement{}).Count(&total)
+	query.Session(&gorm.Session{}).Count(&total)
+
+	// Query : Sort
+	query, err = applySort(query, assetPlacementFilterSpec, filter)
+	if err != nil {
+		return nil, 0, err
+	}
 
 	// Query
-	err := r.db.Order("created_at DESC").
-		Limit(pagination.Limit).
+	err = query.Limit(pagination.Limit).
 		Offset(offset).
 		Find(&assetPlacement).Error
 
@@ -57,12 +83,25 @@ func (r *assetPlacementRepository) FindAll(pagination utils.Pagination) ([]entit
 	return assetPlacement, total, nil
 }
 
+func (r *assetPlacementRepository) FindById(id uuid.UUID) (*entity.AssetPlacement, error) {
+	// Models
+	var assetPlacement entity.AssetPlacement
+
+	// Query
+	err := r.db.Where("id = ? AND deleted_at is null", id).First(&assetPlacement).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &assetPlacement, err
+}
+
 func (r *assetPlacementRepository) FindByAssetIdAndRoomId(assetId, roomId uuid.UUID) (*entity.AssetPlacement, error) {
 	// Models
 	var assetPlacement entity.AssetPlacement
 
 	// Query
-	err := r.db.Where("asset_id = ? AND room_id = ?", assetId, roomId).First(&assetPlacement).Error
+	err := r.db.Where("asset_id = ? AND room_id = ? AND deleted_at is null", assetId, roomId).First(&assetPlacement).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -75,7 +114,7 @@ func (r *assetPlacementRepository) FindByAssetIdRoomIdAndId(assetId, roomId, id
 	var assetPlacement entity.AssetPlacement
 
 	// Query
-	err := r.db.Where("asset_id = ? AND room_id = ? AND id != ?", assetId, roomId, id).First(&assetPlacement).Error
+	err := r.db.Where("asset_id = ? AND room_id = ? AND id != ? AND deleted_at is null", assetId, roomId, id).First(&assetPlacement).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -83,7 +122,52 @@ func (r *assetPlacementRepository) FindByAssetIdRoomIdAndId(assetId, roomId, id
 	return &assetPlacement, err
 }
 
-func (r *assetPlacementRepository) Create(assetPlacement *entity.AssetPlacement, adminId uuid.UUID) error {
+func (r *assetPlacementRepository) FindDeleted() ([]entity.AssetPlacement, error) {
+	// Models
+	var assetPlacement []entity.AssetPlacement
+
+	// Query
+	result := r.db.Order("deleted_at DESC").
+		Where("deleted_at is not null").
+		Find(&assetPlacement)
+
+	// Response
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) || len(assetPlacement) == 0 {
+		return nil, errors.New("deleted asset placement not found")
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return assetPlacement, nil
+}
+
+func (r *assetPlacementRepository) FindAllDeletedIdBefore(before time.Time) ([]uuid.UUID, error) {
+	return findDeletedIdBefore(r.db, "asset_placements", before)
+}
+
+func (r *assetPlacementRepository) FindAllStoredFileByDeletedId(id uuid.UUID) ([]string, error) {
+	// Query : Finding Image That Go Along With A Permanently Deleted Asset Placement
+	files, err := findDeletedFindingImage(r.db, assetPlacementDeleteDependency, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func (r *assetPlacementRepository) FindDeleteDependencyById(id uuid.UUID) ([]entity.DeleteDependency, error) {
+	// Query : Every Row That Is Cascaded Along With The Asset Placement
+	dependencies, err := findDeleteDependency(r.db, assetPlacementDeleteDependency, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dependencies, nil
+}
+
+// Asset Unit Is Created For Every Quantity Along With The Placement
+func (r *assetPlacementRepository) Create(assetPlacement *entity.AssetPlacement, adminId uuid.UUID, prefix string, sequenceLength int) error {
 	now := time.Now()
 
 	assetPlacement.ID = uuid.New()
@@ -91,37 +175,125 @@ func (r *assetPlacementRepository) Create(assetPlacement *entity.AssetPlacement,
 	assetPlacement.CreatedAt = now
 	assetPlacement.UpdatedAt = nil
 
-	// Query
-	return r.db.Create(assetPlacement).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Create Placement
+		if err := tx.Create(assetPlacement).Error; err != nil {
+			return err
+		}
+
+		// Query : Create Asset Unit For Every Quantity
+		_, err := createMissingAssetUnit(tx, prefix, sequenceLength, assetPlacement.AssetQty, assetPlacement.ID)
+		return err
+	})
 }
 
-func (r *assetPlacementRepository) UpdateById(assetPlacement *entity.AssetPlacement, id uuid.UUID) error {
-	now := time.Now()
+// Asset Unit Is Created For The Additional Quantity Along With The Update
+func (r *assetPlacementRepository) UpdateById(assetPlacement *entity.AssetPlacement, id uuid.UUID, prefix string, sequenceLength int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		// Query : Lock Old Asset Placement
+		var existingAssetPlacement entity.AssetPlacement
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&existingAssetPlacement, "id = ? AND deleted_at is null", id).Error; err != nil {
+			return err
+		}
+
+		// Query : Count Asset Unit, Quantity Is Reduced By Deleting Or Moving The Unit
+		var total int64
+		if err := tx.Model(&entity.AssetUnit{}).Where("asset_placement_id = ?", id).Count(&total).Error; err != nil {
+			return err
+		}
+		if int64(assetPlacement.AssetQty) < total {
+			return errors.New("asset qty can't be less than the total asset unit, delete or move the unit instead")
+		}
+
+		// Query : Update
+		existingAssetPlacement.UpdatedAt = &now
+		existingAssetPlacement.AssetQty = assetPlacement.AssetQty
+		if err := tx.Save(&existingAssetPlacement).Error; err != nil {
+			return err
+		}
+
+		// Query : Create Asset Unit For Additional Quantity
+		_, err := createMissingAssetUnit(tx, prefix, sequenceLength, existingAssetPlacement.AssetQty, id)
+		return err
+	})
+}
 
+func (r *assetPlacementRepository) HardDeleteById(id, archivedBy uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Archive And Delete
+		return deleteWithArchive(tx, assetPlacementDeleteDependency, id, archivedBy)
+	})
+}
+
+func (r *assetPlacementRepository) SoftDeleteById(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Check Old Asset Placement, Locked The Same Way As Loan And Transfer Approval
+		var existingAssetPlacement entity.AssetPlacement
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&existingAssetPlacement, "id = ? AND deleted_at is null", id).Error
+		if err != nil {
+			return err
+		}
+
+		// Query : Placement Still Loaned Out Or Waiting For A Transfer Can't Be Deleted
+		var total int64
+		err = tx.Model(&entity.AssetLoan{}).
+			Where("asset_placement_id = ? AND loan_status IN ?", id, []string{"approved", "checked-out"}).
+			Count(&total).Error
+		if err != nil {
+			return err
+		}
+		if total > 0 {
+			return errors.New("asset placement still has active asset loan, return it first")
+		}
+		err = tx.Model(&entity.AssetTransfer{}).
+			Where("(source_placement_id = ? OR target_placement_id = ?) AND transfer_status = ?", id, id, "requested").
+			Count(&total).Error
+		if err != nil {
+			return err
+		}
+		if total > 0 {
+			return errors.New("asset placement still has pending asset transfer, approve or reject it first")
+		}
+		now := time.Now()
+
+		// Query : Update
+		existingAssetPlacement.DeletedAt = &now
+
+		return tx.Save(&existingAssetPlacement).Error
+	})
+}
+
+func (r *assetPlacementRepository) RecoverDeletedById(id uuid.UUID) error {
 	// Query : Check Old Asset Placement
 	var existingAssetPlacement entity.AssetPlacement
-	if err := r.db.First(&existingAssetPlacement, "id = ?", id).Error; err != nil {
+	if err := r.db.First(&existingAssetPlacement, "id = ? AND deleted_at is not null", id).Error; err != nil {
 		return err
 	}
 
-	// Query : Update
-	existingAssetPlacement.UpdatedAt = &now
-	existingAssetPlacement.AssetQty = assetPlacement.AssetQty
-
-	if err := r.db.Save(&existingAssetPlacement).Error; err != nil {
+	// Query : Room Must Not Be Deleted
+	var total int64
+	if err := r.db.Model(&entity.Room{}).Where("id = ? AND deleted_at is null", existingAssetPlacement.RoomId).Count(&total).Error; err != nil {
 		return err
 	}
+	if total == 0 {
+		return errors.New("room of the asset placement is deleted, recover the room first")
+	}
 
-	return nil
-}
+	// Query : Asset Can Only Be Placed Once In A Room
+	if err := r.db.Model(&entity.AssetPlacement{}).Where("asset_id = ? AND room_id = ? AND deleted_at is null", existingAssetPlacement.AssetId, existingAssetPlacement.RoomId).Count(&total).Error; err != nil {
+		return err
+	}
+	if total > 0 {
+		return errors.New("asset is already placed in the room")
+	}
 
-func (r *assetPlacementRepository) DeleteById(id uuid.UUID) error {
-	// Models
-	var assetPlacement entity.AssetPlacement
+	// Query : Update
+	existingAssetPlacement.DeletedAt = nil
 
-	// Query
-	err := r.db.Unscoped().Where("id = ?", id).Delete(&assetPlacement).Error
-	if err != nil {
+	if err := r.db.Save(&existingAssetPlacement).Error; err != nil {
 		return err
 	}
 
@@ -135,7 +307,7 @@ func (r *assetPlacementRepository) DeleteAll() error {
 func (r *assetPlacementRepository) FindOneRandom() (*entity.AssetPlacement, error) {
 	var assetPlacement entity.AssetPlacement
 
-	err := r.db.Order("RAND()").Limit(1).First(&assetPlacement).Error
+	err := r.db.Where("deleted_at is null").Order("RAND()").Limit(1).First(&assetPlacement).Error
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
@@ -143,3 +315,7 @@ func (r *assetPlacementRepository) FindOneRandom() (*entity.AssetPlacement, erro
 
 	return &assetPlacement, err
 }
+
+func (r *assetPlacementRepository) IsInScopeById(id uuid.UUID, scope *entity.AccessScope) (bool, error) {
+	return isInScope(r.db, scope, &entity.AssetPlacement{}, "room_id IN (?)", id)
+}
@@ -2,26 +2,41 @@ package repository
 
 import (
 	"errors"
+	"fmt"
+	"pelita/config"
 	"pelita/entity"
 	"pelita/utils"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Asset Interface
 type AssetRepository interface {
-	FindAll(pagination utils.Pagination) ([]entity.Asset, int64, error)
+	FindAll(pagination utils.Pagination, filter utils.Filter) ([]entity.Asset, int64, error)
 	Create(asset *entity.Asset, adminId uuid.UUID) error
+	FindById(id uuid.UUID) (*entity.Asset, error)
 	FindByAssetPlacementId(id uuid.UUID) (*entity.Asset, error)
+	FindStatusHistoryByAssetId(id uuid.UUID) ([]entity.AssetStatusHistory, error)
 	FindByAssetNameCategoryAndMerk(assetName, assetCategory string, assetMerk *string) (*entity.Asset, error)
 	FindByAssetNameCategoryMerkAndId(assetName, assetCategory string, assetMerk *string, id uuid.UUID) (*entity.Asset, error)
 	FindDeleted() ([]entity.Asset, error)
-	UpdateById(asset *entity.Asset, id uuid.UUID) error
-	HardDeleteById(id uuid.UUID) error
+	FindAllDeletedIdBefore(before time.Time) ([]uuid.UUID, error)
+	FindAllByAssetCategoryId(assetCategoryId uuid.UUID) ([]entity.Asset, error)
+	FindAllValuated(pagination utils.Pagination) ([]entity.Asset, int64, error)
+	FindAllPlacedValuation() ([]entity.AssetPlacedValuation, error)
+	FindAllExport() ([]entity.AssetExport, error)
+	FindAllStoredFileByDeletedId(id uuid.UUID) ([]string, error)
+	FindDeleteDependencyById(id uuid.UUID) ([]entity.DeleteDependency, error)
+	CreateBulk(assetImport []entity.AssetImport, adminId uuid.UUID, tagPrefix string, tagSequenceLength int) error
+	UpdateById(asset *entity.Asset, history *entity.AssetStatusHistory, id uuid.UUID) error
+	HardDeleteById(id, archivedBy uuid.UUID) error
 	SoftDeleteById(id uuid.UUID) error
 	RecoverDeletedById(id uuid.UUID) error
+	MergeById(duplicateId, id uuid.UUID) error
 
 	// For Seeder
 	DeleteAll() error
@@ -38,20 +53,28 @@ func NewAssetRepository(db *gorm.DB) AssetRepository {
 	return &assetRepository{db: db}
 }
 
-func (r *assetRepository) FindAll(pagination utils.Pagination) ([]entity.Asset, int64, error) {
+func (r *assetRepository) FindAll(pagination utils.Pagination, filter utils.Filter) ([]entity.Asset, int64, error) {
 	var total int64
 
 	// Models
 	var asset []entity.Asset
 
+	// Query : Filter
+	query, err := applyFilter(r.db.Model(&entity.Asset{}).Where("assets.deleted_at is null"), assetFilterSpec, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// Pagination
 	offset := (pagination.Page - 1) * pagination.Limit
-	r.db.Model(&entity.Asset{}).Where("deleted_at is null").Count(&total)
+	query.Session(&gorm.Session{}).Count(&total)
 
-	// Query
-	err := r.db.Order("created_at DESC").
-		Where("deleted_at is null").
-		Limit(pagination.Limit).
+	// Query : Sort
+	query, err = applySort(query, assetFilterSpec, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	err = query.Limit(pagination.Limit).
 		Offset(offset).
 		Find(&asset).Error
 
@@ -59,16 +82,55 @@ func (r *assetRepository) FindAll(pagination utils.Pagination) ([]entity.Asset,
 		return nil, 0, err
 	}
 
+	// Query : Custom Attribute
+	if err := fillAssetAttribute(r.db, asset); err != nil {
+		return nil, 0, err
+	}
+
 	return asset, total, nil
 }
 
+func (r *assetRepository) FindById(id uuid.UUID) (*entity.Asset, error) {
+	// Models
+	var asset entity.Asset
+
+	// Query
+	err := r.db.Where("id = ?", id).First(&asset).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Query : Custom Attribute
+	assets := []entity.Asset{asset}
+	if err := fillAssetAttribute(r.db, assets); err != nil {
+		return nil, err
+	}
+
+	return &assets[0], nil
+}
+
+func (r *assetRepository) FindStatusHistoryByAssetId(id uuid.UUID) ([]entity.AssetStatusHistory, error) {
+	// Models
+	var history []entity.AssetStatusHistory
+
+	// Query
+	err := r.db.Where("asset_id = ?", id).
+		Order("created_at ASC").
+		Find(&history).Error
+
+	return history, err
+}
+
 func (r *assetRepository) FindByAssetPlacementId(id uuid.UUID) (*entity.Asset, error) {
 	// Models
 	var asset entity.Asset
 
 	// Query
 	err := r.db.Table("assets").
-		Select("assets.id, asset_name, assets.asset_desc, asset_merk, asset_category, asset_price, asset_status, asset_image_url, assets.created_at, assets.updated_at, deleted_at, assets.created_by").
+		Select("assets.id, asset_name, assets.asset_desc, asset_merk, asset_category, asset_price, asset_currency, asset_status, asset_image_url, assets.created_at, assets.updated_at, deleted_at, purchase_date, useful_life, salvage_value, depreciation_method, assets.created_by").
 		Joins("JOIN asset_placements ON asset_placements.asset_id = assets.id").
 		Where("asset_placements.id = ?", id).
 		First(&asset).Error
@@ -123,9 +185,162 @@ func (r *assetRepository) FindDeleted() ([]entity.Asset, error) {
 		return nil, result.Error
 	}
 
+	// Query : Custom Attribute
+	if err := fillAssetAttribute(r.db, asset); err != nil {
+		return nil, err
+	}
+
 	return asset, nil
 }
 
+func (r *assetRepository) FindAllDeletedIdBefore(before time.Time) ([]uuid.UUID, error) {
+	return findDeletedIdBefore(r.db, "assets", before)
+}
+
+func (r *assetRepository) FindAllByAssetCategoryId(assetCategoryId uuid.UUID) ([]entity.Asset, error) {
+	// Models
+	var asset []entity.Asset
+
+	// Query
+	err := r.db.Where("asset_category_id = ? AND deleted_at is null", assetCategoryId).
+		Order("asset_name ASC").
+		Find(&asset).Error
+
+	return asset, err
+}
+
+func (r *assetRepository) FindAllValuated(pagination utils.Pagination) ([]entity.Asset, int64, error) {
+	var total int64
+
+	// Models
+	var asset []entity.Asset
+
+	// Pagination
+	offset := (pagination.Page - 1) * pagination.Limit
+	condition := r.db.Model(&entity.Asset{}).
+		Where("deleted_at is null AND asset_price is not null AND purchase_date is not null AND useful_life is not null")
+	if err := condition.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Query
+	err := condition.Order("purchase_date ASC").
+		Limit(pagination.Limit).
+		Offset(offset).
+		Find(&asset).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return asset, total, nil
+}
+
+func (r *assetRepository) FindAllPlacedValuation() ([]entity.AssetPlacedValuation, error) {
+	// Models
+	var valuation []entity.AssetPlacedValuation
+
+	// Query
+	err := r.db.Table("asset_placements").
+		Select("assets.*, asset_placements.asset_qty, rooms.room_dept, rooms.floor").
+		Joins("JOIN assets ON assets.id = asset_placements.asset_id").
+		Joins("JOIN rooms ON rooms.id = asset_placements.room_id").
+		Where("assets.deleted_at is null AND asset_placements.deleted_at is null AND asset_price is not null AND purchase_date is not null AND useful_life is not null").
+		Scan(&valuation).Error
+
+	return valuation, err
+}
+
+func (r *assetRepository) FindAllExport() ([]entity.AssetExport, error) {
+	// Models
+	var assetExport []entity.AssetExport
+
+	// Query : Asset Without Placement Is Exported Once With Empty Placement
+	err := r.db.Table("assets").
+		Select("assets.*, rooms.floor, rooms.room_name, asset_placements.asset_qty, technicians.email as owner_email").
+		Joins("LEFT JOIN asset_placements ON asset_placements.asset_id = assets.id AND asset_placements.deleted_at is null").
+		Joins("LEFT JOIN rooms ON rooms.id = asset_placements.room_id").
+		Joins("LEFT JOIN technicians ON technicians.id = asset_placements.asset_owner").
+		Where("assets.deleted_at is null").
+		Order("assets.asset_name ASC").
+		Order("rooms.floor ASC").
+		Order("rooms.room_name ASC").
+		Scan(&assetExport).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Query : Custom Attribute
+	ids := make([]uuid.UUID, len(assetExport))
+	for i, dt := range assetExport {
+		ids[i] = dt.ID
+	}
+	attributes, err := findAssetAttribute(r.db, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range assetExport {
+		assetExport[i].Attributes = attributes[assetExport[i].ID]
+	}
+
+	return assetExport, nil
+}
+
+func (r *assetRepository) CreateBulk(assetImport []entity.AssetImport, adminId uuid.UUID, tagPrefix string, tagSequenceLength int) error {
+	now := time.Now()
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range assetImport {
+			asset := &assetImport[i].Asset
+			asset.ID = uuid.New()
+			asset.CreatedBy = adminId
+			asset.CreatedAt = now
+			asset.UpdatedAt = nil
+			asset.DeletedAt = nil
+
+			// Query : Create Asset Along With Its Custom Attribute
+			if err := tx.Create(asset).Error; err != nil {
+				return err
+			}
+			if err := replaceAssetAttribute(tx, asset); err != nil {
+				return err
+			}
+
+			// Query : Record Initial Status
+			err := tx.Create(&entity.AssetStatusHistory{
+				ID:            uuid.New(),
+				ToStatus:      asset.AssetStatus,
+				Reason:        asset.StatusReason,
+				CreatedAt:     now,
+				ChangedBy:     adminId,
+				ChangedByType: "admin",
+				AssetId:       asset.ID,
+			}).Error
+			if err != nil {
+				return err
+			}
+
+			// Query : Create Asset Placement Along With Its Unit
+			for j := range assetImport[i].AssetPlacement {
+				assetPlacement := &assetImport[i].AssetPlacement[j]
+				assetPlacement.ID = uuid.New()
+				assetPlacement.AssetId = asset.ID
+				assetPlacement.CreatedBy = adminId
+				assetPlacement.CreatedAt = now
+				assetPlacement.UpdatedAt = nil
+
+				if err := tx.Create(assetPlacement).Error; err != nil {
+					return err
+				}
+				if _, err := createMissingAssetUnit(tx, tagPrefix, tagSequenceLength, assetPlacement.AssetQty, assetPlacement.ID); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
 func (r *assetRepository) Create(asset *entity.Asset, adminId uuid.UUID) error {
 	now := time.Now()
 
@@ -135,28 +350,59 @@ func (r *assetRepository) Create(asset *entity.Asset, adminId uuid.UUID) error {
 	asset.UpdatedAt = nil
 	asset.DeletedAt = nil
 
-	// Query
-	return r.db.Create(asset).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Create Asset Along With Its Custom Attribute
+		if err := tx.Create(asset).Error; err != nil {
+			return err
+		}
+		if err := replaceAssetAttribute(tx, asset); err != nil {
+			return err
+		}
+
+		// Query : Record Initial Status
+		return tx.Create(&entity.AssetStatusHistory{
+			ID:            uuid.New(),
+			ToStatus:      asset.AssetStatus,
+			Reason:        asset.StatusReason,
+			CreatedAt:     now,
+			ChangedBy:     adminId,
+			ChangedByType: "admin",
+			AssetId:       asset.ID,
+		}).Error
+	})
 }
 
-func (r *assetRepository) UpdateById(asset *entity.Asset, id uuid.UUID) error {
-	// Query : Check Old Asset
-	var existingAsset entity.Asset
-	if err := r.db.First(&existingAsset, "id = ?", id).Error; err != nil {
-		return err
-	}
-	now := time.Now()
-
-	// Query : Update
-	asset.ID = id
-	asset.CreatedAt = existingAsset.CreatedAt
-	asset.UpdatedAt = &now
-
-	if err := r.db.Save(&asset).Error; err != nil {
-		return err
-	}
-
-	return nil
+// Status Is Only Changed Along With Its History, Nil History Keep The Current Status
+func (r *assetRepository) UpdateById(asset *entity.Asset, history *entity.AssetStatusHistory, id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		// Query : Lock Old Asset
+		var existingAsset entity.Asset
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&existingAsset, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if history != nil && history.FromStatus != nil && *history.FromStatus != existingAsset.AssetStatus {
+			return errors.New("asset status has been changed, please try again")
+		}
+
+		// Query : Update Asset Along With Its Custom Attribute, Primary Image Is Only Changed Through The Gallery
+		asset.ID = id
+		asset.AssetStatus = existingAsset.AssetStatus
+		asset.AssetImageURL = existingAsset.AssetImageURL
+		asset.CreatedAt = existingAsset.CreatedAt
+		asset.CreatedBy = existingAsset.CreatedBy
+		asset.DeletedAt = existingAsset.DeletedAt
+		asset.UpdatedAt = &now
+		if err := tx.Save(&asset).Error; err != nil {
+			return err
+		}
+		if err := replaceAssetAttribute(tx, asset); err != nil {
+			return err
+		}
+
+		return updateAssetStatus(tx, history, id, now)
+	})
 }
 
 func (r *assetRepository) SoftDeleteById(id uuid.UUID) error {
@@ -194,17 +440,187 @@ func (r *assetRepository) RecoverDeletedById(id uuid.UUID) error {
 	return nil
 }
 
-func (r *assetRepository) HardDeleteById(id uuid.UUID) error {
+func (r *assetRepository) MergeById(duplicateId, id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		// Query : Lock Both Asset
+		var asset []entity.Asset
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ? AND deleted_at is null", []uuid.UUID{duplicateId, id}).
+			Find(&asset).Error
+		if err != nil {
+			return err
+		}
+		if len(asset) != 2 {
+			return errors.New("asset not found")
+		}
+
+		// Query : Placement Of The Duplicate
+		var duplicatePlacement []entity.AssetPlacement
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("asset_id = ?", duplicateId).
+			Find(&duplicatePlacement).Error
+		if err != nil {
+			return err
+		}
+
+		for _, source := range duplicatePlacement {
+			// Query : Placement In A Room Without The Surviving Asset Is Simply Re-Pointed
+			var target entity.AssetPlacement
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("asset_id = ? AND room_id = ?", id, source.RoomId).
+				First(&target).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				err = tx.Model(&entity.AssetPlacement{}).
+					Where("id = ?", source.ID).
+					Updates(map[string]interface{}{
+						"asset_id":   id,
+						"updated_at": now,
+					}).Error
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			// Query : Otherwise Everything Under The Placement Is Moved Into The Surviving Placement
+			for _, model := range []interface{}{&entity.AssetUnit{}, &entity.AssetMaintenance{}, &entity.AssetFinding{}, &entity.AssetLoan{}} {
+				err := tx.Model(model).
+					Where("asset_placement_id = ?", source.ID).
+					UpdateColumn("asset_placement_id", target.ID).Error
+				if err != nil {
+					return err
+				}
+			}
+			err = tx.Model(&entity.AssetTransfer{}).
+				Where("source_placement_id = ?", source.ID).
+				UpdateColumn("source_placement_id", target.ID).Error
+			if err != nil {
+				return err
+			}
+			err = tx.Model(&entity.AssetTransfer{}).
+				Where("target_placement_id = ?", source.ID).
+				UpdateColumn("target_placement_id", target.ID).Error
+			if err != nil {
+				return err
+			}
+			if err := updateAssetQtyByDelta(tx, source.AssetQty, target.ID, now); err != nil {
+				return err
+			}
+			if err := tx.Delete(&entity.AssetPlacement{}, "id = ?", source.ID).Error; err != nil {
+				return err
+			}
+		}
+
+		// Query : Asset Level Record Follow The Surviving Asset
+		for _, model := range []interface{}{&entity.AssetTransfer{}, &entity.AssetMovement{}, &entity.Warranty{}} {
+			err := tx.Model(model).
+				Where("asset_id = ?", duplicateId).
+				UpdateColumn("asset_id", id).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		// Query : Gallery Of The Duplicate Is Appended After The Surviving Gallery
+		var survivingImage, duplicateImage []entity.AssetImage
+		if err := tx.Where("asset_id = ?", id).Find(&survivingImage).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("asset_id = ?", duplicateId).Order("image_order ASC").Find(&duplicateImage).Error; err != nil {
+			return err
+		}
+		if len(survivingImage)+len(duplicateImage) > config.ConfigFile.MaxAssetImage {
+			return fmt.Errorf("merged asset would have %d image, at most %d image is allowed", len(survivingImage)+len(duplicateImage), config.ConfigFile.MaxAssetImage)
+		}
+		for i, dt := range duplicateImage {
+			err := tx.Model(&entity.AssetImage{}).
+				Where("id = ?", dt.ID).
+				Updates(map[string]interface{}{
+					"asset_id":    id,
+					"image_order": len(survivingImage) + i + 1,
+					"is_primary":  len(survivingImage) == 0 && dt.IsPrimary,
+				}).Error
+			if err != nil {
+				return err
+			}
+			if len(survivingImage) == 0 && dt.IsPrimary {
+				if err := syncAssetImageURL(tx, id, &dt.ImageURL); err != nil {
+					return err
+				}
+			}
+		}
+		if err := syncAssetImageURL(tx, duplicateId, nil); err != nil {
+			return err
+		}
+
+		// Query : Attribute Is Carried Over When The Surviving Asset Category Define The Field And Its Value Is Still Empty
+		err = tx.Exec(`UPDATE asset_attributes SET asset_id = ? WHERE asset_id = ?
+			AND asset_category_field_id IN (SELECT asset_category_fields.id FROM asset_category_fields JOIN assets ON assets.asset_category_id = asset_category_fields.asset_category_id WHERE assets.id = ?)
+			AND asset_category_field_id NOT IN (SELECT asset_category_field_id FROM (SELECT asset_category_field_id FROM asset_attributes WHERE asset_id = ?) AS filled)`, id, duplicateId, id, id).Error
+		if err != nil {
+			return err
+		}
+
+		// Query : Duplicate Is Soft Deleted, Its Status History And Conflicting Attribute Stay With It
+		return tx.Model(&entity.Asset{}).
+			Where("id = ?", duplicateId).
+			Update("deleted_at", now).Error
+	})
+}
+
+func (r *assetRepository) FindAllStoredFileByDeletedId(id uuid.UUID) ([]string, error) {
 	// Models
-	var asset entity.Asset
+	var files []string
+
+	// Query : Gallery Image, Its Thumbnail, And Finding Image That Go Along With A Permanently Deleted Asset
+	err := r.db.Raw(`
+		SELECT asset_images.image_url FROM asset_images JOIN assets ON assets.id = asset_images.asset_id
+		WHERE assets.id = ? AND assets.deleted_at is not null
+		UNION
+		SELECT asset_images.thumbnail_url FROM asset_images JOIN assets ON assets.id = asset_images.asset_id
+		WHERE assets.id = ? AND assets.deleted_at is not null AND asset_images.thumbnail_url is not null
+		UNION
+		SELECT asset_findings.finding_image FROM asset_findings
+		JOIN asset_placements ON asset_placements.id = asset_findings.asset_placement_id
+		JOIN assets ON assets.id = asset_placements.asset_id
+		WHERE assets.id = ? AND assets.deleted_at is not null AND asset_findings.finding_image is not null`, id, id, id).
+		Scan(&files).Error
+
+	return files, err
+}
 
-	// Query
-	err := r.db.Unscoped().Where("id = ? AND deleted_at is not null", id).Delete(&asset).Error
+func (r *assetRepository) FindDeleteDependencyById(id uuid.UUID) ([]entity.DeleteDependency, error) {
+	// Query : Every Row That Is Cascaded Along With The Asset
+	dependencies, err := findDeleteDependency(r.db, assetDeleteDependency, id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return dependencies, nil
+}
+
+func (r *assetRepository) HardDeleteById(id, archivedBy uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Models
+		var asset entity.Asset
+
+		// Query : Only Soft Deleted Asset Can Be Permanently Deleted
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND deleted_at is not null", id).First(&asset).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// Query : Archive And Delete
+		return deleteWithArchive(tx, assetDeleteDependency, id, archivedBy)
+	})
 }
 
 // For Seeder
@@ -222,3 +638,99 @@ func (r *assetRepository) FindOneRandom() (*entity.Asset, error) {
 
 	return &asset, err
 }
+
+// Custom Attribute Of Every Given Asset, Keyed By Asset Id Then Field Name
+func findAssetAttribute(db *gorm.DB, ids []uuid.UUID) (map[uuid.UUID]map[string]string, error) {
+	// Models
+	var attributeValue []entity.AssetAttributeValue
+	attributes := map[uuid.UUID]map[string]string{}
+	if len(ids) == 0 {
+		return attributes, nil
+	}
+
+	// Query
+	err := db.Table("asset_attributes").
+		Select("asset_attributes.asset_id, asset_category_fields.field_name, asset_attributes.attribute_value").
+		Joins("JOIN asset_category_fields ON asset_category_fields.id = asset_attributes.asset_category_field_id").
+		Where("asset_attributes.asset_id IN ?", ids).
+		Scan(&attributeValue).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dt := range attributeValue {
+		if attributes[dt.AssetId] == nil {
+			attributes[dt.AssetId] = map[string]string{}
+		}
+		attributes[dt.AssetId][dt.FieldName] = dt.AttributeValue
+	}
+
+	return attributes, nil
+}
+
+func fillAssetAttribute(db *gorm.DB, asset []entity.Asset) error {
+	ids := make([]uuid.UUID, len(asset))
+	for i, dt := range asset {
+		ids[i] = dt.ID
+	}
+	attributes, err := findAssetAttribute(db, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range asset {
+		asset[i].Attributes = attributes[asset[i].ID]
+		if asset[i].Attributes == nil {
+			asset[i].Attributes = map[string]string{}
+		}
+	}
+
+	return nil
+}
+
+// Custom Attribute Is Rewritten As A Whole, Only Field Defined By The Asset Category Is Kept
+func replaceAssetAttribute(tx *gorm.DB, asset *entity.Asset) error {
+	// Query : Remove Current Attribute
+	if err := tx.Where("asset_id = ?", asset.ID).Delete(&entity.AssetAttribute{}).Error; err != nil {
+		return err
+	}
+	if asset.AssetCategoryId == nil || len(asset.Attributes) == 0 {
+		return nil
+	}
+
+	// Query : Field Definition Of The Asset Category
+	var fields []entity.AssetCategoryField
+	if err := tx.Where("asset_category_id = ?", asset.AssetCategoryId).Find(&fields).Error; err != nil {
+		return err
+	}
+	fieldId := map[string]uuid.UUID{}
+	for _, dt := range fields {
+		fieldId[dt.FieldName] = dt.ID
+	}
+
+	names := make([]string, 0, len(asset.Attributes))
+	for name := range asset.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	attributes := []entity.AssetAttribute{}
+	for _, name := range names {
+		id, ok := fieldId[name]
+		if !ok || asset.Attributes[name] == "" {
+			continue
+		}
+		attributes = append(attributes, entity.AssetAttribute{
+			ID:                   uuid.New(),
+			AttributeValue:       asset.Attributes[name],
+			AssetId:              asset.ID,
+			AssetCategoryFieldId: id,
+		})
+	}
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	// Query : Create Attribute
+	return tx.Create(&attributes).Error
+}
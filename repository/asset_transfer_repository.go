@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"pelita/entity"
+	"pelita/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Asset Transfer Interface
+type AssetTransferRepository interface {
+	FindAll(pagination utils.Pagination, scope *entity.AccessScope) ([]entity.AssetTransfer, int64, error)
+	FindById(id uuid.UUID) (*entity.AssetTransfer, error)
+	Create(assetTransfer *entity.AssetTransfer) error
+	RejectById(approvedBy, id uuid.UUID) error
+	ApproveById(approvedBy uuid.UUID, approvedByType string, id uuid.UUID) error
+}
+
+// Asset Transfer Struct
+type assetTransferRepository struct {
+	db *gorm.DB
+}
+
+// Asset Transfer Constructor
+func NewAssetTransferRepository(db *gorm.DB) AssetTransferRepository {
+	return &assetTransferRepository{db: db}
+}
+
+func (r *assetTransferRepository) FindAll(pagination utils.Pagination, scope *entity.AccessScope) ([]entity.AssetTransfer, int64, error) {
+	var total int64
+
+	// Models
+	var assetTransfer []entity.AssetTransfer
+
+	// Query : Filter By Access Scope, Either The Source Or The Target Is Inside The Scope
+	query := r.db.Model(&entity.AssetTransfer{})
+	if scope != nil {
+		query = query.Where("source_placement_id IN (?) OR target_room_id IN (?)", findScopedAssetPlacementId(r.db, scope), findScopedRoomId(r.db, scope))
+	}
+
+	// Pagination
+	offset := (pagination.Page - 1) * pagination.Limit
+	query.Session(&gorm.Session{}).Count(&total)
+
+	// Query
+	err := query.Order("created_at DESC").
+		Limit(pagination.Limit).
+		Offset(offset).
+		Find(&assetTransfer).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, 0, err
+	}
+
+	return assetTransfer, total, nil
+}
+
+func (r *assetTransferRepository) FindById(id uuid.UUID) (*entity.AssetTransfer, error) {
+	// Models
+	var assetTransfer entity.AssetTransfer
+
+	// Query
+	err := r.db.Where("id = ?", id).First(&assetTransfer).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &assetTransfer, err
+}
+
+func (r *assetTransferRepository) Create(assetTransfer *entity.AssetTransfer) error {
+	assetTransfer.ID = uuid.New()
+	assetTransfer.TransferStatus = "requested"
+	assetTransfer.CreatedAt = time.Now()
+	assetTransfer.ApprovedAt = nil
+	assetTransfer.ApprovedBy = nil
+	assetTransfer.TargetPlacementId = nil
+
+	// Query
+	return r.db.Create(assetTransfer).Error
+}
+
+func (r *assetTransferRepository) RejectById(approvedBy, id uuid.UUID) error {
+	// Query : Only Requested Transfer Can Be Rejected
+	result := r.db.Model(&entity.AssetTransfer{}).
+		Where("id = ? AND transfer_status = ?", id, "requested").
+		Updates(map[string]interface{}{
+			"transfer_status": "rejected",
+			"approved_by":     approvedBy,
+			"approved_at":     time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("asset transfer is not requested")
+	}
+
+	return nil
+}
+
+func (r *assetTransferRepository) ApproveById(approvedBy uuid.UUID, approvedByType string, id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		// Query : Lock Requested Transfer
+		var assetTransfer entity.AssetTransfer
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND transfer_status = ?", id, "requested").
+			First(&assetTransfer).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("asset transfer is not requested")
+		}
+		if err != nil {
+			return err
+		}
+
+		// Query : Lock Source Placement, So Loan Approval Of The Same Placement Wait For This One
+		var source entity.AssetPlacement
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&source, "id = ? AND deleted_at is null", assetTransfer.SourcePlacementId).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("asset placement not found")
+		}
+		if err != nil {
+			return err
+		}
+
+		// Query : Loaned Asset Can't Be Transferred
+		var totalLoan int64
+		err = tx.Model(&entity.AssetLoan{}).
+			Where("asset_placement_id = ? AND loan_status IN ?", source.ID, []string{"approved", "checked-out"}).
+			Count(&totalLoan).Error
+		if err != nil {
+			return err
+		}
+		if available := source.AssetQty - int(totalLoan); assetTransfer.TransferQty > available {
+			return fmt.Errorf("only %d asset is available to be transferred", available)
+		}
+
+		// Query : Find Or Create Target Placement Of The Same Asset
+		var target entity.AssetPlacement
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("asset_id = ? AND room_id = ?", source.AssetId, assetTransfer.TargetRoomId).
+			First(&target).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Placement Creator Must Be An Admin, Otherwise Keep The Creator Of The Source
+			createdBy := source.CreatedBy
+			if approvedByType == "admin" {
+				createdBy = approvedBy
+			}
+			target = entity.AssetPlacement{
+				ID:         uuid.New(),
+				AssetQty:   0,
+				AssetId:    source.AssetId,
+				RoomId:     assetTransfer.TargetRoomId,
+				CreatedBy:  createdBy,
+				AssetOwner: source.AssetOwner,
+				CreatedAt:  now,
+			}
+			err = tx.Create(&target).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		// Query : Move The Unit Along With The Quantity, Unit Without Open Finding Or Maintenance Is Moved First
+		var assetUnit []struct {
+			ID         uuid.UUID
+			Tag        string
+			Referenced bool
+		}
+		err = tx.Model(&entity.AssetUnit{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select(`id, tag, (
+				EXISTS (SELECT 1 FROM asset_findings WHERE asset_findings.asset_unit_id = asset_units.id AND asset_findings.deleted_at is null) OR
+				EXISTS (SELECT 1 FROM asset_maintenances WHERE asset_maintenances.asset_unit_id = asset_units.id AND asset_maintenances.deleted_at is null)
+			) AS referenced`).
+			Where("asset_placement_id = ?", source.ID).
+			Order("referenced ASC, sequence DESC").
+			Limit(assetTransfer.TransferQty).
+			Scan(&assetUnit).Error
+		if err != nil {
+			return err
+		}
+		assetUnitId := make([]uuid.UUID, 0, len(assetUnit))
+		for _, dt := range assetUnit {
+			if dt.Referenced {
+				return fmt.Errorf("asset unit %s still has an open finding or maintenance in the source placement, resolve it or transfer less asset", dt.Tag)
+			}
+			assetUnitId = append(assetUnitId, dt.ID)
+		}
+		if len(assetUnitId) > 0 {
+			err = tx.Model(&entity.AssetUnit{}).
+				Where("id IN ?", assetUnitId).
+				Updates(map[string]interface{}{
+					"asset_placement_id": target.ID,
+					"updated_at":         now,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		if err := updateAssetQtyByDelta(tx, -assetTransfer.TransferQty, source.ID, now); err != nil {
+			return err
+		}
+		if err := updateAssetQtyByDelta(tx, assetTransfer.TransferQty, target.ID, now); err != nil {
+			return err
+		}
+
+		// Query : Approve Transfer
+		err = tx.Model(&entity.AssetTransfer{}).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"transfer_status":     "approved",
+				"approved_by":         approvedBy,
+				"approved_at":         now,
+				"target_placement_id": target.ID,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		// Query : Log Movement
+		return createAssetMovement(tx, &entity.AssetMovement{
+			AssetQty:        assetTransfer.TransferQty,
+			MovedBy:         approvedBy,
+			MovedByType:     approvedByType,
+			AssetTransferId: &assetTransfer.ID,
+		}, &source, &target, now)
+	})
+}
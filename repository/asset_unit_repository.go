@@ -0,0 +1,390 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"pelita/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Asset Unit Interface
+type AssetUnitRepository interface {
+	FindAllByAssetPlacementId(id uuid.UUID) ([]entity.AssetUnit, error)
+	FindById(id uuid.UUID) (*entity.AssetUnit, error)
+	FindBySerialNumberAndId(serialNumber string, id uuid.UUID) (*entity.AssetUnit, error)
+	FindLookupByTag(tag string, scope *entity.AccessScope) (*entity.AssetTagLookup, error)
+	FindAllLabelByRoomId(id uuid.UUID, scope *entity.AccessScope) ([]entity.AssetTagLabel, error)
+	FindAllLabelByAssetPlacementId(id uuid.UUID, scope *entity.AccessScope) ([]entity.AssetTagLabel, error)
+	CountByAssetPlacementId(id uuid.UUID) (int64, error)
+	CreateMissingByAssetPlacementId(prefix string, sequenceLength, assetQty int, id uuid.UUID) ([]entity.AssetUnit, error)
+	CreateMissingForAllAssetPlacement(prefix string, sequenceLength int) (int, error)
+	DeleteSurplusForAllAssetPlacement() ([]string, error)
+	UpdateById(assetUnit *entity.AssetUnit, id uuid.UUID) error
+	MoveById(movedBy uuid.UUID, movedByType string, assetPlacementId, id uuid.UUID) error
+	DeleteById(id uuid.UUID) error
+}
+
+// Asset Unit Struct
+type assetUnitRepository struct {
+	db *gorm.DB
+}
+
+// Asset Unit Constructor
+func NewAssetUnitRepository(db *gorm.DB) AssetUnitRepository {
+	return &assetUnitRepository{db: db}
+}
+
+func (r *assetUnitRepository) FindAllByAssetPlacementId(id uuid.UUID) ([]entity.AssetUnit, error) {
+	// Models
+	var assetUnit []entity.AssetUnit
+
+	// Query
+	err := r.db.Where("asset_placement_id = ?", id).
+		Order("sequence ASC").
+		Find(&assetUnit).Error
+
+	return assetUnit, err
+}
+
+func (r *assetUnitRepository) FindById(id uuid.UUID) (*entity.AssetUnit, error) {
+	// Models
+	var assetUnit entity.AssetUnit
+
+	// Query
+	err := r.db.Where("id = ?", id).First(&assetUnit).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &assetUnit, err
+}
+
+func (r *assetUnitRepository) FindBySerialNumberAndId(serialNumber string, id uuid.UUID) (*entity.AssetUnit, error) {
+	// Models
+	var assetUnit entity.AssetUnit
+
+	// Query
+	err := r.db.Where("serial_number = ? AND id != ?", serialNumber, id).First(&assetUnit).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &assetUnit, err
+}
+
+func (r *assetUnitRepository) FindLookupByTag(tag string, scope *entity.AccessScope) (*entity.AssetTagLookup, error) {
+	// Models
+	var lookup entity.AssetTagLookup
+
+	// Query
+	query := r.db.Table("asset_units")
+	if scope != nil {
+		query = query.Where("rooms.id IN (?)", findScopedRoomId(r.db, scope))
+	}
+
+	err := query.
+		Select(`asset_units.tag, asset_units.id AS asset_unit_id, asset_units.serial_number, asset_units.unit_condition, 
+			assets.id AS asset_id, assets.asset_name, assets.asset_category, assets.asset_merk, assets.asset_status, 
+			asset_placements.id AS asset_placement_id, asset_placements.asset_qty, rooms.id AS room_id, rooms.room_name, rooms.room_dept, rooms.floor`).
+		Joins("JOIN asset_placements ON asset_placements.id = asset_units.asset_placement_id").
+		Joins("JOIN assets ON assets.id = asset_placements.asset_id").
+		Joins("JOIN rooms ON rooms.id = asset_placements.room_id").
+		Where("asset_units.tag = ? AND assets.deleted_at IS NULL AND asset_placements.deleted_at IS NULL", tag).
+		Take(&lookup).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &lookup, err
+}
+
+func (r *assetUnitRepository) FindAllLabelByRoomId(id uuid.UUID, scope *entity.AccessScope) ([]entity.AssetTagLabel, error) {
+	return r.findAllLabel("asset_placements.room_id = ?", id, scope)
+}
+
+func (r *assetUnitRepository) FindAllLabelByAssetPlacementId(id uuid.UUID, scope *entity.AccessScope) ([]entity.AssetTagLabel, error) {
+	return r.findAllLabel("asset_placements.id = ?", id, scope)
+}
+
+func (r *assetUnitRepository) findAllLabel(condition string, id uuid.UUID, scope *entity.AccessScope) ([]entity.AssetTagLabel, error) {
+	// Models
+	var labels []entity.AssetTagLabel
+
+	// Query
+	query := r.db.Table("asset_units")
+	if scope != nil {
+		query = query.Where("rooms.id IN (?)", findScopedRoomId(r.db, scope))
+	}
+
+	err := query.
+		Select("asset_units.tag, assets.asset_name, assets.asset_category, rooms.room_name, rooms.floor").
+		Joins("JOIN asset_placements ON asset_placements.id = asset_units.asset_placement_id").
+		Joins("JOIN assets ON assets.id = asset_placements.asset_id").
+		Joins("JOIN rooms ON rooms.id = asset_placements.room_id").
+		Where(condition, id).
+		Where("assets.deleted_at IS NULL AND asset_placements.deleted_at IS NULL").
+		Order("asset_units.sequence ASC").
+		Scan(&labels).Error
+
+	return labels, err
+}
+
+func (r *assetUnitRepository) CountByAssetPlacementId(id uuid.UUID) (int64, error) {
+	var total int64
+
+	// Query
+	err := r.db.Model(&entity.AssetUnit{}).
+		Where("asset_placement_id = ?", id).
+		Count(&total).Error
+
+	return total, err
+}
+
+func (r *assetUnitRepository) CreateMissingByAssetPlacementId(prefix string, sequenceLength, assetQty int, id uuid.UUID) ([]entity.AssetUnit, error) {
+	// Models
+	var assetUnit []entity.AssetUnit
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		assetUnit, err = createMissingAssetUnit(tx, prefix, sequenceLength, assetQty, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return assetUnit, nil
+}
+
+func (r *assetUnitRepository) CreateMissingForAllAssetPlacement(prefix string, sequenceLength int) (int, error) {
+	// Models
+	var assetPlacement []entity.AssetPlacement
+	total := 0
+
+	// Query : Every Active Placement Which Quantity Is Not Yet Exploded Into Unit
+	err := r.db.Model(&entity.AssetPlacement{}).
+		Where("deleted_at is null AND asset_qty > (SELECT COUNT(*) FROM asset_units WHERE asset_units.asset_placement_id = asset_placements.id)").
+		Order("created_at ASC").
+		Find(&assetPlacement).Error
+	if err != nil {
+		return 0, err
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		for _, dt := range assetPlacement {
+			assetUnit, err := createMissingAssetUnit(tx, prefix, sequenceLength, dt.AssetQty, dt.ID)
+			if err != nil {
+				return err
+			}
+			total += len(assetUnit)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (r *assetUnitRepository) DeleteSurplusForAllAssetPlacement() ([]string, error) {
+	// Models
+	var assetPlacement []entity.AssetPlacement
+	var tags []string
+
+	// Query : Every Placement Having More Unit Than Its Quantity
+	err := r.db.Model(&entity.AssetPlacement{}).
+		Where("asset_qty < (SELECT COUNT(*) FROM asset_units WHERE asset_units.asset_placement_id = asset_placements.id)").
+		Find(&assetPlacement).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		for _, dt := range assetPlacement {
+			// Query : Unit Beyond The Quantity, Latest Sequence Is Removed First
+			var surplus []entity.AssetUnit
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("asset_placement_id = ?", dt.ID).
+				Order("sequence ASC").
+				Offset(dt.AssetQty).
+				Limit(math.MaxInt32).
+				Find(&surplus).Error
+			if err != nil {
+				return err
+			}
+			if len(surplus) == 0 {
+				continue
+			}
+
+			// Query : Delete Surplus Unit
+			assetUnitId := make([]uuid.UUID, 0, len(surplus))
+			for _, unit := range surplus {
+				assetUnitId = append(assetUnitId, unit.ID)
+				tags = append(tags, unit.Tag)
+			}
+			if err := tx.Where("id IN ?", assetUnitId).Delete(&entity.AssetUnit{}).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (r *assetUnitRepository) UpdateById(assetUnit *entity.AssetUnit, id uuid.UUID) error {
+	// Query
+	result := r.db.Model(&entity.AssetUnit{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"serial_number":  assetUnit.SerialNumber,
+			"unit_condition": assetUnit.UnitCondition,
+			"updated_at":     time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("asset unit not found")
+	}
+
+	return nil
+}
+
+func (r *assetUnitRepository) MoveById(movedBy uuid.UUID, movedByType string, assetPlacementId, id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Find Asset Unit
+		var assetUnit entity.AssetUnit
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&assetUnit, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if assetUnit.AssetPlacementId == assetPlacementId {
+			return errors.New("asset unit is already in the asset placement")
+		}
+
+		// Query : Move Asset Unit
+		now := time.Now()
+		err := tx.Model(&entity.AssetUnit{}).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"asset_placement_id": assetPlacementId,
+				"updated_at":         now,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		// Query : Keep Quantity Of Both Placement Consistent With Its Unit
+		if err := updateAssetQtyByDelta(tx, -1, assetUnit.AssetPlacementId, now); err != nil {
+			return err
+		}
+		if err := updateAssetQtyByDelta(tx, 1, assetPlacementId, now); err != nil {
+			return err
+		}
+
+		// Query : Log Movement
+		var source, target entity.AssetPlacement
+		if err := tx.First(&source, "id = ?", assetUnit.AssetPlacementId).Error; err != nil {
+			return err
+		}
+		if err := tx.First(&target, "id = ?", assetPlacementId).Error; err != nil {
+			return err
+		}
+
+		return createAssetMovement(tx, &entity.AssetMovement{
+			AssetQty:    1,
+			MovedBy:     movedBy,
+			MovedByType: movedByType,
+			AssetUnitId: &assetUnit.ID,
+		}, &source, &target, now)
+	})
+}
+
+func (r *assetUnitRepository) DeleteById(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Find Asset Unit
+		var assetUnit entity.AssetUnit
+		if err := tx.First(&assetUnit, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		// Query : Delete Asset Unit
+		if err := tx.Delete(&entity.AssetUnit{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		// Query : Keep Quantity Of The Placement Consistent With Its Unit
+		return updateAssetQtyByDelta(tx, -1, assetUnit.AssetPlacementId, time.Now())
+	})
+}
+
+func createMissingAssetUnit(tx *gorm.DB, prefix string, sequenceLength, assetQty int, id uuid.UUID) ([]entity.AssetUnit, error) {
+	// Models
+	var assetUnit []entity.AssetUnit
+
+	// Query : Count Existing Unit Of The Placement
+	var total int64
+	if err := tx.Model(&entity.AssetUnit{}).Where("asset_placement_id = ?", id).Count(&total).Error; err != nil {
+		return nil, err
+	}
+	if int(total) >= assetQty {
+		return nil, nil
+	}
+
+	// Query : Lock Last Sequence, So Concurrent Generation Never Reuse The Same Tag
+	var lastSequence int
+	err := tx.Model(&entity.AssetUnit{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("COALESCE(MAX(sequence), 0)").
+		Scan(&lastSequence).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Query : Create Missing Unit
+	now := time.Now()
+	for i := int(total); i < assetQty; i++ {
+		lastSequence++
+		assetUnit = append(assetUnit, entity.AssetUnit{
+			ID:               uuid.New(),
+			Tag:              fmt.Sprintf("%s-%0*d", prefix, sequenceLength, lastSequence),
+			Sequence:         lastSequence,
+			UnitCondition:    "good",
+			CreatedAt:        now,
+			AssetPlacementId: id,
+		})
+	}
+	if err := tx.Create(&assetUnit).Error; err != nil {
+		return nil, err
+	}
+
+	return assetUnit, nil
+}
+
+func updateAssetQtyByDelta(tx *gorm.DB, delta int, id uuid.UUID, now time.Time) error {
+	result := tx.Model(&entity.AssetPlacement{}).
+		Where("id = ? AND asset_qty + ? >= 0", id, delta).
+		Updates(map[string]interface{}{
+			"asset_qty":  gorm.Expr("asset_qty + ?", delta),
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("asset placement not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"pelita/config"
+	"pelita/entity"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Delete Confirmation Interface
+type DeleteConfirmationRepository interface {
+	Create(deleteConfirmation *entity.DeleteConfirmation, tokenHash string, ttl time.Duration) error
+	ConsumeByTokenHash(tokenHash string) (*entity.DeleteConfirmation, error)
+}
+
+// Delete Confirmation Struct
+type deleteConfirmationRepository struct {
+	redisClient *redis.Client
+}
+
+// Delete Confirmation Constructor
+func NewDeleteConfirmationRepository(redisClient *redis.Client) DeleteConfirmationRepository {
+	return &deleteConfirmationRepository{redisClient: redisClient}
+}
+
+func (r *deleteConfirmationRepository) Create(deleteConfirmation *entity.DeleteConfirmation, tokenHash string, ttl time.Duration) error {
+	payload, err := json.Marshal(deleteConfirmation)
+	if err != nil {
+		return err
+	}
+
+	// Query
+	return r.redisClient.Set(context.Background(), fmt.Sprintf(config.RedisKeyDeleteConfirm, tokenHash), payload, ttl).Err()
+}
+
+func (r *deleteConfirmationRepository) ConsumeByTokenHash(tokenHash string) (*entity.DeleteConfirmation, error) {
+	// Models
+	var deleteConfirmation entity.DeleteConfirmation
+
+	// Query : Get And Delete So The Token Only Usable Once
+	payload, err := r.redisClient.GetDel(context.Background(), fmt.Sprintf(config.RedisKeyDeleteConfirm, tokenHash)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(payload, &deleteConfirmation); err != nil {
+		return nil, err
+	}
+
+	return &deleteConfirmation, nil
+}
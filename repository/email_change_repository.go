@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"pelita/config"
+	"pelita/entity"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Email Change Interface
+type EmailChangeRepository interface {
+	Create(emailChange *entity.EmailChange, tokenHash string, ttl time.Duration) error
+	ConsumeByTokenHash(tokenHash string) (*entity.EmailChange, error)
+}
+
+// Email Change Struct
+type emailChangeRepository struct {
+	redisClient *redis.Client
+}
+
+// Email Change Constructor
+func NewEmailChangeRepository(redisClient *redis.Client) EmailChangeRepository {
+	return &emailChangeRepository{redisClient: redisClient}
+}
+
+func (r *emailChangeRepository) Create(emailChange *entity.EmailChange, tokenHash string, ttl time.Duration) error {
+	payload, err := json.Marshal(emailChange)
+	if err != nil {
+		return err
+	}
+
+	// Query
+	return r.redisClient.Set(context.Background(), fmt.Sprintf(config.RedisKeyEmailChange, tokenHash), payload, ttl).Err()
+}
+
+func (r *emailChangeRepository) ConsumeByTokenHash(tokenHash string) (*entity.EmailChange, error) {
+	// Models
+	var emailChange entity.EmailChange
+
+	// Query : Get And Delete So The Token Only Usable Once
+	payload, err := r.redisClient.GetDel(context.Background(), fmt.Sprintf(config.RedisKeyEmailChange, tokenHash)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(payload, &emailChange); err != nil {
+		return nil, err
+	}
+
+	return &emailChange, nil
+}
@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"fmt"
+	"pelita/utils"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type filterKind int
+
+const (
+	filterText filterKind = iota
+	filterNumber
+	filterDateStart
+	filterDateEnd
+	filterUUID
+)
+
+// Query Param Mapped To A Condition With A Single Placeholder
+type filterField struct {
+	condition string
+	kind      filterKind
+}
+
+// Whitelist Of Search, Filter, And Sort Column Of A Listing
+type filterSpec struct {
+	search      []string
+	fields      map[string]filterField
+	sorts       map[string]string
+	defaultSort string
+	// Custom Attribute Condition, Filled With The Field Name And The Value Comparison
+	attribute string
+}
+
+var assetFilterSpec = filterSpec{
+	search: []string{"assets.asset_name LIKE ?", "assets.asset_desc LIKE ?"},
+	fields: map[string]filterField{
+		"category":     {condition: "assets.asset_category = ?"},
+		"category_id":  {condition: "assets.asset_category_id = ?", kind: filterUUID},
+		"status":       {condition: "assets.asset_status = ?"},
+		"merk":         {condition: "assets.asset_merk = ?"},
+		"price_min":    {condition: "assets.asset_price >= ?", kind: filterNumber},
+		"price_max":    {condition: "assets.asset_price <= ?", kind: filterNumber},
+		"created_from": {condition: "assets.created_at >= ?", kind: filterDateStart},
+		"created_to":   {condition: "assets.created_at < ?", kind: filterDateEnd},
+		"room_id":      {condition: "assets.id IN (SELECT asset_id FROM asset_placements WHERE room_id = ?)", kind: filterUUID},
+		"floor":        {condition: "assets.id IN (SELECT asset_placements.asset_id FROM asset_placements JOIN rooms ON rooms.id = asset_placements.room_id WHERE rooms.floor = ?)"},
+		"department":   {condition: "assets.id IN (SELECT asset_placements.asset_id FROM asset_placements JOIN rooms ON rooms.id = asset_placements.room_id WHERE rooms.room_dept = ?)"},
+	},
+	sorts: map[string]string{
+		"asset_name":     "assets.asset_name",
+		"asset_category": "assets.asset_category",
+		"asset_status":   "assets.asset_status",
+		"asset_merk":     "assets.asset_merk",
+		"asset_price":    "assets.asset_price",
+		"purchase_date":  "assets.purchase_date",
+		"created_at":     "assets.created_at",
+		"updated_at":     "assets.updated_at",
+	},
+	defaultSort: "assets.created_at DESC",
+	attribute:   "assets.id IN (SELECT asset_attributes.asset_id FROM asset_attributes JOIN asset_category_fields ON asset_category_fields.id = asset_attributes.asset_category_field_id WHERE asset_category_fields.field_name = ? AND %s)",
+}
+
+var assetPlacementFilterSpec = filterSpec{
+	search: []string{"asset_placements.asset_desc LIKE ?", "asset_placements.asset_id IN (SELECT id FROM assets WHERE asset_name LIKE ?)"},
+	fields: map[string]filterField{
+		"asset_id":     {condition: "asset_placements.asset_id = ?", kind: filterUUID},
+		"category":     {condition: "asset_placements.asset_id IN (SELECT id FROM assets WHERE asset_category = ?)"},
+		"room_id":      {condition: "asset_placements.room_id = ?", kind: filterUUID},
+		"floor":        {condition: "asset_placements.room_id IN (SELECT id FROM rooms WHERE floor = ?)"},
+		"department":   {condition: "asset_placements.room_id IN (SELECT id FROM rooms WHERE room_dept = ?)"},
+		"owner_id":     {condition: "asset_placements.asset_owner = ?", kind: filterUUID},
+		"qty_min":      {condition: "asset_placements.asset_qty >= ?", kind: filterNumber},
+		"qty_max":      {condition: "asset_placements.asset_qty <= ?", kind: filterNumber},
+		"created_from": {condition: "asset_placements.created_at >= ?", kind: filterDateStart},
+		"created_to":   {condition: "asset_placements.created_at < ?", kind: filterDateEnd},
+	},
+	sorts: map[string]string{
+		"asset_qty":  "asset_placements.asset_qty",
+		"created_at": "asset_placements.created_at",
+		"updated_at": "asset_placements.updated_at",
+	},
+	defaultSort: "asset_placements.created_at DESC",
+}
+
+var assetMaintenanceFilterSpec = filterSpec{
+	search: []string{"asset_maintenances.maintenance_notes LIKE ?", "asset_maintenances.asset_placement_id IN (SELECT asset_placements.id FROM asset_placements JOIN assets ON assets.id = asset_placements.asset_id WHERE assets.asset_name LIKE ?)"},
+	fields: map[string]filterField{
+		"asset_placement_id": {condition: "asset_maintenances.asset_placement_id = ?", kind: filterUUID},
+		"asset_id":           {condition: "asset_maintenances.asset_placement_id IN (SELECT id FROM asset_placements WHERE asset_id = ?)", kind: filterUUID},
+		"technician_id":      {condition: "asset_maintenances.maintenance_by = ?", kind: filterUUID},
+		"maintenance_day":    {condition: "asset_maintenances.maintenance_day = ?"},
+		"room_id":            {condition: "asset_maintenances.asset_placement_id IN (SELECT id FROM asset_placements WHERE room_id = ?)", kind: filterUUID},
+		"floor":              {condition: "asset_maintenances.asset_placement_id IN (SELECT asset_placements.id FROM asset_placements JOIN rooms ON rooms.id = asset_placements.room_id WHERE rooms.floor = ?)"},
+		"department":         {condition: "asset_maintenances.asset_placement_id IN (SELECT asset_placements.id FROM asset_placements JOIN rooms ON rooms.id = asset_placements.room_id WHERE rooms.room_dept = ?)"},
+		"created_from":       {condition: "asset_maintenances.created_at >= ?", kind: filterDateStart},
+		"created_to":         {condition: "asset_maintenances.created_at < ?", kind: filterDateEnd},
+	},
+	sorts: map[string]string{
+		"maintenance_day":        "FIELD(asset_maintenances.maintenance_day, 'Mon', 'Tue', 'Wed', 'Thu', 'Fri', 'Sat', 'Sun')",
+		"maintenance_hour_start": "asset_maintenances.maintenance_hour_start",
+		"created_at":             "asset_maintenances.created_at",
+		"updated_at":             "asset_maintenances.updated_at",
+	},
+	defaultSort: "asset_maintenances.created_at DESC",
+}
+
+var assetFindingFilterSpec = filterSpec{
+	search: []string{"asset_findings.finding_notes LIKE ?", "asset_findings.asset_placement_id IN (SELECT asset_placements.id FROM asset_placements JOIN assets ON assets.id = asset_placements.asset_id WHERE assets.asset_name LIKE ?)"},
+	fields: map[string]filterField{
+		"finding_category":   {condition: "asset_findings.finding_category = ?"},
+		"asset_placement_id": {condition: "asset_findings.asset_placement_id = ?", kind: filterUUID},
+		"asset_id":           {condition: "asset_findings.asset_placement_id IN (SELECT id FROM asset_placements WHERE asset_id = ?)", kind: filterUUID},
+		"room_id":            {condition: "asset_findings.asset_placement_id IN (SELECT id FROM asset_placements WHERE room_id = ?)", kind: filterUUID},
+		"floor":              {condition: "asset_findings.asset_placement_id IN (SELECT asset_placements.id FROM asset_placements JOIN rooms ON rooms.id = asset_placements.room_id WHERE rooms.floor = ?)"},
+		"department":         {condition: "asset_findings.asset_placement_id IN (SELECT asset_placements.id FROM asset_placements JOIN rooms ON rooms.id = asset_placements.room_id WHERE rooms.room_dept = ?)"},
+		"created_from":       {condition: "asset_findings.created_at >= ?", kind: filterDateStart},
+		"created_to":         {condition: "asset_findings.created_at < ?", kind: filterDateEnd},
+	},
+	sorts: map[string]string{
+		"finding_category": "asset_findings.finding_category",
+		"created_at":       "asset_findings.created_at",
+	},
+	defaultSort: "asset_findings.created_at DESC",
+}
+
+// Apply Search And Filter Condition, Kept Apart From Sorting So The Query Can Still Be Counted
+func applyFilter(query *gorm.DB, spec filterSpec, filter utils.Filter) (*gorm.DB, error) {
+	// Search : Any Searchable Column Contains The Term
+	if filter.Search != "" && len(spec.search) > 0 {
+		term := "%" + escapeLike(filter.Search) + "%"
+		args := make([]interface{}, len(spec.search))
+		for i := range args {
+			args[i] = term
+		}
+		query = query.Where("("+strings.Join(spec.search, " OR ")+")", args...)
+	}
+
+	// Filter : Sorted So The Generated Query Is Always The Same
+	params := make([]string, 0, len(filter.Params))
+	for param := range filter.Params {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+	for _, param := range params {
+		field, ok := spec.fields[param]
+		if !ok {
+			if spec.attribute != "" && strings.HasPrefix(param, "attr.") {
+				fieldName, comparison, value, err := parseAttributeFilter(param, filter.Params[param])
+				if err != nil {
+					return nil, err
+				}
+				query = query.Where(fmt.Sprintf(spec.attribute, comparison), fieldName, value)
+			}
+			continue
+		}
+
+		value, err := parseFilterValue(param, filter.Params[param], field.kind)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(field.condition, value)
+	}
+
+	return query, nil
+}
+
+func applySort(query *gorm.DB, spec filterSpec, filter utils.Filter) (*gorm.DB, error) {
+	if filter.SortBy == "" {
+		return query.Order(spec.defaultSort), nil
+	}
+
+	column, ok := spec.sorts[filter.SortBy]
+	if !ok {
+		keys := make([]string, 0, len(spec.sorts))
+		for key := range spec.sorts {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return nil, fmt.Errorf("sort_by must be one of %s", strings.Join(keys, ", "))
+	}
+	if filter.SortDir != "asc" && filter.SortDir != "desc" {
+		return nil, fmt.Errorf("sort_dir must be asc or desc")
+	}
+
+	return query.Order(column + " " + strings.ToUpper(filter.SortDir)), nil
+}
+
+// Custom Attribute Is Matched By attr.<field_name>, Or Ranged By attr.<field_name>.min And attr.<field_name>.max
+func parseAttributeFilter(param, value string) (string, string, interface{}, error) {
+	fieldName := strings.TrimPrefix(param, "attr.")
+	operator := ""
+	if name, ok := strings.CutSuffix(fieldName, ".min"); ok {
+		fieldName, operator = name, ">="
+	} else if name, ok := strings.CutSuffix(fieldName, ".max"); ok {
+		fieldName, operator = name, "<="
+	}
+	if fieldName == "" {
+		return "", "", nil, fmt.Errorf("%s must have a field name", param)
+	}
+	if operator == "" {
+		return fieldName, "asset_attributes.attribute_value = ?", value, nil
+	}
+
+	// Range Is Compared As A Number, Or As Text For Date Since It Is Stored As YYYY-MM-DD
+	if number, err := strconv.ParseFloat(value, 64); err == nil {
+		return fieldName, "CAST(asset_attributes.attribute_value AS DECIMAL(20,4)) " + operator + " ?", number, nil
+	}
+	if _, err := time.Parse("2006-01-02", value); err == nil {
+		return fieldName, "asset_attributes.attribute_value " + operator + " ?", value, nil
+	}
+
+	return "", "", nil, fmt.Errorf("%s must be a number or in YYYY-MM-DD format", param)
+}
+
+func parseFilterValue(param, value string, kind filterKind) (interface{}, error) {
+	switch kind {
+	case filterNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a number", param)
+		}
+		return number, nil
+	case filterDateStart, filterDateEnd:
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be in YYYY-MM-DD format", param)
+		}
+		// The End Date Is Inclusive, So Compare With The Start Of The Next Day
+		if kind == filterDateEnd {
+			date = date.AddDate(0, 0, 1)
+		}
+		return date, nil
+	case filterUUID:
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a valid UUID", param)
+		}
+		return id, nil
+	default:
+		return value, nil
+	}
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
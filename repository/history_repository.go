@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"pelita/entity"
 	"pelita/utils"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -14,6 +15,7 @@ import (
 type HistoryRepository interface {
 	FindAll(pagination utils.Pagination) ([]entity.AllHistory, int64, error)
 	FindMy(pagination utils.Pagination, id uuid.UUID, typeUser string) ([]entity.History, int64, error)
+	Create(history *entity.History) error
 }
 
 // History Struct
@@ -41,6 +43,7 @@ func (r *historyRepository) FindAll(pagination utils.Pagination) ([]entity.AllHi
 		Preload("User").
 		Preload("Technician").
 		Preload("Admin").
+		Preload("ApiKey").
 		Order("created_at DESC").
 		Limit(pagination.Limit).
 		Offset(offset).
@@ -89,3 +92,11 @@ func (r *historyRepository) FindMy(pagination utils.Pagination, id uuid.UUID, ty
 
 	return history, total, err
 }
+
+func (r *historyRepository) Create(history *entity.History) error {
+	history.ID = uuid.New()
+	history.CreatedAt = time.Now()
+
+	// Query
+	return r.db.Create(history).Error
+}
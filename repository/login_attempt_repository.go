@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"pelita/config"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Login Attempt Interface
+type LoginAttemptRepository interface {
+	IncrementFailed(target string, window time.Duration) (int64, error)
+	FindLockDuration(target string) (time.Duration, error)
+	Lock(target string, baseDuration, maxDuration time.Duration) (time.Duration, error)
+	Reset(target string) error
+	Unlock(target string) error
+}
+
+// Login Attempt Struct
+type loginAttemptRepository struct {
+	redisClient *redis.Client
+}
+
+// Login Attempt Constructor
+func NewLoginAttemptRepository(redisClient *redis.Client) LoginAttemptRepository {
+	return &loginAttemptRepository{redisClient: redisClient}
+}
+
+func (r *loginAttemptRepository) IncrementFailed(target string, window time.Duration) (int64, error) {
+	ctx := context.Background()
+	key := fmt.Sprintf(config.RedisKeyLoginAttempt, target)
+
+	// Query
+	total, err := r.redisClient.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	// Start The Window On First Failed Attempt
+	if total == 1 {
+		if err := r.redisClient.Expire(ctx, key, window).Err(); err != nil {
+			return 0, err
+		}
+	}
+
+	return total, nil
+}
+
+func (r *loginAttemptRepository) FindLockDuration(target string) (time.Duration, error) {
+	// Query
+	ttl, err := r.redisClient.TTL(context.Background(), fmt.Sprintf(config.RedisKeyLoginLock, target)).Result()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	// Negative TTL Means The Key Is Missing
+	if ttl < 0 {
+		return 0, nil
+	}
+
+	return ttl, nil
+}
+
+func (r *loginAttemptRepository) Lock(target string, baseDuration, maxDuration time.Duration) (time.Duration, error) {
+	ctx := context.Background()
+	lockCountKey := fmt.Sprintf(config.RedisKeyLoginLockCount, target)
+
+	// Query : Count Lockout In The Last Max Duration
+	lockCount, err := r.redisClient.Incr(ctx, lockCountKey).Result()
+	if err != nil {
+		return 0, err
+	}
+	if err := r.redisClient.Expire(ctx, lockCountKey, maxDuration).Err(); err != nil {
+		return 0, err
+	}
+
+	// Exponential Lock Duration
+	duration := maxDuration
+	if lockCount <= 32 {
+		duration = baseDuration * time.Duration(int64(1)<<(lockCount-1))
+	}
+	if duration <= 0 || duration > maxDuration {
+		duration = maxDuration
+	}
+
+	// Query : Lock And Start A New Attempt Window
+	pipe := r.redisClient.TxPipeline()
+	pipe.Set(ctx, fmt.Sprintf(config.RedisKeyLoginLock, target), lockCount, duration)
+	pipe.Del(ctx, fmt.Sprintf(config.RedisKeyLoginAttempt, target))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	return duration, nil
+}
+
+func (r *loginAttemptRepository) Reset(target string) error {
+	// Query
+	return r.redisClient.Del(context.Background(),
+		fmt.Sprintf(config.RedisKeyLoginAttempt, target),
+		fmt.Sprintf(config.RedisKeyLoginLockCount, target),
+	).Err()
+}
+
+func (r *loginAttemptRepository) Unlock(target string) error {
+	// Query
+	return r.redisClient.Del(context.Background(),
+		fmt.Sprintf(config.RedisKeyLoginAttempt, target),
+		fmt.Sprintf(config.RedisKeyLoginLock, target),
+		fmt.Sprintf(config.RedisKeyLoginLockCount, target),
+	).Err()
+}
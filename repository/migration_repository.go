@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"pelita/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Migration Interface
+type MigrationRepository interface {
+	RunOnce(name string, migrate func(tx *gorm.DB) error) (bool, error)
+}
+
+// Migration Struct
+type migrationRepository struct {
+	db *gorm.DB
+}
+
+// Migration Constructor
+func NewMigrationRepository(db *gorm.DB) MigrationRepository {
+	return &migrationRepository{db: db}
+}
+
+// Migrate Is Skipped When Already Recorded, Otherwise It Is Recorded In The Same Transaction So A Failed Migration Is Retried On The Next Boot
+func (r *migrationRepository) RunOnce(name string, migrate func(tx *gorm.DB) error) (bool, error) {
+	migrated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Lock The Migration Record, So Concurrent Boot Wait For The Running One
+		var total int64
+		err := tx.Model(&entity.Migration{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("name = ?", name).
+			Count(&total).Error
+		if err != nil {
+			return err
+		}
+		if total > 0 {
+			return nil
+		}
+
+		if err := migrate(tx); err != nil {
+			return err
+		}
+
+		// Query : Record Migration
+		migrated = true
+		return tx.Create(&entity.Migration{Name: name, MigratedAt: time.Now()}).Error
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return migrated, nil
+}
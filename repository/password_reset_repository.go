@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"pelita/config"
+	"pelita/entity"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Password Reset Interface
+type PasswordResetRepository interface {
+	Create(passwordReset *entity.PasswordReset, tokenHash string, ttl time.Duration) error
+	ConsumeByTokenHash(tokenHash string) (*entity.PasswordReset, error)
+}
+
+// Password Reset Struct
+type passwordResetRepository struct {
+	redisClient *redis.Client
+}
+
+// Password Reset Constructor
+func NewPasswordResetRepository(redisClient *redis.Client) PasswordResetRepository {
+	return &passwordResetRepository{redisClient: redisClient}
+}
+
+func (r *passwordResetRepository) Create(passwordReset *entity.PasswordReset, tokenHash string, ttl time.Duration) error {
+	payload, err := json.Marshal(passwordReset)
+	if err != nil {
+		return err
+	}
+
+	// Query
+	return r.redisClient.Set(context.Background(), fmt.Sprintf(config.RedisKeyPasswordReset, tokenHash), payload, ttl).Err()
+}
+
+func (r *passwordResetRepository) ConsumeByTokenHash(tokenHash string) (*entity.PasswordReset, error) {
+	// Models
+	var passwordReset entity.PasswordReset
+
+	// Query : Get And Delete So The Token Only Usable Once
+	payload, err := r.redisClient.GetDel(context.Background(), fmt.Sprintf(config.RedisKeyPasswordReset, tokenHash)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(payload, &passwordReset); err != nil {
+		return nil, err
+	}
+
+	return &passwordReset, nil
+}
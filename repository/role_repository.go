@@ -0,0 +1,298 @@
+package repository
+
+import (
+	"errors"
+	"pelita/entity"
+	"pelita/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Role Interface
+type RoleRepository interface {
+	FindAll(pagination utils.Pagination) ([]entity.Role, int64, error)
+	FindById(id uuid.UUID) (*entity.Role, error)
+	FindByRoleName(roleName string) (*entity.Role, error)
+	FindByRoleNameAndNotId(roleName string, id uuid.UUID) (*entity.Role, error)
+	FindPermissionByAccount(accountId uuid.UUID, accountType string) ([]string, error)
+	Create(role *entity.Role, permissions []string) error
+	UpdateById(role *entity.Role, permissions []string, id uuid.UUID) error
+	DeleteById(id uuid.UUID) error
+	AssignAccountRole(accountRole *entity.AccountRole) error
+	DeleteAccountRoleByAccountId(accountId uuid.UUID) error
+
+	// For Seeder
+	SyncPermissionByRoleName(roleName string, permissions []string) error
+	SyncNewPermissionByRoleName(roleName string, permissions []string) error
+}
+
+// Role Struct
+type roleRepository struct {
+	db *gorm.DB
+}
+
+// Role Constructor
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return &roleRepository{db: db}
+}
+
+func (r *roleRepository) FindAll(pagination utils.Pagination) ([]entity.Role, int64, error) {
+	var total int64
+
+	// Models
+	var roles []entity.Role
+
+	// Pagination
+	offset := (pagination.Page - 1) * pagination.Limit
+	r.db.Model(&entity.Role{}).Count(&total)
+
+	// Query
+	err := r.db.Preload("Permissions").
+		Order("is_system DESC").
+		Order("role_name ASC").
+		Limit(pagination.Limit).
+		Offset(offset).
+		Find(&roles).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, 0, err
+	}
+
+	return roles, total, nil
+}
+
+func (r *roleRepository) FindById(id uuid.UUID) (*entity.Role, error) {
+	// Models
+	var role entity.Role
+
+	// Query
+	err := r.db.Preload("Permissions").Where("id = ?", id).First(&role).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &role, err
+}
+
+func (r *roleRepository) FindByRoleName(roleName string) (*entity.Role, error) {
+	// Models
+	var role entity.Role
+
+	// Query
+	err := r.db.Preload("Permissions").Where("role_name = ?", roleName).First(&role).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &role, err
+}
+
+func (r *roleRepository) FindByRoleNameAndNotId(roleName string, id uuid.UUID) (*entity.Role, error) {
+	// Models
+	var role entity.Role
+
+	// Query
+	err := r.db.Where("role_name = ? AND id != ?", roleName, id).First(&role).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &role, err
+}
+
+func (r *roleRepository) FindPermissionByAccount(accountId uuid.UUID, accountType string) ([]string, error) {
+	// Models
+	var permissions []string
+
+	// Query : Use Assigned Custom Role, Fallback To System Role Of The Account Type
+	roleId := r.db.Model(&entity.AccountRole{}).Select("role_id").Where("account_id = ?", accountId)
+	systemRoleId := r.db.Model(&entity.Role{}).Select("id").Where("role_name = ? AND is_system = ?", accountType, true)
+
+	var total int64
+	if err := r.db.Model(&entity.AccountRole{}).Where("account_id = ?", accountId).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	query := r.db.Model(&entity.RolePermission{}).Select("permission")
+	if total > 0 {
+		query = query.Where("role_id IN (?)", roleId)
+	} else {
+		query = query.Where("role_id IN (?)", systemRoleId)
+	}
+
+	if err := query.Pluck("permission", &permissions).Error; err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+func (r *roleRepository) Create(role *entity.Role, permissions []string) error {
+	role.ID = uuid.New()
+	role.CreatedAt = time.Now()
+	role.Permissions = buildRolePermission(role.ID, permissions)
+
+	// Query
+	return r.db.Create(role).Error
+}
+
+func (r *roleRepository) UpdateById(role *entity.Role, permissions []string, id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Check Old Role
+		var existingRole entity.Role
+		if err := tx.First(&existingRole, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		// Query : Update
+		role.ID = id
+		role.IsSystem = existingRole.IsSystem
+		role.CreatedAt = existingRole.CreatedAt
+		role.CreatedBy = existingRole.CreatedBy
+		role.Permissions = nil
+		if err := tx.Save(role).Error; err != nil {
+			return err
+		}
+
+		// Query : Replace Permission
+		if err := tx.Where("role_id = ?", id).Delete(&entity.RolePermission{}).Error; err != nil {
+			return err
+		}
+		role.Permissions = buildRolePermission(id, permissions)
+		if len(role.Permissions) > 0 {
+			if err := tx.Create(&role.Permissions).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *roleRepository) DeleteById(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Delete Permission & Account Role
+		if err := tx.Where("role_id = ?", id).Delete(&entity.RolePermission{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("role_id = ?", id).Delete(&entity.AccountRole{}).Error; err != nil {
+			return err
+		}
+
+		// Query : Delete Role
+		result := tx.Where("id = ?", id).Delete(&entity.Role{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("role not found")
+		}
+
+		return nil
+	})
+}
+
+func (r *roleRepository) AssignAccountRole(accountRole *entity.AccountRole) error {
+	accountRole.CreatedAt = time.Now()
+
+	// Query : One Role Per Account
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role_id", "account_type", "created_at"}),
+	}).Create(accountRole).Error
+}
+
+func (r *roleRepository) DeleteAccountRoleByAccountId(accountId uuid.UUID) error {
+	// Query
+	result := r.db.Where("account_id = ?", accountId).Delete(&entity.AccountRole{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("account does not have a custom role")
+	}
+
+	return nil
+}
+
+// For Seeder, Give Every Permission Even If It Was Removed Before
+func (r *roleRepository) SyncPermissionByRoleName(roleName string, permissions []string) error {
+	return r.syncPermissionByRoleName(roleName, permissions, false)
+}
+
+// For Seeder, Only Give Permission That Was Never Seeded Before, So Permission Removed By Admin Stay Removed
+func (r *roleRepository) SyncNewPermissionByRoleName(roleName string, permissions []string) error {
+	return r.syncPermissionByRoleName(roleName, permissions, true)
+}
+
+func (r *roleRepository) syncPermissionByRoleName(roleName string, permissions []string, skipSeeded bool) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Create System Role If Not Exist
+		var role entity.Role
+		err := tx.Where("role_name = ?", roleName).First(&role).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			role = entity.Role{
+				ID:        uuid.New(),
+				RoleName:  roleName,
+				IsSystem:  true,
+				CreatedAt: time.Now(),
+			}
+			if err := tx.Create(&role).Error; err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+
+		// Query : Find Permission Seeded Before
+		var seededPermissions []string
+		if err := tx.Model(&entity.RoleSeededPermission{}).Where("role_id = ?", role.ID).Pluck("permission", &seededPermissions).Error; err != nil {
+			return err
+		}
+		seeded := make(map[string]bool, len(seededPermissions))
+		for _, permission := range seededPermissions {
+			seeded[permission] = true
+		}
+
+		for _, rp := range buildRolePermission(role.ID, permissions) {
+			if skipSeeded && seeded[rp.Permission] {
+				continue
+			}
+
+			// Query : Add Missing Permission
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rp).Error; err != nil {
+				return err
+			}
+
+			// Query : Remember The Seeded Permission
+			if !seeded[rp.Permission] {
+				seededPermission := entity.RoleSeededPermission{
+					ID:         uuid.New(),
+					RoleId:     role.ID,
+					Permission: rp.Permission,
+				}
+				if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&seededPermission).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+func buildRolePermission(roleId uuid.UUID, permissions []string) []entity.RolePermission {
+	rolePermissions := make([]entity.RolePermission, 0, len(permissions))
+	for _, permission := range permissions {
+		rolePermissions = append(rolePermissions, entity.RolePermission{
+			ID:         uuid.New(),
+			RoleId:     roleId,
+			Permission: permission,
+		})
+	}
+
+	return rolePermissions
+}
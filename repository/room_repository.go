@@ -6,6 +6,7 @@ import (
 	"pelita/entity"
 	"pelita/utils"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -14,13 +15,21 @@ import (
 // Room Interface
 type RoomRepository interface {
 	FindAll(pagination utils.Pagination) ([]entity.Room, int64, error)
+	FindById(id uuid.UUID) (*entity.Room, error)
+	FindDeleted() ([]entity.Room, error)
+	FindAllDeletedIdBefore(before time.Time) ([]uuid.UUID, error)
 	Create(room *entity.Room) error
-	DeleteById(id uuid.UUID) error
+	FindAllStoredFileByDeletedId(id uuid.UUID) ([]string, error)
+	FindDeleteDependencyById(id uuid.UUID) ([]entity.DeleteDependency, error)
+	HardDeleteById(id, archivedBy uuid.UUID) error
+	SoftDeleteById(id uuid.UUID) error
+	RecoverDeletedById(id uuid.UUID) error
 	UpdateById(room *entity.Room, id uuid.UUID) error
 	FindByRoomNameAndFloor(roomName, floor string) (*entity.Room, error)
 	FindByRoomNameFloorAndId(roomName, floor string, id uuid.UUID) (*entity.Room, error)
-	FindRoomAssetByFloorAndRoomName(floor, roomName string) ([]entity.RoomAsset, error)
-	FindRoomAssetShortByFloorAndRoomName(floor, roomName string) ([]entity.RoomAssetShort, error)
+	FindRoomAssetByFloorAndRoomName(floor, roomName string, scope *entity.AccessScope) ([]entity.RoomAsset, error)
+	FindRoomAssetShortByFloorAndRoomName(floor, roomName string, scope *entity.AccessScope) ([]entity.RoomAssetShort, error)
+	IsInScopeById(id uuid.UUID, scope *entity.AccessScope) (bool, error)
 
 	// For Seeder
 	DeleteAll() error
@@ -45,10 +54,11 @@ func (r *roomRepository) FindAll(pagination utils.Pagination) ([]entity.Room, in
 
 	// Pagination
 	offset := (pagination.Page - 1) * pagination.Limit
-	r.db.Model(&entity.Room{}).Count(&total)
+	r.db.Model(&entity.Room{}).Where("deleted_at is null").Count(&total)
 
 	// Query
-	err := r.db.Order("floor ASC").
+	err := r.db.Where("deleted_at is null").
+		Order("floor ASC").
 		Order("room_name ASC").
 		Limit(pagination.Limit).
 		Offset(offset).
@@ -61,7 +71,7 @@ func (r *roomRepository) FindAll(pagination utils.Pagination) ([]entity.Room, in
 	return room, total, nil
 }
 
-func (r *roomRepository) FindRoomAssetByFloorAndRoomName(floor, roomName string) ([]entity.RoomAsset, error) {
+func (r *roomRepository) FindRoomAssetByFloorAndRoomName(floor, roomName string, scope *entity.AccessScope) ([]entity.RoomAsset, error) {
 	// Models
 	var roomAsset []entity.RoomAsset
 	roomName = strings.ToLower(roomName)
@@ -76,13 +86,16 @@ func (r *roomRepository) FindRoomAssetByFloorAndRoomName(floor, roomName string)
 
 	query := r.db.Table("rooms").
 		Select(fmt.Sprintf(`floor, %s, room_dept, asset_name, assets.asset_desc, SUM(asset_qty) as total_asset, asset_merk, asset_category`, roomNameSelect)).
-		Joins("JOIN asset_placements ON asset_placements.room_id = rooms.id").
+		Joins("JOIN asset_placements ON asset_placements.room_id = rooms.id AND asset_placements.deleted_at is null").
 		Joins("JOIN assets ON assets.id = asset_placements.asset_id").
-		Where("floor = ?", floor)
+		Where("floor = ? AND rooms.deleted_at is null", floor)
 
 	if roomName != "all" {
 		query = query.Where("room_name = ?", roomName)
 	}
+	if scope != nil {
+		query = query.Where("rooms.id IN (?)", findScopedRoomId(r.db, scope))
+	}
 
 	result := query.Group("assets.id").
 		Order("assets.asset_name ASC").
@@ -99,7 +112,7 @@ func (r *roomRepository) FindRoomAssetByFloorAndRoomName(floor, roomName string)
 	return roomAsset, nil
 }
 
-func (r *roomRepository) FindRoomAssetShortByFloorAndRoomName(floor, roomName string) ([]entity.RoomAssetShort, error) {
+func (r *roomRepository) FindRoomAssetShortByFloorAndRoomName(floor, roomName string, scope *entity.AccessScope) ([]entity.RoomAssetShort, error) {
 	// Models
 	var roomAsset []entity.RoomAssetShort
 	roomName = strings.ToLower(roomName)
@@ -114,13 +127,16 @@ func (r *roomRepository) FindRoomAssetShortByFloorAndRoomName(floor, roomName st
 
 	query := r.db.Table("rooms").
 		Select(fmt.Sprintf(`floor, %s, room_dept, asset_name, asset_category`, roomNameSelect)).
-		Joins("JOIN asset_placements ON asset_placements.room_id = rooms.id").
+		Joins("JOIN asset_placements ON asset_placements.room_id = rooms.id AND asset_placements.deleted_at is null").
 		Joins("JOIN assets ON assets.id = asset_placements.asset_id").
-		Where("floor = ?", floor)
+		Where("floor = ? AND rooms.deleted_at is null", floor)
 
 	if roomName != "all" {
 		query = query.Where("room_name = ?", roomName)
 	}
+	if scope != nil {
+		query = query.Where("rooms.id IN (?)", findScopedRoomId(r.db, scope))
+	}
 
 	result := query.Group("assets.id").
 		Order("assets.asset_name ASC").
@@ -142,7 +158,7 @@ func (r *roomRepository) FindByRoomNameAndFloor(roomName, floor string) (*entity
 	var room entity.Room
 
 	// Query
-	err := r.db.Where("room_name = ? AND floor = ?", roomName, floor).First(&room).Error
+	err := r.db.Where("room_name = ? AND floor = ? AND deleted_at is null", roomName, floor).First(&room).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -155,7 +171,7 @@ func (r *roomRepository) FindByRoomNameFloorAndId(roomName, floor string, id uui
 	var room entity.Room
 
 	// Query
-	err := r.db.Where("room_name = ? AND floor = ? AND id != ?", roomName, floor, id).First(&room).Error
+	err := r.db.Where("room_name = ? AND floor = ? AND id != ? AND deleted_at is null", roomName, floor, id).First(&room).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -163,6 +179,43 @@ func (r *roomRepository) FindByRoomNameFloorAndId(roomName, floor string, id uui
 	return &room, err
 }
 
+func (r *roomRepository) FindById(id uuid.UUID) (*entity.Room, error) {
+	// Models
+	var room entity.Room
+
+	// Query
+	err := r.db.Where("id = ? AND deleted_at is null", id).First(&room).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &room, err
+}
+
+func (r *roomRepository) FindDeleted() ([]entity.Room, error) {
+	// Models
+	var room []entity.Room
+
+	// Query
+	result := r.db.Order("deleted_at DESC").
+		Where("deleted_at is not null").
+		Find(&room)
+
+	// Response
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) || len(room) == 0 {
+		return nil, errors.New("deleted room not found")
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return room, nil
+}
+
+func (r *roomRepository) FindAllDeletedIdBefore(before time.Time) ([]uuid.UUID, error) {
+	return findDeletedIdBefore(r.db, "rooms", before)
+}
+
 func (r *roomRepository) Create(room *entity.Room) error {
 	room.ID = uuid.New()
 
@@ -173,7 +226,7 @@ func (r *roomRepository) Create(room *entity.Room) error {
 func (r *roomRepository) UpdateById(room *entity.Room, id uuid.UUID) error {
 	// Query : Check Old Room
 	var existingRoom entity.Room
-	if err := r.db.First(&existingRoom, "id = ?", id).Error; err != nil {
+	if err := r.db.First(&existingRoom, "id = ? AND deleted_at is null", id).Error; err != nil {
 		return err
 	}
 
@@ -188,13 +241,80 @@ func (r *roomRepository) UpdateById(room *entity.Room, id uuid.UUID) error {
 	return nil
 }
 
-func (r *roomRepository) DeleteById(id uuid.UUID) error {
-	// Models
-	var room entity.Room
+func (r *roomRepository) FindAllStoredFileByDeletedId(id uuid.UUID) ([]string, error) {
+	// Query : Finding Image That Go Along With A Permanently Deleted Room
+	files, err := findDeletedFindingImage(r.db, roomDeleteDependency, id)
+	if err != nil {
+		return nil, err
+	}
 
-	// Query
-	err := r.db.Unscoped().Where("id = ?", id).Delete(&room).Error
+	return files, nil
+}
+
+func (r *roomRepository) FindDeleteDependencyById(id uuid.UUID) ([]entity.DeleteDependency, error) {
+	// Query : Every Row That Is Cascaded Along With The Room
+	dependencies, err := findDeleteDependency(r.db, roomDeleteDependency, id)
 	if err != nil {
+		return nil, err
+	}
+
+	return dependencies, nil
+}
+
+func (r *roomRepository) HardDeleteById(id, archivedBy uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Archive And Delete
+		return deleteWithArchive(tx, roomDeleteDependency, id, archivedBy)
+	})
+}
+
+func (r *roomRepository) SoftDeleteById(id uuid.UUID) error {
+	// Query : Check Old Room
+	var existingRoom entity.Room
+	if err := r.db.First(&existingRoom, "id = ? AND deleted_at is null", id).Error; err != nil {
+		return err
+	}
+
+	// Query : Room Still Holding An Asset Can't Be Deleted
+	var total int64
+	if err := r.db.Model(&entity.AssetPlacement{}).Where("room_id = ? AND deleted_at is null", id).Count(&total).Error; err != nil {
+		return err
+	}
+	if total > 0 {
+		return errors.New("room still has asset placement, move or delete it first")
+	}
+	now := time.Now()
+
+	// Query : Update
+	existingRoom.DeletedAt = &now
+
+	if err := r.db.Save(&existingRoom).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *roomRepository) RecoverDeletedById(id uuid.UUID) error {
+	// Query : Check Old Room
+	var existingRoom entity.Room
+	if err := r.db.First(&existingRoom, "id = ? AND deleted_at is not null", id).Error; err != nil {
+		return err
+	}
+
+	// Query : Room Name Must Still Be Unique On The Floor
+	var total int64
+	if err := r.db.Model(&entity.Room{}).Where("room_name = ? AND floor = ? AND deleted_at is null", existingRoom.RoomName, existingRoom.Floor).Count(&total).Error; err != nil {
+		return err
+	}
+	if total > 0 {
+		return errors.New("room with the same name already exist on the floor")
+	}
+
+	// Query : Update
+	existingRoom.DeletedAt = nil
+
+	if err := r.db.Save(&existingRoom).Error; err != nil {
 		return err
 	}
 
@@ -208,7 +328,7 @@ func (r *roomRepository) DeleteAll() error {
 func (r *roomRepository) FindOneRandom() (*entity.Room, error) {
 	var room entity.Room
 
-	err := r.db.Order("RAND()").Limit(1).First(&room).Error
+	err := r.db.Where("deleted_at is null").Order("RAND()").Limit(1).First(&room).Error
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
@@ -216,3 +336,7 @@ func (r *roomRepository) FindOneRandom() (*entity.Room, error) {
 
 	return &room, err
 }
+
+func (r *roomRepository) IsInScopeById(id uuid.UUID, scope *entity.AccessScope) (bool, error) {
+	return isInScope(r.db, scope, &entity.Room{}, "id IN (?)", id)
+}
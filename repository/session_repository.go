@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"pelita/config"
+	"pelita/entity"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const rotatedRefreshTokenPrefix = "rotated:"
+
+// Old Refresh Token Is Swapped Only While It Still Point To The Session, So Only One Concurrent Refresh Can Win
+var rotateRefreshTokenScript = redis.NewScript(`
+if redis.call("GET", KEYS[2]) ~= ARGV[1] then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[3], "PX", ARGV[4])
+redis.call("SET", KEYS[2], ARGV[2], "PX", ARGV[4])
+redis.call("SET", KEYS[3], ARGV[1], "PX", ARGV[4])
+return 1
+`)
+
+// Session Interface
+type SessionRepository interface {
+	Create(session *entity.Session, refreshTokenHash string, ttl time.Duration) error
+	FindById(id uuid.UUID) (*entity.Session, error)
+	FindByRefreshTokenHash(refreshTokenHash string) (*entity.Session, bool, error)
+	FindByAccountId(accountId uuid.UUID) ([]entity.Session, error)
+	Rotate(session *entity.Session, oldRefreshTokenHash, newRefreshTokenHash string, ttl time.Duration) (bool, error)
+	DeleteById(id uuid.UUID) error
+	DeleteByAccountId(accountId uuid.UUID) error
+	BlacklistAccountById(accountId uuid.UUID, ttl time.Duration) error
+}
+
+// Session Struct
+type sessionRepository struct {
+	redisClient *redis.Client
+}
+
+// Session Constructor
+func NewSessionRepository(redisClient *redis.Client) SessionRepository {
+	return &sessionRepository{redisClient: redisClient}
+}
+
+func (r *sessionRepository) Create(session *entity.Session, refreshTokenHash string, ttl time.Duration) error {
+	ctx := context.Background()
+
+	payload, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	// Query
+	pipe := r.redisClient.TxPipeline()
+	pipe.Set(ctx, fmt.Sprintf(config.RedisKeySession, session.ID), payload, ttl)
+	pipe.Set(ctx, fmt.Sprintf(config.RedisKeyRefreshToken, refreshTokenHash), session.ID.String(), ttl)
+	pipe.SAdd(ctx, fmt.Sprintf(config.RedisKeyAccountSession, session.AccountId), session.ID.String())
+	_, err = pipe.Exec(ctx)
+
+	return err
+}
+
+func (r *sessionRepository) FindById(id uuid.UUID) (*entity.Session, error) {
+	// Models
+	var session entity.Session
+
+	// Query
+	payload, err := r.redisClient.Get(context.Background(), fmt.Sprintf(config.RedisKeySession, id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(payload, &session); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (r *sessionRepository) FindByRefreshTokenHash(refreshTokenHash string) (*entity.Session, bool, error) {
+	// Query
+	val, err := r.redisClient.Get(context.Background(), fmt.Sprintf(config.RedisKeyRefreshToken, refreshTokenHash)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Check If Refresh Token Has Been Rotated Before
+	isRotated := strings.HasPrefix(val, rotatedRefreshTokenPrefix)
+	sessionId, err := uuid.Parse(strings.TrimPrefix(val, rotatedRefreshTokenPrefix))
+	if err != nil {
+		return nil, false, err
+	}
+
+	session, err := r.FindById(sessionId)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return session, isRotated, nil
+}
+
+func (r *sessionRepository) FindByAccountId(accountId uuid.UUID) ([]entity.Session, error) {
+	ctx := context.Background()
+	accountKey := fmt.Sprintf(config.RedisKeyAccountSession, accountId)
+
+	// Models
+	var sessions []entity.Session
+
+	// Query
+	sessionIds, err := r.redisClient.SMembers(ctx, accountKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sessionId := range sessionIds {
+		id, err := uuid.Parse(sessionId)
+		if err != nil {
+			continue
+		}
+
+		session, err := r.FindById(id)
+		if err != nil {
+			return nil, err
+		}
+
+		// Clean Up Expired Session
+		if session == nil {
+			r.redisClient.SRem(ctx, accountKey, sessionId)
+			continue
+		}
+
+		sessions = append(sessions, *session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt)
+	})
+
+	return sessions, nil
+}
+
+// False Means The Old Refresh Token Has Already Been Rotated By Another Request
+func (r *sessionRepository) Rotate(session *entity.Session, oldRefreshTokenHash, newRefreshTokenHash string, ttl time.Duration) (bool, error) {
+	ctx := context.Background()
+
+	payload, err := json.Marshal(session)
+	if err != nil {
+		return false, err
+	}
+
+	// Query : Keep The Old Refresh Token To Detect Reuse
+	keys := []string{
+		fmt.Sprintf(config.RedisKeySession, session.ID),
+		fmt.Sprintf(config.RedisKeyRefreshToken, oldRefreshTokenHash),
+		fmt.Sprintf(config.RedisKeyRefreshToken, newRefreshTokenHash),
+	}
+	swapped, err := rotateRefreshTokenScript.Run(ctx, r.redisClient, keys, session.ID.String(), rotatedRefreshTokenPrefix+session.ID.String(), payload, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+
+	return swapped == 1, nil
+}
+
+func (r *sessionRepository) DeleteById(id uuid.UUID) error {
+	ctx := context.Background()
+
+	// Query : Check Old Session
+	session, err := r.FindById(id)
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return errors.New("session not found")
+	}
+
+	// Query : Delete
+	pipe := r.redisClient.TxPipeline()
+	pipe.Del(ctx, fmt.Sprintf(config.RedisKeySession, id))
+	pipe.SRem(ctx, fmt.Sprintf(config.RedisKeyAccountSession, session.AccountId), id.String())
+	_, err = pipe.Exec(ctx)
+
+	return err
+}
+
+func (r *sessionRepository) DeleteByAccountId(accountId uuid.UUID) error {
+	ctx := context.Background()
+	accountKey := fmt.Sprintf(config.RedisKeyAccountSession, accountId)
+
+	// Query
+	sessionIds, err := r.redisClient.SMembers(ctx, accountKey).Result()
+	if err != nil {
+		return err
+	}
+
+	pipe := r.redisClient.TxPipeline()
+	for _, sessionId := range sessionIds {
+		pipe.Del(ctx, fmt.Sprintf(config.RedisKeySession, sessionId))
+	}
+	pipe.Del(ctx, accountKey)
+	_, err = pipe.Exec(ctx)
+
+	return err
+}
+
+func (r *sessionRepository) BlacklistAccountById(accountId uuid.UUID, ttl time.Duration) error {
+	// Query : Every Token Issued Before Or At This Time (In Millisecond) Is Rejected
+	return r.redisClient.Set(context.Background(), fmt.Sprintf(config.RedisKeyRevokedAccount, accountId), time.Now().UnixMilli(), ttl).Err()
+}
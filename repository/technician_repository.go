@@ -2,12 +2,15 @@ package repository
 
 import (
 	"errors"
+	"time"
 
+	"pelita/config"
 	"pelita/entity"
 	"pelita/utils"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Technician Interface
@@ -16,9 +19,17 @@ type TechnicianRepository interface {
 	FindByEmailAndId(email string, id uuid.UUID) (*entity.Technician, error)
 	FindById(id uuid.UUID) (*entity.Technician, error)
 	FindAll(pagination utils.Pagination) ([]entity.Technician, int64, error)
+	FindDeleted() ([]entity.Technician, error)
+	FindAllDeletedIdBefore(before time.Time) ([]uuid.UUID, error)
+	FindAllStoredFileByDeletedId(id uuid.UUID) ([]string, error)
+	FindDeleteDependencyById(id uuid.UUID) ([]entity.DeleteDependency, error)
 	Create(technician *entity.Technician, adminId uuid.UUID) error
-	DeleteById(id uuid.UUID) error
+	HardDeleteById(id, archivedBy uuid.UUID) error
+	SoftDeleteById(id uuid.UUID) error
+	RecoverDeletedById(id uuid.UUID) error
 	UpdateById(technician *entity.Technician, adminId uuid.UUID) error
+	FindScopeById(id uuid.UUID) (*entity.AccessScope, error)
+	UpdateScopeById(scope *entity.AccessScope, id uuid.UUID) error
 
 	// For Seeder
 	DeleteAll() error
@@ -40,7 +51,7 @@ func (r *technicianRepository) FindByEmail(email string) (*entity.Technician, er
 	var technician entity.Technician
 
 	// Query
-	err := r.db.Where("email = ?", email).First(&technician).Error
+	err := r.db.Where("email = ? AND deleted_at is null", email).First(&technician).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -53,7 +64,7 @@ func (r *technicianRepository) FindById(id uuid.UUID) (*entity.Technician, error
 	var technician entity.Technician
 
 	// Query
-	err := r.db.Where("id = ?", id).First(&technician).Error
+	err := r.db.Where("id = ? AND deleted_at is null", id).First(&technician).Error
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
@@ -83,10 +94,11 @@ func (r *technicianRepository) FindAll(pagination utils.Pagination) ([]entity.Te
 
 	// Pagination
 	offset := (pagination.Page - 1) * pagination.Limit
-	r.db.Model(&entity.Technician{}).Count(&total)
+	r.db.Model(&entity.Technician{}).Where("deleted_at is null").Count(&total)
 
 	// Query
-	err := r.db.Order("created_at DESC").
+	err := r.db.Where("deleted_at is null").
+		Order("created_at DESC").
 		Limit(pagination.Limit).
 		Offset(offset).
 		Find(&technician).Error
@@ -98,18 +110,69 @@ func (r *technicianRepository) FindAll(pagination utils.Pagination) ([]entity.Te
 	return technician, total, nil
 }
 
+func (r *technicianRepository) FindDeleted() ([]entity.Technician, error) {
+	// Models
+	var technician []entity.Technician
+
+	// Query
+	result := r.db.Order("deleted_at DESC").
+		Where("deleted_at is not null").
+		Find(&technician)
+
+	// Response
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) || len(technician) == 0 {
+		return nil, errors.New("deleted technician not found")
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return technician, nil
+}
+
+func (r *technicianRepository) FindAllDeletedIdBefore(before time.Time) ([]uuid.UUID, error) {
+	return findDeletedIdBefore(r.db, "technicians", before)
+}
+
+func (r *technicianRepository) FindAllStoredFileByDeletedId(id uuid.UUID) ([]string, error) {
+	// Query : Finding Image That Go Along With A Permanently Deleted Technician
+	files, err := findDeletedFindingImage(r.db, technicianDeleteDependency, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func (r *technicianRepository) FindDeleteDependencyById(id uuid.UUID) ([]entity.DeleteDependency, error) {
+	// Query : Every Row That Is Cascaded Along With The Technician
+	dependencies, err := findDeleteDependency(r.db, technicianDeleteDependency, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dependencies, nil
+}
+
 func (r *technicianRepository) Create(technician *entity.Technician, adminId uuid.UUID) error {
 	technician.ID = uuid.New()
 	technician.CreatedBy = adminId
 
-	// Query
-	return r.db.Create(technician).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Reserve Email
+		if err := reserveAccountEmail(tx, technician.ID, "technician", technician.Email); err != nil {
+			return err
+		}
+
+		// Query
+		return tx.Create(technician).Error
+	})
 }
 
 func (r *technicianRepository) UpdateById(technician *entity.Technician, id uuid.UUID) error {
 	// Query : Check Old Technician
 	var existingTechnician entity.Technician
-	if err := r.db.First(&existingTechnician, "id = ?", id).Error; err != nil {
+	if err := r.db.First(&existingTechnician, "id = ? AND deleted_at is null", id).Error; err != nil {
 		return err
 	}
 
@@ -118,34 +181,156 @@ func (r *technicianRepository) UpdateById(technician *entity.Technician, id uuid
 	technician.CreatedAt = existingTechnician.CreatedAt
 	technician.CreatedBy = existingTechnician.CreatedBy
 
-	if err := r.db.Save(&technician).Error; err != nil {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&technician).Error; err != nil {
+			return err
+		}
+
+		// Query : Reserve Email
+		return reserveAccountEmail(tx, id, "technician", technician.Email)
+	})
+}
+
+func (r *technicianRepository) HardDeleteById(id, archivedBy uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Release Email
+		if err := releaseAccountEmail(tx, id); err != nil {
+			return err
+		}
+
+		// Query : Archive And Delete
+		return deleteWithArchive(tx, technicianDeleteDependency, id, archivedBy)
+	})
+}
+
+func (r *technicianRepository) SoftDeleteById(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Check Old Technician
+		var existingTechnician entity.Technician
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&existingTechnician, "id = ? AND deleted_at is null", id).Error
+		if err != nil {
+			return err
+		}
+
+		// Query : Technician Still Owning A Placement, Doing A Maintenance, Or Holding A Loan Can't Be Deleted
+		var total int64
+		if err := tx.Model(&entity.AssetPlacement{}).Where("asset_owner = ? AND deleted_at is null", id).Count(&total).Error; err != nil {
+			return err
+		}
+		if total > 0 {
+			return errors.New("technician still owns asset placement, hand it over or delete it first")
+		}
+		if err := tx.Model(&entity.AssetMaintenance{}).Where("maintenance_by = ? AND deleted_at is null", id).Count(&total).Error; err != nil {
+			return err
+		}
+		if total > 0 {
+			return errors.New("technician still has asset maintenance, reassign or delete it first")
+		}
+		err = tx.Model(&entity.AssetLoan{}).
+			Where("borrower_id = ? AND borrower_type = ? AND loan_status IN ?", id, config.RoleTechnician, []string{"approved", "checked-out"}).
+			Count(&total).Error
+		if err != nil {
+			return err
+		}
+		if total > 0 {
+			return errors.New("technician still has active asset loan, return it first")
+		}
+		now := time.Now()
+
+		// Query : Update
+		existingTechnician.DeletedAt = &now
+
+		return tx.Save(&existingTechnician).Error
+	})
+}
+
+func (r *technicianRepository) RecoverDeletedById(id uuid.UUID) error {
+	// Query : Check Old Technician
+	var existingTechnician entity.Technician
+	if err := r.db.First(&existingTechnician, "id = ? AND deleted_at is not null", id).Error; err != nil {
+		return err
+	}
+
+	// Query : Update
+	existingTechnician.DeletedAt = nil
+
+	if err := r.db.Save(&existingTechnician).Error; err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (r *technicianRepository) DeleteById(id uuid.UUID) error {
+func (r *technicianRepository) FindScopeById(id uuid.UUID) (*entity.AccessScope, error) {
 	// Models
-	var technician entity.Technician
+	var technicianScopes []entity.TechnicianScope
+	scope := entity.AccessScope{
+		Departments: []string{},
+		Floors:      []string{},
+	}
 
 	// Query
-	err := r.db.Unscoped().Where("id = ?", id).Delete(&technician).Error
+	err := r.db.Where("technician_id = ?", id).
+		Order("scope_type ASC").
+		Order("scope_value ASC").
+		Find(&technicianScopes).Error
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	for _, dt := range technicianScopes {
+		switch dt.ScopeType {
+		case "department":
+			scope.Departments = append(scope.Departments, dt.ScopeValue)
+		case "floor":
+			scope.Floors = append(scope.Floors, dt.ScopeValue)
+		}
+	}
+
+	return &scope, nil
+}
+
+func (r *technicianRepository) UpdateScopeById(scope *entity.AccessScope, id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Delete Old Scope
+		if err := tx.Where("technician_id = ?", id).Delete(&entity.TechnicianScope{}).Error; err != nil {
+			return err
+		}
+
+		// Query : Create New Scope
+		var technicianScopes []entity.TechnicianScope
+		for scopeType, values := range map[string][]string{"department": scope.Departments, "floor": scope.Floors} {
+			for _, value := range values {
+				technicianScopes = append(technicianScopes, entity.TechnicianScope{
+					ID:           uuid.New(),
+					ScopeType:    scopeType,
+					ScopeValue:   value,
+					CreatedAt:    time.Now(),
+					TechnicianId: id,
+				})
+			}
+		}
+		if len(technicianScopes) == 0 {
+			return nil
+		}
+
+		return tx.Create(&technicianScopes).Error
+	})
 }
 
 // For Seeder
 func (r *technicianRepository) DeleteAll() error {
+	if err := r.db.Where("role = ?", "technician").Delete(&entity.AccountEmail{}).Error; err != nil {
+		return err
+	}
+
 	return r.db.Where("1 = 1").Delete(&entity.Technician{}).Error
 }
 func (r *technicianRepository) FindOneRandom() (*entity.Technician, error) {
 	var technician entity.Technician
 
-	err := r.db.Order("RAND()").Limit(1).First(&technician).Error
+	err := r.db.Where("deleted_at is null").Order("RAND()").Limit(1).First(&technician).Error
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
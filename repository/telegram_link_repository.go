@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"pelita/config"
+	"pelita/entity"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Telegram Link Interface
+type TelegramLinkRepository interface {
+	Create(telegramLink *entity.TelegramLink, code string, ttl time.Duration) error
+	ConsumeByCode(code string) (*entity.TelegramLink, error)
+}
+
+// Telegram Link Struct
+type telegramLinkRepository struct {
+	redisClient *redis.Client
+}
+
+// Telegram Link Constructor
+func NewTelegramLinkRepository(redisClient *redis.Client) TelegramLinkRepository {
+	return &telegramLinkRepository{redisClient: redisClient}
+}
+
+func (r *telegramLinkRepository) Create(telegramLink *entity.TelegramLink, code string, ttl time.Duration) error {
+	payload, err := json.Marshal(telegramLink)
+	if err != nil {
+		return err
+	}
+
+	// Query
+	return r.redisClient.Set(context.Background(), fmt.Sprintf(config.RedisKeyTelegramLink, code), payload, ttl).Err()
+}
+
+func (r *telegramLinkRepository) ConsumeByCode(code string) (*entity.TelegramLink, error) {
+	// Models
+	var telegramLink entity.TelegramLink
+
+	// Query : Get And Delete So The Code Only Usable Once
+	payload, err := r.redisClient.GetDel(context.Background(), fmt.Sprintf(config.RedisKeyTelegramLink, code)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(payload, &telegramLink); err != nil {
+		return nil, err
+	}
+
+	return &telegramLink, nil
+}
@@ -15,8 +15,19 @@ import (
 type UserRepository interface {
 	FindByUsernameOrEmail(username, email string) (*entity.User, error)
 	FindByEmail(email string) (*entity.User, error)
+	FindByUsernameAndId(username string, id uuid.UUID) (*entity.User, error)
+	IsEmailUsedByOtherAccount(email string, id uuid.UUID) (bool, error)
+	IsTelegramUserIdUsedByOtherAccount(telegramUserId string, id uuid.UUID) (bool, error)
+	SyncAccountEmail() error
+	FindAllEmailCollision() ([]entity.EmailCollision, error)
 	FindById(id, role string) (*entity.MyProfile, error)
+	FindAccountById(id uuid.UUID, role string) (entity.Account, error)
 	Create(user *entity.User) error
+	UpdatePasswordById(id uuid.UUID, role, password string) error
+	UpdateTelegramById(id uuid.UUID, role string, telegramUserId *string, telegramIsValid bool) error
+	LinkTelegramById(id uuid.UUID, role, telegramUserId string) error
+	UpdateProfileById(id uuid.UUID, role, username string, telegramUserId *string, telegramIsValid bool) error
+	UpdateEmailById(id uuid.UUID, role, email string) error
 
 	// For Seeder
 	DeleteAll() error
@@ -59,16 +70,99 @@ func (r *userRepository) FindByEmail(email string) (*entity.User, error) {
 	return &user, err
 }
 
+func (r *userRepository) FindByUsernameAndId(username string, id uuid.UUID) (*entity.User, error) {
+	// Models
+	var user entity.User
+
+	// Query
+	err := r.db.Where("username = ? AND id != ?", username, id).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &user, err
+}
+
+func (r *userRepository) IsEmailUsedByOtherAccount(email string, id uuid.UUID) (bool, error) {
+	// Query : Login Find Account By Email Across Every Role Table
+	for _, role := range []string{"admin", "technician", "guest"} {
+		var total int64
+		err := r.db.Table(accountTableName(role)).
+			Where("LOWER(email) = LOWER(?) AND id != ?", email, id).
+			Count(&total).Error
+		if err != nil {
+			return false, err
+		}
+		if total > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *userRepository) IsTelegramUserIdUsedByOtherAccount(telegramUserId string, id uuid.UUID) (bool, error) {
+	// Query : Reminder Is Sent By Telegram User Id Across Every Role Table
+	for _, role := range []string{"admin", "technician", "guest"} {
+		var total int64
+		err := r.db.Table(accountTableName(role)).
+			Where("telegram_user_id = ? AND id != ?", telegramUserId, id).
+			Count(&total).Error
+		if err != nil {
+			return false, err
+		}
+		if total > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *userRepository) SyncAccountEmail() error {
+	// Query : Reserve Email Of Account Created Before The Registry, Colliding Email Is Skipped And Reported Instead
+	for _, role := range []string{"admin", "technician", "guest"} {
+		err := r.db.Exec(fmt.Sprintf(`INSERT IGNORE INTO account_emails (email, account_id, role)
+			SELECT LOWER(TRIM(email)), id, ? FROM %s
+			ORDER BY created_at ASC`, accountTableName(role)), role).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *userRepository) FindAllEmailCollision() ([]entity.EmailCollision, error) {
+	// Models
+	var collisions []entity.EmailCollision
+
+	// Query : Every Account Which Email Is Shared With Another Account
+	accounts := `SELECT email, 'admin' AS role, id, username, created_at FROM admins
+		UNION ALL SELECT email, 'technician' AS role, id, username, created_at FROM technicians
+		UNION ALL SELECT email, 'guest' AS role, id, username, created_at FROM users`
+	err := r.db.Raw(fmt.Sprintf(`SELECT accounts.email, accounts.role, accounts.id AS account_id, accounts.username, accounts.created_at
+		FROM (%s) AS accounts
+		WHERE LOWER(accounts.email) IN (
+			SELECT LOWER(duplicates.email) FROM (%s) AS duplicates
+			GROUP BY LOWER(duplicates.email)
+			HAVING COUNT(*) > 1
+		)
+		ORDER BY LOWER(accounts.email) ASC, accounts.created_at ASC`, accounts, accounts)).
+		Scan(&collisions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return collisions, nil
+}
+
 func (r *userRepository) FindById(id, role string) (*entity.MyProfile, error) {
 	// Models
 	var user entity.MyProfile
-	var tableName = fmt.Sprintf("%ss", role)
-	if role == "guest" {
-		tableName = "users"
-	}
 
 	// Query
-	err := r.db.Table(tableName).
+	err := r.db.Table(accountTableName(role)).
 		Select("username, email, telegram_is_valid, telegram_user_id, created_at").
 		Where("id = ?", id).
 		First(&user).Error
@@ -80,18 +174,158 @@ func (r *userRepository) FindById(id, role string) (*entity.MyProfile, error) {
 	return &user, err
 }
 
+func (r *userRepository) FindAccountById(id uuid.UUID, role string) (entity.Account, error) {
+	// Models
+	var account entity.Account
+	switch role {
+	case "admin":
+		account = &entity.Admin{}
+	case "technician":
+		account = &entity.Technician{}
+	case "guest":
+		account = &entity.User{}
+	default:
+		return nil, errors.New("role is not valid")
+	}
+
+	// Query
+	err := r.db.Where("id = ?", id).First(account).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
 func (r *userRepository) Create(user *entity.User) error {
 	user.ID = uuid.New()
 	user.CreatedAt = time.Now()
 	user.TelegramIsValid = false
 	user.ID = uuid.New()
 
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Reserve Email
+		if err := reserveAccountEmail(tx, user.ID, "guest", user.Email); err != nil {
+			return err
+		}
+
+		// Query
+		return tx.Create(user).Error
+	})
+}
+
+func (r *userRepository) UpdatePasswordById(id uuid.UUID, role, password string) error {
 	// Query
-	return r.db.Create(user).Error
+	result := r.db.Table(accountTableName(role)).
+		Where("id = ?", id).
+		Update("password", password)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("account not found")
+	}
+
+	return nil
+}
+
+func (r *userRepository) UpdateTelegramById(id uuid.UUID, role string, telegramUserId *string, telegramIsValid bool) error {
+	// Query
+	result := r.db.Table(accountTableName(role)).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"telegram_user_id":  telegramUserId,
+			"telegram_is_valid": telegramIsValid,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("account not found")
+	}
+
+	return nil
+}
+
+// Telegram Owner Proved By The Link Code Take The Telegram User Id Over From Any Other Account
+func (r *userRepository) LinkTelegramById(id uuid.UUID, role, telegramUserId string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Release Telegram User Id From Other Account
+		for _, otherRole := range []string{"admin", "technician", "guest"} {
+			query := tx.Table(accountTableName(otherRole)).Where("telegram_user_id = ?", telegramUserId)
+			if otherRole == role {
+				query = query.Where("id != ?", id)
+			}
+			err := query.Updates(map[string]interface{}{
+				"telegram_user_id":  nil,
+				"telegram_is_valid": false,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		// Query : Link Telegram User Id
+		result := tx.Table(accountTableName(role)).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"telegram_user_id":  telegramUserId,
+				"telegram_is_valid": true,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("account not found")
+		}
+
+		return nil
+	})
+}
+
+func (r *userRepository) UpdateProfileById(id uuid.UUID, role, username string, telegramUserId *string, telegramIsValid bool) error {
+	// Query
+	return r.db.Table(accountTableName(role)).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"username":          username,
+			"telegram_user_id":  telegramUserId,
+			"telegram_is_valid": telegramIsValid,
+		}).Error
+}
+
+func (r *userRepository) UpdateEmailById(id uuid.UUID, role, email string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query
+		err := tx.Table(accountTableName(role)).
+			Where("id = ?", id).
+			Update("email", email).Error
+		if err != nil {
+			return err
+		}
+
+		// Query : Reserve Email
+		return reserveAccountEmail(tx, id, role, email)
+	})
+}
+
+func accountTableName(role string) string {
+	if role == "guest" {
+		return "users"
+	}
+
+	return fmt.Sprintf("%ss", role)
 }
 
 // For Seeder
 func (r *userRepository) DeleteAll() error {
+	if err := r.db.Where("role = ?", "guest").Delete(&entity.AccountEmail{}).Error; err != nil {
+		return err
+	}
+
 	return r.db.Where("1 = 1").Delete(&entity.User{}).Error
 }
 func (r *userRepository) FindOneRandom() (*entity.User, error) {
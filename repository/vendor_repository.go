@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"errors"
+	"pelita/entity"
+	"pelita/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Vendor Interface
+type VendorRepository interface {
+	FindAll(pagination utils.Pagination) ([]entity.Vendor, int64, error)
+	FindById(id uuid.UUID) (*entity.Vendor, error)
+	FindByVendorName(vendorName string) (*entity.Vendor, error)
+	FindByVendorNameAndId(vendorName string, id uuid.UUID) (*entity.Vendor, error)
+	Create(vendor *entity.Vendor) error
+	UpdateById(vendor *entity.Vendor, id uuid.UUID) error
+	DeleteById(id uuid.UUID) error
+}
+
+// Vendor Struct
+type vendorRepository struct {
+	db *gorm.DB
+}
+
+// Vendor Constructor
+func NewVendorRepository(db *gorm.DB) VendorRepository {
+	return &vendorRepository{db: db}
+}
+
+func (r *vendorRepository) FindAll(pagination utils.Pagination) ([]entity.Vendor, int64, error) {
+	var total int64
+
+	// Models
+	var vendor []entity.Vendor
+
+	// Pagination
+	offset := (pagination.Page - 1) * pagination.Limit
+	r.db.Model(&entity.Vendor{}).Count(&total)
+
+	// Query
+	err := r.db.Order("vendor_name ASC").
+		Limit(pagination.Limit).
+		Offset(offset).
+		Find(&vendor).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return vendor, total, nil
+}
+
+func (r *vendorRepository) FindById(id uuid.UUID) (*entity.Vendor, error) {
+	// Models
+	var vendor entity.Vendor
+
+	// Query
+	err := r.db.Where("id = ?", id).First(&vendor).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &vendor, err
+}
+
+func (r *vendorRepository) FindByVendorName(vendorName string) (*entity.Vendor, error) {
+	// Models
+	var vendor entity.Vendor
+
+	// Query
+	err := r.db.Where("vendor_name = ?", vendorName).First(&vendor).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &vendor, err
+}
+
+func (r *vendorRepository) FindByVendorNameAndId(vendorName string, id uuid.UUID) (*entity.Vendor, error) {
+	// Models
+	var vendor entity.Vendor
+
+	// Query
+	err := r.db.Where("vendor_name = ? AND id != ?", vendorName, id).First(&vendor).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &vendor, err
+}
+
+func (r *vendorRepository) Create(vendor *entity.Vendor) error {
+	vendor.ID = uuid.New()
+	vendor.CreatedAt = time.Now()
+	vendor.UpdatedAt = nil
+
+	// Query
+	return r.db.Create(vendor).Error
+}
+
+func (r *vendorRepository) UpdateById(vendor *entity.Vendor, id uuid.UUID) error {
+	// Query : Check Old Vendor
+	var existingVendor entity.Vendor
+	if err := r.db.First(&existingVendor, "id = ?", id).Error; err != nil {
+		return err
+	}
+	now := time.Now()
+
+	// Query : Update
+	vendor.ID = id
+	vendor.CreatedAt = existingVendor.CreatedAt
+	vendor.UpdatedAt = &now
+
+	return r.db.Save(vendor).Error
+}
+
+func (r *vendorRepository) DeleteById(id uuid.UUID) error {
+	// Query
+	result := r.db.Where("id = ?", id).Delete(&entity.Vendor{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("vendor not found")
+	}
+
+	return nil
+}
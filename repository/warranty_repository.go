@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"errors"
+	"pelita/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Warranty Interface
+type WarrantyRepository interface {
+	FindAllByAssetId(id uuid.UUID) ([]entity.Warranty, error)
+	FindById(id uuid.UUID) (*entity.Warranty, error)
+	FindActiveByAssetPlacementId(at time.Time, id uuid.UUID) (*entity.Warranty, error)
+	FindAllUnremindedByEndDate(from, to time.Time) ([]entity.WarrantyReminder, error)
+	Create(warranty *entity.Warranty) error
+	UpdateById(warranty *entity.Warranty, id uuid.UUID) error
+	UpdateRemindedAtByIds(remindedAt time.Time, ids []uuid.UUID) error
+	DeleteById(id uuid.UUID) error
+}
+
+// Warranty Struct
+type warrantyRepository struct {
+	db *gorm.DB
+}
+
+// Warranty Constructor
+func NewWarrantyRepository(db *gorm.DB) WarrantyRepository {
+	return &warrantyRepository{db: db}
+}
+
+func (r *warrantyRepository) FindAllByAssetId(id uuid.UUID) ([]entity.Warranty, error) {
+	// Models
+	var warranty []entity.Warranty
+
+	// Query
+	err := r.db.Preload("Vendor").
+		Where("asset_id = ?", id).
+		Order("end_date DESC").
+		Find(&warranty).Error
+
+	return warranty, err
+}
+
+func (r *warrantyRepository) FindById(id uuid.UUID) (*entity.Warranty, error) {
+	// Models
+	var warranty entity.Warranty
+
+	// Query
+	err := r.db.Where("id = ?", id).First(&warranty).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &warranty, err
+}
+
+func (r *warrantyRepository) FindActiveByAssetPlacementId(at time.Time, id uuid.UUID) (*entity.Warranty, error) {
+	// Models
+	var warranty entity.Warranty
+	date := at.Format("2006-01-02")
+
+	// Query : The Longest Coverage Is Preferred When Several Warranty Overlap
+	err := r.db.Preload("Vendor").
+		Joins("JOIN asset_placements ON asset_placements.asset_id = warranties.asset_id").
+		Where("asset_placements.id = ? AND warranties.start_date <= ? AND warranties.end_date >= ?", id, date, date).
+		Order("warranties.end_date DESC").
+		First(&warranty).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &warranty, err
+}
+
+func (r *warrantyRepository) FindAllUnremindedByEndDate(from, to time.Time) ([]entity.WarrantyReminder, error) {
+	// Models
+	var warranty []entity.WarrantyReminder
+
+	// Query : Warranty Not Reminded Yet, So A Missed Day Or A Warranty Created Late Is Still Reminded Once
+	err := r.db.Table("warranties").
+		Select("warranties.id, assets.asset_name, warranties.warranty_type, warranties.end_date, vendors.vendor_name, COALESCE(warranties.contact_name, vendors.contact_name) as contact_name, COALESCE(warranties.contact_phone, vendors.contact_phone) as contact_phone").
+		Joins("JOIN assets ON assets.id = warranties.asset_id").
+		Joins("LEFT JOIN vendors ON vendors.id = warranties.vendor_id").
+		Where("assets.deleted_at is null AND warranties.reminded_at is null AND warranties.end_date BETWEEN ? AND ?", from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("warranties.end_date ASC, assets.asset_name ASC").
+		Scan(&warranty).Error
+
+	return warranty, err
+}
+
+func (r *warrantyRepository) Create(warranty *entity.Warranty) error {
+	warranty.ID = uuid.New()
+	warranty.CreatedAt = time.Now()
+	warranty.UpdatedAt = nil
+
+	// Query
+	return r.db.Omit("Vendor").Create(warranty).Error
+}
+
+func (r *warrantyRepository) UpdateById(warranty *entity.Warranty, id uuid.UUID) error {
+	// Query : Check Old Warranty
+	var existingWarranty entity.Warranty
+	if err := r.db.First(&existingWarranty, "id = ?", id).Error; err != nil {
+		return err
+	}
+	now := time.Now()
+
+	// Query : Update, Changed End Date Is Reminded Again
+	warranty.ID = id
+	warranty.RemindedAt = existingWarranty.RemindedAt
+	if warranty.EndDate.Format("2006-01-02") != existingWarranty.EndDate.Format("2006-01-02") {
+		warranty.RemindedAt = nil
+	}
+	warranty.CreatedAt = existingWarranty.CreatedAt
+	warranty.UpdatedAt = &now
+
+	return r.db.Omit("Vendor").Save(warranty).Error
+}
+
+func (r *warrantyRepository) UpdateRemindedAtByIds(remindedAt time.Time, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// Query
+	return r.db.Model(&entity.Warranty{}).Where("id IN ?", ids).Update("reminded_at", remindedAt).Error
+}
+
+func (r *warrantyRepository) DeleteById(id uuid.UUID) error {
+	// Query
+	result := r.db.Where("id = ?", id).Delete(&entity.Warranty{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("warranty not found")
+	}
+
+	return nil
+}
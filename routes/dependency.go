@@ -4,6 +4,7 @@ import (
 	"pelita/controller"
 	"pelita/repository"
 	"pelita/service"
+	"pelita/utils"
 
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
@@ -18,22 +19,53 @@ func SetUpDependency(r *gin.Engine, db *gorm.DB, redisClient *redis.Client) {
 	technicianRepo := repository.NewTechnicianRepository(db)
 	roomRepo := repository.NewRoomRepository(db)
 	assetRepo := repository.NewAssetRepository(db)
+	assetImageRepo := repository.NewAssetImageRepository(db)
+	assetCategoryRepo := repository.NewAssetCategoryRepository(db)
 	assetPlacementRepo := repository.NewAssetPlacementRepository(db)
 	assetMaintenanceRepo := repository.NewAssetMaintenanceRepository(db)
 	assetFindingRepo := repository.NewAssetFindingRepository(db)
+	assetLoanRepo := repository.NewAssetLoanRepository(db)
+	assetUnitRepo := repository.NewAssetUnitRepository(db)
+	assetTransferRepo := repository.NewAssetTransferRepository(db)
+	assetMovementRepo := repository.NewAssetMovementRepository(db)
+	vendorRepo := repository.NewVendorRepository(db)
+	warrantyRepo := repository.NewWarrantyRepository(db)
 	historyRepo := repository.NewHistoryRepository(db)
+	sessionRepo := repository.NewSessionRepository(redisClient)
+	passwordResetRepo := repository.NewPasswordResetRepository(redisClient)
+	emailChangeRepo := repository.NewEmailChangeRepository(redisClient)
+	loginAttemptRepo := repository.NewLoginAttemptRepository(redisClient)
+	roleRepo := repository.NewRoleRepository(db)
+	telegramLinkRepo := repository.NewTelegramLinkRepository(redisClient)
+	deleteConfirmRepo := repository.NewDeleteConfirmationRepository(redisClient)
+	apiKeyRepo := repository.NewApiKeyRepository(db)
+
+	// Dependency Utils
+	notifier := utils.NewNotifier()
+	mailer := utils.NewMailer()
 
 	// Dependency Services
-	authService := service.NewAuthService(userRepo, adminRepo, technicianRepo, redisClient)
-	technicianService := service.NewTechnicianService(technicianRepo)
-	userService := service.NewUserService(userRepo, redisClient)
-	roomService := service.NewRoomService(roomRepo, statsRepo)
-	assetService := service.NewAssetService(assetRepo, statsRepo)
-	assetPlacementService := service.NewAssetPlacementService(assetPlacementRepo)
-	assetMaintenanceService := service.NewAssetMaintenanceService(assetMaintenanceRepo, technicianRepo, assetRepo, statsRepo)
-	assetFindingService := service.NewAssetFindingService(assetFindingRepo, statsRepo)
+	authService := service.NewAuthService(userRepo, adminRepo, technicianRepo, sessionRepo, passwordResetRepo, loginAttemptRepo, historyRepo, notifier, redisClient)
+	technicianService := service.NewTechnicianService(technicianRepo, userRepo, sessionRepo, deleteConfirmRepo)
+	userService := service.NewUserService(userRepo, sessionRepo, emailChangeRepo, historyRepo, mailer, redisClient)
+	roomService := service.NewRoomService(roomRepo, technicianRepo, statsRepo, deleteConfirmRepo)
+	assetService := service.NewAssetService(assetRepo, statsRepo, assetImageRepo, assetCategoryRepo, deleteConfirmRepo)
+	assetImageService := service.NewAssetImageService(assetImageRepo, assetRepo)
+	assetCategoryService := service.NewAssetCategoryService(assetCategoryRepo)
+	assetImportService := service.NewAssetImportService(assetRepo, roomRepo, technicianRepo, assetCategoryRepo)
+	assetPlacementService := service.NewAssetPlacementService(assetPlacementRepo, technicianRepo, roomRepo, deleteConfirmRepo)
+	assetMaintenanceService := service.NewAssetMaintenanceService(assetMaintenanceRepo, technicianRepo, assetRepo, statsRepo, assetUnitRepo, deleteConfirmRepo)
+	assetFindingService := service.NewAssetFindingService(assetFindingRepo, technicianRepo, statsRepo, assetUnitRepo, warrantyRepo, deleteConfirmRepo)
+	assetLoanService := service.NewAssetLoanService(assetLoanRepo, assetPlacementRepo, assetRepo, technicianRepo)
+	assetUnitService := service.NewAssetUnitService(assetUnitRepo, assetPlacementRepo, technicianRepo)
+	assetTransferService := service.NewAssetTransferService(assetTransferRepo, assetMovementRepo, assetPlacementRepo, roomRepo, technicianRepo)
+	vendorService := service.NewVendorService(vendorRepo)
+	warrantyService := service.NewWarrantyService(warrantyRepo, vendorRepo, assetRepo)
 	historyService := service.NewHistoryService(historyRepo, statsRepo)
-	adminService := service.NewAdminService(adminRepo)
+	adminService := service.NewAdminService(adminRepo, sessionRepo, userRepo)
+	roleService := service.NewRoleService(roleRepo, userRepo)
+	telegramService := service.NewTelegramService(userRepo, telegramLinkRepo, historyRepo)
+	apiKeyService := service.NewApiKeyService(apiKeyRepo)
 
 	// Dependency Controllers
 	authController := controller.NewAuthController(authService)
@@ -41,10 +73,22 @@ func SetUpDependency(r *gin.Engine, db *gorm.DB, redisClient *redis.Client) {
 	userController := controller.NewUserController(userService)
 	roomController := controller.NewRoomRepository(roomService)
 	assetController := controller.NewAssetRepository(assetService)
+	assetImportController := controller.NewAssetImportController(assetImportService)
+	assetImageController := controller.NewAssetImageController(assetImageService)
+	assetCategoryController := controller.NewAssetCategoryController(assetCategoryService)
 	assetPlacementController := controller.NewAssetPlacementRepository(assetPlacementService)
 	assetMaintenanceController := controller.NewAssetMaintenanceRepository(assetMaintenanceService)
 	assetFindingController := controller.NewAssetFindingRepository(assetFindingService)
+	assetLoanController := controller.NewAssetLoanController(assetLoanService)
+	assetUnitController := controller.NewAssetUnitController(assetUnitService)
+	assetTransferController := controller.NewAssetTransferController(assetTransferService)
+	vendorController := controller.NewVendorController(vendorService)
+	warrantyController := controller.NewWarrantyController(warrantyService)
 	historyController := controller.NewHistoryRepository(historyService)
+	roleController := controller.NewRoleController(roleService)
+	adminController := controller.NewAdminController(adminService)
+	telegramController := controller.NewTelegramController(telegramService)
+	apiKeyController := controller.NewApiKeyController(apiKeyService)
 
 	// Routes Endpoint
 	SetUpRoutes(r, db, redisClient,
@@ -53,15 +97,32 @@ func SetUpDependency(r *gin.Engine, db *gorm.DB, redisClient *redis.Client) {
 		userController,
 		roomController,
 		assetController,
+		assetImportController,
+		assetImageController,
+		assetCategoryController,
 		assetPlacementController,
 		assetMaintenanceController,
 		assetFindingController,
+		assetLoanController,
+		assetUnitController,
+		assetTransferController,
+		warrantyController,
+		vendorController,
 		historyController,
+		roleController,
+		adminController,
+		telegramController,
+		apiKeyController,
+		roleRepo,
+		apiKeyRepo,
 	)
 
 	// Task Scheduler
-	SetUpScheduler(assetMaintenanceService, assetFindingService, adminService)
+	SetUpScheduler(assetMaintenanceService, assetFindingService, assetLoanService, warrantyService, adminService, assetService, roomService, technicianService, assetPlacementService)
+
+	// Telegram Bot Update
+	SetUpTelegram(telegramService)
 
 	// Seeder & Factories
-	SetUpSeeder(db, roomRepo, adminRepo, technicianRepo, userRepo, assetRepo, assetPlacementRepo, assetMaintenanceRepo, assetFindingRepo)
+	SetUpSeeder(db, roleRepo, roomRepo, adminRepo, technicianRepo, userRepo, assetRepo, assetCategoryRepo, assetPlacementRepo, assetMaintenanceRepo, assetFindingRepo)
 }
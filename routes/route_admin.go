@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"pelita/config"
+	"pelita/controller"
+	"pelita/middleware"
+	"pelita/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func SetUpRouteAdmin(api *gin.RouterGroup, adminController *controller.AdminController, redisClient *redis.Client, db *gorm.DB, roleRepo repository.RoleRepository) {
+	// Permission Based
+	protected := api.Group("/")
+	protected.Use(middleware.AuthMiddleware(redisClient, nil))
+	{
+		admin := protected.Group("/admins")
+		{
+			admin.GET("/", middleware.PermissionMiddleware(roleRepo, config.PermissionAdminRead), adminController.GetAllAdmin)
+			admin.POST("/", middleware.PermissionMiddleware(roleRepo, config.PermissionAdminCreate), adminController.Create, middleware.AuditTrailMiddleware(db, "create_admin"))
+			admin.PUT("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionAdminUpdate), adminController.UpdateById, middleware.AuditTrailMiddleware(db, "update_admin_by_id"))
+			admin.PUT("/deactivate/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionAdminUpdate), adminController.DeactivateById, middleware.AuditTrailMiddleware(db, "deactivate_admin_by_id"))
+			admin.PUT("/activate/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionAdminUpdate), adminController.ActivateById, middleware.AuditTrailMiddleware(db, "activate_admin_by_id"))
+			admin.DELETE("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionAdminDelete), adminController.DeleteById, middleware.AuditTrailMiddleware(db, "delete_admin_by_id"))
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"pelita/config"
+	"pelita/controller"
+	"pelita/middleware"
+	"pelita/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func SetUpRouteApiKey(api *gin.RouterGroup, apiKeyController *controller.ApiKeyController, redisClient *redis.Client, db *gorm.DB, roleRepo repository.RoleRepository) {
+	// Permission Based
+	protected := api.Group("/")
+	protected.Use(middleware.AuthMiddleware(redisClient, nil))
+	{
+		apiKey := protected.Group("/api-keys")
+		{
+			apiKey.GET("/", middleware.PermissionMiddleware(roleRepo, config.PermissionApiKeyRead), apiKeyController.GetAllApiKey)
+			apiKey.POST("/", middleware.PermissionMiddleware(roleRepo, config.PermissionApiKeyManage), apiKeyController.Create, middleware.AuditTrailMiddleware(db, "create_api_key"))
+			apiKey.DELETE("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionApiKeyManage), apiKeyController.RevokeById, middleware.AuditTrailMiddleware(db, "revoke_api_key_by_id"))
+		}
+	}
+}
@@ -1,84 +1,132 @@
 package routes
 
 import (
+	"pelita/config"
 	"pelita/controller"
 	"pelita/middleware"
+	"pelita/repository"
 
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
-func SetUpRouteAsset(api *gin.RouterGroup, assetController *controller.AssetController, assetFindingController *controller.AssetFindingController, assetMaintenanceController *controller.AssetMaintenanceController, assetPlacementController *controller.AssetPlacementController, redisClient *redis.Client, db *gorm.DB) {
-	// Admin Only
-	protected_admin := api.Group("/")
-	protected_admin.Use(middleware.AuthMiddleware(redisClient, "admin"))
+func SetUpRouteAsset(api *gin.RouterGroup, assetController *controller.AssetController, assetImportController *controller.AssetImportController, assetImageController *controller.AssetImageController, assetCategoryController *controller.AssetCategoryController, assetFindingController *controller.AssetFindingController, assetMaintenanceController *controller.AssetMaintenanceController, assetPlacementController *controller.AssetPlacementController, assetLoanController *controller.AssetLoanController, assetUnitController *controller.AssetUnitController, assetTransferController *controller.AssetTransferController, warrantyController *controller.WarrantyController, redisClient *redis.Client, db *gorm.DB, roleRepo repository.RoleRepository, apiKeyRepo repository.ApiKeyRepository) {
+	// Permission Based
+	protected := api.Group("/")
+	protected.Use(middleware.AuthMiddleware(redisClient, apiKeyRepo))
 	{
-		asset := protected_admin.Group("/assets")
+		asset := protected.Group("/assets")
 		{
-			asset.POST("/", assetController.Create, middleware.AuditTrailMiddleware(db, "create_asset"))
-			asset.GET("/most-context/:targetCol", assetController.GetMostContext)
-			asset.GET("/", assetController.GetAllAsset)
-			asset.DELETE("/destroy/:id", assetController.HardDeleteById, middleware.AuditTrailMiddleware(db, "hard_delete_asset_by_id"))
-			asset.DELETE("/:id", assetController.SoftDeleteById, middleware.AuditTrailMiddleware(db, "soft_delete_asset_by_id"))
-			asset.PUT("/:id", assetController.UpdateById, middleware.AuditTrailMiddleware(db, "update_asset_by_id"))
-			asset.PUT("/recover/:id", assetController.RecoverDeletedById, middleware.AuditTrailMiddleware(db, "recover_delete_asset_by_id"))
+			asset.POST("/", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetCreate), assetController.Create, middleware.AuditTrailMiddleware(db, "create_asset"))
+			asset.GET("/most-context/:targetCol", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetStats), assetController.GetMostContext)
+			asset.GET("/", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetRead), assetController.GetAllAsset)
+			asset.GET("/deleted", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetReadDeleted), assetController.GetDeletedAsset)
+			asset.POST("/import", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetImport), assetImportController.Import, middleware.AuditTrailMiddleware(db, "import_asset"))
+			asset.GET("/export", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetExport), assetImportController.Export)
+			asset.GET("/book-values", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetValue), assetController.GetAllBookValue)
+			asset.GET("/book-values/summary/:context", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetValue), assetController.GetBookValueSummary)
+			asset.GET("/:id/book-value", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetValue), assetController.GetBookValueById)
+			asset.GET("/:id/status-history", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetRead), assetController.GetStatusHistoryById)
+			asset.GET("/:id/images", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetRead), assetImageController.GetAllByAssetId)
+			asset.POST("/:id/images", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetUpdate), assetImageController.Create, middleware.AuditTrailMiddleware(db, "create_asset_image"))
+			asset.PUT("/:id/images/order", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetUpdate), assetImageController.UpdateOrderByAssetId, middleware.AuditTrailMiddleware(db, "update_asset_image_order"))
+			asset.PUT("/images/primary/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetUpdate), assetImageController.UpdatePrimaryById, middleware.AuditTrailMiddleware(db, "update_asset_image_primary_by_id"))
+			asset.DELETE("/images/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetUpdate), assetImageController.DeleteById, middleware.AuditTrailMiddleware(db, "delete_asset_image_by_id"))
+			asset.GET("/:id/warranties", middleware.PermissionMiddleware(roleRepo, config.PermissionWarrantyRead), warrantyController.GetAllByAssetId)
+			asset.GET("/:id/movements", middleware.PermissionMiddleware(roleRepo, config.PermissionTransferRead), assetTransferController.GetAllMovementByAssetId)
+			asset.GET("/movements/rooms/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionTransferRead), assetTransferController.GetAllMovementByRoomId)
+			asset.GET("/destroy/:id/delete-preview", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetDestroy), assetController.GetDeletePreviewById)
+			asset.DELETE("/destroy/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetDestroy), assetController.HardDeleteById, middleware.AuditTrailMiddleware(db, "hard_delete_asset_by_id"))
+			asset.DELETE("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetDelete), assetController.SoftDeleteById, middleware.AuditTrailMiddleware(db, "soft_delete_asset_by_id"))
+			asset.PUT("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetUpdate), assetController.UpdateById, middleware.AuditTrailMiddleware(db, "update_asset_by_id"))
+			asset.POST("/:id/merge", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetMerge), assetController.MergeById, middleware.AuditTrailMiddleware(db, "merge_asset_by_id"))
+			asset.PUT("/recover/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetRecover), assetController.RecoverDeletedById, middleware.AuditTrailMiddleware(db, "recover_delete_asset_by_id"))
 
-			asset_placement := asset.Group("/placements")
+			asset_category := asset.Group("/categories")
 			{
-				asset_placement.POST("/", assetPlacementController.Create)
-				asset_placement.DELETE("/:id", assetPlacementController.DeleteById)
+				asset_category.GET("/", middleware.PermissionMiddleware(roleRepo, config.PermissionCategoryRead), assetCategoryController.GetAllAssetCategory)
+				asset_category.GET("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionCategoryRead), assetCategoryController.GetById)
+				asset_category.POST("/", middleware.PermissionMiddleware(roleRepo, config.PermissionCategoryManage), assetCategoryController.Create, middleware.AuditTrailMiddleware(db, "create_asset_category"))
+				asset_category.PUT("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionCategoryManage), assetCategoryController.UpdateById, middleware.AuditTrailMiddleware(db, "update_asset_category_by_id"))
+				asset_category.DELETE("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionCategoryManage), assetCategoryController.DeleteById, middleware.AuditTrailMiddleware(db, "delete_asset_category_by_id"))
 			}
-			asset_maintenance := asset.Group("/maintenances")
+			asset_placement := asset.Group("/placements")
 			{
-				asset_maintenance.GET("/most-context/:targetCol", assetMaintenanceController.GetMostContext)
-				asset_maintenance.POST("/", assetMaintenanceController.Create, middleware.AuditTrailMiddleware(db, "create_asset_maintenance_by_id"))
-				asset_maintenance.PUT("/:id", assetMaintenanceController.UpdateById, middleware.AuditTrailMiddleware(db, "update_asset_maintenance_by_id"))
-				asset_maintenance.DELETE("/:id", assetMaintenanceController.DeleteById, middleware.AuditTrailMiddleware(db, "delete_asset_maintenance_by_id"))
+				asset_placement.GET("/", middleware.PermissionMiddleware(roleRepo, config.PermissionPlacementRead), assetPlacementController.GetAllAssetPlacement)
+				asset_placement.POST("/", middleware.PermissionMiddleware(roleRepo, config.PermissionPlacementCreate), assetPlacementController.Create)
+				asset_placement.PUT("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionPlacementUpdate), assetPlacementController.UpdateById, middleware.AuditTrailMiddleware(db, "update_asset_placement_by_id"))
+				asset_placement.GET("/deleted", middleware.PermissionMiddleware(roleRepo, config.PermissionPlacementReadDeleted), assetPlacementController.GetDeleted)
+				asset_placement.GET("/destroy/:id/delete-preview", middleware.PermissionMiddleware(roleRepo, config.PermissionPlacementDestroy), assetPlacementController.GetDeletePreviewById)
+				asset_placement.DELETE("/destroy/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionPlacementDestroy), assetPlacementController.HardDeleteById, middleware.AuditTrailMiddleware(db, "hard_delete_asset_placement_by_id"))
+				asset_placement.DELETE("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionPlacementDelete), assetPlacementController.SoftDeleteById, middleware.AuditTrailMiddleware(db, "soft_delete_asset_placement_by_id"))
+				asset_placement.PUT("/recover/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionPlacementRecover), assetPlacementController.RecoverDeletedById, middleware.AuditTrailMiddleware(db, "recover_delete_asset_placement_by_id"))
+				asset_placement.GET("/:id/units", middleware.PermissionMiddleware(roleRepo, config.PermissionUnitRead), assetUnitController.GetAllByAssetPlacementId)
+				asset_placement.POST("/:id/units", middleware.PermissionMiddleware(roleRepo, config.PermissionUnitCreate), assetUnitController.GenerateByAssetPlacementId, middleware.AuditTrailMiddleware(db, "generate_asset_unit_by_asset_placement_id"))
 			}
-			asset_finding := asset.Group("/findings")
+			asset_unit := asset.Group("/units")
 			{
-				asset_finding.DELETE("/:id", assetFindingController.DeleteById, middleware.AuditTrailMiddleware(db, "delete_asset_finding_by_id"))
-				asset_finding.GET("/most-context/:targetCol", assetFindingController.GetMostContext)
-				asset_finding.GET("/hour-total", assetFindingController.GetFindingHourTotal)
+				asset_unit.PUT("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionUnitUpdate), assetUnitController.UpdateById, middleware.AuditTrailMiddleware(db, "update_asset_unit_by_id"))
+				asset_unit.PUT("/move/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionUnitMove), assetUnitController.MoveById, middleware.AuditTrailMiddleware(db, "move_asset_unit_by_id"))
+				asset_unit.DELETE("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionUnitDelete), assetUnitController.DeleteById, middleware.AuditTrailMiddleware(db, "delete_asset_unit_by_id"))
 			}
-		}
-	}
-
-	// Admin & Technician Only
-	protected_admin_technician := api.Group("/")
-	protected_admin_technician.Use(middleware.AuthMiddleware(redisClient, "admin", "technician"))
-	{
-		asset := protected_admin_technician.Group("/assets")
-		{
-			asset.GET("/deleted", assetController.GetDeletedAsset)
-
-			asset_placement := asset.Group("/placements")
+			asset_tag := asset.Group("/tags")
 			{
-				asset_placement.GET("/", assetPlacementController.GetAllAssetPlacement)
-				asset_placement.PUT("/:id", assetPlacementController.UpdateById, middleware.AuditTrailMiddleware(db, "update_asset_placement_by_id"))
+				asset_tag.GET("/labels", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetTagRead), assetUnitController.GetLabelSheet)
+				asset_tag.GET("/:tag", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetTagRead), assetUnitController.GetLookupByTag)
+				asset_tag.GET("/:tag/qr", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetTagRead), assetUnitController.GetQRCodeByTag)
+				asset_tag.GET("/:tag/barcode", middleware.PermissionMiddleware(roleRepo, config.PermissionAssetTagRead), assetUnitController.GetBarcodeByTag)
 			}
 			asset_maintenance := asset.Group("/maintenances")
 			{
-				asset_maintenance.GET("/", assetMaintenanceController.GetAllAssetMaintenance)
-				asset_maintenance.GET("/schedule", assetMaintenanceController.GetAllAssetMaintenanceSchedule)
+				asset_maintenance.GET("/", middleware.PermissionMiddleware(roleRepo, config.PermissionMaintenanceRead), assetMaintenanceController.GetAllAssetMaintenance)
+				asset_maintenance.GET("/schedule", middleware.PermissionMiddleware(roleRepo, config.PermissionMaintenanceRead), assetMaintenanceController.GetAllAssetMaintenanceSchedule)
+				asset_maintenance.GET("/most-context/:targetCol", middleware.PermissionMiddleware(roleRepo, config.PermissionMaintenanceStats), assetMaintenanceController.GetMostContext)
+				asset_maintenance.POST("/", middleware.PermissionMiddleware(roleRepo, config.PermissionMaintenanceCreate), assetMaintenanceController.Create, middleware.AuditTrailMiddleware(db, "create_asset_maintenance_by_id"))
+				asset_maintenance.PUT("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionMaintenanceUpdate), assetMaintenanceController.UpdateById, middleware.AuditTrailMiddleware(db, "update_asset_maintenance_by_id"))
+				asset_maintenance.GET("/deleted", middleware.PermissionMiddleware(roleRepo, config.PermissionMaintenanceReadDeleted), assetMaintenanceController.GetDeleted)
+				asset_maintenance.GET("/destroy/:id/delete-preview", middleware.PermissionMiddleware(roleRepo, config.PermissionMaintenanceDestroy), assetMaintenanceController.GetDeletePreviewById)
+				asset_maintenance.DELETE("/destroy/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionMaintenanceDestroy), assetMaintenanceController.HardDeleteById, middleware.AuditTrailMiddleware(db, "hard_delete_asset_maintenance_by_id"))
+				asset_maintenance.DELETE("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionMaintenanceDelete), assetMaintenanceController.SoftDeleteById, middleware.AuditTrailMiddleware(db, "soft_delete_asset_maintenance_by_id"))
+				asset_maintenance.PUT("/recover/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionMaintenanceRecover), assetMaintenanceController.RecoverDeletedById, middleware.AuditTrailMiddleware(db, "recover_delete_asset_maintenance_by_id"))
 			}
 			asset_finding := asset.Group("/findings")
 			{
-				asset_finding.GET("/", assetFindingController.GetAllAssetFinding)
+				asset_finding.GET("/", middleware.PermissionMiddleware(roleRepo, config.PermissionFindingRead), assetFindingController.GetAllAssetFinding)
+				asset_finding.GET("/most-context/:targetCol", middleware.PermissionMiddleware(roleRepo, config.PermissionFindingStats), assetFindingController.GetMostContext)
+				asset_finding.GET("/hour-total", middleware.PermissionMiddleware(roleRepo, config.PermissionFindingStats), assetFindingController.GetFindingHourTotal)
+				asset_finding.POST("/", middleware.PermissionMiddleware(roleRepo, config.PermissionFindingCreate), assetFindingController.Create, middleware.AuditTrailMiddleware(db, "create_asset_finding"))
+				asset_finding.GET("/deleted", middleware.PermissionMiddleware(roleRepo, config.PermissionFindingReadDeleted), assetFindingController.GetDeleted)
+				asset_finding.GET("/destroy/:id/delete-preview", middleware.PermissionMiddleware(roleRepo, config.PermissionFindingDestroy), assetFindingController.GetDeletePreviewById)
+				asset_finding.DELETE("/destroy/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionFindingDestroy), assetFindingController.HardDeleteById, middleware.AuditTrailMiddleware(db, "hard_delete_asset_finding_by_id"))
+				asset_finding.DELETE("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionFindingDelete), assetFindingController.SoftDeleteById, middleware.AuditTrailMiddleware(db, "soft_delete_asset_finding_by_id"))
+				asset_finding.PUT("/recover/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionFindingRecover), assetFindingController.RecoverDeletedById, middleware.AuditTrailMiddleware(db, "recover_delete_asset_finding_by_id"))
+			}
+			asset_loan := asset.Group("/loans")
+			{
+				asset_loan.GET("/", middleware.PermissionMiddleware(roleRepo, config.PermissionLoanRead), assetLoanController.GetAllAssetLoan)
+				asset_loan.GET("/my", middleware.PermissionMiddleware(roleRepo, config.PermissionLoanRequest), assetLoanController.GetMyAssetLoan)
+				asset_loan.GET("/overdue", middleware.PermissionMiddleware(roleRepo, config.PermissionLoanRead), assetLoanController.GetAllOverdueAssetLoan)
+				asset_loan.POST("/", middleware.PermissionMiddleware(roleRepo, config.PermissionLoanRequest), assetLoanController.Create, middleware.AuditTrailMiddleware(db, "request_asset_loan"))
+				asset_loan.PUT("/approve/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionLoanApprove), assetLoanController.ApproveById, middleware.AuditTrailMiddleware(db, "approve_asset_loan_by_id"))
+				asset_loan.PUT("/reject/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionLoanApprove), assetLoanController.RejectById, middleware.AuditTrailMiddleware(db, "reject_asset_loan_by_id"))
+				asset_loan.PUT("/checkout/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionLoanCheckout), assetLoanController.CheckOutById, middleware.AuditTrailMiddleware(db, "check_out_asset_loan_by_id"))
+				asset_loan.PUT("/return/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionLoanCheckout), assetLoanController.ReturnById, middleware.AuditTrailMiddleware(db, "return_asset_loan_by_id"))
 			}
-		}
-	}
 
-	// User / Guest & Technician Only
-	protected_user_technician := api.Group("/")
-	protected_user_technician.Use(middleware.AuthMiddleware(redisClient, "guest", "technician"))
-	{
-		asset := protected_user_technician.Group("/assets")
-		{
-			asset_finding := asset.Group("/findings")
+			asset_warranty := asset.Group("/warranties")
+			{
+				asset_warranty.POST("/", middleware.PermissionMiddleware(roleRepo, config.PermissionWarrantyManage), warrantyController.Create, middleware.AuditTrailMiddleware(db, "create_warranty"))
+				asset_warranty.PUT("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionWarrantyManage), warrantyController.UpdateById, middleware.AuditTrailMiddleware(db, "update_warranty_by_id"))
+				asset_warranty.DELETE("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionWarrantyManage), warrantyController.DeleteById, middleware.AuditTrailMiddleware(db, "delete_warranty_by_id"))
+			}
+
+			asset_transfer := asset.Group("/transfers")
 			{
-				asset_finding.POST("/", assetFindingController.Create, middleware.AuditTrailMiddleware(db, "create_asset_finding"))
+				asset_transfer.GET("/", middleware.PermissionMiddleware(roleRepo, config.PermissionTransferRead), assetTransferController.GetAllAssetTransfer)
+				asset_transfer.POST("/", middleware.PermissionMiddleware(roleRepo, config.PermissionTransferRequest), assetTransferController.Create, middleware.AuditTrailMiddleware(db, "request_asset_transfer"))
+				asset_transfer.PUT("/approve/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionTransferApprove), assetTransferController.ApproveById, middleware.AuditTrailMiddleware(db, "approve_asset_transfer_by_id"))
+				asset_transfer.PUT("/reject/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionTransferApprove), assetTransferController.RejectById, middleware.AuditTrailMiddleware(db, "reject_asset_transfer_by_id"))
 			}
 		}
 	}
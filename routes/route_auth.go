@@ -1,17 +1,37 @@
 package routes
 
 import (
+	"pelita/config"
 	"pelita/controller"
+	"pelita/middleware"
+	"pelita/repository"
 
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
 )
 
-func SetUpRouteAuth(api *gin.RouterGroup, authController *controller.AuthController) {
+func SetUpRouteAuth(api *gin.RouterGroup, authController *controller.AuthController, redisClient *redis.Client, db *gorm.DB, roleRepo repository.RoleRepository) {
 	// Public Routes
 	auth := api.Group("/auths")
 	{
 		auth.POST("/register", authController.Register)
 		auth.POST("/login", authController.Login)
+		auth.POST("/refresh", authController.RefreshToken)
 		auth.POST("/signout", authController.SignOut)
+		auth.POST("/password/forgot", authController.ForgotPassword)
+		auth.POST("/password/reset", authController.ResetPassword)
+	}
+	// All Role
+	protected := api.Group("/")
+	protected.Use(middleware.AuthMiddleware(redisClient, nil))
+	{
+		auth := protected.Group("/auths")
+		{
+			auth.GET("/sessions", authController.GetMySession)
+			auth.DELETE("/sessions/:id", authController.RevokeSessionById)
+			auth.PUT("/password", authController.ChangePassword, middleware.AuditTrailMiddleware(db, "change_password"))
+			auth.PUT("/unlock", middleware.PermissionMiddleware(roleRepo, config.PermissionAccountUnlock), authController.UnlockAccount, middleware.AuditTrailMiddleware(db, "unlock_account"))
+		}
 	}
 }
@@ -1,31 +1,25 @@
 package routes
 
 import (
+	"pelita/config"
 	"pelita/controller"
 	"pelita/middleware"
+	"pelita/repository"
 
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
 )
 
-func SetUpRouteHistory(api *gin.RouterGroup, historyController *controller.HistoryController, redisClient *redis.Client) {
+func SetUpRouteHistory(api *gin.RouterGroup, historyController *controller.HistoryController, redisClient *redis.Client, roleRepo repository.RoleRepository) {
 	// All Role
 	protected := api.Group("/")
-	protected.Use(middleware.AuthMiddleware(redisClient, "admin", "technician", "guest"))
+	protected.Use(middleware.AuthMiddleware(redisClient, nil))
 	{
 		history := protected.Group("/histories")
 		{
 			history.GET("/my", historyController.GetMyHistory)
-		}
-	}
-	// Admin Only
-	protected_admin := api.Group("/")
-	protected_admin.Use(middleware.AuthMiddleware(redisClient, "admin"))
-	{
-		history := protected_admin.Group("/histories")
-		{
-			history.GET("/all", historyController.GetAllHistory)
-			history.GET("/:targetCol", historyController.GetMostContext)
+			history.GET("/all", middleware.PermissionMiddleware(roleRepo, config.PermissionHistoryRead), historyController.GetAllHistory)
+			history.GET("/:targetCol", middleware.PermissionMiddleware(roleRepo, config.PermissionHistoryStats), historyController.GetMostContext)
 		}
 	}
 }
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"pelita/config"
+	"pelita/controller"
+	"pelita/middleware"
+	"pelita/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func SetUpRouteRole(api *gin.RouterGroup, roleController *controller.RoleController, redisClient *redis.Client, db *gorm.DB, roleRepo repository.RoleRepository) {
+	// Permission Based
+	protected := api.Group("/")
+	protected.Use(middleware.AuthMiddleware(redisClient, nil))
+	{
+		role := protected.Group("/roles")
+		{
+			role.GET("/", middleware.PermissionMiddleware(roleRepo, config.PermissionRoleRead), roleController.GetAllRole)
+			role.GET("/permissions", middleware.PermissionMiddleware(roleRepo, config.PermissionRoleRead), roleController.GetAllPermission)
+			role.POST("/", middleware.PermissionMiddleware(roleRepo, config.PermissionRoleManage), roleController.Create, middleware.AuditTrailMiddleware(db, "create_role"))
+			role.PUT("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionRoleManage), roleController.UpdateById, middleware.AuditTrailMiddleware(db, "update_role_by_id"))
+			role.DELETE("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionRoleManage), roleController.DeleteById, middleware.AuditTrailMiddleware(db, "delete_role_by_id"))
+			role.PUT("/:id/accounts", middleware.PermissionMiddleware(roleRepo, config.PermissionRoleManage), roleController.AssignRoleToAccount, middleware.AuditTrailMiddleware(db, "assign_role_to_account"))
+			role.DELETE("/accounts/:accountId", middleware.PermissionMiddleware(roleRepo, config.PermissionRoleManage), roleController.RemoveRoleFromAccount, middleware.AuditTrailMiddleware(db, "remove_role_from_account"))
+		}
+	}
+}
@@ -1,44 +1,38 @@
 package routes
 
 import (
+	"pelita/config"
 	"pelita/controller"
 	"pelita/middleware"
+	"pelita/repository"
 
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
-func SetUpRouteRoom(api *gin.RouterGroup, roomController *controller.RoomController, redisClient *redis.Client, db *gorm.DB) {
-	// All Role
+func SetUpRouteRoom(api *gin.RouterGroup, roomController *controller.RoomController, redisClient *redis.Client, db *gorm.DB, roleRepo repository.RoleRepository, apiKeyRepo repository.ApiKeyRepository) {
+	// Permission Based
 	protected := api.Group("/")
-	protected.Use(middleware.AuthMiddleware(redisClient, "admin", "technician", "guest"))
+	protected.Use(middleware.AuthMiddleware(redisClient, apiKeyRepo))
 	{
 		room := protected.Group("/rooms")
 		{
-			room.GET("/", roomController.GetAllRoom)
-		}
-	}
-	// Admin Only
-	protected_admin := api.Group("/")
-	protected_admin.Use(middleware.AuthMiddleware(redisClient, "admin"))
-	{
-		room := protected_admin.Group("/rooms")
-		{
-			room.GET("/most-context/:targetCol", roomController.GetMostContext)
-			room.POST("/", roomController.Create, middleware.AuditTrailMiddleware(db, "create_room"))
-			room.DELETE("/:id", roomController.DeleteById, middleware.AuditTrailMiddleware(db, "delete_room_by_id"))
-			room.PUT("/:id", roomController.UpdateById, middleware.AuditTrailMiddleware(db, "update_room_by_id"))
-		}
-	}
-	// Admin & Technician Only
-	protected_admin_technician := api.Group("/")
-	protected_admin_technician.Use(middleware.AuthMiddleware(redisClient, "admin", "technician"))
-	{
-		room := protected_admin_technician.Group("/rooms/assets")
-		{
-			room.GET("/detail/:floor/:roomName", roomController.GetRoomAssetByFloorAndRoomName)
-			room.GET("/short/:floor/:roomName", roomController.GetRoomAssetShortByFloorAndRoomName)
+			room.GET("/", middleware.PermissionMiddleware(roleRepo, config.PermissionRoomRead), roomController.GetAllRoom)
+			room.GET("/most-context/:targetCol", middleware.PermissionMiddleware(roleRepo, config.PermissionRoomStats), roomController.GetMostContext)
+			room.POST("/", middleware.PermissionMiddleware(roleRepo, config.PermissionRoomCreate), roomController.Create, middleware.AuditTrailMiddleware(db, "create_room"))
+			room.GET("/deleted", middleware.PermissionMiddleware(roleRepo, config.PermissionRoomReadDeleted), roomController.GetDeleted)
+			room.GET("/destroy/:id/delete-preview", middleware.PermissionMiddleware(roleRepo, config.PermissionRoomDestroy), roomController.GetDeletePreviewById)
+			room.DELETE("/destroy/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionRoomDestroy), roomController.HardDeleteById, middleware.AuditTrailMiddleware(db, "hard_delete_room_by_id"))
+			room.DELETE("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionRoomDelete), roomController.SoftDeleteById, middleware.AuditTrailMiddleware(db, "soft_delete_room_by_id"))
+			room.PUT("/recover/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionRoomRecover), roomController.RecoverDeletedById, middleware.AuditTrailMiddleware(db, "recover_delete_room_by_id"))
+			room.PUT("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionRoomUpdate), roomController.UpdateById, middleware.AuditTrailMiddleware(db, "update_room_by_id"))
+
+			room_asset := room.Group("/assets")
+			{
+				room_asset.GET("/detail/:floor/:roomName", middleware.PermissionMiddleware(roleRepo, config.PermissionRoomReadAsset), roomController.GetRoomAssetByFloorAndRoomName)
+				room_asset.GET("/short/:floor/:roomName", middleware.PermissionMiddleware(roleRepo, config.PermissionRoomReadAsset), roomController.GetRoomAssetShortByFloorAndRoomName)
+			}
 		}
 	}
 }
@@ -1,33 +1,33 @@
 package routes
 
 import (
+	"pelita/config"
 	"pelita/controller"
 	"pelita/middleware"
+	"pelita/repository"
 
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
-func SetUpRouteTechnician(api *gin.RouterGroup, technicianController *controller.TechnicianController, redisClient *redis.Client, db *gorm.DB) {
-	// Admin Only
-	protected_admin := api.Group("/")
-	protected_admin.Use(middleware.AuthMiddleware(redisClient, "admin"))
+func SetUpRouteTechnician(api *gin.RouterGroup, technicianController *controller.TechnicianController, redisClient *redis.Client, db *gorm.DB, roleRepo repository.RoleRepository) {
+	// Permission Based
+	protected := api.Group("/")
+	protected.Use(middleware.AuthMiddleware(redisClient, nil))
 	{
-		technician := protected_admin.Group("/technicians")
+		technician := protected.Group("/technicians")
 		{
-			technician.POST("/", technicianController.Create, middleware.AuditTrailMiddleware(db, "create_technician"))
-			technician.PUT("/:id", technicianController.UpdateById, middleware.AuditTrailMiddleware(db, "update_technician_by_id"))
-			technician.DELETE("/:id", technicianController.DeleteById, middleware.AuditTrailMiddleware(db, "delete_technician_by_id"))
-		}
-	}
-	// Admin & Technician Only
-	protected_admin_technician := api.Group("/")
-	protected_admin_technician.Use(middleware.AuthMiddleware(redisClient, "admin", "technician"))
-	{
-		technician := protected_admin_technician.Group("/technicians")
-		{
-			technician.GET("/", technicianController.GetAllTechnician)
+			technician.GET("/", middleware.PermissionMiddleware(roleRepo, config.PermissionTechnicianRead), technicianController.GetAllTechnician)
+			technician.POST("/", middleware.PermissionMiddleware(roleRepo, config.PermissionTechnicianCreate), technicianController.Create, middleware.AuditTrailMiddleware(db, "create_technician"))
+			technician.PUT("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionTechnicianUpdate), technicianController.UpdateById, middleware.AuditTrailMiddleware(db, "update_technician_by_id"))
+			technician.GET("/:id/scopes", middleware.PermissionMiddleware(roleRepo, config.PermissionTechnicianRead), technicianController.GetScopeById)
+			technician.PUT("/:id/scopes", middleware.PermissionMiddleware(roleRepo, config.PermissionTechnicianUpdate), technicianController.UpdateScopeById, middleware.AuditTrailMiddleware(db, "update_technician_scope_by_id"))
+			technician.GET("/deleted", middleware.PermissionMiddleware(roleRepo, config.PermissionTechnicianReadDeleted), technicianController.GetDeleted)
+			technician.GET("/destroy/:id/delete-preview", middleware.PermissionMiddleware(roleRepo, config.PermissionTechnicianDestroy), technicianController.GetDeletePreviewById)
+			technician.DELETE("/destroy/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionTechnicianDestroy), technicianController.HardDeleteById, middleware.AuditTrailMiddleware(db, "hard_delete_technician_by_id"))
+			technician.DELETE("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionTechnicianDelete), technicianController.SoftDeleteById, middleware.AuditTrailMiddleware(db, "soft_delete_technician_by_id"))
+			technician.PUT("/recover/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionTechnicianRecover), technicianController.RecoverDeletedById, middleware.AuditTrailMiddleware(db, "recover_delete_technician_by_id"))
 		}
 	}
 }
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"pelita/controller"
+	"pelita/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func SetUpRouteTelegram(api *gin.RouterGroup, telegramController *controller.TelegramController, redisClient *redis.Client, db *gorm.DB) {
+	// Public Routes
+	telegram := api.Group("/telegram")
+	{
+		telegram.POST("/webhook", telegramController.Webhook)
+	}
+	// All Role
+	protected := api.Group("/")
+	protected.Use(middleware.AuthMiddleware(redisClient, nil))
+	{
+		auth := protected.Group("/auths")
+		{
+			auth.POST("/telegram", telegramController.CreateLinkCode)
+			auth.DELETE("/telegram", telegramController.Unlink, middleware.AuditTrailMiddleware(db, "unlink_telegram"))
+		}
+	}
+}
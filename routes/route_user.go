@@ -6,13 +6,20 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
 )
 
-func SetUpRouteUser(api *gin.RouterGroup, userController *controller.UserController, redisClient *redis.Client) {
+func SetUpRouteUser(api *gin.RouterGroup, userController *controller.UserController, redisClient *redis.Client, db *gorm.DB) {
+	// Public Routes
+	profile := api.Group("/profile")
+	{
+		profile.POST("/email/confirm", userController.ConfirmEmail)
+	}
 	// All Role
 	protected := api.Group("/")
-	protected.Use(middleware.AuthMiddleware(redisClient, "admin", "technician", "guest"))
+	protected.Use(middleware.AuthMiddleware(redisClient, nil))
 	{
 		protected.GET("/profile", userController.GetMyProfile)
+		protected.PUT("/profile", userController.UpdateMyProfile, middleware.AuditTrailMiddleware(db, "update_profile"))
 	}
 }
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"pelita/config"
+	"pelita/controller"
+	"pelita/middleware"
+	"pelita/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func SetUpRouteVendor(api *gin.RouterGroup, vendorController *controller.VendorController, redisClient *redis.Client, db *gorm.DB, roleRepo repository.RoleRepository) {
+	// Permission Based
+	protected := api.Group("/")
+	protected.Use(middleware.AuthMiddleware(redisClient, nil))
+	{
+		vendor := protected.Group("/vendors")
+		{
+			vendor.GET("/", middleware.PermissionMiddleware(roleRepo, config.PermissionVendorRead), vendorController.GetAllVendor)
+			vendor.POST("/", middleware.PermissionMiddleware(roleRepo, config.PermissionVendorManage), vendorController.Create, middleware.AuditTrailMiddleware(db, "create_vendor"))
+			vendor.PUT("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionVendorManage), vendorController.UpdateById, middleware.AuditTrailMiddleware(db, "update_vendor_by_id"))
+			vendor.DELETE("/:id", middleware.PermissionMiddleware(roleRepo, config.PermissionVendorManage), vendorController.DeleteById, middleware.AuditTrailMiddleware(db, "delete_vendor_by_id"))
+		}
+	}
+}
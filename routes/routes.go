@@ -2,6 +2,7 @@ package routes
 
 import (
 	"pelita/controller"
+	"pelita/repository"
 
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
@@ -14,19 +15,38 @@ func SetUpRoutes(r *gin.Engine, db *gorm.DB, redisClient *redis.Client,
 	userController *controller.UserController,
 	roomController *controller.RoomController,
 	assetController *controller.AssetController,
+	assetImportController *controller.AssetImportController,
+	assetImageController *controller.AssetImageController,
+	assetCategoryController *controller.AssetCategoryController,
 	assetPlacementController *controller.AssetPlacementController,
 	assetMaintenanceController *controller.AssetMaintenanceController,
 	assetFindingController *controller.AssetFindingController,
-	historyController *controller.HistoryController) {
+	assetLoanController *controller.AssetLoanController,
+	assetUnitController *controller.AssetUnitController,
+	assetTransferController *controller.AssetTransferController,
+	warrantyController *controller.WarrantyController,
+	vendorController *controller.VendorController,
+	historyController *controller.HistoryController,
+	roleController *controller.RoleController,
+	adminController *controller.AdminController,
+	telegramController *controller.TelegramController,
+	apiKeyController *controller.ApiKeyController,
+	roleRepo repository.RoleRepository,
+	apiKeyRepo repository.ApiKeyRepository) {
 
 	// V1 Endpoint
 	api := r.Group("/api/v1")
 
 	// Routes Endpoint
-	SetUpRouteAuth(api, authController)
-	SetUpRouteUser(api, userController, redisClient)
-	SetUpRouteTechnician(api, technicianController, redisClient, db)
-	SetUpRouteRoom(api, roomController, redisClient, db)
-	SetUpRouteAsset(api, assetController, assetFindingController, assetMaintenanceController, assetPlacementController, redisClient, db)
-	SetUpRouteHistory(api, historyController, redisClient)
+	SetUpRouteAuth(api, authController, redisClient, db, roleRepo)
+	SetUpRouteUser(api, userController, redisClient, db)
+	SetUpRouteTechnician(api, technicianController, redisClient, db, roleRepo)
+	SetUpRouteRoom(api, roomController, redisClient, db, roleRepo, apiKeyRepo)
+	SetUpRouteAsset(api, assetController, assetImportController, assetImageController, assetCategoryController, assetFindingController, assetMaintenanceController, assetPlacementController, assetLoanController, assetUnitController, assetTransferController, warrantyController, redisClient, db, roleRepo, apiKeyRepo)
+	SetUpRouteVendor(api, vendorController, redisClient, db, roleRepo)
+	SetUpRouteHistory(api, historyController, redisClient, roleRepo)
+	SetUpRouteRole(api, roleController, redisClient, db, roleRepo)
+	SetUpRouteAdmin(api, adminController, redisClient, db, roleRepo)
+	SetUpRouteTelegram(api, telegramController, redisClient, db)
+	SetUpRouteApiKey(api, apiKeyController, redisClient, db, roleRepo)
 }
@@ -8,28 +8,39 @@ import (
 	"github.com/robfig/cron"
 )
 
-func SetUpScheduler(assetMaintenanceService service.AssetMaintenanceService, assetFindingService service.AssetFindingService, adminService service.AdminService) {
+func SetUpScheduler(assetMaintenanceService service.AssetMaintenanceService, assetFindingService service.AssetFindingService, assetLoanService service.AssetLoanService, warrantyService service.WarrantyService, adminService service.AdminService, assetService service.AssetService, roomService service.RoomService, technicianService service.TechnicianService, assetPlacementService service.AssetPlacementService) {
 	// Initialize Scheduler
 	maintenanceScheduler := scheduler.NewAssetMaintenanceScheduler(assetMaintenanceService, assetFindingService, adminService)
+	loanScheduler := scheduler.NewAssetLoanScheduler(assetLoanService, adminService)
+	warrantyScheduler := scheduler.NewWarrantyScheduler(warrantyService, adminService)
+	purgeScheduler := scheduler.NewPurgeScheduler(assetService, roomService, technicianService, assetPlacementService, assetMaintenanceService, assetFindingService)
 
 	// Init Scheduler
 	c := cron.New()
-	Scheduler(c, maintenanceScheduler)
+	Scheduler(c, maintenanceScheduler, loanScheduler, warrantyScheduler, purgeScheduler)
+	// Cron Is Kept Running For The Life Of The Process, Stopping It Would End Every Job
 	c.Start()
-	defer c.Stop()
-}
-
-func Scheduler(c *cron.Cron, maintenanceScheduler *scheduler.AssetMaintenanceScheduler) {
-	// Production
-	// Every day at 00:10 AM)
-	c.AddFunc("10 0 * * *", maintenanceScheduler.ReminderSchedulerTodayMaintenance)
-	// Every day at 00:20 AM)
-	c.AddFunc("20 0 * * *", maintenanceScheduler.AuditSchedulerAssetFindingReport)
 
 	// Development (after 5 sec)
 	go func() {
 		time.Sleep(5 * time.Second)
 		maintenanceScheduler.ReminderSchedulerTodayMaintenance()
 		maintenanceScheduler.AuditSchedulerAssetFindingReport()
+		loanScheduler.ReminderSchedulerOverdueLoan()
+		warrantyScheduler.ReminderSchedulerExpiringWarranty()
 	}()
 }
+
+func Scheduler(c *cron.Cron, maintenanceScheduler *scheduler.AssetMaintenanceScheduler, loanScheduler *scheduler.AssetLoanScheduler, warrantyScheduler *scheduler.WarrantyScheduler, purgeScheduler *scheduler.PurgeScheduler) {
+	// Production
+	// Every day at 00:10 AM)
+	c.AddFunc("0 10 0 * * *", maintenanceScheduler.ReminderSchedulerTodayMaintenance)
+	// Every day at 00:20 AM)
+	c.AddFunc("0 20 0 * * *", maintenanceScheduler.AuditSchedulerAssetFindingReport)
+	// Every day at 00:30 AM)
+	c.AddFunc("0 30 0 * * *", purgeScheduler.PurgeSchedulerSoftDeleted)
+	// Every day at 08:00 AM)
+	c.AddFunc("0 0 8 * * *", loanScheduler.ReminderSchedulerOverdueLoan)
+	// Every day at 08:10 AM)
+	c.AddFunc("0 10 8 * * *", warrantyScheduler.ReminderSchedulerExpiringWarranty)
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"log"
+	"pelita/config"
+	"pelita/service"
+)
+
+func SetUpTelegram(telegramService service.TelegramService) {
+	// Webhook Mode Is Handled By The Webhook Route
+	if config.GetTelegramUpdateMode() != "polling" {
+		return
+	}
+
+	// Long Polling
+	go func() {
+		if err := telegramService.StartPolling(); err != nil {
+			log.Println("Failed to start telegram polling:", err)
+		}
+	}()
+}
@@ -0,0 +1,47 @@
+package scheduler
+
+import (
+	"log"
+	"os"
+	"pelita/entity"
+	"pelita/utils"
+	"strconv"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// Send The Same Message To Every Admin With A Valid Telegram Account, True When At Least One Admin Received It
+func sendAdminMessage(adminContacts []entity.AdminContact, message string, topic string) bool {
+	bot, err := utils.NewTelegramBot(os.Getenv("TELEGRAM_BOT_TOKEN"))
+	if err != nil {
+		log.Println("Failed to connect to Telegram bot:", err)
+		return false
+	}
+
+	isSent := false
+
+	for _, contact := range adminContacts {
+		if contact.TelegramUserId == nil || !contact.TelegramIsValid {
+			continue
+		}
+
+		telegramID, err := strconv.ParseInt(*contact.TelegramUserId, 10, 64)
+		if err != nil {
+			log.Printf("Invalid Telegram ID for admin %s: %v\n", contact.Username, err)
+			continue
+		}
+
+		msg := tgbotapi.NewMessage(telegramID, message)
+		msg.ParseMode = "Markdown"
+
+		_, err = bot.Send(msg)
+		if err != nil {
+			log.Printf("Failed to send message to admin %s: %v\n", contact.Username, err)
+		} else {
+			log.Printf("%s sent to admin %s (%s)\n", topic, contact.Username, *contact.TelegramUserId)
+			isSent = true
+		}
+	}
+
+	return isSent
+}
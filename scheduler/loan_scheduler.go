@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"fmt"
+	"log"
+	"pelita/service"
+)
+
+type AssetLoanScheduler struct {
+	AssetLoanService service.AssetLoanService
+	AdminService     service.AdminService
+}
+
+func NewAssetLoanScheduler(
+	assetLoanService service.AssetLoanService,
+	adminService service.AdminService,
+) *AssetLoanScheduler {
+	return &AssetLoanScheduler{
+		AssetLoanService: assetLoanService,
+		AdminService:     adminService,
+	}
+}
+
+func (s *AssetLoanScheduler) ReminderSchedulerOverdueLoan() {
+	// Service : Get All Overdue Asset Loan
+	overdueLoans, err := s.AssetLoanService.GetAllOverdueAssetLoan()
+	if err != nil {
+		log.Println("Failed to fetch overdue asset loans:", err)
+		return
+	}
+
+	if len(overdueLoans) == 0 {
+		log.Println("No overdue asset loan.")
+		return
+	}
+
+	// Service : Get All Admin Contact
+	adminContacts, err := s.AdminService.GetAllContact()
+	if err != nil {
+		log.Println("Failed to fetch admin contacts:", err)
+		return
+	}
+
+	// Admin Message
+	fullMessage := "📦 *Overdue Asset Loan:*\n\n"
+	for i, loan := range overdueLoans {
+		fullMessage += fmt.Sprintf("%d. %s\n📍 Floor %s - %s\n👤 %s (%s)\n⏰ Due %s\n\n",
+			i+1,
+			loan.AssetName,
+			loan.Floor,
+			loan.RoomName,
+			loan.BorrowerName,
+			loan.BorrowerType,
+			loan.DueAt.Format("2006-01-02 15:04"),
+		)
+	}
+
+	// Send Admin Message
+	sendAdminMessage(adminContacts, fullMessage, "Overdue asset loan")
+}
@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"log"
+	"pelita/config"
+	"pelita/service"
+	"time"
+)
+
+type PurgeScheduler struct {
+	AssetService            service.AssetService
+	RoomService             service.RoomService
+	TechnicianService       service.TechnicianService
+	AssetPlacementService   service.AssetPlacementService
+	AssetMaintenanceService service.AssetMaintenanceService
+	AssetFindingService     service.AssetFindingService
+}
+
+func NewPurgeScheduler(
+	assetService service.AssetService,
+	roomService service.RoomService,
+	technicianService service.TechnicianService,
+	assetPlacementService service.AssetPlacementService,
+	assetMaintenanceService service.AssetMaintenanceService,
+	assetFindingService service.AssetFindingService,
+) *PurgeScheduler {
+	return &PurgeScheduler{
+		AssetService:            assetService,
+		RoomService:             roomService,
+		TechnicianService:       technicianService,
+		AssetPlacementService:   assetPlacementService,
+		AssetMaintenanceService: assetMaintenanceService,
+		AssetFindingService:     assetFindingService,
+	}
+}
+
+func (s *PurgeScheduler) PurgeSchedulerSoftDeleted() {
+	// Soft Deleted Row Older Than The Retention Is Permanently Deleted
+	before := time.Now().Add(-config.GetSoftDeleteRetentionDuration())
+
+	// Child Table Go First, So Its Row Is Archived On Its Own Instead Of Along With The Parent
+	purges := []struct {
+		name  string
+		purge func(before time.Time) (int, error)
+	}{
+		{"asset finding", s.AssetFindingService.PurgeDeleted},
+		{"asset maintenance", s.AssetMaintenanceService.PurgeDeleted},
+		{"asset placement", s.AssetPlacementService.PurgeDeleted},
+		{"technician", s.TechnicianService.PurgeDeleted},
+		{"room", s.RoomService.PurgeDeleted},
+		{"asset", s.AssetService.PurgeDeleted},
+	}
+
+	for _, p := range purges {
+		// Service : Purge Deleted
+		total, err := p.purge(before)
+		if err != nil {
+			log.Printf("Failed to purge deleted %s: %v\n", p.name, err)
+			continue
+		}
+
+		log.Printf("Purged %d deleted %s\n", total, p.name)
+	}
+}
@@ -50,7 +50,7 @@ func (s *AssetMaintenanceScheduler) ReminderSchedulerTodayMaintenance() {
 		return
 	}
 
-	bot, err := tgbotapi.NewBotAPI(os.Getenv("TELEGRAM_BOT_TOKEN"))
+	bot, err := utils.NewTelegramBot(os.Getenv("TELEGRAM_BOT_TOKEN"))
 	if err != nil {
 		log.Println("Failed to connect to Telegram bot:", err)
 		return
@@ -154,7 +154,7 @@ func (s *AssetMaintenanceScheduler) AuditSchedulerAssetFindingReport() {
 		return
 	}
 
-	bot, err := tgbotapi.NewBotAPI(os.Getenv("TELEGRAM_BOT_TOKEN"))
+	bot, err := utils.NewTelegramBot(os.Getenv("TELEGRAM_BOT_TOKEN"))
 	if err != nil {
 		log.Println("Failed to connect to Telegram bot:", err)
 		return
@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"fmt"
+	"log"
+	"pelita/config"
+	"pelita/service"
+	"pelita/utils"
+
+	"github.com/google/uuid"
+)
+
+type WarrantyScheduler struct {
+	WarrantyService service.WarrantyService
+	AdminService    service.AdminService
+}
+
+func NewWarrantyScheduler(
+	warrantyService service.WarrantyService,
+	adminService service.AdminService,
+) *WarrantyScheduler {
+	return &WarrantyScheduler{
+		WarrantyService: warrantyService,
+		AdminService:    adminService,
+	}
+}
+
+func (s *WarrantyScheduler) ReminderSchedulerExpiringWarranty() {
+	// Service : Get All Expiring Warranty
+	expiringWarranties, err := s.WarrantyService.GetAllExpiringWarranty()
+	if err != nil {
+		log.Println("Failed to fetch expiring warranties:", err)
+		return
+	}
+
+	if len(expiringWarranties) == 0 {
+		log.Println("No expiring warranty.")
+		return
+	}
+
+	// Service : Get All Admin Contact
+	adminContacts, err := s.AdminService.GetAllContact()
+	if err != nil {
+		log.Println("Failed to fetch admin contacts:", err)
+		return
+	}
+
+	// Admin Message
+	fullMessage := fmt.Sprintf("🛡️ *Warranty Expiring Within %d Days:*\n\n", config.WarrantyReminderDays)
+	for i, warranty := range expiringWarranties {
+		fullMessage += fmt.Sprintf("%d. %s (%s)\n🏢 %s\n📞 %s %s\n⏰ Ends %s\n\n",
+			i+1,
+			warranty.AssetName,
+			warranty.WarrantyType,
+			utils.NullSafeString(warranty.VendorName),
+			utils.NullSafeString(warranty.ContactName),
+			utils.NullSafeString(warranty.ContactPhone),
+			warranty.EndDate.Format("2006-01-02"),
+		)
+	}
+
+	// Send Admin Message
+	if !sendAdminMessage(adminContacts, fullMessage, "Expiring warranty") {
+		return
+	}
+
+	// Service : Mark Warranty As Reminded
+	ids := make([]uuid.UUID, 0, len(expiringWarranties))
+	for _, warranty := range expiringWarranties {
+		ids = append(ids, warranty.ID)
+	}
+	if err := s.WarrantyService.MarkRemindedByIds(ids); err != nil {
+		log.Println("Failed to mark warranty as reminded:", err)
+	}
+}
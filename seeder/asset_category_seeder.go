@@ -0,0 +1,21 @@
+package seeder
+
+import (
+	"fmt"
+	"pelita/factory"
+	"pelita/repository"
+)
+
+func SeedAssetCategories(repo repository.AssetCategoryRepository, count int) {
+	// Fill Table, Category Is Kept Because Asset Outside The Seeder May Still Use It
+	for i := 0; i < count; i++ {
+		assetCategory := factory.GenerateAssetCategory()
+		is_exist, err := repo.FindByCategoryName(assetCategory.CategoryName)
+		if err != nil || is_exist != nil {
+			continue
+		}
+		if err := repo.Create(&assetCategory); err != nil {
+			fmt.Printf("failed to seed asset category %d: %v\n", i, err)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package seeder
 
 import (
 	"fmt"
+	"pelita/config"
 	"pelita/factory"
 	"pelita/repository"
 )
@@ -18,7 +19,8 @@ func SeedAssetPlacements(repo repository.AssetPlacementRepository, adminRepo rep
 		asset, _ := assetRepo.FindOneRandom()
 		assetPlacement := factory.GenerateAssetPlacement(asset.ID, room.ID, technician.ID)
 
-		err := repo.Create(&assetPlacement, admin.ID)
+		// Asset Unit Is Created Along With The Placement
+		err := repo.Create(&assetPlacement, admin.ID, config.GetAssetTagPrefix(), config.GetAssetTagSequenceLength())
 		if err != nil {
 			fmt.Printf("failed to seed asset placement %d: %v\n", i, err)
 		}
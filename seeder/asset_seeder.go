@@ -2,18 +2,29 @@ package seeder
 
 import (
 	"fmt"
+	"math/rand"
 	"pelita/factory"
 	"pelita/repository"
 )
 
-func SeedAssets(repo repository.AssetRepository, adminRepo repository.AdminRepository, count int) {
+func SeedAssets(repo repository.AssetRepository, adminRepo repository.AdminRepository, assetCategoryRepo repository.AssetCategoryRepository, count int) {
 	// Empty Table
 	repo.DeleteAll()
 
+	// Asset Category
+	assetCategories, err := assetCategoryRepo.FindAll()
+	if err != nil || len(assetCategories) == 0 {
+		fmt.Printf("failed to seed asset: asset category not found\n")
+		return
+	}
+
 	// Fill Table
 	for i := 0; i < count; i++ {
 		admin, _ := adminRepo.FindOneRandom()
+		assetCategory := assetCategories[rand.Intn(len(assetCategories))]
 		asset := factory.GenerateAsset()
+		asset.AssetCategoryId = &assetCategory.ID
+		asset.AssetCategory = assetCategory.CategoryName
 		err := repo.Create(&asset, admin.ID)
 		if err != nil {
 			fmt.Printf("failed to seed asset %d: %v\n", i, err)
@@ -0,0 +1,24 @@
+package seeder
+
+import (
+	"fmt"
+	"pelita/config"
+	"pelita/repository"
+)
+
+func SeedRoles(repo repository.RoleRepository) {
+	for _, roleName := range config.SystemRoles {
+		// Admin Role Always Get Every Permission
+		if roleName == config.RoleAdmin {
+			if err := repo.SyncPermissionByRoleName(roleName, config.DefaultRolePermissions[roleName]); err != nil {
+				fmt.Printf("failed to seed role %s: %v\n", roleName, err)
+			}
+			continue
+		}
+
+		// Other System Role Get Default Permission Added Since The Last Seed, Permission Removed By Admin Is Kept Removed
+		if err := repo.SyncNewPermissionByRoleName(roleName, config.DefaultRolePermissions[roleName]); err != nil {
+			fmt.Printf("failed to seed role %s: %v\n", roleName, err)
+		}
+	}
+}
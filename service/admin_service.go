@@ -2,36 +2,183 @@ package service
 
 import (
 	"errors"
+	"pelita/config"
 	"pelita/entity"
 	"pelita/repository"
+	"pelita/utils"
+
+	"github.com/google/uuid"
 )
 
 // Admin Interface
 type AdminService interface {
+	GetAllAdmin(pagination utils.Pagination) ([]entity.Admin, int64, error)
 	GetAllContact() ([]entity.AdminContact, error)
+	Create(admin *entity.Admin) error
+	UpdateById(admin *entity.Admin, id uuid.UUID) error
+	UpdateIsActiveById(isActive bool, id uuid.UUID) error
+	DeleteById(id uuid.UUID) error
 }
 
 // Admin Struct
 type adminService struct {
-	adminRepo repository.AdminRepository
+	adminRepo   repository.AdminRepository
+	sessionRepo repository.SessionRepository
+	userRepo    repository.UserRepository
 }
 
 // Admin Constructor
-func NewAdminService(adminRepo repository.AdminRepository) AdminService {
+func NewAdminService(adminRepo repository.AdminRepository, sessionRepo repository.SessionRepository, userRepo repository.UserRepository) AdminService {
 	return &adminService{
-		adminRepo: adminRepo,
+		adminRepo:   adminRepo,
+		sessionRepo: sessionRepo,
+		userRepo:    userRepo,
+	}
+}
+
+func (s *adminService) GetAllAdmin(pagination utils.Pagination) ([]entity.Admin, int64, error) {
+	// Repo : Get All Admin
+	admin, total, err := s.adminRepo.FindAll(pagination)
+	if err != nil {
+		return nil, 0, err
+	}
+	if admin == nil {
+		return nil, 0, errors.New("admin not found")
 	}
+
+	return admin, total, nil
 }
 
 func (s *adminService) GetAllContact() ([]entity.AdminContact, error) {
-	// Repo : Get All Asset Placement
-	assetPlacement, err := s.adminRepo.FindAllContact()
+	// Repo : Get All Admin Contact
+	adminContact, err := s.adminRepo.FindAllContact()
 	if err != nil {
 		return nil, err
 	}
-	if assetPlacement == nil {
+	if adminContact == nil {
 		return nil, errors.New("admin not found")
 	}
 
-	return assetPlacement, nil
+	return adminContact, nil
+}
+
+func (s *adminService) Create(admin *entity.Admin) error {
+	// Repo : Check Email Across Admin, Technician, and User
+	isUsed, err := s.userRepo.IsEmailUsedByOtherAccount(admin.Email, uuid.Nil)
+	if err != nil {
+		return err
+	}
+	if isUsed {
+		return errors.New("email already used")
+	}
+
+	// Repo : Check Telegram User Id Across Admin, Technician, and User
+	if err := checkTelegramUserIdUsed(s.userRepo, admin.TelegramUserId, uuid.Nil); err != nil {
+		return err
+	}
+
+	// Utils : Hash Password
+	hashedPassword, err := utils.GeneratePasswordHash(admin.Password)
+	if err != nil {
+		return err
+	}
+	admin.Password = hashedPassword
+
+	// Repo : Create Admin
+	if err := s.adminRepo.Create(admin); err != nil {
+		return err
+	}
+	admin.Password = ""
+
+	return nil
+}
+
+func (s *adminService) UpdateById(admin *entity.Admin, id uuid.UUID) error {
+	// Repo : Check Email Across Admin, Technician, and User
+	isUsed, err := s.userRepo.IsEmailUsedByOtherAccount(admin.Email, id)
+	if err != nil {
+		return err
+	}
+	if isUsed {
+		return errors.New("email already used")
+	}
+
+	// Repo : Find Admin By Id
+	existingAdmin, err := s.adminRepo.FindById(id)
+	if err != nil {
+		return err
+	}
+	if existingAdmin == nil {
+		return errors.New("admin not found")
+	}
+
+	// Repo : Check Changed Telegram User Id Across Admin, Technician, and User
+	if !isSameTelegramUserId(existingAdmin.TelegramUserId, admin.TelegramUserId) {
+		if err := checkTelegramUserIdUsed(s.userRepo, admin.TelegramUserId, id); err != nil {
+			return err
+		}
+	}
+
+	// Telegram Account Only Verified Through Telegram Linking
+	admin.TelegramIsValid = existingAdmin.TelegramIsValid && isSameTelegramUserId(existingAdmin.TelegramUserId, admin.TelegramUserId)
+
+	// Repo : Update Admin By Id
+	if err := s.adminRepo.UpdateById(admin, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *adminService) UpdateIsActiveById(isActive bool, id uuid.UUID) error {
+	// Repo : Find Admin By Id
+	admin, err := s.adminRepo.FindById(id)
+	if err != nil {
+		return err
+	}
+	if admin == nil {
+		return errors.New("admin not found")
+	}
+	if admin.IsActive == isActive {
+		return nil
+	}
+
+	// Repo : Update Is Active By Id, The Last Active Admin Can't Be Deactivated
+	if err := s.adminRepo.UpdateIsActiveById(isActive, id); err != nil {
+		return err
+	}
+
+	// Repo : Revoke All Existing Token Of Deactivated Admin
+	if !isActive {
+		return s.revokeAccount(id)
+	}
+
+	return nil
+}
+
+func (s *adminService) DeleteById(id uuid.UUID) error {
+	// Repo : Find Admin By Id
+	admin, err := s.adminRepo.FindById(id)
+	if err != nil {
+		return err
+	}
+	if admin == nil {
+		return errors.New("admin not found")
+	}
+
+	// Repo : Delete Admin By Id, The Last Active Admin Can't Be Deleted
+	if err := s.adminRepo.DeleteById(id); err != nil {
+		return err
+	}
+
+	return s.revokeAccount(id)
+}
+
+func (s *adminService) revokeAccount(id uuid.UUID) error {
+	// Repo : Revoke All Existing Token
+	if err := s.sessionRepo.DeleteByAccountId(id); err != nil {
+		return err
+	}
+
+	return s.sessionRepo.BlacklistAccountById(id, config.GetJWTExpirationDuration())
 }
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"pelita/config"
+	"pelita/entity"
+	"pelita/repository"
+	"pelita/utils"
+
+	"github.com/google/uuid"
+)
+
+// Api Key Interface
+type ApiKeyService interface {
+	GetAllApiKey(pagination utils.Pagination) ([]entity.ApiKey, int64, error)
+	Create(req *entity.RequestPostCreateApiKey, adminId uuid.UUID) (*entity.ApiKeyCreated, error)
+	RevokeById(id uuid.UUID) error
+}
+
+// Api Key Struct
+type apiKeyService struct {
+	apiKeyRepo repository.ApiKeyRepository
+}
+
+// Api Key Constructor
+func NewApiKeyService(apiKeyRepo repository.ApiKeyRepository) ApiKeyService {
+	return &apiKeyService{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+func (s *apiKeyService) GetAllApiKey(pagination utils.Pagination) ([]entity.ApiKey, int64, error) {
+	// Repo : Get All Api Key
+	apiKeys, total, err := s.apiKeyRepo.FindAll(pagination)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(apiKeys) == 0 {
+		return nil, 0, errors.New("api key not found")
+	}
+
+	return apiKeys, total, nil
+}
+
+func (s *apiKeyService) Create(req *entity.RequestPostCreateApiKey, adminId uuid.UUID) (*entity.ApiKeyCreated, error) {
+	// Validator : Scope
+	for _, scope := range req.Scopes {
+		if !utils.Contains(config.ApiKeyScopes, scope) {
+			return nil, fmt.Errorf("scope %s is not valid for api key", scope)
+		}
+	}
+	if !req.ExpiredAt.After(time.Now()) {
+		return nil, errors.New("expired at must be in the future")
+	}
+
+	// Utils : Generate Key, Only The Hash Is Stored
+	token, err := utils.GenerateRandomToken(20)
+	if err != nil {
+		return nil, err
+	}
+	key := "pelita_" + token
+
+	// Repo : Create Api Key
+	apiKey := entity.ApiKey{
+		Name:      req.Name,
+		KeyPrefix: key[:len("pelita_")+6],
+		KeyHash:   utils.HashToken(key),
+		ExpiredAt: req.ExpiredAt,
+		CreatedBy: adminId,
+	}
+	if err := s.apiKeyRepo.Create(&apiKey, req.Scopes); err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiKeyCreated{
+		ApiKey: apiKey,
+		Key:    key,
+	}, nil
+}
+
+func (s *apiKeyService) RevokeById(id uuid.UUID) error {
+	// Repo : Revoke Api Key By Id
+	if err := s.apiKeyRepo.RevokeById(id); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,297 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"pelita/config"
+	"pelita/entity"
+	"pelita/repository"
+	"pelita/utils"
+	"regexp"
+
+	"github.com/google/uuid"
+)
+
+var assetCategoryFieldNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Asset Category Interface
+type AssetCategoryService interface {
+	GetAllAssetCategory() ([]entity.AssetCategory, error)
+	GetById(id uuid.UUID) (*entity.AssetCategory, error)
+	Create(assetCategory *entity.AssetCategory) error
+	UpdateById(assetCategory *entity.AssetCategory, id uuid.UUID) error
+	DeleteById(id uuid.UUID) error
+}
+
+// Asset Category Struct
+type assetCategoryService struct {
+	assetCategoryRepo repository.AssetCategoryRepository
+}
+
+// Asset Category Constructor
+func NewAssetCategoryService(assetCategoryRepo repository.AssetCategoryRepository) AssetCategoryService {
+	return &assetCategoryService{
+		assetCategoryRepo: assetCategoryRepo,
+	}
+}
+
+func (s *assetCategoryService) GetAllAssetCategory() ([]entity.AssetCategory, error) {
+	// Repo : Get All Asset Category
+	assetCategory, err := s.assetCategoryRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(assetCategory) == 0 {
+		return nil, errors.New("asset category not found")
+	}
+
+	// Nest Every Child Category Under Its Parent
+	return buildAssetCategoryTree(assetCategory, nil), nil
+}
+
+func (s *assetCategoryService) GetById(id uuid.UUID) (*entity.AssetCategory, error) {
+	// Repo : Get All Asset Category
+	assetCategory, err := s.assetCategoryRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dt := range assetCategory {
+		if dt.ID == id {
+			dt.Children = buildAssetCategoryTree(assetCategory, &id)
+			return &dt, nil
+		}
+	}
+
+	return nil, errors.New("asset category not found")
+}
+
+func (s *assetCategoryService) Create(assetCategory *entity.AssetCategory) error {
+	// Repo : Get Asset Category By Category Name
+	is_exist, err := s.assetCategoryRepo.FindByCategoryName(assetCategory.CategoryName)
+	if err != nil {
+		return err
+	}
+	if is_exist != nil {
+		return errors.New("asset category already exist")
+	}
+
+	// Validator : Parent And Field Definition
+	if err := s.validateAssetCategory(assetCategory, nil); err != nil {
+		return err
+	}
+
+	// Repo : Create Asset Category
+	if err := s.assetCategoryRepo.Create(assetCategory); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *assetCategoryService) UpdateById(assetCategory *entity.AssetCategory, id uuid.UUID) error {
+	// Repo : Get Asset Category By Category Name
+	is_exist, err := s.assetCategoryRepo.FindByCategoryNameAndId(assetCategory.CategoryName, id)
+	if err != nil {
+		return err
+	}
+	if is_exist != nil {
+		return errors.New("asset category already exist")
+	}
+
+	// Validator : Parent And Field Definition
+	if err := s.validateAssetCategory(assetCategory, &id); err != nil {
+		return err
+	}
+
+	// Repo : Update Asset Category By Id, Parent And Existing Attribute Are Rechecked Under Lock
+	validateParent := func(parentOf map[uuid.UUID]*uuid.UUID) error {
+		return validateAssetCategoryParent(parentOf, assetCategory.ParentId, &id)
+	}
+	validateAttribute := func(assetIds []uuid.UUID, attributes []entity.AssetAttributeValue) ([]entity.AssetAttributeValue, error) {
+		return validateExistingAssetAttribute(assetCategory.FieldDefinitions, assetIds, attributes)
+	}
+	if err := s.assetCategoryRepo.UpdateById(assetCategory, id, validateParent, validateAttribute); err != nil {
+		return err
+	}
+	assetCategory.ID = id
+
+	return nil
+}
+
+func (s *assetCategoryService) DeleteById(id uuid.UUID) error {
+	// Repo : Find Asset Category By Id
+	assetCategory, err := s.assetCategoryRepo.FindById(id)
+	if err != nil {
+		return err
+	}
+	if assetCategory == nil {
+		return errors.New("asset category not found")
+	}
+
+	// Validator : Category Still In Use Can't Be Deleted
+	totalChildren, err := s.assetCategoryRepo.CountChildrenById(id)
+	if err != nil {
+		return err
+	}
+	if totalChildren > 0 {
+		return errors.New("asset category still has child category")
+	}
+	totalAsset, err := s.assetCategoryRepo.CountAssetById(id)
+	if err != nil {
+		return err
+	}
+	if totalAsset > 0 {
+		return fmt.Errorf("asset category is still used by %d asset", totalAsset)
+	}
+
+	// Repo : Delete Asset Category By Id
+	if err := s.assetCategoryRepo.DeleteById(id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *assetCategoryService) validateAssetCategory(assetCategory *entity.AssetCategory, id *uuid.UUID) error {
+	// Validator : Parent Must Exist And Can't Be The Category Itself Or Its Descendant
+	if assetCategory.ParentId != nil {
+		allCategory, err := s.assetCategoryRepo.FindAll()
+		if err != nil {
+			return err
+		}
+		parentOf := map[uuid.UUID]*uuid.UUID{}
+		for _, dt := range allCategory {
+			parentOf[dt.ID] = dt.ParentId
+		}
+		if err := validateAssetCategoryParent(parentOf, assetCategory.ParentId, id); err != nil {
+			return err
+		}
+	}
+
+	// Validator : Field Definition
+	fieldNames := map[string]bool{}
+	for i := range assetCategory.FieldDefinitions {
+		field := &assetCategory.FieldDefinitions[i]
+		if !assetCategoryFieldNamePattern.MatchString(field.FieldName) {
+			return fmt.Errorf("field name %s must be lowercase letter, number, or underscore", field.FieldName)
+		}
+		if fieldNames[field.FieldName] {
+			return fmt.Errorf("field name %s is defined more than once", field.FieldName)
+		}
+		fieldNames[field.FieldName] = true
+		if !utils.Contains(config.AssetCategoryFieldTypes, field.FieldType) {
+			return fmt.Errorf("field type of %s is not valid", field.FieldName)
+		}
+		if field.FieldType == "enum" && len(field.FieldOptions) == 0 {
+			return fmt.Errorf("field %s must have at least one option", field.FieldName)
+		}
+		if field.FieldType != "enum" {
+			field.FieldOptions = nil
+		}
+	}
+
+	return nil
+}
+
+// Parent Must Exist And Can't Be The Category Itself Or Its Descendant, Visited Ancestor Stop A Cycle Already Saved
+func validateAssetCategoryParent(parentOf map[uuid.UUID]*uuid.UUID, parentId, id *uuid.UUID) error {
+	if parentId == nil {
+		return nil
+	}
+	if _, ok := parentOf[*parentId]; !ok {
+		return errors.New("parent category not found")
+	}
+	visited := map[uuid.UUID]bool{}
+	for ancestor := parentId; ancestor != nil && id != nil; ancestor = parentOf[*ancestor] {
+		if *ancestor == *id || visited[*ancestor] {
+			return errors.New("parent category can't be the category itself or its child")
+		}
+		visited[*ancestor] = true
+	}
+
+	return nil
+}
+
+// Attribute Already Saved Must Still Fit The Changed Field Definition, Return The Value Whose Normalized Form Changed
+func validateExistingAssetAttribute(fields []entity.AssetCategoryField, assetIds []uuid.UUID, attributes []entity.AssetAttributeValue) ([]entity.AssetAttributeValue, error) {
+	fieldByName := map[string]entity.AssetCategoryField{}
+	for _, field := range fields {
+		fieldByName[field.FieldName] = field
+	}
+
+	// Validator : Type And Option Of Every Kept Field
+	changed := []entity.AssetAttributeValue{}
+	filled := map[string]map[uuid.UUID]bool{}
+	for _, dt := range attributes {
+		field, ok := fieldByName[dt.FieldName]
+		if !ok {
+			continue
+		}
+		value, err := utils.NormalizeAttributeValue(field.FieldType, dt.AttributeValue, field.FieldOptions)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s of asset %s %s, update the asset first", dt.FieldName, dt.AssetId, err.Error())
+		}
+		if filled[dt.FieldName] == nil {
+			filled[dt.FieldName] = map[uuid.UUID]bool{}
+		}
+		filled[dt.FieldName][dt.AssetId] = true
+		if value != dt.AttributeValue {
+			dt.AttributeValue = value
+			changed = append(changed, dt)
+		}
+	}
+
+	// Validator : Required Field Must Already Be Filled By Every Asset
+	for _, field := range fields {
+		if !field.IsRequired {
+			continue
+		}
+		missing := 0
+		for _, assetId := range assetIds {
+			if !filled[field.FieldName][assetId] {
+				missing++
+			}
+		}
+		if missing > 0 {
+			return nil, fmt.Errorf("field %s can't be required, %d asset has no value for it", field.FieldName, missing)
+		}
+	}
+
+	return changed, nil
+}
+
+// Child Category Of The Given Parent, Nil Parent Return The Root Category
+func buildAssetCategoryTree(assetCategory []entity.AssetCategory, parentId *uuid.UUID) []entity.AssetCategory {
+	tree := []entity.AssetCategory{}
+	for _, dt := range assetCategory {
+		if (parentId == nil && dt.ParentId == nil) || (parentId != nil && dt.ParentId != nil && *dt.ParentId == *parentId) {
+			dt.Children = buildAssetCategoryTree(assetCategory, &dt.ID)
+			tree = append(tree, dt)
+		}
+	}
+
+	return tree
+}
+
+// Asset Is Linked By Category Id, Or By Category Name When The Id Is Empty
+func resolveAssetCategory(assetCategoryRepo repository.AssetCategoryRepository, asset *entity.Asset) (*entity.AssetCategory, error) {
+	var assetCategory *entity.AssetCategory
+	var err error
+	if asset.AssetCategoryId != nil {
+		assetCategory, err = assetCategoryRepo.FindById(*asset.AssetCategoryId)
+	} else {
+		assetCategory, err = assetCategoryRepo.FindByCategoryName(asset.AssetCategory)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if assetCategory == nil {
+		return nil, errors.New("asset category not found")
+	}
+
+	asset.AssetCategoryId = &assetCategory.ID
+	asset.AssetCategory = assetCategory.CategoryName
+
+	return assetCategory, nil
+}
@@ -6,39 +6,59 @@ import (
 	"pelita/entity"
 	"pelita/repository"
 	"pelita/utils"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 // Asset Finding Interface
 type AssetFindingService interface {
-	GetAllAssetFinding(pagination utils.Pagination) ([]entity.AssetFinding, int64, error)
+	GetAllAssetFinding(pagination utils.Pagination, filter utils.Filter, accountId uuid.UUID, role string) ([]entity.AssetFinding, int64, error)
 	GetMostContext(targetCol string) ([]entity.StatsContextTotal, error)
 	GetFindingHourTotal() ([]entity.StatsContextTotal, error)
 	Create(assetFinding *entity.AssetFinding, technicianId, userId uuid.UUID, file *multipart.FileHeader, fileExt string, fileSize int64) error
-	DeleteById(id uuid.UUID) error
+	GetDeleted() ([]entity.AssetFinding, error)
+	GetDeletePreviewById(id, accountId uuid.UUID) (*entity.DeletePreview, error)
+	HardDeleteById(id, accountId uuid.UUID, confirmationToken string) error
+	SoftDeleteById(id uuid.UUID) error
+	RecoverDeletedById(id uuid.UUID) error
 
 	// Scheduler Service
+	PurgeDeleted(before time.Time) (int, error)
 	GetAllAssetFindingReport() ([]entity.AssetFindingReport, error)
 }
 
 // Asset Finding Struct
 type assetFindingService struct {
-	assetFindingRepo repository.AssetFindingRepository
-	statsRepo        repository.StatsRepository
+	assetFindingRepo  repository.AssetFindingRepository
+	technicianRepo    repository.TechnicianRepository
+	statsRepo         repository.StatsRepository
+	assetUnitRepo     repository.AssetUnitRepository
+	warrantyRepo      repository.WarrantyRepository
+	deleteConfirmRepo repository.DeleteConfirmationRepository
 }
 
 // Asset Finding Constructor
-func NewAssetFindingService(assetFindingRepo repository.AssetFindingRepository, statsRepo repository.StatsRepository) AssetFindingService {
+func NewAssetFindingService(assetFindingRepo repository.AssetFindingRepository, technicianRepo repository.TechnicianRepository, statsRepo repository.StatsRepository, assetUnitRepo repository.AssetUnitRepository, warrantyRepo repository.WarrantyRepository, deleteConfirmRepo repository.DeleteConfirmationRepository) AssetFindingService {
 	return &assetFindingService{
-		assetFindingRepo: assetFindingRepo,
-		statsRepo:        statsRepo,
+		assetFindingRepo:  assetFindingRepo,
+		technicianRepo:    technicianRepo,
+		statsRepo:         statsRepo,
+		assetUnitRepo:     assetUnitRepo,
+		warrantyRepo:      warrantyRepo,
+		deleteConfirmRepo: deleteConfirmRepo,
 	}
 }
 
-func (s *assetFindingService) GetAllAssetFinding(pagination utils.Pagination) ([]entity.AssetFinding, int64, error) {
+func (s *assetFindingService) GetAllAssetFinding(pagination utils.Pagination, filter utils.Filter, accountId uuid.UUID, role string) ([]entity.AssetFinding, int64, error) {
+	// Repo : Find Access Scope
+	scope, err := findAccessScope(s.technicianRepo, accountId, role)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// Repo : Get All Asset Finding
-	assetFinding, total, err := s.assetFindingRepo.FindAll(pagination)
+	assetFinding, total, err := s.assetFindingRepo.FindAll(pagination, filter, scope)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -63,6 +83,11 @@ func (s *assetFindingService) GetAllAssetFindingReport() ([]entity.AssetFindingR
 }
 
 func (s *assetFindingService) Create(assetFinding *entity.AssetFinding, technicianId, userId uuid.UUID, file *multipart.FileHeader, fileExt string, fileSize int64) error {
+	// Repo : Validate Asset Unit
+	if err := validateAssetUnit(s.assetUnitRepo, assetFinding.AssetUnitId, assetFinding.AssetPlacementId); err != nil {
+		return err
+	}
+
 	// Utils : Firebase Upload image
 	if file != nil {
 		var createdBy uuid.UUID
@@ -71,10 +96,9 @@ func (s *assetFindingService) Create(assetFinding *entity.AssetFinding, technici
 		} else if userId != uuid.Nil {
 			createdBy = userId
 		}
-		assetImage, err := utils.UploadFile(createdBy, "asset", file, fileExt)
-
+		assetImage, err := uploadFindingImage(createdBy, file)
 		if err != nil {
-			return errors.New(err.Error())
+			return err
 		}
 		assetFinding.FindingImage = &assetImage
 	} else {
@@ -86,12 +110,90 @@ func (s *assetFindingService) Create(assetFinding *entity.AssetFinding, technici
 		return err
 	}
 
+	// Repo : Broken Asset Should Be Claimed When Still Under Warranty
+	if assetFinding.FindingCategory == "broken" {
+		warrantyStatus, err := findAssetWarrantyStatus(s.warrantyRepo, assetFinding.AssetPlacementId)
+		if err != nil {
+			return err
+		}
+		assetFinding.WarrantyStatus = warrantyStatus
+	}
+
 	return nil
 }
 
-func (s *assetFindingService) DeleteById(id uuid.UUID) error {
-	// Repo : Delete Asset Finding By Id
-	err := s.assetFindingRepo.DeleteById(id)
+func (s *assetFindingService) GetDeleted() ([]entity.AssetFinding, error) {
+	// Repo : Get All Deleted Asset Finding
+	assetFinding, err := s.assetFindingRepo.FindDeleted()
+	if err != nil {
+		return nil, err
+	}
+	if assetFinding == nil {
+		return nil, errors.New("deleted asset finding not found")
+	}
+
+	return assetFinding, nil
+}
+
+func (s *assetFindingService) GetDeletePreviewById(id, accountId uuid.UUID) (*entity.DeletePreview, error) {
+	// Repo : Find Every Row That Is Cascaded Along With The Deleted Asset Finding
+	dependencies, err := s.assetFindingRepo.FindDeleteDependencyById(id)
+	if err != nil {
+		return nil, err
+	}
+	if dependencies == nil {
+		return nil, errors.New("deleted asset finding not found")
+	}
+
+	return buildDeletePreview(s.deleteConfirmRepo, "asset_findings", id, accountId, dependencies)
+}
+
+func (s *assetFindingService) HardDeleteById(id, accountId uuid.UUID, confirmationToken string) error {
+	// Repo : Find Every Row That Is Cascaded Along With The Deleted Asset Finding
+	dependencies, err := s.assetFindingRepo.FindDeleteDependencyById(id)
+	if err != nil {
+		return err
+	}
+	if dependencies == nil {
+		return errors.New("deleted asset finding not found")
+	}
+
+	// Repo : Consume Delete Confirmation
+	if err := consumeDeleteConfirmation(s.deleteConfirmRepo, confirmationToken, "asset_findings", id, accountId, dependencies); err != nil {
+		return err
+	}
+
+	// Repo : Find Every Stored File Before Its Record Is Cascaded
+	files, err := s.assetFindingRepo.FindAllStoredFileByDeletedId(id)
+	if err != nil {
+		return err
+	}
+
+	// Repo : Delete Asset Finding By Id, Cascaded Row Is Archived
+	err = s.assetFindingRepo.HardDeleteById(id, accountId)
+	if err != nil {
+		return err
+	}
+
+	// Utils : Firebase Delete Stored File
+	deleteStoredFile(files)
+
+	return nil
+}
+
+func (s *assetFindingService) SoftDeleteById(id uuid.UUID) error {
+	// Repo : Soft Delete Asset Finding By Id
+	err := s.assetFindingRepo.SoftDeleteById(id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *assetFindingService) RecoverDeletedById(id uuid.UUID) error {
+	// Repo : Recover Asset Finding By Id
+	err := s.assetFindingRepo.RecoverDeletedById(id)
 	if err != nil {
 		return err
 	}
@@ -99,6 +201,11 @@ func (s *assetFindingService) DeleteById(id uuid.UUID) error {
 	return nil
 }
 
+func (s *assetFindingService) PurgeDeleted(before time.Time) (int, error) {
+	// Repo : Permanently Delete Asset Finding That Has Passed The Retention
+	return purgeDeleted(s.assetFindingRepo.FindAllDeletedIdBefore, s.assetFindingRepo.FindAllStoredFileByDeletedId, s.assetFindingRepo.HardDeleteById, before)
+}
+
 func (s *assetFindingService) GetMostContext(targetCol string) ([]entity.StatsContextTotal, error) {
 	// Repo : Get Most Context
 	asset, err := s.statsRepo.FindMostUsedContext("asset_findings", targetCol)
@@ -124,3 +231,20 @@ func (s *assetFindingService) GetFindingHourTotal() ([]entity.StatsContextTotal,
 
 	return asset, nil
 }
+
+func uploadFindingImage(createdBy uuid.UUID, file *multipart.FileHeader) (string, error) {
+	fileReader, err := file.Open()
+	if err != nil {
+		return "", errors.New("failed to open the file")
+	}
+	defer fileReader.Close()
+
+	// Utils : Strip Metadata, Same As The Asset Image
+	processed, err := utils.ProcessImage(fileReader)
+	if err != nil {
+		return "", err
+	}
+
+	// Utils : Firebase Upload Image
+	return utils.UploadBytes(createdBy, "asset", processed.Image, processed.FileExt)
+}
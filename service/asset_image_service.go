@@ -0,0 +1,203 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"pelita/config"
+	"pelita/entity"
+	"pelita/repository"
+	"pelita/utils"
+
+	"github.com/google/uuid"
+)
+
+// Asset Image Interface
+type AssetImageService interface {
+	GetAllByAssetId(assetId uuid.UUID) ([]entity.AssetImage, error)
+	Create(assetId, adminId uuid.UUID, files []*multipart.FileHeader) ([]entity.AssetImage, error)
+	UpdateOrderByAssetId(assetId uuid.UUID, ids []uuid.UUID) error
+	UpdatePrimaryById(id uuid.UUID) error
+	DeleteById(id uuid.UUID) error
+}
+
+// Asset Image Struct
+type assetImageService struct {
+	assetImageRepo repository.AssetImageRepository
+	assetRepo      repository.AssetRepository
+}
+
+// Asset Image Constructor
+func NewAssetImageService(assetImageRepo repository.AssetImageRepository, assetRepo repository.AssetRepository) AssetImageService {
+	return &assetImageService{
+		assetImageRepo: assetImageRepo,
+		assetRepo:      assetRepo,
+	}
+}
+
+func (s *assetImageService) GetAllByAssetId(assetId uuid.UUID) ([]entity.AssetImage, error) {
+	// Repo : Get All Asset Image By Asset Id
+	assetImage, err := s.assetImageRepo.FindAllByAssetId(assetId)
+	if err != nil {
+		return nil, err
+	}
+	if len(assetImage) == 0 {
+		return nil, errors.New("asset image not found")
+	}
+
+	return assetImage, nil
+}
+
+func (s *assetImageService) Create(assetId, adminId uuid.UUID, files []*multipart.FileHeader) ([]entity.AssetImage, error) {
+	// Repo : Find Asset By Id
+	asset, err := s.assetRepo.FindById(assetId)
+	if err != nil {
+		return nil, err
+	}
+	if asset == nil || asset.DeletedAt != nil {
+		return nil, errors.New("asset not found")
+	}
+
+	// Validator : Gallery Size
+	existingImage, err := s.assetImageRepo.FindAllByAssetId(assetId)
+	if err != nil {
+		return nil, err
+	}
+	if len(existingImage)+len(files) > config.ConfigFile.MaxAssetImage {
+		return nil, fmt.Errorf("asset can only have up to %d images", config.ConfigFile.MaxAssetImage)
+	}
+
+	// Utils : Firebase Upload Image And Its Thumbnail
+	assetImage := make([]entity.AssetImage, 0, len(files))
+	for _, file := range files {
+		image, err := uploadAssetImage(adminId, file)
+		if err != nil {
+			deleteAssetImageFile(assetImage)
+			return nil, err
+		}
+		assetImage = append(assetImage, *image)
+	}
+
+	// Repo : Create Asset Image
+	if err := s.assetImageRepo.Create(assetImage, assetId, adminId); err != nil {
+		deleteAssetImageFile(assetImage)
+		return nil, err
+	}
+
+	return assetImage, nil
+}
+
+func (s *assetImageService) UpdateOrderByAssetId(assetId uuid.UUID, ids []uuid.UUID) error {
+	// Repo : Get All Asset Image By Asset Id
+	assetImage, err := s.assetImageRepo.FindAllByAssetId(assetId)
+	if err != nil {
+		return err
+	}
+	if len(assetImage) == 0 {
+		return errors.New("asset image not found")
+	}
+
+	// Validator : Every Image Of The Asset Must Be Listed Exactly Once
+	if len(ids) != len(assetImage) {
+		return errors.New("image ids must contain every image of the asset")
+	}
+	listed := map[uuid.UUID]bool{}
+	for _, id := range ids {
+		listed[id] = true
+	}
+	for _, dt := range assetImage {
+		if !listed[dt.ID] {
+			return errors.New("image ids must contain every image of the asset")
+		}
+	}
+
+	// Repo : Update Asset Image Order
+	return s.assetImageRepo.UpdateOrderByAssetId(assetId, ids)
+}
+
+func (s *assetImageService) UpdatePrimaryById(id uuid.UUID) error {
+	// Repo : Find Asset Image By Id
+	assetImage, err := s.assetImageRepo.FindById(id)
+	if err != nil {
+		return err
+	}
+	if assetImage == nil {
+		return errors.New("asset image not found")
+	}
+	if assetImage.IsPrimary {
+		return errors.New("asset image is already the primary image")
+	}
+
+	// Repo : Update Asset Image Primary
+	return s.assetImageRepo.UpdatePrimaryById(assetImage)
+}
+
+func (s *assetImageService) DeleteById(id uuid.UUID) error {
+	// Repo : Find Asset Image By Id
+	assetImage, err := s.assetImageRepo.FindById(id)
+	if err != nil {
+		return err
+	}
+	if assetImage == nil {
+		return errors.New("asset image not found")
+	}
+
+	// Repo : Delete Asset Image
+	if err := s.assetImageRepo.DeleteById(assetImage); err != nil {
+		return err
+	}
+
+	// Utils : Firebase Delete Image And Its Thumbnail
+	deleteAssetImageFile([]entity.AssetImage{*assetImage})
+
+	return nil
+}
+
+func uploadAssetImage(adminId uuid.UUID, file *multipart.FileHeader) (*entity.AssetImage, error) {
+	fileReader, err := file.Open()
+	if err != nil {
+		return nil, errors.New("failed to open the file")
+	}
+	defer fileReader.Close()
+
+	// Utils : Strip Metadata And Generate Thumbnail
+	processed, err := utils.ProcessImage(fileReader)
+	if err != nil {
+		return nil, err
+	}
+
+	// Utils : Firebase Upload Image
+	imageURL, err := utils.UploadBytes(adminId, "asset", processed.Image, processed.FileExt)
+	if err != nil {
+		return nil, err
+	}
+	thumbnailURL, err := utils.UploadBytes(adminId, "asset/thumbnail", processed.Thumbnail, processed.FileExt)
+	if err != nil {
+		deleteStoredFile([]string{imageURL})
+		return nil, err
+	}
+
+	return &entity.AssetImage{ImageURL: imageURL, ThumbnailURL: &thumbnailURL}, nil
+}
+
+func deleteAssetImageFile(assetImage []entity.AssetImage) {
+	files := []string{}
+	for _, dt := range assetImage {
+		files = append(files, dt.ImageURL)
+		if dt.ThumbnailURL != nil {
+			files = append(files, *dt.ThumbnailURL)
+		}
+	}
+
+	deleteStoredFile(files)
+}
+
+// The Record Is Already Gone, A File That Failed To Be Deleted Is Only Logged
+func deleteStoredFile(files []string) {
+	for _, file := range files {
+		if err := utils.DeleteFile(file); err != nil {
+			log.Printf("failed to delete stored file %s: %v\n", file, err)
+		}
+	}
+}
@@ -0,0 +1,419 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"pelita/config"
+	"pelita/entity"
+	"pelita/repository"
+	"pelita/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Asset Import Interface
+type AssetImportService interface {
+	Import(rows [][]string, adminId uuid.UUID, dryRun bool) (*entity.AssetImportReport, error)
+	Export() ([][]string, error)
+}
+
+// Asset Import Struct
+type assetImportService struct {
+	assetRepo         repository.AssetRepository
+	roomRepo          repository.RoomRepository
+	technicianRepo    repository.TechnicianRepository
+	assetCategoryRepo repository.AssetCategoryRepository
+}
+
+// Asset Import Constructor
+func NewAssetImportService(assetRepo repository.AssetRepository, roomRepo repository.RoomRepository, technicianRepo repository.TechnicianRepository, assetCategoryRepo repository.AssetCategoryRepository) AssetImportService {
+	return &assetImportService{
+		assetRepo:         assetRepo,
+		roomRepo:          roomRepo,
+		technicianRepo:    technicianRepo,
+		assetCategoryRepo: assetCategoryRepo,
+	}
+}
+
+// Custom Attribute Column Is Named After The Field Name Of The Asset Category
+const assetImportAttributePrefix = "attr."
+
+// Column That Identify The Asset Or Belong To The Asset Placement, Every Other Column Must Match On A Repeated Asset Row
+var assetImportMergeColumns = []string{"asset_name", "asset_category", "asset_merk", "floor", "room_name", "asset_qty", "owner_email", "force"}
+
+// Asset Import Row, Every Error Found Is Collected Instead Of Stopping At The First One
+type assetImportRow struct {
+	row    int
+	cells  []string
+	header map[string]int
+	errors []entity.AssetImportError
+}
+
+func (r *assetImportRow) get(column string) string {
+	idx, ok := r.header[column]
+	if !ok || idx >= len(r.cells) {
+		return ""
+	}
+
+	return r.cells[idx]
+}
+
+func (r *assetImportRow) fail(column, message string) {
+	r.errors = append(r.errors, entity.AssetImportError{Row: r.row, Column: column, Message: message})
+}
+
+func (s *assetImportService) Import(rows [][]string, adminId uuid.UUID, dryRun bool) (*entity.AssetImportReport, error) {
+	if len(rows) < 2 {
+		return nil, errors.New("file has no asset row")
+	}
+
+	// Validator : Header
+	header := map[string]int{}
+	for i, column := range rows[0] {
+		header[strings.ToLower(column)] = i
+	}
+	for _, column := range []string{"asset_name", "asset_category", "asset_status"} {
+		if _, ok := header[column]; !ok {
+			return nil, fmt.Errorf("column %s is required", column)
+		}
+	}
+
+	// Lookup Cache
+	rooms := map[string]*entity.Room{}
+	technicians := map[string]*entity.Technician{}
+	categories := map[string]*entity.AssetCategory{}
+	assetIndex := map[string]int{}
+	assetRow := map[string]*assetImportRow{}
+	placementIndex := map[string]int{}
+
+	report := entity.AssetImportReport{DryRun: dryRun, Errors: []entity.AssetImportError{}}
+	assetImport := []entity.AssetImport{}
+	for i, cells := range rows[1:] {
+		// Empty Row Is Skipped, But Still Counted In The Row Number
+		if utils.IsEmptySpreadsheetRow(cells) {
+			continue
+		}
+		row := &assetImportRow{row: i + 2, cells: cells, header: header}
+		report.TotalRow++
+
+		// Same Asset In Several Row Only Add Its Placement, Its Other Column Must Be Empty Or Match The First Row
+		assetName := row.get("asset_name")
+		assetCategory := row.get("asset_category")
+		assetMerk := utils.OptionalString(row.get("asset_merk"))
+		assetKey := strings.ToLower(fmt.Sprintf("%s|%s|%s", assetName, utils.NormalizeCategoryKey(assetCategory), utils.NullSafeString(assetMerk)))
+		idx, isExist := assetIndex[assetKey]
+		if isExist {
+			validateAssetImportConflict(row, assetRow[assetKey])
+		} else {
+			asset, err := s.parseAsset(row, assetName, assetCategory, assetMerk, categories)
+			if err != nil {
+				return nil, err
+			}
+			if len(row.errors) == 0 {
+				idx = len(assetImport)
+				assetIndex[assetKey] = idx
+				assetRow[assetKey] = row
+				assetImport = append(assetImport, entity.AssetImport{Row: row.row, Asset: *asset})
+			}
+		}
+
+		// Optional Placement
+		placement, err := s.parsePlacement(row, rooms, technicians)
+		if err != nil {
+			return nil, err
+		}
+		if placement != nil && len(row.errors) == 0 {
+			placementKey := fmt.Sprintf("%s|%s", assetKey, placement.RoomId)
+			if prevRow, ok := placementIndex[placementKey]; ok {
+				row.fail("room_name", fmt.Sprintf("asset is already placed in this room on row %d", prevRow))
+			} else {
+				placementIndex[placementKey] = row.row
+				assetImport[idx].AssetPlacement = append(assetImport[idx].AssetPlacement, *placement)
+				report.TotalAssetPlacement++
+			}
+		}
+
+		report.Errors = append(report.Errors, row.errors...)
+	}
+	if report.TotalRow == 0 {
+		return nil, errors.New("file has no asset row")
+	}
+	report.TotalAsset = len(assetImport)
+
+	// Nothing Is Saved On Dry Run Or When Any Row Is Invalid
+	if dryRun || len(report.Errors) > 0 {
+		return &report, nil
+	}
+
+	// Repo : Create Every Asset And Placement In A Single Transaction
+	if err := s.assetRepo.CreateBulk(assetImport, adminId, config.GetAssetTagPrefix(), config.GetAssetTagSequenceLength()); err != nil {
+		return nil, err
+	}
+	report.IsCommitted = true
+
+	return &report, nil
+}
+
+func (s *assetImportService) Export() ([][]string, error) {
+	// Repo : Get All Asset Export
+	assetExport, err := s.assetRepo.FindAllExport()
+	if err != nil {
+		return nil, err
+	}
+	if len(assetExport) == 0 {
+		return nil, errors.New("asset not found")
+	}
+
+	// Repo : Get All Asset Category, Every Custom Attribute Get Its Own Column
+	assetCategory, err := s.assetCategoryRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	attributeColumns := []string{}
+	for _, category := range assetCategory {
+		for _, field := range category.FieldDefinitions {
+			if !utils.Contains(attributeColumns, field.FieldName) {
+				attributeColumns = append(attributeColumns, field.FieldName)
+			}
+		}
+	}
+
+	// Same Column As The Import, So The File Can Be Imported Back
+	header := append([]string{}, config.AssetImportColumns...)
+	for _, column := range attributeColumns {
+		header = append(header, assetImportAttributePrefix+column)
+	}
+	rows := [][]string{header}
+	for _, dt := range assetExport {
+		row := []string{
+			dt.AssetName,
+			utils.OptionalCell(dt.AssetDesc),
+			utils.OptionalCell(dt.AssetMerk),
+			dt.AssetCategory,
+			formatImportMoney(dt.AssetPrice),
+			dt.AssetCurrency,
+			dt.AssetStatus,
+			formatImportDate(dt.PurchaseDate),
+			formatImportInt(dt.UsefulLife),
+			formatImportMoney(dt.SalvageValue),
+			dt.DepreciationMethod,
+			utils.OptionalCell(dt.Floor),
+			utils.OptionalCell(dt.RoomName),
+			formatImportInt(dt.AssetQty),
+			utils.OptionalCell(dt.OwnerEmail),
+		}
+		for _, column := range attributeColumns {
+			row = append(row, dt.Attributes[column])
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func (s *assetImportService) parseAsset(row *assetImportRow, assetName, assetCategory string, assetMerk *string, categories map[string]*entity.AssetCategory) (*entity.Asset, error) {
+	asset := entity.Asset{
+		AssetName:          assetName,
+		AssetDesc:          utils.OptionalString(row.get("asset_desc")),
+		AssetMerk:          assetMerk,
+		AssetCategory:      assetCategory,
+		AssetCurrency:      strings.ToUpper(row.get("asset_currency")),
+		AssetStatus:        row.get("asset_status"),
+		DepreciationMethod: row.get("depreciation_method"),
+	}
+
+	// Validator Field
+	if asset.AssetName == "" {
+		row.fail("asset_name", "asset name is required")
+	}
+	if asset.AssetCategory == "" {
+		row.fail("asset_category", "asset category is required")
+	}
+	if !utils.Contains(config.AssetInitialStatus, asset.AssetStatus) {
+		row.fail("asset_status", fmt.Sprintf("asset status must be one of %s", strings.Join(config.AssetInitialStatus, ", ")))
+	}
+	if value := row.get("asset_price"); value != "" {
+		price, err := utils.ParseMoney(value)
+		if err != nil {
+			row.fail("asset_price", "asset price must be a number")
+		}
+		asset.AssetPrice = &price
+	}
+	if value := row.get("salvage_value"); value != "" {
+		salvageValue, err := utils.ParseMoney(value)
+		if err != nil {
+			row.fail("salvage_value", "salvage value must be a number")
+		}
+		asset.SalvageValue = &salvageValue
+	}
+	if value := row.get("useful_life"); value != "" {
+		usefulLife, err := strconv.Atoi(value)
+		if err != nil {
+			row.fail("useful_life", "useful life must be a number")
+		}
+		asset.UsefulLife = &usefulLife
+	}
+	if value := row.get("purchase_date"); value != "" {
+		purchaseDate, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			row.fail("purchase_date", "purchase date must be in YYYY-MM-DD format")
+		}
+		asset.PurchaseDate = &purchaseDate
+	}
+	if len(row.errors) > 0 {
+		return &asset, nil
+	}
+
+	// Repo : Find Asset Category By Category Name
+	categoryKey := utils.NormalizeCategoryKey(asset.AssetCategory)
+	category, ok := categories[categoryKey]
+	if !ok {
+		var err error
+		category, err = s.assetCategoryRepo.FindByCategoryName(asset.AssetCategory)
+		if err != nil {
+			return nil, err
+		}
+		categories[categoryKey] = category
+	}
+	if category == nil {
+		row.fail("asset_category", "asset category not found")
+		return &asset, nil
+	}
+	asset.AssetCategoryId = &category.ID
+	asset.AssetCategory = category.CategoryName
+
+	// Validator : Custom Attribute, Empty Cell Is Left Out
+	asset.Attributes = entity.AssetAttributes{}
+	for column := range row.header {
+		if fieldName, ok := strings.CutPrefix(column, assetImportAttributePrefix); ok && row.get(column) != "" {
+			asset.Attributes[fieldName] = row.get(column)
+		}
+	}
+	if err := validateAssetAttribute(category, &asset); err != nil {
+		row.fail("", err.Error())
+		return &asset, nil
+	}
+
+	// Validator : Asset Valuation, Error Can Come From Several Column
+	if err := validateAssetValuation(&asset); err != nil {
+		row.fail("", err.Error())
+		return &asset, nil
+	}
+
+	// Repo : Find Similar Asset, Skipped When The Row Is Forced
+	force, _ := strconv.ParseBool(row.get("force"))
+	if !force {
+		duplicates, err := findAssetDuplicate(s.assetRepo, &asset, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(duplicates) > 0 {
+			names := []string{}
+			for _, dt := range duplicates {
+				names = append(names, fmt.Sprintf("%s (%.0f%%)", dt.AssetName, dt.Similarity*100))
+			}
+			row.fail("asset_name", fmt.Sprintf("asset may already exist as %s, set force to true to import it anyway", strings.Join(names, ", ")))
+		}
+	}
+
+	return &asset, nil
+}
+
+// Asset Column Of A Repeated Asset Row Would Be Dropped On Merge, So Any Different Value Is Reported
+func validateAssetImportConflict(row, firstRow *assetImportRow) {
+	for column := range row.header {
+		if utils.Contains(assetImportMergeColumns, column) {
+			continue
+		}
+
+		value, firstValue := row.get(column), firstRow.get(column)
+		if column == "asset_price" || column == "salvage_value" {
+			money, err := utils.ParseMoney(value)
+			firstMoney, firstErr := utils.ParseMoney(firstValue)
+			if err == nil && firstErr == nil && money == firstMoney {
+				continue
+			}
+		}
+		if value != "" && !strings.EqualFold(value, firstValue) {
+			row.fail(column, fmt.Sprintf("%s is different from the same asset on row %d", column, firstRow.row))
+		}
+	}
+}
+
+func (s *assetImportService) parsePlacement(row *assetImportRow, rooms map[string]*entity.Room, technicians map[string]*entity.Technician) (*entity.AssetPlacement, error) {
+	floor := row.get("floor")
+	roomName := row.get("room_name")
+	assetQty := row.get("asset_qty")
+	ownerEmail := row.get("owner_email")
+	if floor == "" && roomName == "" && assetQty == "" && ownerEmail == "" {
+		return nil, nil
+	}
+
+	// Validator Field
+	var placement entity.AssetPlacement
+	qty, err := strconv.Atoi(assetQty)
+	if err != nil || qty < 1 {
+		row.fail("asset_qty", "asset qty must be at least 1")
+	}
+	placement.AssetQty = qty
+
+	// Repo : Find Room By Room Name & Floor
+	roomKey := strings.ToLower(floor + "|" + roomName)
+	room, ok := rooms[roomKey]
+	if !ok {
+		room, err = s.roomRepo.FindByRoomNameAndFloor(roomName, floor)
+		if err != nil {
+			return nil, err
+		}
+		rooms[roomKey] = room
+	}
+	if room == nil {
+		row.fail("room_name", fmt.Sprintf("room %s on floor %s not found", roomName, floor))
+	} else {
+		placement.RoomId = room.ID
+	}
+
+	// Repo : Find Technician By Email
+	technician, ok := technicians[strings.ToLower(ownerEmail)]
+	if !ok {
+		technician, err = s.technicianRepo.FindByEmail(ownerEmail)
+		if err != nil {
+			return nil, err
+		}
+		technicians[strings.ToLower(ownerEmail)] = technician
+	}
+	if technician == nil {
+		row.fail("owner_email", "technician not found")
+	} else {
+		placement.AssetOwner = technician.ID
+	}
+
+	return &placement, nil
+}
+
+func formatImportMoney(value *entity.Money) string {
+	if value == nil {
+		return ""
+	}
+
+	return value.String()
+}
+
+func formatImportInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+
+	return strconv.Itoa(*value)
+}
+
+func formatImportDate(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+
+	return value.Format("2006-01-02")
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"pelita/config"
+	"pelita/entity"
+	"pelita/repository"
+	"pelita/utils"
+
+	"github.com/google/uuid"
+)
+
+// Asset Loan Interface
+type AssetLoanService interface {
+	GetAllAssetLoan(pagination utils.Pagination, accountId uuid.UUID, role string) ([]entity.AssetLoan, int64, error)
+	GetMyAssetLoan(pagination utils.Pagination, accountId uuid.UUID) ([]entity.AssetLoan, int64, error)
+	GetAllOverdueAssetLoan() ([]entity.AssetLoanOverdue, error)
+	Create(assetLoan *entity.AssetLoan, borrowerId uuid.UUID, role string) error
+	ApproveById(id, adminId uuid.UUID) error
+	RejectById(id, adminId uuid.UUID) error
+	CheckOutById(id, accountId uuid.UUID, role string) error
+	ReturnById(returnCondition string, returnNotes *string, id, accountId uuid.UUID, role string) error
+}
+
+// Asset Loan Struct
+type assetLoanService struct {
+	assetLoanRepo      repository.AssetLoanRepository
+	assetPlacementRepo repository.AssetPlacementRepository
+	assetRepo          repository.AssetRepository
+	technicianRepo     repository.TechnicianRepository
+}
+
+// Asset Loan Constructor
+func NewAssetLoanService(assetLoanRepo repository.AssetLoanRepository, assetPlacementRepo repository.AssetPlacementRepository, assetRepo repository.AssetRepository, technicianRepo repository.TechnicianRepository) AssetLoanService {
+	return &assetLoanService{
+		assetLoanRepo:      assetLoanRepo,
+		assetPlacementRepo: assetPlacementRepo,
+		assetRepo:          assetRepo,
+		technicianRepo:     technicianRepo,
+	}
+}
+
+func (s *assetLoanService) GetAllAssetLoan(pagination utils.Pagination, accountId uuid.UUID, role string) ([]entity.AssetLoan, int64, error) {
+	// Repo : Find Access Scope
+	scope, err := findAccessScope(s.technicianRepo, accountId, role)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Repo : Get All Asset Loan
+	assetLoan, total, err := s.assetLoanRepo.FindAll(pagination, scope)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(assetLoan) == 0 {
+		return nil, 0, errors.New("asset loan not found")
+	}
+
+	return assetLoan, total, nil
+}
+
+func (s *assetLoanService) GetMyAssetLoan(pagination utils.Pagination, accountId uuid.UUID) ([]entity.AssetLoan, int64, error) {
+	// Repo : Get All Asset Loan By Borrower Id
+	assetLoan, total, err := s.assetLoanRepo.FindAllByBorrowerId(pagination, accountId)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(assetLoan) == 0 {
+		return nil, 0, errors.New("asset loan not found")
+	}
+
+	return assetLoan, total, nil
+}
+
+func (s *assetLoanService) GetAllOverdueAssetLoan() ([]entity.AssetLoanOverdue, error) {
+	// Repo : Get All Checked Out Loan Past Its Due Date
+	assetLoan, err := s.assetLoanRepo.FindAllOverdue(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return assetLoan, nil
+}
+
+func (s *assetLoanService) Create(assetLoan *entity.AssetLoan, borrowerId uuid.UUID, role string) error {
+	// Validator : Due Date
+	if !assetLoan.DueAt.After(time.Now()) {
+		return errors.New("due at must be in the future")
+	}
+
+	// Repo : Find Asset Placement By Id
+	assetPlacement, err := s.assetPlacementRepo.FindById(assetLoan.AssetPlacementId)
+	if err != nil {
+		return err
+	}
+	if assetPlacement == nil {
+		return errors.New("asset placement not found")
+	}
+
+	// Repo : Find Asset By Asset Placement Id
+	asset, err := s.assetRepo.FindByAssetPlacementId(assetLoan.AssetPlacementId)
+	if err != nil {
+		return err
+	}
+	if asset == nil || asset.DeletedAt != nil {
+		return errors.New("asset not found")
+	}
+	if asset.AssetStatus != "available" && asset.AssetStatus != "in-use" {
+		return fmt.Errorf("asset is %s and can't be loaned", asset.AssetStatus)
+	}
+
+	// Repo : Create Asset Loan
+	assetLoan.BorrowerId = borrowerId
+	assetLoan.BorrowerType = role
+	if err := s.assetLoanRepo.Create(assetLoan); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *assetLoanService) ApproveById(id, adminId uuid.UUID) error {
+	// Repo : Find Asset Loan By Id
+	assetLoan, err := s.findAssetLoanByStatus(id, "requested")
+	if err != nil {
+		return err
+	}
+
+	// Repo : Check Remaining Quantity Of The Placement
+	total, err := s.assetLoanRepo.CountActiveByAssetPlacementId(assetLoan.AssetPlacementId)
+	if err != nil {
+		return err
+	}
+	if total >= int64(assetLoan.AssetPlacement.AssetQty) {
+		return errors.New("no asset left to be loaned in this placement")
+	}
+
+	// Repo : Approve Asset Loan, Remaining Quantity Is Checked Again Under Placement Lock
+	if err := s.assetLoanRepo.ApproveById(adminId, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *assetLoanService) RejectById(id, adminId uuid.UUID) error {
+	// Repo : Find Asset Loan By Id
+	if _, err := s.findAssetLoanByStatus(id, "requested"); err != nil {
+		return err
+	}
+
+	// Repo : Reject Asset Loan
+	if err := s.assetLoanRepo.UpdateApprovalById("rejected", adminId, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *assetLoanService) CheckOutById(id, accountId uuid.UUID, role string) error {
+	// Repo : Find Asset Loan By Id
+	if _, err := s.findAssetLoanByStatus(id, "approved"); err != nil {
+		return err
+	}
+
+	// Repo : Check Out Asset Loan And Mark Asset As In-Use, Transition Is Resolved Under Asset Lock
+	reason := fmt.Sprintf("checked out by asset loan %s", id)
+	err := s.assetLoanRepo.CheckOutById(id, func(asset *entity.Asset) (*entity.AssetStatusHistory, error) {
+		// Validator : Asset Status Transition
+		return buildAssetStatusHistory(asset, "in-use", &reason, accountId, role)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *assetLoanService) ReturnById(returnCondition string, returnNotes *string, id, accountId uuid.UUID, role string) error {
+	// Validator : Return Condition
+	if !utils.Contains(config.LoanReturnConditions, returnCondition) {
+		return errors.New("return condition is not valid")
+	}
+
+	// Repo : Find Asset Loan By Id
+	if _, err := s.findAssetLoanByStatus(id, "checked-out"); err != nil {
+		return err
+	}
+
+	// Repo : Return Asset Loan And Update Asset Status, Resulting Status Is Resolved Under Asset Lock
+	reason := fmt.Sprintf("returned %s from asset loan %s", returnCondition, id)
+	err := s.assetLoanRepo.ReturnById(returnCondition, returnNotes, id, func(asset *entity.Asset, totalCheckedOut int64) (*entity.AssetStatusHistory, error) {
+		// Validator : Asset Status Transition
+		return buildAssetStatusHistory(asset, resolveReturnedAssetStatus(asset, returnCondition, totalCheckedOut), &reason, accountId, role)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Damaged Asset Need Maintenance, Otherwise Stay In-Use While Another Loan Still Checked Out
+func resolveReturnedAssetStatus(asset *entity.Asset, returnCondition string, totalCheckedOut int64) string {
+	if returnCondition == "damaged" {
+		return "maintenance"
+	}
+	if totalCheckedOut > 0 || asset.AssetStatus != "in-use" {
+		return asset.AssetStatus
+	}
+
+	return "available"
+}
+
+func (s *assetLoanService) findAssetLoanByStatus(id uuid.UUID, loanStatus string) (*entity.AssetLoan, error) {
+	// Repo : Find Asset Loan By Id
+	assetLoan, err := s.assetLoanRepo.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	if assetLoan == nil {
+		return nil, errors.New("asset loan not found")
+	}
+	if assetLoan.LoanStatus != loanStatus {
+		return nil, fmt.Errorf("asset loan is %s, expected %s", assetLoan.LoanStatus, loanStatus)
+	}
+
+	return assetLoan, nil
+}
@@ -17,14 +17,19 @@ import (
 
 // Asset Maintenance Interface
 type AssetMaintenanceService interface {
-	GetAllAssetMaintenance(pagination utils.Pagination) ([]entity.AssetMaintenance, int64, error)
-	GetAllAssetMaintenanceSchedule() ([]entity.AssetMaintenanceSchedule, error)
+	GetAllAssetMaintenance(pagination utils.Pagination, filter utils.Filter, accountId uuid.UUID, role string) ([]entity.AssetMaintenance, int64, error)
+	GetAllAssetMaintenanceSchedule(accountId uuid.UUID, role string) ([]entity.AssetMaintenanceSchedule, error)
 	GetMostContext(targetCol string) ([]entity.StatsContextTotal, error)
 	Create(assetMaintenance *entity.AssetMaintenance, adminId uuid.UUID) error
 	UpdateById(assetMaintenance *entity.AssetMaintenance, id uuid.UUID) error
-	DeleteById(id uuid.UUID) error
+	GetDeleted() ([]entity.AssetMaintenance, error)
+	GetDeletePreviewById(id, accountId uuid.UUID) (*entity.DeletePreview, error)
+	HardDeleteById(id, accountId uuid.UUID, confirmationToken string) error
+	SoftDeleteById(id uuid.UUID) error
+	RecoverDeletedById(id uuid.UUID) error
 
 	// Scheduler Service
+	PurgeDeleted(before time.Time) (int, error)
 	GetTodayValidSchedules() (map[string][]entity.AssetMaintenanceSchedule, error)
 }
 
@@ -34,21 +39,31 @@ type assetMaintenanceService struct {
 	technicianRepo       repository.TechnicianRepository
 	assetRepo            repository.AssetRepository
 	statsRepo            repository.StatsRepository
+	assetUnitRepo        repository.AssetUnitRepository
+	deleteConfirmRepo    repository.DeleteConfirmationRepository
 }
 
 // Asset Maintenance Constructor
-func NewAssetMaintenanceService(assetMaintenanceRepo repository.AssetMaintenanceRepository, technicianRepo repository.TechnicianRepository, assetRepo repository.AssetRepository, statsRepo repository.StatsRepository) AssetMaintenanceService {
+func NewAssetMaintenanceService(assetMaintenanceRepo repository.AssetMaintenanceRepository, technicianRepo repository.TechnicianRepository, assetRepo repository.AssetRepository, statsRepo repository.StatsRepository, assetUnitRepo repository.AssetUnitRepository, deleteConfirmRepo repository.DeleteConfirmationRepository) AssetMaintenanceService {
 	return &assetMaintenanceService{
 		assetMaintenanceRepo: assetMaintenanceRepo,
 		technicianRepo:       technicianRepo,
 		assetRepo:            assetRepo,
 		statsRepo:            statsRepo,
+		assetUnitRepo:        assetUnitRepo,
+		deleteConfirmRepo:    deleteConfirmRepo,
 	}
 }
 
-func (s *assetMaintenanceService) GetAllAssetMaintenance(pagination utils.Pagination) ([]entity.AssetMaintenance, int64, error) {
+func (s *assetMaintenanceService) GetAllAssetMaintenance(pagination utils.Pagination, filter utils.Filter, accountId uuid.UUID, role string) ([]entity.AssetMaintenance, int64, error) {
+	// Repo : Find Access Scope
+	scope, err := findAccessScope(s.technicianRepo, accountId, role)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// Repo : Get All Asset Maintenance
-	assetMaintenance, total, err := s.assetMaintenanceRepo.FindAll(pagination)
+	assetMaintenance, total, err := s.assetMaintenanceRepo.FindAll(pagination, filter, scope)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -59,9 +74,15 @@ func (s *assetMaintenanceService) GetAllAssetMaintenance(pagination utils.Pagina
 	return assetMaintenance, total, nil
 }
 
-func (s *assetMaintenanceService) GetAllAssetMaintenanceSchedule() ([]entity.AssetMaintenanceSchedule, error) {
+func (s *assetMaintenanceService) GetAllAssetMaintenanceSchedule(accountId uuid.UUID, role string) ([]entity.AssetMaintenanceSchedule, error) {
+	// Repo : Find Access Scope
+	scope, err := findAccessScope(s.technicianRepo, accountId, role)
+	if err != nil {
+		return nil, err
+	}
+
 	// Repo : Get All Asset Maintenance Schedule
-	assetMaintenance, err := s.assetMaintenanceRepo.FindAllSchedule()
+	assetMaintenance, err := s.assetMaintenanceRepo.FindAllSchedule(scope)
 	if err != nil {
 		return nil, err
 	}
@@ -82,6 +103,11 @@ func (s *assetMaintenanceService) Create(assetMaintenance *entity.AssetMaintenan
 		return errors.New("asset is already assigned to maintenance by a technician")
 	}
 
+	// Repo : Validate Asset Unit
+	if err := validateAssetUnit(s.assetUnitRepo, assetMaintenance.AssetUnitId, assetMaintenance.AssetPlacementId); err != nil {
+		return err
+	}
+
 	// Repo : Create Asset Maintenance
 	if err := s.assetMaintenanceRepo.Create(assetMaintenance, adminId); err != nil {
 		return err
@@ -95,9 +121,9 @@ func (s *assetMaintenanceService) Create(assetMaintenance *entity.AssetMaintenan
 
 	// Send Telegram
 	if technician != nil && technician.TelegramIsValid && technician.TelegramUserId != nil {
-		bot, err := tgbotapi.NewBotAPI(os.Getenv("TELEGRAM_BOT_TOKEN"))
+		bot, err := utils.NewTelegramBot(os.Getenv("TELEGRAM_BOT_TOKEN"))
 		if err != nil {
-			return errors.New(fmt.Sprintf("Failed to connect to Telegram bot:", err.Error()))
+			return fmt.Errorf("failed to connect to Telegram bot: %w", err)
 		}
 
 		telegramID, err := strconv.ParseInt(*technician.TelegramUserId, 10, 64)
@@ -143,6 +169,20 @@ func (s *assetMaintenanceService) UpdateById(assetMaintenance *entity.AssetMaint
 		return errors.New("asset is already assigned to maintenance by a technician")
 	}
 
+	// Repo : Find Asset Maintenance By Id
+	existingAssetMaintenance, err := s.assetMaintenanceRepo.FindById(id)
+	if err != nil {
+		return err
+	}
+	if existingAssetMaintenance == nil {
+		return errors.New("asset maintenance not found")
+	}
+
+	// Repo : Validate Asset Unit
+	if err := validateAssetUnit(s.assetUnitRepo, assetMaintenance.AssetUnitId, existingAssetMaintenance.AssetPlacementId); err != nil {
+		return err
+	}
+
 	// Repo : Update Asset Maintenance By Id
 	if err := s.assetMaintenanceRepo.UpdateById(assetMaintenance, id); err != nil {
 		return err
@@ -151,9 +191,49 @@ func (s *assetMaintenanceService) UpdateById(assetMaintenance *entity.AssetMaint
 	return nil
 }
 
-func (s *assetMaintenanceService) DeleteById(id uuid.UUID) error {
-	// Repo : Delete Asset Maintenance By Id
-	err := s.assetMaintenanceRepo.DeleteById(id)
+func (s *assetMaintenanceService) GetDeleted() ([]entity.AssetMaintenance, error) {
+	// Repo : Get All Deleted Asset Maintenance
+	assetMaintenance, err := s.assetMaintenanceRepo.FindDeleted()
+	if err != nil {
+		return nil, err
+	}
+	if assetMaintenance == nil {
+		return nil, errors.New("deleted asset maintenance not found")
+	}
+
+	return assetMaintenance, nil
+}
+
+func (s *assetMaintenanceService) GetDeletePreviewById(id, accountId uuid.UUID) (*entity.DeletePreview, error) {
+	// Repo : Find Every Row That Is Cascaded Along With The Deleted Asset Maintenance
+	dependencies, err := s.assetMaintenanceRepo.FindDeleteDependencyById(id)
+	if err != nil {
+		return nil, err
+	}
+	if dependencies == nil {
+		return nil, errors.New("deleted asset maintenance not found")
+	}
+
+	return buildDeletePreview(s.deleteConfirmRepo, "asset_maintenances", id, accountId, dependencies)
+}
+
+func (s *assetMaintenanceService) HardDeleteById(id, accountId uuid.UUID, confirmationToken string) error {
+	// Repo : Find Every Row That Is Cascaded Along With The Deleted Asset Maintenance
+	dependencies, err := s.assetMaintenanceRepo.FindDeleteDependencyById(id)
+	if err != nil {
+		return err
+	}
+	if dependencies == nil {
+		return errors.New("deleted asset maintenance not found")
+	}
+
+	// Repo : Consume Delete Confirmation
+	if err := consumeDeleteConfirmation(s.deleteConfirmRepo, confirmationToken, "asset_maintenances", id, accountId, dependencies); err != nil {
+		return err
+	}
+
+	// Repo : Delete Asset Maintenance By Id, Cascaded Row Is Archived
+	err = s.assetMaintenanceRepo.HardDeleteById(id, accountId)
 	if err != nil {
 		return err
 	}
@@ -161,6 +241,31 @@ func (s *assetMaintenanceService) DeleteById(id uuid.UUID) error {
 	return nil
 }
 
+func (s *assetMaintenanceService) SoftDeleteById(id uuid.UUID) error {
+	// Repo : Soft Delete Asset Maintenance By Id
+	err := s.assetMaintenanceRepo.SoftDeleteById(id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *assetMaintenanceService) RecoverDeletedById(id uuid.UUID) error {
+	// Repo : Recover Asset Maintenance By Id
+	err := s.assetMaintenanceRepo.RecoverDeletedById(id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *assetMaintenanceService) PurgeDeleted(before time.Time) (int, error) {
+	// Repo : Permanently Delete Asset Maintenance That Has Passed The Retention
+	return purgeDeleted(s.assetMaintenanceRepo.FindAllDeletedIdBefore, nil, s.assetMaintenanceRepo.HardDeleteById, before)
+}
+
 func (s *assetMaintenanceService) GetMostContext(targetCol string) ([]entity.StatsContextTotal, error) {
 	// Repo : Get My History
 	asset, err := s.statsRepo.FindMostUsedContext("asset_maintenances", targetCol)
@@ -176,7 +281,7 @@ func (s *assetMaintenanceService) GetMostContext(targetCol string) ([]entity.Sta
 
 // Scheduler Service
 func (s *assetMaintenanceService) GetTodayValidSchedules() (map[string][]entity.AssetMaintenanceSchedule, error) {
-	allSchedules, err := s.assetMaintenanceRepo.FindAllSchedule()
+	allSchedules, err := s.assetMaintenanceRepo.FindAllSchedule(nil)
 	if err != nil {
 		return nil, err
 	}
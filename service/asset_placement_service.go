@@ -2,36 +2,57 @@ package service
 
 import (
 	"errors"
+	"pelita/config"
 	"pelita/entity"
 	"pelita/repository"
 	"pelita/utils"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 // Asset Placement Interface
 type AssetPlacementService interface {
-	GetAllAssetPlacement(pagination utils.Pagination) ([]entity.AssetPlacement, int64, error)
+	GetAllAssetPlacement(pagination utils.Pagination, filter utils.Filter, accountId uuid.UUID, role string) ([]entity.AssetPlacement, int64, error)
 	Create(assetPlacement *entity.AssetPlacement, adminId uuid.UUID) error
-	UpdateById(assetPlacement *entity.AssetPlacement, id uuid.UUID) error
-	DeleteById(id uuid.UUID) error
+	UpdateById(assetPlacement *entity.AssetPlacement, id, accountId uuid.UUID, role string) error
+	GetDeleted() ([]entity.AssetPlacement, error)
+	GetDeletePreviewById(id, accountId uuid.UUID) (*entity.DeletePreview, error)
+	HardDeleteById(id, accountId uuid.UUID, confirmationToken string) error
+	SoftDeleteById(id uuid.UUID) error
+	RecoverDeletedById(id uuid.UUID) error
+
+	// Scheduler Service
+	PurgeDeleted(before time.Time) (int, error)
 }
 
 // Asset Placement Struct
 type assetPlacementService struct {
 	assetPlacementRepo repository.AssetPlacementRepository
+	technicianRepo     repository.TechnicianRepository
+	roomRepo           repository.RoomRepository
+	deleteConfirmRepo  repository.DeleteConfirmationRepository
 }
 
 // Asset Placement Constructor
-func NewAssetPlacementService(assetPlacementRepo repository.AssetPlacementRepository) AssetPlacementService {
+func NewAssetPlacementService(assetPlacementRepo repository.AssetPlacementRepository, technicianRepo repository.TechnicianRepository, roomRepo repository.RoomRepository, deleteConfirmRepo repository.DeleteConfirmationRepository) AssetPlacementService {
 	return &assetPlacementService{
 		assetPlacementRepo: assetPlacementRepo,
+		technicianRepo:     technicianRepo,
+		roomRepo:           roomRepo,
+		deleteConfirmRepo:  deleteConfirmRepo,
 	}
 }
 
-func (s *assetPlacementService) GetAllAssetPlacement(pagination utils.Pagination) ([]entity.AssetPlacement, int64, error) {
+func (s *assetPlacementService) GetAllAssetPlacement(pagination utils.Pagination, filter utils.Filter, accountId uuid.UUID, role string) ([]entity.AssetPlacement, int64, error) {
+	// Repo : Find Access Scope
+	scope, err := findAccessScope(s.technicianRepo, accountId, role)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// Repo : Get All Asset Placement
-	assetPlacement, total, err := s.assetPlacementRepo.FindAll(pagination)
+	assetPlacement, total, err := s.assetPlacementRepo.FindAll(pagination, filter, scope)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -52,15 +73,36 @@ func (s *assetPlacementService) Create(assetPlacement *entity.AssetPlacement, ad
 		return errors.New("asset is already placed in the room")
 	}
 
-	// Repo : Create Asset Placement
-	if err := s.assetPlacementRepo.Create(assetPlacement, adminId); err != nil {
+	// Repo : Create Asset Placement Along With Asset Unit For Every Quantity
+	if err := s.assetPlacementRepo.Create(assetPlacement, adminId, config.GetAssetTagPrefix(), config.GetAssetTagSequenceLength()); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (s *assetPlacementService) UpdateById(assetPlacement *entity.AssetPlacement, id uuid.UUID) error {
+func (s *assetPlacementService) UpdateById(assetPlacement *entity.AssetPlacement, id, accountId uuid.UUID, role string) error {
+	// Repo : Find Access Scope
+	scope, err := findAccessScope(s.technicianRepo, accountId, role)
+	if err != nil {
+		return err
+	}
+
+	// Repo : Check The Current And The Target Room Is Inside The Scope
+	inScope, err := s.assetPlacementRepo.IsInScopeById(id, scope)
+	if err != nil {
+		return err
+	}
+	if inScope {
+		inScope, err = s.roomRepo.IsInScopeById(assetPlacement.RoomId, scope)
+		if err != nil {
+			return err
+		}
+	}
+	if !inScope {
+		return errors.New("asset placement is outside of your access scope")
+	}
+
 	// Repo : Get Asset by Asset Name & Floor
 	is_exist, err := s.assetPlacementRepo.FindByAssetIdRoomIdAndId(assetPlacement.AssetId, assetPlacement.RoomId, id)
 	if err != nil {
@@ -70,20 +112,94 @@ func (s *assetPlacementService) UpdateById(assetPlacement *entity.AssetPlacement
 		return errors.New("asset already exist on the same floor")
 	}
 
-	// Repo : Update Asset By Id
-	if err := s.assetPlacementRepo.UpdateById(assetPlacement, id); err != nil {
+	// Repo : Update Asset Placement By Id Along With Asset Unit For Additional Quantity, Quantity Can't Be Less Than Its Unit
+	if err := s.assetPlacementRepo.UpdateById(assetPlacement, id, config.GetAssetTagPrefix(), config.GetAssetTagSequenceLength()); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (s *assetPlacementService) DeleteById(id uuid.UUID) error {
-	// Repo : Delete Asset Placement By Id
-	err := s.assetPlacementRepo.DeleteById(id)
+func (s *assetPlacementService) GetDeleted() ([]entity.AssetPlacement, error) {
+	// Repo : Get All Deleted Asset Placement
+	assetPlacement, err := s.assetPlacementRepo.FindDeleted()
+	if err != nil {
+		return nil, err
+	}
+	if assetPlacement == nil {
+		return nil, errors.New("deleted asset placement not found")
+	}
+
+	return assetPlacement, nil
+}
+
+func (s *assetPlacementService) GetDeletePreviewById(id, accountId uuid.UUID) (*entity.DeletePreview, error) {
+	// Repo : Find Every Row That Is Cascaded Along With The Deleted Asset Placement
+	dependencies, err := s.assetPlacementRepo.FindDeleteDependencyById(id)
+	if err != nil {
+		return nil, err
+	}
+	if dependencies == nil {
+		return nil, errors.New("deleted asset placement not found")
+	}
+
+	return buildDeletePreview(s.deleteConfirmRepo, "asset_placements", id, accountId, dependencies)
+}
+
+func (s *assetPlacementService) HardDeleteById(id, accountId uuid.UUID, confirmationToken string) error {
+	// Repo : Find Every Row That Is Cascaded Along With The Deleted Asset Placement
+	dependencies, err := s.assetPlacementRepo.FindDeleteDependencyById(id)
+	if err != nil {
+		return err
+	}
+	if dependencies == nil {
+		return errors.New("deleted asset placement not found")
+	}
+
+	// Repo : Consume Delete Confirmation
+	if err := consumeDeleteConfirmation(s.deleteConfirmRepo, confirmationToken, "asset_placements", id, accountId, dependencies); err != nil {
+		return err
+	}
+
+	// Repo : Find Every Stored File Before Its Record Is Cascaded
+	files, err := s.assetPlacementRepo.FindAllStoredFileByDeletedId(id)
+	if err != nil {
+		return err
+	}
+
+	// Repo : Delete Asset Placement By Id, Cascaded Row Is Archived
+	err = s.assetPlacementRepo.HardDeleteById(id, accountId)
+	if err != nil {
+		return err
+	}
+
+	// Utils : Firebase Delete Stored File
+	deleteStoredFile(files)
+
+	return nil
+}
+
+func (s *assetPlacementService) SoftDeleteById(id uuid.UUID) error {
+	// Repo : Soft Delete Asset Placement By Id
+	err := s.assetPlacementRepo.SoftDeleteById(id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *assetPlacementService) RecoverDeletedById(id uuid.UUID) error {
+	// Repo : Recover Asset Placement By Id
+	err := s.assetPlacementRepo.RecoverDeletedById(id)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+func (s *assetPlacementService) PurgeDeleted(before time.Time) (int, error) {
+	// Repo : Permanently Delete Asset Placement That Has Passed The Retention
+	return purgeDeleted(s.assetPlacementRepo.FindAllDeletedIdBefore, s.assetPlacementRepo.FindAllStoredFileByDeletedId, s.assetPlacementRepo.HardDeleteById, before)
+}
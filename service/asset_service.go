@@ -2,43 +2,75 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"mime/multipart"
+	"pelita/config"
 	"pelita/entity"
 	"pelita/repository"
 	"pelita/utils"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+var ErrIllegalAssetStatusTransition = errors.New("illegal asset status transition")
+
+// Possible Duplicate Is Returned To The Caller, Who Can Still Force The Asset To Be Saved
+type AssetDuplicateError struct {
+	Duplicates []entity.AssetDuplicate
+}
+
+func (e *AssetDuplicateError) Error() string {
+	return "asset may already exist, set force to true to save it anyway"
+}
+
 // Asset Interface
 type AssetService interface {
-	GetAllAsset(pagination utils.Pagination) ([]entity.Asset, int64, error)
+	GetAllAsset(pagination utils.Pagination, filter utils.Filter) ([]entity.Asset, int64, error)
 	GetDeleted() ([]entity.Asset, error)
 	GetMostContext(targetCol string) ([]entity.StatsContextTotal, error)
-	Create(asset *entity.Asset, adminId uuid.UUID, file *multipart.FileHeader, fileExt string, fileSize int64) error
-	UpdateById(asset *entity.Asset, id uuid.UUID) error
-	HardDeleteById(id uuid.UUID) error
+	Create(asset *entity.Asset, adminId uuid.UUID, file *multipart.FileHeader, fileExt string, fileSize int64, force bool) error
+	UpdateById(asset *entity.Asset, id, accountId uuid.UUID, role string, force bool) error
+	MergeById(duplicateId, id uuid.UUID) error
+	GetStatusHistoryById(id uuid.UUID) ([]entity.AssetStatusHistory, error)
+	GetAllBookValue(pagination utils.Pagination, at time.Time) ([]entity.AssetBookValue, int64, error)
+	GetBookValueById(id uuid.UUID, at time.Time) (*entity.AssetBookValue, error)
+	GetBookValueSummary(context string, at time.Time) ([]entity.AssetBookValueSummary, error)
+	GetDeletePreviewById(id, accountId uuid.UUID) (*entity.DeletePreview, error)
+	HardDeleteById(id, accountId uuid.UUID, confirmationToken string) error
 	SoftDeleteById(id uuid.UUID) error
 	RecoverDeletedById(id uuid.UUID) error
+
+	// Scheduler Service
+	PurgeDeleted(before time.Time) (int, error)
 }
 
 // Asset Struct
 type assetService struct {
-	assetRepo repository.AssetRepository
-	statsRepo repository.StatsRepository
+	assetRepo         repository.AssetRepository
+	statsRepo         repository.StatsRepository
+	assetImageRepo    repository.AssetImageRepository
+	assetCategoryRepo repository.AssetCategoryRepository
+	deleteConfirmRepo repository.DeleteConfirmationRepository
 }
 
 // Asset Constructor
-func NewAssetService(assetRepo repository.AssetRepository, statsRepo repository.StatsRepository) AssetService {
+func NewAssetService(assetRepo repository.AssetRepository, statsRepo repository.StatsRepository, assetImageRepo repository.AssetImageRepository, assetCategoryRepo repository.AssetCategoryRepository, deleteConfirmRepo repository.DeleteConfirmationRepository) AssetService {
 	return &assetService{
-		assetRepo: assetRepo,
-		statsRepo: statsRepo,
+		assetRepo:         assetRepo,
+		statsRepo:         statsRepo,
+		assetImageRepo:    assetImageRepo,
+		assetCategoryRepo: assetCategoryRepo,
+		deleteConfirmRepo: deleteConfirmRepo,
 	}
 }
 
-func (s *assetService) GetAllAsset(pagination utils.Pagination) ([]entity.Asset, int64, error) {
+func (s *assetService) GetAllAsset(pagination utils.Pagination, filter utils.Filter) ([]entity.Asset, int64, error) {
 	// Repo : Get All Asset
-	asset, total, err := s.assetRepo.FindAll(pagination)
+	asset, total, err := s.assetRepo.FindAll(pagination, filter)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -62,63 +94,301 @@ func (s *assetService) GetDeleted() ([]entity.Asset, error) {
 	return asset, nil
 }
 
-func (s *assetService) Create(asset *entity.Asset, adminId uuid.UUID, file *multipart.FileHeader, fileExt string, fileSize int64) error {
-	// Repo : Get Asset by Asset Name & Category & Merk
-	is_exist, err := s.assetRepo.FindByAssetNameCategoryAndMerk(asset.AssetName, asset.AssetCategory, asset.AssetMerk)
+func (s *assetService) Create(asset *entity.Asset, adminId uuid.UUID, file *multipart.FileHeader, fileExt string, fileSize int64, force bool) error {
+	// Repo : Find Asset Category
+	assetCategory, err := resolveAssetCategory(s.assetCategoryRepo, asset)
 	if err != nil {
 		return err
 	}
-	if is_exist != nil {
-		return errors.New("asset already exist on the same floor")
+
+	// Validator : Custom Attribute
+	if err := validateAssetAttribute(assetCategory, asset); err != nil {
+		return err
+	}
+
+	// Repo : Find Similar Asset, Skipped When Forced
+	if !force {
+		duplicates, err := findAssetDuplicate(s.assetRepo, asset, nil)
+		if err != nil {
+			return err
+		}
+		if len(duplicates) > 0 {
+			return &AssetDuplicateError{Duplicates: duplicates}
+		}
+	}
+
+	// Validator : Asset Valuation
+	if err := validateAssetValuation(asset); err != nil {
+		return err
 	}
 
-	// Utils : Firebase Upload image
+	// Utils : Firebase Upload Image And Its Thumbnail
+	var assetImage *entity.AssetImage
+	asset.AssetImageURL = nil
 	if file != nil {
-		assetImage, err := utils.UploadFile(adminId, "asset", file, fileExt)
+		assetImage, err = uploadAssetImage(adminId, file)
 		if err != nil {
-			return errors.New(err.Error())
+			return err
 		}
-		asset.AssetImageURL = &assetImage
-	} else {
-		asset.AssetImageURL = nil
+		asset.AssetImageURL = &assetImage.ImageURL
 	}
 
 	// Repo : Create Asset
 	if err := s.assetRepo.Create(asset, adminId); err != nil {
+		if assetImage != nil {
+			deleteAssetImageFile([]entity.AssetImage{*assetImage})
+		}
 		return err
 	}
 
+	// Repo : The Uploaded Image Become The First Image Of The Gallery
+	if assetImage != nil {
+		if err := s.assetImageRepo.Create([]entity.AssetImage{*assetImage}, asset.ID, adminId); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (s *assetService) UpdateById(asset *entity.Asset, id uuid.UUID) error {
-	// Repo : Get Asset by Asset Name & Floor
-	is_exist, err := s.assetRepo.FindByAssetNameCategoryMerkAndId(asset.AssetName, asset.AssetCategory, asset.AssetMerk, id)
+func (s *assetService) UpdateById(asset *entity.Asset, id, accountId uuid.UUID, role string, force bool) error {
+	// Repo : Find Asset Category
+	assetCategory, err := resolveAssetCategory(s.assetCategoryRepo, asset)
+	if err != nil {
+		return err
+	}
+
+	// Repo : Find Similar Asset Other Than Itself, Skipped When Forced
+	if !force {
+		duplicates, err := findAssetDuplicate(s.assetRepo, asset, &id)
+		if err != nil {
+			return err
+		}
+		if len(duplicates) > 0 {
+			return &AssetDuplicateError{Duplicates: duplicates}
+		}
+	}
+
+	// Validator : Asset Valuation
+	if err := validateAssetValuation(asset); err != nil {
+		return err
+	}
+
+	// Repo : Find Asset By Id
+	existingAsset, err := s.assetRepo.FindById(id)
 	if err != nil {
 		return err
 	}
-	if is_exist != nil {
-		return errors.New("asset already exist on the same floor")
+	if existingAsset == nil {
+		return errors.New("asset not found")
+	}
+
+	// Validator : Custom Attribute, Current Attribute Still Defined By The Category Is Kept When None Is Given
+	if asset.Attributes == nil {
+		asset.Attributes = map[string]string{}
+		for _, field := range assetCategory.FieldDefinitions {
+			if value, ok := existingAsset.Attributes[field.FieldName]; ok {
+				asset.Attributes[field.FieldName] = value
+			}
+		}
+	}
+	if err := validateAssetAttribute(assetCategory, asset); err != nil {
+		return err
+	}
+
+	// Validator : Asset Status Transition
+	history, err := buildAssetStatusHistory(existingAsset, asset.AssetStatus, asset.StatusReason, accountId, role)
+	if err != nil {
+		return err
 	}
 
-	// Repo : Update Asset By Id
-	if err := s.assetRepo.UpdateById(asset, id); err != nil {
+	// Repo : Update Asset By Id Along With Its Status History In One Transaction
+	newStatus := asset.AssetStatus
+	if err := s.assetRepo.UpdateById(asset, history, id); err != nil {
 		return err
 	}
+	asset.AssetStatus = newStatus
 
 	return nil
 }
 
-func (s *assetService) HardDeleteById(id uuid.UUID) error {
-	// Repo : Delete Asset By Id
-	err := s.assetRepo.HardDeleteById(id)
+func (s *assetService) GetStatusHistoryById(id uuid.UUID) ([]entity.AssetStatusHistory, error) {
+	// Repo : Find Asset By Id
+	asset, err := s.assetRepo.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	if asset == nil {
+		return nil, errors.New("asset not found")
+	}
+
+	// Repo : Get Asset Status History
+	history, err := s.assetRepo.FindStatusHistoryByAssetId(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) == 0 {
+		return nil, errors.New("asset status history not found")
+	}
+
+	return history, nil
+}
+
+func (s *assetService) GetAllBookValue(pagination utils.Pagination, at time.Time) ([]entity.AssetBookValue, int64, error) {
+	// Repo : Get All Asset With Complete Valuation
+	asset, total, err := s.assetRepo.FindAllValuated(pagination)
 	if err != nil {
+		return nil, 0, err
+	}
+	if len(asset) == 0 {
+		return nil, 0, errors.New("asset book value not found")
+	}
+
+	// Utils : Calculate Book Value
+	bookValue := make([]entity.AssetBookValue, 0, len(asset))
+	for i := range asset {
+		bookValue = append(bookValue, buildAssetBookValue(&asset[i], at))
+	}
+
+	return bookValue, total, nil
+}
+
+func (s *assetService) GetBookValueById(id uuid.UUID, at time.Time) (*entity.AssetBookValue, error) {
+	// Repo : Find Asset By Id
+	asset, err := s.assetRepo.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	if asset == nil || asset.DeletedAt != nil {
+		return nil, errors.New("asset not found")
+	}
+	if asset.AssetPrice == nil || asset.PurchaseDate == nil || asset.UsefulLife == nil {
+		return nil, errors.New("asset price, purchase date, and useful life must be filled to calculate its book value")
+	}
+
+	// Utils : Calculate Book Value
+	bookValue := buildAssetBookValue(asset, at)
+
+	return &bookValue, nil
+}
+
+func (s *assetService) GetBookValueSummary(context string, at time.Time) ([]entity.AssetBookValueSummary, error) {
+	// Repo : Get All Placed Asset With Complete Valuation
+	valuation, err := s.assetRepo.FindAllPlacedValuation()
+	if err != nil {
+		return nil, err
+	}
+	if len(valuation) == 0 {
+		return nil, errors.New("asset book value not found")
+	}
+
+	// Utils : Aggregate Book Value Per Context, Currency Is Never Mixed
+	index := map[string]int{}
+	summary := []entity.AssetBookValueSummary{}
+	for i := range valuation {
+		key := valuation[i].RoomDept
+		if context == "floor" {
+			key = valuation[i].Floor
+		}
+
+		bookValue := buildAssetBookValue(&valuation[i].Asset, at)
+		idx, ok := index[key+"|"+bookValue.AssetCurrency]
+		if !ok {
+			idx = len(summary)
+			index[key+"|"+bookValue.AssetCurrency] = idx
+			summary = append(summary, entity.AssetBookValueSummary{Context: key, AssetCurrency: bookValue.AssetCurrency})
+		}
+		summary[idx].TotalQty += valuation[i].AssetQty
+		summary[idx].TotalPrice += bookValue.AssetPrice * entity.Money(valuation[i].AssetQty)
+		summary[idx].TotalBookValue += bookValue.BookValue * entity.Money(valuation[i].AssetQty)
+	}
+
+	return summary, nil
+}
+
+func (s *assetService) GetDeletePreviewById(id, accountId uuid.UUID) (*entity.DeletePreview, error) {
+	// Repo : Find Asset By Id
+	asset, err := s.assetRepo.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	if asset == nil {
+		return nil, errors.New("asset not found")
+	}
+	if asset.DeletedAt == nil {
+		return nil, errors.New("asset must be deleted before it can be permanently deleted")
+	}
+
+	// Repo : Find Every Row That Is Cascaded Along With The Asset
+	dependencies, err := s.assetRepo.FindDeleteDependencyById(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildDeletePreview(s.deleteConfirmRepo, "assets", id, accountId, dependencies)
+}
+
+func (s *assetService) HardDeleteById(id, accountId uuid.UUID, confirmationToken string) error {
+	// Repo : Find Every Row That Is Cascaded Along With The Asset
+	dependencies, err := s.assetRepo.FindDeleteDependencyById(id)
+	if err != nil {
+		return err
+	}
+	if dependencies == nil {
+		return errors.New("deleted asset not found")
+	}
+
+	// Repo : Consume Delete Confirmation
+	if err := consumeDeleteConfirmation(s.deleteConfirmRepo, confirmationToken, "assets", id, accountId, dependencies); err != nil {
 		return err
 	}
 
+	// Repo : Find Every Stored File Before Its Record Is Cascaded
+	files, err := s.assetRepo.FindAllStoredFileByDeletedId(id)
+	if err != nil {
+		return err
+	}
+
+	// Repo : Delete Asset By Id, Cascaded Row Is Archived
+	err = s.assetRepo.HardDeleteById(id, accountId)
+	if err != nil {
+		return err
+	}
+
+	// Utils : Firebase Delete Stored File
+	deleteStoredFile(files)
+
 	return nil
 }
 
+func (s *assetService) PurgeDeleted(before time.Time) (int, error) {
+	// Repo : Find Every Asset That Has Passed The Retention
+	ids, err := s.assetRepo.FindAllDeletedIdBefore(before)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, id := range ids {
+		// Repo : Find Every Stored File Before Its Record Is Cascaded
+		files, err := s.assetRepo.FindAllStoredFileByDeletedId(id)
+		if err != nil {
+			return total, err
+		}
+
+		// Repo : Delete Asset By Id, Archived By The System
+		if err := s.assetRepo.HardDeleteById(id, uuid.Nil); err != nil {
+			return total, err
+		}
+
+		// Utils : Firebase Delete Stored File
+		deleteStoredFile(files)
+		total++
+	}
+
+	return total, nil
+}
+
 func (s *assetService) SoftDeleteById(id uuid.UUID) error {
 	// Repo : Delete Asset By Id
 	err := s.assetRepo.SoftDeleteById(id)
@@ -139,6 +409,20 @@ func (s *assetService) RecoverDeletedById(id uuid.UUID) error {
 	return nil
 }
 
+func (s *assetService) MergeById(duplicateId, id uuid.UUID) error {
+	// Validator : Asset Can't Be Merged Into Itself
+	if duplicateId == id {
+		return errors.New("asset can't be merged into itself")
+	}
+
+	// Repo : Move Placement, Maintenance, And Finding Of The Duplicate Into The Surviving Asset
+	if err := s.assetRepo.MergeById(duplicateId, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *assetService) GetMostContext(targetCol string) ([]entity.StatsContextTotal, error) {
 	// Repo : Get My History
 	asset, err := s.statsRepo.FindMostUsedContext("assets", targetCol)
@@ -151,3 +435,250 @@ func (s *assetService) GetMostContext(targetCol string) ([]entity.StatsContextTo
 
 	return asset, nil
 }
+
+// Nil History Means The Status Is Unchanged
+func buildAssetStatusHistory(asset *entity.Asset, toStatus string, reason *string, accountId uuid.UUID, role string) (*entity.AssetStatusHistory, error) {
+	if asset.AssetStatus == toStatus {
+		return nil, nil
+	}
+	if !utils.Contains(config.AssetStatusTransitions[asset.AssetStatus], toStatus) {
+		return nil, fmt.Errorf("%w: %s to %s", ErrIllegalAssetStatusTransition, asset.AssetStatus, toStatus)
+	}
+
+	fromStatus := asset.AssetStatus
+	return &entity.AssetStatusHistory{
+		FromStatus:    &fromStatus,
+		ToStatus:      toStatus,
+		Reason:        reason,
+		ChangedBy:     accountId,
+		ChangedByType: role,
+	}, nil
+}
+
+// Default Currency And Depreciation Method Are Applied When Empty
+func validateAssetValuation(asset *entity.Asset) error {
+	if asset.AssetCurrency == "" {
+		asset.AssetCurrency = config.Currencies[0]
+	}
+	if asset.DepreciationMethod == "" {
+		asset.DepreciationMethod = config.DepreciationMethods[0]
+	}
+	if !utils.Contains(config.Currencies, asset.AssetCurrency) {
+		return errors.New("asset currency is not valid")
+	}
+	if !utils.Contains(config.DepreciationMethods, asset.DepreciationMethod) {
+		return errors.New("depreciation method is not valid")
+	}
+	if asset.AssetPrice != nil && *asset.AssetPrice < 0 {
+		return errors.New("asset price can't be negative")
+	}
+	if asset.SalvageValue != nil {
+		if *asset.SalvageValue < 0 {
+			return errors.New("salvage value can't be negative")
+		}
+		if asset.AssetPrice == nil || *asset.SalvageValue > *asset.AssetPrice {
+			return errors.New("salvage value can't be more than the asset price")
+		}
+	}
+	if asset.UsefulLife != nil && *asset.UsefulLife <= 0 {
+		return errors.New("useful life must be at least 1 year")
+	}
+	if asset.PurchaseDate != nil && asset.PurchaseDate.After(time.Now()) {
+		return errors.New("purchase date can't be in the future")
+	}
+
+	return nil
+}
+
+// Asset In The Same Category With A Similar Name Is A Possible Duplicate, Merk Is Only Compared When Both Are Filled
+func findAssetDuplicate(assetRepo repository.AssetRepository, asset *entity.Asset, excludeId *uuid.UUID) ([]entity.AssetDuplicate, error) {
+	// Repo : Get All Asset In The Same Category
+	candidates, err := assetRepo.FindAllByAssetCategoryId(*asset.AssetCategoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicates := []entity.AssetDuplicate{}
+	assetMerk := utils.NullSafeString(asset.AssetMerk)
+	for _, dt := range candidates {
+		if excludeId != nil && dt.ID == *excludeId {
+			continue
+		}
+		candidateMerk := utils.NullSafeString(dt.AssetMerk)
+		if assetMerk != "" && candidateMerk != "" && utils.Similarity(assetMerk, candidateMerk) < config.AssetDuplicateSimilarity {
+			continue
+		}
+
+		similarity := utils.Similarity(asset.AssetName, dt.AssetName)
+		if similarity >= config.AssetDuplicateSimilarity {
+			duplicates = append(duplicates, entity.AssetDuplicate{Asset: dt, Similarity: math.Round(similarity*100) / 100})
+		}
+	}
+
+	// The Most Similar First
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		return duplicates[i].Similarity > duplicates[j].Similarity
+	})
+
+	return duplicates, nil
+}
+
+// Custom Attribute Must Be Defined By The Asset Category And Match Its Field Type
+func validateAssetAttribute(assetCategory *entity.AssetCategory, asset *entity.Asset) error {
+	// Validator : Unknown Attribute, Sorted So The Same Error Is Always Returned
+	fields := map[string]bool{}
+	for _, field := range assetCategory.FieldDefinitions {
+		fields[field.FieldName] = true
+	}
+	names := make([]string, 0, len(asset.Attributes))
+	for name := range asset.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !fields[name] {
+			return fmt.Errorf("attribute %s is not defined for asset category %s", name, assetCategory.CategoryName)
+		}
+	}
+
+	// Validator : Required And Type Of Every Field
+	attributes := map[string]string{}
+	for _, field := range assetCategory.FieldDefinitions {
+		value := strings.TrimSpace(asset.Attributes[field.FieldName])
+		if value == "" {
+			if field.IsRequired {
+				return fmt.Errorf("attribute %s is required", field.FieldName)
+			}
+			continue
+		}
+
+		value, err := utils.NormalizeAttributeValue(field.FieldType, value, field.FieldOptions)
+		if err != nil {
+			return fmt.Errorf("attribute %s %s", field.FieldName, err.Error())
+		}
+		attributes[field.FieldName] = value
+	}
+	asset.Attributes = attributes
+
+	return nil
+}
+
+// Asset Must Have Its Price, Purchase Date, And Useful Life Filled
+func buildAssetBookValue(asset *entity.Asset, at time.Time) entity.AssetBookValue {
+	var salvageValue entity.Money
+	if asset.SalvageValue != nil {
+		salvageValue = *asset.SalvageValue
+	}
+	bookValue := utils.CalculateBookValue(*asset.AssetPrice, salvageValue, *asset.PurchaseDate, *asset.UsefulLife, asset.DepreciationMethod, at)
+
+	return entity.AssetBookValue{
+		AssetId:                 asset.ID,
+		AssetName:               asset.AssetName,
+		AssetCategory:           asset.AssetCategory,
+		AssetCurrency:           asset.AssetCurrency,
+		AssetPrice:              *asset.AssetPrice,
+		SalvageValue:            salvageValue,
+		PurchaseDate:            *asset.PurchaseDate,
+		UsefulLife:              *asset.UsefulLife,
+		DepreciationMethod:      asset.DepreciationMethod,
+		AccumulatedDepreciation: *asset.AssetPrice - bookValue,
+		BookValue:               bookValue,
+	}
+}
+
+// Dependency Is Hashed So A Token Can't Be Used Once The Dependency Has Changed Since The Preview
+func buildDeleteFingerprint(recordTable string, recordId uuid.UUID, dependencies []entity.DeleteDependency) string {
+	parts := []string{recordTable + ":" + recordId.String()}
+	for _, dependency := range dependencies {
+		ids := append([]string{}, dependency.RecordIds...)
+		sort.Strings(ids)
+		parts = append(parts, dependency.RecordTable+":"+strings.Join(ids, ","))
+	}
+	sort.Strings(parts[1:])
+
+	return utils.HashToken(strings.Join(parts, "|"))
+}
+
+func buildDeletePreview(deleteConfirmRepo repository.DeleteConfirmationRepository, recordTable string, recordId, accountId uuid.UUID, dependencies []entity.DeleteDependency) (*entity.DeletePreview, error) {
+	// Utils : Generate Confirmation Token
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	// Repo : Create Delete Confirmation
+	ttl := config.GetDeleteConfirmationExpirationDuration()
+	deleteConfirmation := entity.DeleteConfirmation{
+		RecordTable: recordTable,
+		RecordId:    recordId,
+		Fingerprint: buildDeleteFingerprint(recordTable, recordId, dependencies),
+		RequestedBy: accountId,
+	}
+	if err := deleteConfirmRepo.Create(&deleteConfirmation, utils.HashToken(token), ttl); err != nil {
+		return nil, err
+	}
+
+	if dependencies == nil {
+		dependencies = []entity.DeleteDependency{}
+	}
+
+	return &entity.DeletePreview{
+		RecordTable:       recordTable,
+		RecordId:          recordId,
+		Dependencies:      dependencies,
+		ConfirmationToken: token,
+		ExpiredAt:         time.Now().Add(ttl),
+	}, nil
+}
+
+func consumeDeleteConfirmation(deleteConfirmRepo repository.DeleteConfirmationRepository, token, recordTable string, recordId, accountId uuid.UUID, dependencies []entity.DeleteDependency) error {
+	if token == "" {
+		return errors.New("confirmation token is required, request a delete preview first")
+	}
+
+	// Repo : Consume Delete Confirmation Token
+	deleteConfirmation, err := deleteConfirmRepo.ConsumeByTokenHash(utils.HashToken(token))
+	if err != nil {
+		return err
+	}
+	if deleteConfirmation == nil || deleteConfirmation.RecordTable != recordTable || deleteConfirmation.RecordId != recordId || deleteConfirmation.RequestedBy != accountId {
+		return errors.New("invalid or expired confirmation token")
+	}
+	if deleteConfirmation.Fingerprint != buildDeleteFingerprint(recordTable, recordId, dependencies) {
+		return errors.New("dependency has changed since the preview, request a new delete preview")
+	}
+
+	return nil
+}
+
+// Soft Deleted Row That Has Passed The Retention Is Permanently Deleted And Archived By The System, Row Without Stored File Pass Nil
+func purgeDeleted(findAllDeletedIdBefore func(before time.Time) ([]uuid.UUID, error), findAllStoredFileByDeletedId func(id uuid.UUID) ([]string, error), hardDeleteById func(id, archivedBy uuid.UUID) error, before time.Time) (int, error) {
+	// Repo : Find All Deleted Id Before
+	ids, err := findAllDeletedIdBefore(before)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, id := range ids {
+		// Repo : Find Every Stored File Before Its Record Is Cascaded
+		var files []string
+		if findAllStoredFileByDeletedId != nil {
+			files, err = findAllStoredFileByDeletedId(id)
+			if err != nil {
+				return total, err
+			}
+		}
+
+		// Repo : Hard Delete By Id
+		if err := hardDeleteById(id, uuid.Nil); err != nil {
+			return total, err
+		}
+
+		// Utils : Firebase Delete Stored File
+		deleteStoredFile(files)
+		total++
+	}
+
+	return total, nil
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"pelita/entity"
+	"pelita/repository"
+	"pelita/utils"
+
+	"github.com/google/uuid"
+)
+
+// Asset Transfer Interface
+type AssetTransferService interface {
+	GetAllAssetTransfer(pagination utils.Pagination, accountId uuid.UUID, role string) ([]entity.AssetTransfer, int64, error)
+	GetAllMovementByAssetId(pagination utils.Pagination, id, accountId uuid.UUID, role string) ([]entity.AssetMovement, int64, error)
+	GetAllMovementByRoomId(pagination utils.Pagination, id, accountId uuid.UUID, role string) ([]entity.AssetMovement, int64, error)
+	Create(assetTransfer *entity.AssetTransfer, accountId uuid.UUID, role string) error
+	ApproveById(id, accountId uuid.UUID, role string) error
+	RejectById(id, accountId uuid.UUID) error
+}
+
+// Asset Transfer Struct
+type assetTransferService struct {
+	assetTransferRepo  repository.AssetTransferRepository
+	assetMovementRepo  repository.AssetMovementRepository
+	assetPlacementRepo repository.AssetPlacementRepository
+	roomRepo           repository.RoomRepository
+	technicianRepo     repository.TechnicianRepository
+}
+
+// Asset Transfer Constructor
+func NewAssetTransferService(assetTransferRepo repository.AssetTransferRepository, assetMovementRepo repository.AssetMovementRepository, assetPlacementRepo repository.AssetPlacementRepository, roomRepo repository.RoomRepository, technicianRepo repository.TechnicianRepository) AssetTransferService {
+	return &assetTransferService{
+		assetTransferRepo:  assetTransferRepo,
+		assetMovementRepo:  assetMovementRepo,
+		assetPlacementRepo: assetPlacementRepo,
+		roomRepo:           roomRepo,
+		technicianRepo:     technicianRepo,
+	}
+}
+
+func (s *assetTransferService) GetAllAssetTransfer(pagination utils.Pagination, accountId uuid.UUID, role string) ([]entity.AssetTransfer, int64, error) {
+	// Repo : Find Access Scope
+	scope, err := findAccessScope(s.technicianRepo, accountId, role)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Repo : Get All Asset Transfer
+	assetTransfer, total, err := s.assetTransferRepo.FindAll(pagination, scope)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(assetTransfer) == 0 {
+		return nil, 0, errors.New("asset transfer not found")
+	}
+
+	return assetTransfer, total, nil
+}
+
+func (s *assetTransferService) GetAllMovementByAssetId(pagination utils.Pagination, id, accountId uuid.UUID, role string) ([]entity.AssetMovement, int64, error) {
+	// Repo : Find Access Scope
+	scope, err := findAccessScope(s.technicianRepo, accountId, role)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Repo : Get All Asset Movement By Asset Id
+	assetMovement, total, err := s.assetMovementRepo.FindAllByAssetId(pagination, id, scope)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(assetMovement) == 0 {
+		return nil, 0, errors.New("asset movement not found")
+	}
+
+	return assetMovement, total, nil
+}
+
+func (s *assetTransferService) GetAllMovementByRoomId(pagination utils.Pagination, id, accountId uuid.UUID, role string) ([]entity.AssetMovement, int64, error) {
+	// Repo : Find Access Scope
+	scope, err := findAccessScope(s.technicianRepo, accountId, role)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Repo : Get All Asset Movement By Room Id
+	assetMovement, total, err := s.assetMovementRepo.FindAllByRoomId(pagination, id, scope)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(assetMovement) == 0 {
+		return nil, 0, errors.New("asset movement not found")
+	}
+
+	return assetMovement, total, nil
+}
+
+func (s *assetTransferService) Create(assetTransfer *entity.AssetTransfer, accountId uuid.UUID, role string) error {
+	// Repo : Find Source Asset Placement By Id
+	source, err := s.assetPlacementRepo.FindById(assetTransfer.SourcePlacementId)
+	if err != nil {
+		return err
+	}
+	if source == nil {
+		return errors.New("asset placement not found")
+	}
+	if assetTransfer.TransferQty > source.AssetQty {
+		return fmt.Errorf("transfer qty can't be more than %d", source.AssetQty)
+	}
+
+	// Repo : Find Target Room By Id
+	room, err := s.roomRepo.FindById(assetTransfer.TargetRoomId)
+	if err != nil {
+		return err
+	}
+	if room == nil {
+		return errors.New("room not found")
+	}
+	if room.ID == source.RoomId {
+		return errors.New("asset is already placed in the target room")
+	}
+
+	// Repo : Create Asset Transfer
+	assetTransfer.AssetId = source.AssetId
+	assetTransfer.RequestedBy = accountId
+	assetTransfer.RequestedByType = role
+	if err := s.assetTransferRepo.Create(assetTransfer); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *assetTransferService) ApproveById(id, accountId uuid.UUID, role string) error {
+	// Repo : Find Asset Transfer By Id
+	if _, err := s.findRequestedAssetTransfer(id); err != nil {
+		return err
+	}
+
+	// Repo : Approve Asset Transfer And Move The Asset, Loaned Asset Is Checked Under Source Placement Lock
+	if err := s.assetTransferRepo.ApproveById(accountId, role, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *assetTransferService) RejectById(id, accountId uuid.UUID) error {
+	// Repo : Find Asset Transfer By Id
+	if _, err := s.findRequestedAssetTransfer(id); err != nil {
+		return err
+	}
+
+	// Repo : Reject Asset Transfer
+	if err := s.assetTransferRepo.RejectById(accountId, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *assetTransferService) findRequestedAssetTransfer(id uuid.UUID) (*entity.AssetTransfer, error) {
+	// Repo : Find Asset Transfer By Id
+	assetTransfer, err := s.assetTransferRepo.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	if assetTransfer == nil {
+		return nil, errors.New("asset transfer not found")
+	}
+	if assetTransfer.TransferStatus != "requested" {
+		return nil, fmt.Errorf("asset transfer is %s, expected requested", assetTransfer.TransferStatus)
+	}
+
+	return assetTransfer, nil
+}
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"pelita/config"
+	"pelita/entity"
+	"pelita/repository"
+	"pelita/utils"
+
+	"github.com/google/uuid"
+)
+
+// Asset Unit Interface
+type AssetUnitService interface {
+	GetAllByAssetPlacementId(id, accountId uuid.UUID, role string) ([]entity.AssetUnit, error)
+	GenerateByAssetPlacementId(id uuid.UUID) ([]entity.AssetUnit, error)
+	UpdateById(assetUnit *entity.AssetUnit, id, accountId uuid.UUID, role string) error
+	MoveById(assetPlacementId, id, accountId uuid.UUID, role string) error
+	DeleteById(id uuid.UUID) error
+	GetLookupByTag(tag string, accountId uuid.UUID, role string) (*entity.AssetTagLookup, error)
+	GetQRCodeByTag(tag string, accountId uuid.UUID, role string) ([]byte, error)
+	GetBarcodeByTag(tag string, accountId uuid.UUID, role string) ([]byte, error)
+	GetLabelSheet(roomId, assetPlacementId *uuid.UUID, accountId uuid.UUID, role string) ([]byte, error)
+}
+
+// Asset Unit Struct
+type assetUnitService struct {
+	assetUnitRepo      repository.AssetUnitRepository
+	assetPlacementRepo repository.AssetPlacementRepository
+	technicianRepo     repository.TechnicianRepository
+}
+
+// Asset Unit Constructor
+func NewAssetUnitService(assetUnitRepo repository.AssetUnitRepository, assetPlacementRepo repository.AssetPlacementRepository, technicianRepo repository.TechnicianRepository) AssetUnitService {
+	return &assetUnitService{
+		assetUnitRepo:      assetUnitRepo,
+		assetPlacementRepo: assetPlacementRepo,
+		technicianRepo:     technicianRepo,
+	}
+}
+
+func (s *assetUnitService) GetAllByAssetPlacementId(id, accountId uuid.UUID, role string) ([]entity.AssetUnit, error) {
+	// Service : Check Asset Placement Scope
+	if err := checkAssetPlacementScope(s.technicianRepo, s.assetPlacementRepo, accountId, role, id); err != nil {
+		return nil, err
+	}
+
+	// Repo : Get All Asset Unit By Asset Placement Id
+	assetUnit, err := s.assetUnitRepo.FindAllByAssetPlacementId(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(assetUnit) == 0 {
+		return nil, errors.New("asset unit not found")
+	}
+
+	return assetUnit, nil
+}
+
+func (s *assetUnitService) GenerateByAssetPlacementId(id uuid.UUID) ([]entity.AssetUnit, error) {
+	// Repo : Find Asset Placement By Id
+	assetPlacement, err := s.assetPlacementRepo.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	if assetPlacement == nil {
+		return nil, errors.New("asset placement not found")
+	}
+
+	// Repo : Create Missing Asset Unit
+	if _, err := generateAssetUnit(s.assetUnitRepo, assetPlacement); err != nil {
+		return nil, err
+	}
+
+	return s.assetUnitRepo.FindAllByAssetPlacementId(id)
+}
+
+func (s *assetUnitService) UpdateById(assetUnit *entity.AssetUnit, id, accountId uuid.UUID, role string) error {
+	// Validator Contain : Unit Condition
+	if !utils.Contains(config.UnitConditions, assetUnit.UnitCondition) {
+		return errors.New("unit condition is not valid")
+	}
+
+	// Repo : Find Asset Unit By Id
+	current, err := s.assetUnitRepo.FindById(id)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return errors.New("asset unit not found")
+	}
+
+	// Service : Check Asset Placement Scope
+	if err := checkAssetPlacementScope(s.technicianRepo, s.assetPlacementRepo, accountId, role, current.AssetPlacementId); err != nil {
+		return err
+	}
+
+	// Repo : Find Asset Unit By Serial Number
+	if assetUnit.SerialNumber != nil {
+		is_exist, err := s.assetUnitRepo.FindBySerialNumberAndId(*assetUnit.SerialNumber, id)
+		if err != nil {
+			return err
+		}
+		if is_exist != nil {
+			return errors.New("serial number already used by another unit")
+		}
+	}
+
+	// Repo : Update Asset Unit By Id
+	if err := s.assetUnitRepo.UpdateById(assetUnit, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *assetUnitService) MoveById(assetPlacementId, id, accountId uuid.UUID, role string) error {
+	// Repo : Find Asset Unit By Id
+	assetUnit, err := s.assetUnitRepo.FindById(id)
+	if err != nil {
+		return err
+	}
+	if assetUnit == nil {
+		return errors.New("asset unit not found")
+	}
+
+	// Repo : Find Source And Target Asset Placement
+	source, err := s.assetPlacementRepo.FindById(assetUnit.AssetPlacementId)
+	if err != nil {
+		return err
+	}
+	target, err := s.assetPlacementRepo.FindById(assetPlacementId)
+	if err != nil {
+		return err
+	}
+	if source == nil || target == nil {
+		return errors.New("asset placement not found")
+	}
+	if source.AssetId != target.AssetId {
+		return errors.New("asset unit can only be moved to a placement of the same asset")
+	}
+
+	// Service : Check Source And Target Asset Placement Scope
+	for _, assetPlacement := range []*entity.AssetPlacement{source, target} {
+		if err := checkAssetPlacementScope(s.technicianRepo, s.assetPlacementRepo, accountId, role, assetPlacement.ID); err != nil {
+			return err
+		}
+	}
+
+	// Repo : Move Asset Unit, Update Both Quantity, And Log The Movement
+	if err := s.assetUnitRepo.MoveById(accountId, role, assetPlacementId, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *assetUnitService) DeleteById(id uuid.UUID) error {
+	// Repo : Delete Asset Unit By Id And Reduce The Quantity
+	err := s.assetUnitRepo.DeleteById(id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *assetUnitService) GetLookupByTag(tag string, accountId uuid.UUID, role string) (*entity.AssetTagLookup, error) {
+	// Repo : Find Access Scope
+	scope, err := findAccessScope(s.technicianRepo, accountId, role)
+	if err != nil {
+		return nil, err
+	}
+
+	// Repo : Find Asset Unit, Asset, Placement, and Room By Tag
+	lookup, err := s.assetUnitRepo.FindLookupByTag(tag, scope)
+	if err != nil {
+		return nil, err
+	}
+	if lookup == nil {
+		return nil, errors.New("asset tag not found")
+	}
+
+	return lookup, nil
+}
+
+func (s *assetUnitService) GetQRCodeByTag(tag string, accountId uuid.UUID, role string) ([]byte, error) {
+	// Service : Only Registered Tag Can Be Rendered
+	if _, err := s.GetLookupByTag(tag, accountId, role); err != nil {
+		return nil, err
+	}
+
+	// Utils : Generate QR Code
+	return utils.GenerateQRCode(tag, 256)
+}
+
+func (s *assetUnitService) GetBarcodeByTag(tag string, accountId uuid.UUID, role string) ([]byte, error) {
+	// Service : Only Registered Tag Can Be Rendered
+	if _, err := s.GetLookupByTag(tag, accountId, role); err != nil {
+		return nil, err
+	}
+
+	// Utils : Generate Code128
+	return utils.GenerateCode128(tag, 300, 80)
+}
+
+func (s *assetUnitService) GetLabelSheet(roomId, assetPlacementId *uuid.UUID, accountId uuid.UUID, role string) ([]byte, error) {
+	// Repo : Find Access Scope
+	scope, err := findAccessScope(s.technicianRepo, accountId, role)
+	if err != nil {
+		return nil, err
+	}
+
+	// Repo : Get All Label By Room Or Asset Placement
+	var labels []entity.AssetTagLabel
+	switch {
+	case assetPlacementId != nil:
+		labels, err = s.assetUnitRepo.FindAllLabelByAssetPlacementId(*assetPlacementId, scope)
+	case roomId != nil:
+		labels, err = s.assetUnitRepo.FindAllLabelByRoomId(*roomId, scope)
+	default:
+		return nil, errors.New("room id or asset placement id is required")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(labels) == 0 {
+		return nil, errors.New("asset tag not found")
+	}
+
+	// Utils : Generate Label Sheet
+	var buf bytes.Buffer
+	if err := utils.GeneratePDFAssetTagLabel(labels, &buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Every Quantity Of A Placement Is Backed By An Asset Unit With Its Own Tag
+func generateAssetUnit(assetUnitRepo repository.AssetUnitRepository, assetPlacement *entity.AssetPlacement) ([]entity.AssetUnit, error) {
+	return assetUnitRepo.CreateMissingByAssetPlacementId(config.GetAssetTagPrefix(), config.GetAssetTagSequenceLength(), assetPlacement.AssetQty, assetPlacement.ID)
+}
+
+// Asset Unit Is Optional, But When Given It Must Belong To The Placement
+func validateAssetUnit(assetUnitRepo repository.AssetUnitRepository, assetUnitId *uuid.UUID, assetPlacementId uuid.UUID) error {
+	if assetUnitId == nil {
+		return nil
+	}
+
+	// Repo : Find Asset Unit By Id
+	assetUnit, err := assetUnitRepo.FindById(*assetUnitId)
+	if err != nil {
+		return err
+	}
+	if assetUnit == nil {
+		return errors.New("asset unit not found")
+	}
+	if assetUnit.AssetPlacementId != assetPlacementId {
+		return errors.New("asset unit doesn't belong to the asset placement")
+	}
+
+	return nil
+}
@@ -3,6 +3,9 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"strings"
 	"time"
 
 	"pelita/config"
@@ -11,118 +14,193 @@ import (
 	"pelita/utils"
 
 	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
 // Auth Interface
 type AuthService interface {
-	Register(user *entity.User) (string, error)
-	Login(email, password string) (string, string, error)
+	Register(user *entity.User, device, ipAddress string) (*entity.LoginData, error)
+	Login(email, password, device, ipAddress string) (*entity.LoginData, error)
+	RefreshToken(refreshToken string) (*entity.LoginData, error)
 	SignOut(token string) error
+	GetMySession(accountId, sessionId uuid.UUID) ([]entity.Session, error)
+	RevokeSessionById(id, accountId uuid.UUID, role string) error
+	ChangePassword(accountId uuid.UUID, role, oldPassword, newPassword string) error
+	ForgotPassword(email string) error
+	ResetPassword(token, newPassword string) error
+	UnlockAccount(email, ipAddress string) error
 }
 
+// Login Attempt Error
+var ErrAccountLocked = errors.New("too many failed login attempts, account is locked")
+
 // Auth Struct
 type authService struct {
-	userRepo       repository.UserRepository
-	adminRepo      repository.AdminRepository
-	technicianRepo repository.TechnicianRepository
-	redisClient    *redis.Client
+	userRepo          repository.UserRepository
+	adminRepo         repository.AdminRepository
+	technicianRepo    repository.TechnicianRepository
+	sessionRepo       repository.SessionRepository
+	passwordResetRepo repository.PasswordResetRepository
+	loginAttemptRepo  repository.LoginAttemptRepository
+	historyRepo       repository.HistoryRepository
+	notifier          utils.Notifier
+	redisClient       *redis.Client
 }
 
 // Auth Constructor
-func NewAuthService(userRepo repository.UserRepository, adminRepo repository.AdminRepository, technicianRepo repository.TechnicianRepository, redisClient *redis.Client) AuthService {
+func NewAuthService(userRepo repository.UserRepository, adminRepo repository.AdminRepository, technicianRepo repository.TechnicianRepository, sessionRepo repository.SessionRepository, passwordResetRepo repository.PasswordResetRepository, loginAttemptRepo repository.LoginAttemptRepository, historyRepo repository.HistoryRepository, notifier utils.Notifier, redisClient *redis.Client) AuthService {
 	return &authService{
-		userRepo:       userRepo,
-		adminRepo:      adminRepo,
-		technicianRepo: technicianRepo,
-		redisClient:    redisClient,
+		userRepo:          userRepo,
+		adminRepo:         adminRepo,
+		technicianRepo:    technicianRepo,
+		sessionRepo:       sessionRepo,
+		passwordResetRepo: passwordResetRepo,
+		loginAttemptRepo:  loginAttemptRepo,
+		historyRepo:       historyRepo,
+		notifier:          notifier,
+		redisClient:       redisClient,
 	}
 }
 
-func (s *authService) Register(user *entity.User) (string, error) {
+func (s *authService) Register(user *entity.User, device, ipAddress string) (*entity.LoginData, error) {
 	// Check duplicate
 	existing, err := s.userRepo.FindByUsernameOrEmail(user.Username, user.Email)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if existing != nil {
-		return "", errors.New("username or email has already been used")
+		return nil, errors.New("username or email has already been used")
+	}
+
+	// Repo : Check Email Across Admin, Technician, and User
+	isUsed, err := s.userRepo.IsEmailUsedByOtherAccount(user.Email, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	if isUsed {
+		return nil, errors.New("username or email has already been used")
 	}
 
 	// Utils : Hash Password
 	if err := utils.HashPassword(user, user.Password); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// Repo : Create Register
 	if err := s.userRepo.Create(user); err != nil {
-		return "", err
-	}
-
-	// Utils : Generate Token
-	token, err := utils.GenerateToken(user.ID, "user")
-	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return token, nil
+	return s.createSession(user.ID, "guest", device, ipAddress)
 }
 
-func (s *authService) Login(email, password string) (string, string, error) {
-	// Model
-	var account entity.Account
-	var role string
-
-	// Repo : Check Admin By Email
-	admin, err := s.adminRepo.FindByEmail(email)
-	if err != nil {
-		return "", "", err
-	}
-	if admin != nil {
-		account = admin
-		role = "admin"
-	}
+func (s *authService) Login(email, password, device, ipAddress string) (*entity.LoginData, error) {
+	emailTarget := loginAttemptTarget("email", email)
+	ipTarget := loginAttemptTarget("ip", ipAddress)
 
-	// Repo : Check Technician By Email
-	if account == nil {
-		technician, err := s.technicianRepo.FindByEmail(email)
+	// Repo : Check Lock By Email And IP Address
+	for _, target := range []string{emailTarget, ipTarget} {
+		remaining, err := s.loginAttemptRepo.FindLockDuration(target)
 		if err != nil {
-			return "", "", err
+			return nil, err
 		}
-		if technician != nil {
-			account = technician
-			role = "technician"
+		if remaining > 0 {
+			return nil, fmt.Errorf("%w, try again in %s", ErrAccountLocked, remaining.Round(time.Second))
 		}
 	}
 
-	// Repo : Check User (Guest) By Email
+	// Repo : Find Account By Email
+	account, role, err := s.findAccountByEmail(email)
+	if err != nil {
+		return nil, err
+	}
 	if account == nil {
-		user, err := s.userRepo.FindByEmail(email)
-		if err != nil {
-			return "", "", err
-		}
-		if user != nil {
-			account = user
-			role = "guest"
+		if err := s.recordFailedLogin(nil, "", emailTarget, ipTarget); err != nil {
+			return nil, err
 		}
+		return nil, errors.New("account not found")
 	}
 
-	if account == nil {
-		return "", "", errors.New("account not found")
+	// Deactivated Admin Can't Login
+	if !isAccountActive(account) {
+		return nil, errors.New("account has been deactivated")
 	}
 
 	// Utils : Compare Password
 	if err := utils.CheckPassword(account, password); err != nil {
-		return "", "", errors.New("invalid password")
+		if err := s.recordFailedLogin(account, role, emailTarget, ipTarget); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("invalid password")
+	}
+
+	// Repo : Reset Failed Attempt
+	if err := s.loginAttemptRepo.Reset(emailTarget); err != nil {
+		return nil, err
+	}
+
+	return s.createSession(account.GetID(), role, device, ipAddress)
+}
+
+func (s *authService) RefreshToken(refreshToken string) (*entity.LoginData, error) {
+	oldRefreshTokenHash := utils.HashToken(refreshToken)
+
+	// Repo : Find Session By Refresh Token
+	session, isRotated, err := s.sessionRepo.FindByRefreshTokenHash(oldRefreshTokenHash)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, errors.New("invalid refresh token")
+	}
+
+	// Reused Refresh Token, Revoke The Whole Session
+	if isRotated {
+		return nil, s.revokeReusedSession(session.ID)
+	}
+
+	// Utils : Generate Refresh Token
+	newRefreshToken, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	// Repo : Rotate Refresh Token
+	now := time.Now()
+	ttl := config.GetJWTRefreshExpirationDuration()
+	session.LastUsedAt = now
+	session.ExpiredAt = now.Add(ttl)
+	isSwapped, err := s.sessionRepo.Rotate(session, oldRefreshTokenHash, utils.HashToken(newRefreshToken), ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	// Concurrent Refresh With The Same Token Lost The Swap, It Is Treated As Reuse
+	if !isSwapped {
+		return nil, s.revokeReusedSession(session.ID)
 	}
 
 	// Utils : Generate Token
-	token, err := utils.GenerateToken(account.GetID(), role)
+	token, err := utils.GenerateSessionToken(session.AccountId, session.Role, session.ID)
 	if err != nil {
-		return "", "", err
+		return nil, err
+	}
+
+	return &entity.LoginData{
+		AccessToken:  token,
+		RefreshToken: newRefreshToken,
+		Role:         session.Role,
+	}, nil
+}
+
+func (s *authService) revokeReusedSession(sessionId uuid.UUID) error {
+	// Repo : Delete Session By Id
+	if err := s.sessionRepo.DeleteById(sessionId); err != nil {
+		return err
 	}
 
-	return token, role, nil
+	return errors.New("refresh token has already been used")
 }
 
 func (s *authService) SignOut(tokenString string) error {
@@ -157,5 +235,321 @@ func (s *authService) SignOut(tokenString string) error {
 		return errors.New("failed to blacklist token")
 	}
 
+	// Repo : Delete Session
+	if sessionIdStr, ok := claims["session_id"].(string); ok {
+		sessionId, err := uuid.Parse(sessionIdStr)
+		if err != nil {
+			return errors.New("invalid session_id format")
+		}
+
+		session, err := s.sessionRepo.FindById(sessionId)
+		if err != nil {
+			return err
+		}
+		if session != nil {
+			if err := s.sessionRepo.DeleteById(sessionId); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *authService) GetMySession(accountId, sessionId uuid.UUID) ([]entity.Session, error) {
+	// Repo : Get Session By Account Id
+	sessions, err := s.sessionRepo.FindByAccountId(accountId)
+	if err != nil {
+		return nil, err
+	}
+	if sessions == nil {
+		return nil, errors.New("session not found")
+	}
+
+	// Mark Current Device
+	for i := range sessions {
+		sessions[i].IsCurrent = sessions[i].ID == sessionId
+	}
+
+	return sessions, nil
+}
+
+func (s *authService) RevokeSessionById(id, accountId uuid.UUID, role string) error {
+	// Repo : Find Session By Id
+	session, err := s.sessionRepo.FindById(id)
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return errors.New("session not found")
+	}
+
+	// Only Admin Can Revoke Other Account Session
+	if role != "admin" && session.AccountId != accountId {
+		return errors.New("session not found")
+	}
+
+	// Repo : Delete Session By Id
+	if err := s.sessionRepo.DeleteById(id); err != nil {
+		return err
+	}
+
 	return nil
 }
+
+func (s *authService) ChangePassword(accountId uuid.UUID, role, oldPassword, newPassword string) error {
+	// Repo : Find Account By Id
+	account, err := s.userRepo.FindAccountById(accountId, role)
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		return errors.New("account not found")
+	}
+
+	// Utils : Compare Password
+	if err := utils.CheckPassword(account, oldPassword); err != nil {
+		return errors.New("invalid old password")
+	}
+	if oldPassword == newPassword {
+		return errors.New("new password must be different from old password")
+	}
+
+	return s.updatePassword(accountId, role, newPassword)
+}
+
+func (s *authService) ForgotPassword(email string) error {
+	// Repo : Find Account By Email
+	account, role, err := s.findAccountByEmail(email)
+	if err != nil {
+		return err
+	}
+	// Dont Tell The Caller Whether The Email Is Registered Or Active
+	if account == nil || !isAccountActive(account) {
+		return nil
+	}
+
+	// Utils : Generate Reset Token
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	// Repo : Create Password Reset
+	ttl := config.GetPasswordResetExpirationDuration()
+	passwordReset := entity.PasswordReset{
+		AccountId: account.GetID(),
+		Role:      role,
+	}
+	if err := s.passwordResetRepo.Create(&passwordReset, utils.HashToken(token), ttl); err != nil {
+		return err
+	}
+
+	// Utils : Deliver Reset Token
+	message := fmt.Sprintf("🔑 *Password Reset Request*\n\nUse this token to reset your password : `%s`\nThis token will expire in %s.", token, ttl.String())
+	if err := s.notifier.Send(account.GetContact(), message); err != nil {
+		// Failed Delivery Is Only Logged, Returning It Would Tell The Caller That The Email Is Registered
+		log.Printf("Failed to deliver password reset token to account %s: %v\n", account.GetID(), err)
+	}
+
+	return nil
+}
+
+func (s *authService) ResetPassword(token, newPassword string) error {
+	// Repo : Consume Password Reset Token
+	passwordReset, err := s.passwordResetRepo.ConsumeByTokenHash(utils.HashToken(token))
+	if err != nil {
+		return err
+	}
+	if passwordReset == nil {
+		return errors.New("invalid or expired reset token")
+	}
+
+	return s.updatePassword(passwordReset.AccountId, passwordReset.Role, newPassword)
+}
+
+func (s *authService) UnlockAccount(email, ipAddress string) error {
+	// Repo : Find Account By Email
+	account, _, err := s.findAccountByEmail(email)
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		return errors.New("account not found")
+	}
+
+	// Repo : Unlock Email
+	if err := s.loginAttemptRepo.Unlock(loginAttemptTarget("email", email)); err != nil {
+		return err
+	}
+
+	// Repo : Unlock IP Address
+	if ipAddress != "" {
+		if err := s.loginAttemptRepo.Unlock(loginAttemptTarget("ip", ipAddress)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *authService) recordFailedLogin(account entity.Account, role, emailTarget, ipTarget string) error {
+	// Repo : Create History Of Failed Login
+	if account != nil {
+		if err := s.historyRepo.Create(buildAccountHistory(account.GetID(), role, "login_failed")); err != nil {
+			return err
+		}
+	}
+
+	// Repo : Count Failed Attempt By Email And IP Address. Every Attempt Count Toward The IP Address, But With A Higher Limit,
+	// So A Mistyped Password Behind A Shared IP Address (Such As An Office NAT) Only Lock That One Account
+	limits := map[string]int64{
+		emailTarget: config.LoginAttempt.MaxAttemptPerEmail,
+		ipTarget:    config.LoginAttempt.MaxAttemptPerIP,
+	}
+	for target, limit := range limits {
+		total, err := s.loginAttemptRepo.IncrementFailed(target, config.LoginAttempt.AttemptWindow)
+		if err != nil {
+			return err
+		}
+		if total < limit {
+			continue
+		}
+
+		// Repo : Lock With Exponential Duration
+		if _, err := s.loginAttemptRepo.Lock(target, config.LoginAttempt.BaseLockDuration, config.LoginAttempt.MaxLockDuration); err != nil {
+			return err
+		}
+
+		// Repo : Create History Of Locked Account
+		if account != nil && target == emailTarget {
+			if err := s.historyRepo.Create(buildAccountHistory(account.GetID(), role, "account_locked")); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *authService) updatePassword(accountId uuid.UUID, role, newPassword string) error {
+	// Utils : Hash Password
+	hashedPassword, err := utils.GeneratePasswordHash(newPassword)
+	if err != nil {
+		return err
+	}
+
+	// Repo : Update Password By Id
+	if err := s.userRepo.UpdatePasswordById(accountId, role, hashedPassword); err != nil {
+		return err
+	}
+
+	// Repo : Revoke All Existing Token
+	if err := s.sessionRepo.DeleteByAccountId(accountId); err != nil {
+		return err
+	}
+	if err := s.sessionRepo.BlacklistAccountById(accountId, config.GetJWTExpirationDuration()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *authService) findAccountByEmail(email string) (entity.Account, string, error) {
+	// Repo : Check Admin By Email
+	admin, err := s.adminRepo.FindByEmail(email)
+	if err != nil {
+		return nil, "", err
+	}
+	if admin != nil {
+		return admin, "admin", nil
+	}
+
+	// Repo : Check Technician By Email
+	technician, err := s.technicianRepo.FindByEmail(email)
+	if err != nil {
+		return nil, "", err
+	}
+	if technician != nil {
+		return technician, "technician", nil
+	}
+
+	// Repo : Check User (Guest) By Email
+	user, err := s.userRepo.FindByEmail(email)
+	if err != nil {
+		return nil, "", err
+	}
+	if user != nil {
+		return user, "guest", nil
+	}
+
+	return nil, "", nil
+}
+
+func (s *authService) createSession(accountId uuid.UUID, role, device, ipAddress string) (*entity.LoginData, error) {
+	// Utils : Generate Refresh Token
+	refreshToken, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	// Repo : Create Session
+	now := time.Now()
+	ttl := config.GetJWTRefreshExpirationDuration()
+	session := entity.Session{
+		ID:         uuid.New(),
+		AccountId:  accountId,
+		Role:       role,
+		Device:     device,
+		IPAddress:  ipAddress,
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiredAt:  now.Add(ttl),
+	}
+	if err := s.sessionRepo.Create(&session, utils.HashToken(refreshToken), ttl); err != nil {
+		return nil, err
+	}
+
+	// Utils : Generate Token
+	token, err := utils.GenerateSessionToken(accountId, role, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.LoginData{
+		AccessToken:  token,
+		RefreshToken: refreshToken,
+		Role:         role,
+	}, nil
+}
+
+func isAccountActive(account entity.Account) bool {
+	if admin, ok := account.(*entity.Admin); ok {
+		return admin.IsActive
+	}
+
+	return true
+}
+
+func loginAttemptTarget(kind, value string) string {
+	return fmt.Sprintf("%s:%s", kind, strings.ToLower(strings.TrimSpace(value)))
+}
+
+func buildAccountHistory(accountId uuid.UUID, role, typeHistory string) *entity.History {
+	history := entity.History{
+		TypeUser:    role,
+		TypeHistory: typeHistory,
+	}
+
+	// Fill ID Based Role
+	switch role {
+	case "admin":
+		history.AdminID = &accountId
+	case "technician":
+		history.TechnicianID = &accountId
+	default:
+		history.UserID = &accountId
+	}
+
+	return &history
+}
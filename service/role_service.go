@@ -0,0 +1,205 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"pelita/config"
+	"pelita/entity"
+	"pelita/repository"
+	"pelita/utils"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Role Interface
+type RoleService interface {
+	GetAllRole(pagination utils.Pagination) ([]entity.Role, int64, error)
+	GetAllPermission() []string
+	Create(role *entity.Role, permissions []string, createdBy uuid.UUID) error
+	UpdateById(role *entity.Role, permissions []string, id uuid.UUID) error
+	DeleteById(id uuid.UUID) error
+	AssignRoleToAccount(roleId, accountId uuid.UUID, accountType string) error
+	RemoveRoleFromAccount(accountId uuid.UUID) error
+}
+
+// Role Struct
+type roleService struct {
+	roleRepo repository.RoleRepository
+	userRepo repository.UserRepository
+}
+
+// Role Constructor
+func NewRoleService(roleRepo repository.RoleRepository, userRepo repository.UserRepository) RoleService {
+	return &roleService{
+		roleRepo: roleRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (s *roleService) GetAllRole(pagination utils.Pagination) ([]entity.Role, int64, error) {
+	// Repo : Get All Role
+	roles, total, err := s.roleRepo.FindAll(pagination)
+	if err != nil {
+		return nil, 0, err
+	}
+	if roles == nil {
+		return nil, 0, errors.New("role not found")
+	}
+
+	return roles, total, nil
+}
+
+func (s *roleService) GetAllPermission() []string {
+	return config.Permissions
+}
+
+func (s *roleService) Create(role *entity.Role, permissions []string, createdBy uuid.UUID) error {
+	// Validator : Permission
+	if err := validatePermission(permissions); err != nil {
+		return err
+	}
+
+	// Repo : Find Role By Role Name
+	role.RoleName = strings.TrimSpace(role.RoleName)
+	isExist, err := s.roleRepo.FindByRoleName(role.RoleName)
+	if err != nil {
+		return err
+	}
+	if isExist != nil || utils.Contains(config.SystemRoles, role.RoleName) {
+		return errors.New("role name has already been used")
+	}
+
+	// Repo : Create Role
+	role.IsSystem = false
+	role.CreatedBy = &createdBy
+	if err := s.roleRepo.Create(role, permissions); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *roleService) UpdateById(role *entity.Role, permissions []string, id uuid.UUID) error {
+	// Validator : Permission
+	if err := validatePermission(permissions); err != nil {
+		return err
+	}
+
+	// Repo : Find Role By Id
+	existingRole, err := s.roleRepo.FindById(id)
+	if err != nil {
+		return err
+	}
+	if existingRole == nil {
+		return errors.New("role not found")
+	}
+
+	// Admin Always Keep Every Permission, System Role Can't Be Renamed
+	if existingRole.RoleName == config.RoleAdmin {
+		return errors.New("admin role can't be changed")
+	}
+	role.RoleName = strings.TrimSpace(role.RoleName)
+	if existingRole.IsSystem && role.RoleName != existingRole.RoleName {
+		return errors.New("system role can't be renamed")
+	}
+
+	// Repo : Find Role By Role Name
+	isExist, err := s.roleRepo.FindByRoleNameAndNotId(role.RoleName, id)
+	if err != nil {
+		return err
+	}
+	if isExist != nil || (!existingRole.IsSystem && utils.Contains(config.SystemRoles, role.RoleName)) {
+		return errors.New("role name has already been used")
+	}
+
+	// Repo : Update Role By Id
+	if err := s.roleRepo.UpdateById(role, permissions, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *roleService) DeleteById(id uuid.UUID) error {
+	// Repo : Find Role By Id
+	role, err := s.roleRepo.FindById(id)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		return errors.New("role not found")
+	}
+	if role.IsSystem {
+		return errors.New("system role can't be deleted")
+	}
+
+	// Repo : Delete Role By Id
+	if err := s.roleRepo.DeleteById(id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *roleService) AssignRoleToAccount(roleId, accountId uuid.UUID, accountType string) error {
+	// Validator : Account Type
+	if !utils.Contains(config.SystemRoles, accountType) {
+		return errors.New("account type is not valid")
+	}
+
+	// Repo : Find Role By Id
+	role, err := s.roleRepo.FindById(roleId)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		return errors.New("role not found")
+	}
+	if role.IsSystem {
+		return errors.New("system role can't be assigned, remove the custom role instead")
+	}
+
+	// Repo : Find Account By Id
+	account, err := s.userRepo.FindAccountById(accountId, accountType)
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		return errors.New("account not found")
+	}
+
+	// Repo : Assign Account Role
+	accountRole := entity.AccountRole{
+		AccountId:   accountId,
+		AccountType: accountType,
+		RoleId:      roleId,
+	}
+	if err := s.roleRepo.AssignAccountRole(&accountRole); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *roleService) RemoveRoleFromAccount(accountId uuid.UUID) error {
+	// Repo : Delete Account Role By Account Id
+	if err := s.roleRepo.DeleteAccountRoleByAccountId(accountId); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validatePermission(permissions []string) error {
+	if len(permissions) == 0 {
+		return errors.New("permission is required")
+	}
+	for _, permission := range permissions {
+		if !utils.Contains(config.Permissions, permission) {
+			return fmt.Errorf("permission %s is not valid", permission)
+		}
+	}
+
+	return nil
+}
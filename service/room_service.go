@@ -5,6 +5,7 @@ import (
 	"pelita/entity"
 	"pelita/repository"
 	"pelita/utils"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -12,25 +13,36 @@ import (
 // Room Interface
 type RoomService interface {
 	GetAllRoom(pagination utils.Pagination) ([]entity.Room, int64, error)
-	GetRoomAssetByFloorAndRoomName(floor, roomName string) ([]entity.RoomAsset, error)
-	GetRoomAssetShortByFloorAndRoomName(floor, roomName string) ([]entity.RoomAssetShort, error)
+	GetRoomAssetByFloorAndRoomName(floor, roomName string, accountId uuid.UUID, role string) ([]entity.RoomAsset, error)
+	GetRoomAssetShortByFloorAndRoomName(floor, roomName string, accountId uuid.UUID, role string) ([]entity.RoomAssetShort, error)
 	GetMostContext(targetCol string) ([]entity.StatsContextTotal, error)
 	Create(room *entity.Room) error
 	UpdateById(room *entity.Room, id uuid.UUID) error
-	DeleteById(id uuid.UUID) error
+	GetDeleted() ([]entity.Room, error)
+	GetDeletePreviewById(id, accountId uuid.UUID) (*entity.DeletePreview, error)
+	HardDeleteById(id, accountId uuid.UUID, confirmationToken string) error
+	SoftDeleteById(id uuid.UUID) error
+	RecoverDeletedById(id uuid.UUID) error
+
+	// Scheduler Service
+	PurgeDeleted(before time.Time) (int, error)
 }
 
 // Room Struct
 type roomService struct {
-	roomRepo  repository.RoomRepository
-	statsRepo repository.StatsRepository
+	roomRepo          repository.RoomRepository
+	technicianRepo    repository.TechnicianRepository
+	statsRepo         repository.StatsRepository
+	deleteConfirmRepo repository.DeleteConfirmationRepository
 }
 
 // Room Constructor
-func NewRoomService(roomRepo repository.RoomRepository, statsRepo repository.StatsRepository) RoomService {
+func NewRoomService(roomRepo repository.RoomRepository, technicianRepo repository.TechnicianRepository, statsRepo repository.StatsRepository, deleteConfirmRepo repository.DeleteConfirmationRepository) RoomService {
 	return &roomService{
-		roomRepo:  roomRepo,
-		statsRepo: statsRepo,
+		roomRepo:          roomRepo,
+		technicianRepo:    technicianRepo,
+		statsRepo:         statsRepo,
+		deleteConfirmRepo: deleteConfirmRepo,
 	}
 }
 
@@ -47,9 +59,15 @@ func (s *roomService) GetAllRoom(pagination utils.Pagination) ([]entity.Room, in
 	return room, total, nil
 }
 
-func (s *roomService) GetRoomAssetByFloorAndRoomName(floor, roomName string) ([]entity.RoomAsset, error) {
+func (s *roomService) GetRoomAssetByFloorAndRoomName(floor, roomName string, accountId uuid.UUID, role string) ([]entity.RoomAsset, error) {
+	// Repo : Find Access Scope
+	scope, err := findAccessScope(s.technicianRepo, accountId, role)
+	if err != nil {
+		return nil, err
+	}
+
 	// Repo : Get Find Room Asset By Floor And Room Name
-	roomAsset, err := s.roomRepo.FindRoomAssetByFloorAndRoomName(floor, roomName)
+	roomAsset, err := s.roomRepo.FindRoomAssetByFloorAndRoomName(floor, roomName, scope)
 	if err != nil {
 		return nil, err
 	}
@@ -60,9 +78,15 @@ func (s *roomService) GetRoomAssetByFloorAndRoomName(floor, roomName string) ([]
 	return roomAsset, nil
 }
 
-func (s *roomService) GetRoomAssetShortByFloorAndRoomName(floor, roomName string) ([]entity.RoomAssetShort, error) {
+func (s *roomService) GetRoomAssetShortByFloorAndRoomName(floor, roomName string, accountId uuid.UUID, role string) ([]entity.RoomAssetShort, error) {
+	// Repo : Find Access Scope
+	scope, err := findAccessScope(s.technicianRepo, accountId, role)
+	if err != nil {
+		return nil, err
+	}
+
 	// Repo : Get Find Room Asset Short By Floor And Room Name
-	roomAsset, err := s.roomRepo.FindRoomAssetShortByFloorAndRoomName(floor, roomName)
+	roomAsset, err := s.roomRepo.FindRoomAssetShortByFloorAndRoomName(floor, roomName, scope)
 	if err != nil {
 		return nil, err
 	}
@@ -109,9 +133,68 @@ func (s *roomService) UpdateById(room *entity.Room, id uuid.UUID) error {
 	return nil
 }
 
-func (s *roomService) DeleteById(id uuid.UUID) error {
-	// Repo : Delete Room By Id
-	err := s.roomRepo.DeleteById(id)
+func (s *roomService) GetDeleted() ([]entity.Room, error) {
+	// Repo : Get All Deleted Room
+	room, err := s.roomRepo.FindDeleted()
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, errors.New("deleted room not found")
+	}
+
+	return room, nil
+}
+
+func (s *roomService) GetDeletePreviewById(id, accountId uuid.UUID) (*entity.DeletePreview, error) {
+	// Repo : Find Every Row That Is Cascaded Along With The Deleted Room
+	dependencies, err := s.roomRepo.FindDeleteDependencyById(id)
+	if err != nil {
+		return nil, err
+	}
+	if dependencies == nil {
+		return nil, errors.New("deleted room not found")
+	}
+
+	return buildDeletePreview(s.deleteConfirmRepo, "rooms", id, accountId, dependencies)
+}
+
+func (s *roomService) HardDeleteById(id, accountId uuid.UUID, confirmationToken string) error {
+	// Repo : Find Every Row That Is Cascaded Along With The Deleted Room
+	dependencies, err := s.roomRepo.FindDeleteDependencyById(id)
+	if err != nil {
+		return err
+	}
+	if dependencies == nil {
+		return errors.New("deleted room not found")
+	}
+
+	// Repo : Consume Delete Confirmation
+	if err := consumeDeleteConfirmation(s.deleteConfirmRepo, confirmationToken, "rooms", id, accountId, dependencies); err != nil {
+		return err
+	}
+
+	// Repo : Find Every Stored File Before Its Record Is Cascaded
+	files, err := s.roomRepo.FindAllStoredFileByDeletedId(id)
+	if err != nil {
+		return err
+	}
+
+	// Repo : Delete Room By Id, Cascaded Row Is Archived
+	err = s.roomRepo.HardDeleteById(id, accountId)
+	if err != nil {
+		return err
+	}
+
+	// Utils : Firebase Delete Stored File
+	deleteStoredFile(files)
+
+	return nil
+}
+
+func (s *roomService) SoftDeleteById(id uuid.UUID) error {
+	// Repo : Soft Delete Room By Id
+	err := s.roomRepo.SoftDeleteById(id)
 	if err != nil {
 		return err
 	}
@@ -119,6 +202,21 @@ func (s *roomService) DeleteById(id uuid.UUID) error {
 	return nil
 }
 
+func (s *roomService) RecoverDeletedById(id uuid.UUID) error {
+	// Repo : Recover Room By Id
+	err := s.roomRepo.RecoverDeletedById(id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *roomService) PurgeDeleted(before time.Time) (int, error) {
+	// Repo : Permanently Delete Room That Has Passed The Retention
+	return purgeDeleted(s.roomRepo.FindAllDeletedIdBefore, s.roomRepo.FindAllStoredFileByDeletedId, s.roomRepo.HardDeleteById, before)
+}
+
 func (s *roomService) GetMostContext(targetCol string) ([]entity.StatsContextTotal, error) {
 	// Repo : Get My Room
 	room, err := s.statsRepo.FindMostUsedContext("rooms", targetCol)
@@ -3,9 +3,11 @@ package service
 import (
 	"errors"
 	"fmt"
+	"pelita/config"
 	"pelita/entity"
 	"pelita/repository"
 	"pelita/utils"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,18 +17,33 @@ type TechnicianService interface {
 	GetAllTechnician(pagination utils.Pagination) ([]entity.Technician, int64, error)
 	Create(technician *entity.Technician, adminId uuid.UUID) error
 	UpdateById(technician *entity.Technician, id uuid.UUID) error
-	DeleteById(id uuid.UUID) error
+	GetDeleted() ([]entity.Technician, error)
+	GetDeletePreviewById(id, accountId uuid.UUID) (*entity.DeletePreview, error)
+	HardDeleteById(id, accountId uuid.UUID, confirmationToken string) error
+	SoftDeleteById(id uuid.UUID) error
+	RecoverDeletedById(id uuid.UUID) error
+	GetScopeById(id uuid.UUID) (*entity.AccessScope, error)
+	UpdateScopeById(scope *entity.AccessScope, id uuid.UUID) error
+
+	// Scheduler Service
+	PurgeDeleted(before time.Time) (int, error)
 }
 
 // Technician Struct
 type technicianService struct {
-	technicianRepo repository.TechnicianRepository
+	technicianRepo    repository.TechnicianRepository
+	userRepo          repository.UserRepository
+	sessionRepo       repository.SessionRepository
+	deleteConfirmRepo repository.DeleteConfirmationRepository
 }
 
 // Technician Constructor
-func NewTechnicianService(technicianRepo repository.TechnicianRepository) TechnicianService {
+func NewTechnicianService(technicianRepo repository.TechnicianRepository, userRepo repository.UserRepository, sessionRepo repository.SessionRepository, deleteConfirmRepo repository.DeleteConfirmationRepository) TechnicianService {
 	return &technicianService{
-		technicianRepo: technicianRepo,
+		technicianRepo:    technicianRepo,
+		userRepo:          userRepo,
+		sessionRepo:       sessionRepo,
+		deleteConfirmRepo: deleteConfirmRepo,
 	}
 }
 
@@ -44,17 +61,22 @@ func (s *technicianService) GetAllTechnician(pagination utils.Pagination) ([]ent
 }
 
 func (s *technicianService) Create(technician *entity.Technician, adminId uuid.UUID) error {
-	// Repo : Get Technician by email
-	is_exist, err := s.technicianRepo.FindByEmail(technician.Email)
+	// Repo : Check Email Across Admin, Technician, and User
+	isUsed, err := s.userRepo.IsEmailUsedByOtherAccount(technician.Email, uuid.Nil)
 	if err != nil {
 		return err
 	}
-	if is_exist != nil {
+	if isUsed {
 		return errors.New("email already used")
 	}
 
-	// Repo : Create Technician
-	fmt.Println(adminId)
+	// Repo : Check Telegram User Id Across Admin, Technician, and User
+	if err := checkTelegramUserIdUsed(s.userRepo, technician.TelegramUserId, uuid.Nil); err != nil {
+		return err
+	}
+
+	// Repo : Create Technician, Telegram Account Only Verified Through Telegram Linking
+	technician.TelegramIsValid = false
 	if err := s.technicianRepo.Create(technician, adminId); err != nil {
 		return err
 	}
@@ -63,15 +85,34 @@ func (s *technicianService) Create(technician *entity.Technician, adminId uuid.U
 }
 
 func (s *technicianService) UpdateById(technician *entity.Technician, id uuid.UUID) error {
-	// Repo : Get Technician by email
-	is_exist, err := s.technicianRepo.FindByEmailAndId(technician.Email, id)
+	// Repo : Check Email Across Admin, Technician, and User
+	isUsed, err := s.userRepo.IsEmailUsedByOtherAccount(technician.Email, id)
 	if err != nil {
 		return err
 	}
-	if is_exist != nil {
+	if isUsed {
 		return errors.New("email already used")
 	}
 
+	// Repo : Find Technician By Id
+	existingTechnician, err := s.technicianRepo.FindById(id)
+	if err != nil {
+		return err
+	}
+	if existingTechnician == nil {
+		return errors.New("technician not found")
+	}
+
+	// Repo : Check Changed Telegram User Id Across Admin, Technician, and User
+	if !isSameTelegramUserId(existingTechnician.TelegramUserId, technician.TelegramUserId) {
+		if err := checkTelegramUserIdUsed(s.userRepo, technician.TelegramUserId, id); err != nil {
+			return err
+		}
+	}
+
+	// Telegram Account Only Verified Through Telegram Linking
+	technician.TelegramIsValid = existingTechnician.TelegramIsValid && isSameTelegramUserId(existingTechnician.TelegramUserId, technician.TelegramUserId)
+
 	// Repo : Update Technician By Id
 	if err := s.technicianRepo.UpdateById(technician, id); err != nil {
 		return err
@@ -80,12 +121,167 @@ func (s *technicianService) UpdateById(technician *entity.Technician, id uuid.UU
 	return nil
 }
 
-func (s *technicianService) DeleteById(id uuid.UUID) error {
-	// Repo : Delete Technician By Id
-	err := s.technicianRepo.DeleteById(id)
+func (s *technicianService) GetDeleted() ([]entity.Technician, error) {
+	// Repo : Get All Deleted Technician
+	technician, err := s.technicianRepo.FindDeleted()
+	if err != nil {
+		return nil, err
+	}
+	if technician == nil {
+		return nil, errors.New("deleted technician not found")
+	}
+
+	return technician, nil
+}
+
+func (s *technicianService) GetDeletePreviewById(id, accountId uuid.UUID) (*entity.DeletePreview, error) {
+	// Repo : Find Every Row That Is Cascaded Along With The Deleted Technician
+	dependencies, err := s.technicianRepo.FindDeleteDependencyById(id)
+	if err != nil {
+		return nil, err
+	}
+	if dependencies == nil {
+		return nil, errors.New("deleted technician not found")
+	}
+
+	return buildDeletePreview(s.deleteConfirmRepo, "technicians", id, accountId, dependencies)
+}
+
+func (s *technicianService) HardDeleteById(id, accountId uuid.UUID, confirmationToken string) error {
+	// Repo : Find Every Row That Is Cascaded Along With The Deleted Technician
+	dependencies, err := s.technicianRepo.FindDeleteDependencyById(id)
 	if err != nil {
 		return err
 	}
+	if dependencies == nil {
+		return errors.New("deleted technician not found")
+	}
+
+	// Repo : Consume Delete Confirmation
+	if err := consumeDeleteConfirmation(s.deleteConfirmRepo, confirmationToken, "technicians", id, accountId, dependencies); err != nil {
+		return err
+	}
+
+	// Repo : Find Every Stored File Before Its Record Is Cascaded
+	files, err := s.technicianRepo.FindAllStoredFileByDeletedId(id)
+	if err != nil {
+		return err
+	}
+
+	// Repo : Delete Technician By Id, Cascaded Row Is Archived
+	err = s.technicianRepo.HardDeleteById(id, accountId)
+	if err != nil {
+		return err
+	}
+
+	// Utils : Firebase Delete Stored File
+	deleteStoredFile(files)
+
+	return nil
+}
+
+func (s *technicianService) SoftDeleteById(id uuid.UUID) error {
+	// Repo : Soft Delete Technician By Id
+	err := s.technicianRepo.SoftDeleteById(id)
+	if err != nil {
+		return err
+	}
+
+	// Repo : Revoke All Existing Token
+	if err := s.sessionRepo.DeleteByAccountId(id); err != nil {
+		return err
+	}
+	if err := s.sessionRepo.BlacklistAccountById(id, config.GetJWTExpirationDuration()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *technicianService) RecoverDeletedById(id uuid.UUID) error {
+	// Repo : Recover Technician By Id
+	err := s.technicianRepo.RecoverDeletedById(id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *technicianService) PurgeDeleted(before time.Time) (int, error) {
+	// Repo : Permanently Delete Technician That Has Passed The Retention
+	return purgeDeleted(s.technicianRepo.FindAllDeletedIdBefore, s.technicianRepo.FindAllStoredFileByDeletedId, s.technicianRepo.HardDeleteById, before)
+}
+
+func (s *technicianService) GetScopeById(id uuid.UUID) (*entity.AccessScope, error) {
+	// Repo : Find Technician By Id
+	technician, err := s.technicianRepo.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	if technician == nil {
+		return nil, errors.New("technician not found")
+	}
+
+	// Repo : Find Scope By Id
+	return s.technicianRepo.FindScopeById(id)
+}
+
+func (s *technicianService) UpdateScopeById(scope *entity.AccessScope, id uuid.UUID) error {
+	// Validator Contain : Department & Floor
+	for _, dept := range scope.Departments {
+		if !utils.Contains(config.Departments, dept) {
+			return fmt.Errorf("department %s is not valid", dept)
+		}
+	}
+	for _, floor := range scope.Floors {
+		if !utils.Contains(config.Floors, floor) {
+			return fmt.Errorf("floor %s is not valid", floor)
+		}
+	}
+
+	// Repo : Find Technician By Id
+	technician, err := s.technicianRepo.FindById(id)
+	if err != nil {
+		return err
+	}
+	if technician == nil {
+		return errors.New("technician not found")
+	}
+
+	// Repo : Update Scope By Id
+	if err := s.technicianRepo.UpdateScopeById(scope, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Only Technician Is Scoped, Admin Always See Every Department And Floor
+func findAccessScope(technicianRepo repository.TechnicianRepository, accountId uuid.UUID, role string) (*entity.AccessScope, error) {
+	if role != config.RoleTechnician {
+		return nil, nil
+	}
+
+	return technicianRepo.FindScopeById(accountId)
+}
+
+// Technician Can Only Reach Asset Placement Inside Its Scope
+func checkAssetPlacementScope(technicianRepo repository.TechnicianRepository, assetPlacementRepo repository.AssetPlacementRepository, accountId uuid.UUID, role string, assetPlacementId uuid.UUID) error {
+	// Repo : Find Access Scope
+	scope, err := findAccessScope(technicianRepo, accountId, role)
+	if err != nil {
+		return err
+	}
+
+	// Repo : Check Asset Placement Scope
+	inScope, err := assetPlacementRepo.IsInScopeById(assetPlacementId, scope)
+	if err != nil {
+		return err
+	}
+	if !inScope {
+		return errors.New("asset placement is outside of your access scope")
+	}
 
 	return nil
 }
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"pelita/config"
+	"pelita/entity"
+	"pelita/repository"
+	"pelita/utils"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/google/uuid"
+)
+
+// Telegram Interface
+type TelegramService interface {
+	CreateLinkCode(accountId uuid.UUID, role string) (*entity.TelegramLinkCode, error)
+	Unlink(accountId uuid.UUID, role string) error
+	HandleUpdate(update tgbotapi.Update) error
+	StartPolling() error
+}
+
+// Telegram Struct
+type telegramService struct {
+	userRepo         repository.UserRepository
+	telegramLinkRepo repository.TelegramLinkRepository
+	historyRepo      repository.HistoryRepository
+	bot              *tgbotapi.BotAPI
+	botMutex         sync.Mutex
+}
+
+// Telegram Constructor
+func NewTelegramService(userRepo repository.UserRepository, telegramLinkRepo repository.TelegramLinkRepository, historyRepo repository.HistoryRepository) TelegramService {
+	return &telegramService{
+		userRepo:         userRepo,
+		telegramLinkRepo: telegramLinkRepo,
+		historyRepo:      historyRepo,
+	}
+}
+
+func (s *telegramService) CreateLinkCode(accountId uuid.UUID, role string) (*entity.TelegramLinkCode, error) {
+	// Repo : Find Account By Id
+	account, err := s.userRepo.FindAccountById(accountId, role)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, errors.New("account not found")
+	}
+
+	// Utils : Generate Link Code
+	code, err := utils.GenerateRandomToken(4)
+	if err != nil {
+		return nil, err
+	}
+	code = strings.ToUpper(code)
+
+	// Repo : Create Telegram Link
+	ttl := config.GetTelegramLinkExpirationDuration()
+	telegramLink := entity.TelegramLink{
+		AccountId: accountId,
+		Role:      role,
+	}
+	if err := s.telegramLinkRepo.Create(&telegramLink, code, ttl); err != nil {
+		return nil, err
+	}
+
+	return &entity.TelegramLinkCode{
+		Code:      code,
+		Command:   fmt.Sprintf("/link %s", code),
+		ExpiredAt: time.Now().Add(ttl),
+	}, nil
+}
+
+func (s *telegramService) Unlink(accountId uuid.UUID, role string) error {
+	// Repo : Clear Telegram Account
+	if err := s.userRepo.UpdateTelegramById(accountId, role, nil, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *telegramService) HandleUpdate(update tgbotapi.Update) error {
+	// Only Handle Private Text Message
+	if update.Message == nil || update.Message.From == nil || update.Message.Chat == nil || !update.Message.Chat.IsPrivate() {
+		return nil
+	}
+	chatID := update.Message.Chat.ID
+
+	code := parseTelegramLinkCode(update.Message.Text)
+	if code == "" {
+		return s.reply(chatID, "👋 Send `/link <code>` with the code from Pelita to link your account.")
+	}
+
+	// Repo : Consume Telegram Link Code
+	telegramLink, err := s.telegramLinkRepo.ConsumeByCode(code)
+	if err != nil {
+		return err
+	}
+	if telegramLink == nil {
+		return s.reply(chatID, "❌ The link code is invalid or has expired. Please generate a new one from Pelita.")
+	}
+
+	// Repo : Mark Telegram Account As Valid
+	telegramUserId := strconv.Itoa(update.Message.From.ID)
+	if err := s.userRepo.LinkTelegramById(telegramLink.AccountId, telegramLink.Role, telegramUserId); err != nil {
+		return err
+	}
+
+	// Repo : Create History Of Linked Telegram
+	if err := s.historyRepo.Create(buildAccountHistory(telegramLink.AccountId, telegramLink.Role, "link_telegram")); err != nil {
+		return err
+	}
+
+	return s.reply(chatID, "✅ Your Telegram account has been linked to Pelita.")
+}
+
+func (s *telegramService) StartPolling() error {
+	bot, err := s.getBot()
+	if err != nil {
+		return err
+	}
+
+	updateConfig := tgbotapi.NewUpdate(0)
+	updateConfig.Timeout = 60
+	updates, err := bot.GetUpdatesChan(updateConfig)
+	if err != nil {
+		return err
+	}
+
+	for update := range updates {
+		if err := s.HandleUpdate(update); err != nil {
+			log.Println("Failed to handle telegram update:", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *telegramService) reply(chatID int64, message string) error {
+	bot, err := s.getBot()
+	if err != nil {
+		return err
+	}
+
+	msg := tgbotapi.NewMessage(chatID, message)
+	msg.ParseMode = "Markdown"
+	if _, err := bot.Send(msg); err != nil {
+		return fmt.Errorf("failed to send telegram message: %w", err)
+	}
+
+	return nil
+}
+
+func (s *telegramService) getBot() (*tgbotapi.BotAPI, error) {
+	s.botMutex.Lock()
+	defer s.botMutex.Unlock()
+
+	if s.bot == nil {
+		bot, err := utils.NewTelegramBot(config.GetTelegramBotToken())
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to telegram bot: %w", err)
+		}
+		s.bot = bot
+	}
+
+	return s.bot, nil
+}
+
+// Telegram User Id Can Only Belong To One Account Across Admin, Technician, and User
+func checkTelegramUserIdUsed(userRepo repository.UserRepository, telegramUserId *string, id uuid.UUID) error {
+	if telegramUserId == nil || *telegramUserId == "" {
+		return nil
+	}
+
+	// Repo : Check Telegram User Id Across Admin, Technician, and User
+	isUsed, err := userRepo.IsTelegramUserIdUsedByOtherAccount(*telegramUserId, id)
+	if err != nil {
+		return err
+	}
+	if isUsed {
+		return errors.New("telegram user id already used")
+	}
+
+	return nil
+}
+
+func isSameTelegramUserId(old, new *string) bool {
+	if old == nil || new == nil {
+		return old == new
+	}
+
+	return *old == *new
+}
+
+// Accept "/link CODE", "/start CODE" (Deep Link) Or Only "CODE"
+func parseTelegramLinkCode(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) > 0 && strings.HasPrefix(fields[0], "/") {
+		fields = fields[1:]
+	}
+	if len(fields) != 1 {
+		return ""
+	}
+
+	return strings.ToUpper(fields[0])
+}
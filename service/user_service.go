@@ -2,9 +2,12 @@ package service
 
 import (
 	"errors"
+	"fmt"
 
+	"pelita/config"
 	"pelita/entity"
 	"pelita/repository"
+	"pelita/utils"
 
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
@@ -13,19 +16,29 @@ import (
 // User Interface
 type UserService interface {
 	GetMyProfile(user uuid.UUID, role string) (*entity.MyProfile, error)
+	UpdateMyProfile(userId uuid.UUID, role string, req *entity.RequestPutUpdateProfile) (*entity.UpdateProfileData, error)
+	ConfirmEmail(token string) error
 }
 
 // User Struct
 type userService struct {
-	userRepo    repository.UserRepository
-	redisClient *redis.Client
+	userRepo        repository.UserRepository
+	sessionRepo     repository.SessionRepository
+	emailChangeRepo repository.EmailChangeRepository
+	historyRepo     repository.HistoryRepository
+	mailer          utils.Mailer
+	redisClient     *redis.Client
 }
 
 // User Constructor
-func NewUserService(userRepo repository.UserRepository, redisClient *redis.Client) UserService {
+func NewUserService(userRepo repository.UserRepository, sessionRepo repository.SessionRepository, emailChangeRepo repository.EmailChangeRepository, historyRepo repository.HistoryRepository, mailer utils.Mailer, redisClient *redis.Client) UserService {
 	return &userService{
-		userRepo:    userRepo,
-		redisClient: redisClient,
+		userRepo:        userRepo,
+		sessionRepo:     sessionRepo,
+		emailChangeRepo: emailChangeRepo,
+		historyRepo:     historyRepo,
+		mailer:          mailer,
+		redisClient:     redisClient,
 	}
 }
 
@@ -41,3 +54,122 @@ func (s *userService) GetMyProfile(userId uuid.UUID, role string) (*entity.MyPro
 
 	return user, nil
 }
+
+func (s *userService) UpdateMyProfile(userId uuid.UUID, role string, req *entity.RequestPutUpdateProfile) (*entity.UpdateProfileData, error) {
+	// Repo : Find Account By Id
+	account, err := s.userRepo.FindAccountById(userId, role)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, errors.New("user not found")
+	}
+	contact := account.GetContact()
+
+	// Repo : Check Username Of Guest (Register Keep Guest Username Unique)
+	if role == "guest" && req.Username != contact.Username {
+		isExist, err := s.userRepo.FindByUsernameAndId(req.Username, userId)
+		if err != nil {
+			return nil, err
+		}
+		if isExist != nil {
+			return nil, errors.New("username already been used")
+		}
+	}
+
+	// Repo : Check Email Across Admin, Technician, and User
+	emailChanged := req.Email != contact.Email
+	if emailChanged {
+		isUsed, err := s.userRepo.IsEmailUsedByOtherAccount(req.Email, userId)
+		if err != nil {
+			return nil, err
+		}
+		if isUsed {
+			return nil, errors.New("email already been used")
+		}
+	}
+
+	// Repo : Check Changed Telegram User Id Across Admin, Technician, and User
+	if !isSameTelegramUserId(contact.TelegramUserId, req.TelegramUserId) {
+		if err := checkTelegramUserIdUsed(s.userRepo, req.TelegramUserId, userId); err != nil {
+			return nil, err
+		}
+	}
+
+	// Telegram Account Only Verified Through Telegram Linking
+	telegramIsValid := contact.TelegramIsValid && isSameTelegramUserId(contact.TelegramUserId, req.TelegramUserId)
+
+	result := entity.UpdateProfileData{EmailConfirmationRequired: emailChanged}
+	if emailChanged {
+		// Utils : Generate Confirmation Token
+		token, err := utils.GenerateRandomToken(32)
+		if err != nil {
+			return nil, err
+		}
+
+		// Repo : Create Email Change, Email Only Updated After Confirmed
+		ttl := config.GetEmailChangeExpirationDuration()
+		emailChange := entity.EmailChange{
+			AccountId: userId,
+			Role:      role,
+			Email:     req.Email,
+		}
+		if err := s.emailChangeRepo.Create(&emailChange, utils.HashToken(token), ttl); err != nil {
+			return nil, err
+		}
+
+		// Utils : Deliver Confirmation Token To The New Email, So Only Its Owner Can Confirm It
+		message := fmt.Sprintf("Use this token to confirm your new email : %s\nThis token will expire in %s.", token, ttl.String())
+		if err := s.mailer.Send(req.Email, "Pelita Email Change Request", message); err != nil {
+			return nil, err
+		}
+		result.PendingEmail = &req.Email
+	}
+
+	// Repo : Update Profile
+	if err := s.userRepo.UpdateProfileById(userId, role, req.Username, req.TelegramUserId, telegramIsValid); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (s *userService) ConfirmEmail(token string) error {
+	// Repo : Consume Email Change Token
+	emailChange, err := s.emailChangeRepo.ConsumeByTokenHash(utils.HashToken(token))
+	if err != nil {
+		return err
+	}
+	if emailChange == nil {
+		return errors.New("invalid or expired confirmation token")
+	}
+
+	// Repo : Check Email Again, It Could Be Taken While Waiting For Confirmation
+	isUsed, err := s.userRepo.IsEmailUsedByOtherAccount(emailChange.Email, emailChange.AccountId)
+	if err != nil {
+		return err
+	}
+	if isUsed {
+		return errors.New("email already been used")
+	}
+
+	// Repo : Update Email By Id
+	if err := s.userRepo.UpdateEmailById(emailChange.AccountId, emailChange.Role, emailChange.Email); err != nil {
+		return err
+	}
+
+	// Repo : Revoke All Existing Token
+	if err := s.sessionRepo.DeleteByAccountId(emailChange.AccountId); err != nil {
+		return err
+	}
+	if err := s.sessionRepo.BlacklistAccountById(emailChange.AccountId, config.GetJWTExpirationDuration()); err != nil {
+		return err
+	}
+
+	// Repo : Create History Of Email Change
+	if err := s.historyRepo.Create(buildAccountHistory(emailChange.AccountId, emailChange.Role, "change_email")); err != nil {
+		return err
+	}
+
+	return nil
+}
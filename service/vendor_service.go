@@ -0,0 +1,88 @@
+package service
+
+import (
+	"errors"
+	"pelita/entity"
+	"pelita/repository"
+	"pelita/utils"
+
+	"github.com/google/uuid"
+)
+
+// Vendor Interface
+type VendorService interface {
+	GetAllVendor(pagination utils.Pagination) ([]entity.Vendor, int64, error)
+	Create(vendor *entity.Vendor) error
+	UpdateById(vendor *entity.Vendor, id uuid.UUID) error
+	DeleteById(id uuid.UUID) error
+}
+
+// Vendor Struct
+type vendorService struct {
+	vendorRepo repository.VendorRepository
+}
+
+// Vendor Constructor
+func NewVendorService(vendorRepo repository.VendorRepository) VendorService {
+	return &vendorService{
+		vendorRepo: vendorRepo,
+	}
+}
+
+func (s *vendorService) GetAllVendor(pagination utils.Pagination) ([]entity.Vendor, int64, error) {
+	// Repo : Get All Vendor
+	vendor, total, err := s.vendorRepo.FindAll(pagination)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(vendor) == 0 {
+		return nil, 0, errors.New("vendor not found")
+	}
+
+	return vendor, total, nil
+}
+
+func (s *vendorService) Create(vendor *entity.Vendor) error {
+	// Repo : Get Vendor By Vendor Name
+	is_exist, err := s.vendorRepo.FindByVendorName(vendor.VendorName)
+	if err != nil {
+		return err
+	}
+	if is_exist != nil {
+		return errors.New("vendor already exist")
+	}
+
+	// Repo : Create Vendor
+	if err := s.vendorRepo.Create(vendor); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *vendorService) UpdateById(vendor *entity.Vendor, id uuid.UUID) error {
+	// Repo : Get Vendor By Vendor Name
+	is_exist, err := s.vendorRepo.FindByVendorNameAndId(vendor.VendorName, id)
+	if err != nil {
+		return err
+	}
+	if is_exist != nil {
+		return errors.New("vendor already exist")
+	}
+
+	// Repo : Update Vendor By Id
+	if err := s.vendorRepo.UpdateById(vendor, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *vendorService) DeleteById(id uuid.UUID) error {
+	// Repo : Delete Vendor By Id
+	if err := s.vendorRepo.DeleteById(id); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"errors"
+	"pelita/config"
+	"pelita/entity"
+	"pelita/repository"
+	"pelita/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Warranty Interface
+type WarrantyService interface {
+	GetAllByAssetId(id uuid.UUID) ([]entity.Warranty, error)
+	Create(warranty *entity.Warranty) error
+	UpdateById(warranty *entity.Warranty, id uuid.UUID) error
+	DeleteById(id uuid.UUID) error
+
+	// Scheduler Service
+	GetAllExpiringWarranty() ([]entity.WarrantyReminder, error)
+	MarkRemindedByIds(ids []uuid.UUID) error
+}
+
+// Warranty Struct
+type warrantyService struct {
+	warrantyRepo repository.WarrantyRepository
+	vendorRepo   repository.VendorRepository
+	assetRepo    repository.AssetRepository
+}
+
+// Warranty Constructor
+func NewWarrantyService(warrantyRepo repository.WarrantyRepository, vendorRepo repository.VendorRepository, assetRepo repository.AssetRepository) WarrantyService {
+	return &warrantyService{
+		warrantyRepo: warrantyRepo,
+		vendorRepo:   vendorRepo,
+		assetRepo:    assetRepo,
+	}
+}
+
+func (s *warrantyService) GetAllByAssetId(id uuid.UUID) ([]entity.Warranty, error) {
+	// Repo : Get All Warranty By Asset Id
+	warranty, err := s.warrantyRepo.FindAllByAssetId(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(warranty) == 0 {
+		return nil, errors.New("warranty not found")
+	}
+
+	return warranty, nil
+}
+
+func (s *warrantyService) GetAllExpiringWarranty() ([]entity.WarrantyReminder, error) {
+	// Repo : Get All Warranty Not Reminded Yet That End Within The Reminder Days
+	now := time.Now()
+	warranty, err := s.warrantyRepo.FindAllUnremindedByEndDate(now, now.AddDate(0, 0, config.WarrantyReminderDays))
+	if err != nil {
+		return nil, err
+	}
+
+	return warranty, nil
+}
+
+func (s *warrantyService) MarkRemindedByIds(ids []uuid.UUID) error {
+	// Repo : Update Reminded At, So Each Warranty Is Reminded Once
+	if err := s.warrantyRepo.UpdateRemindedAtByIds(time.Now(), ids); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *warrantyService) Create(warranty *entity.Warranty) error {
+	// Validator : Warranty Asset & Vendor
+	if err := s.validateWarranty(warranty); err != nil {
+		return err
+	}
+
+	// Repo : Create Warranty
+	if err := s.warrantyRepo.Create(warranty); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *warrantyService) UpdateById(warranty *entity.Warranty, id uuid.UUID) error {
+	// Repo : Find Warranty By Id
+	existingWarranty, err := s.warrantyRepo.FindById(id)
+	if err != nil {
+		return err
+	}
+	if existingWarranty == nil {
+		return errors.New("warranty not found")
+	}
+
+	// Validator : Warranty Asset & Vendor
+	if err := s.validateWarranty(warranty); err != nil {
+		return err
+	}
+
+	// Repo : Update Warranty By Id
+	if err := s.warrantyRepo.UpdateById(warranty, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *warrantyService) DeleteById(id uuid.UUID) error {
+	// Repo : Delete Warranty By Id
+	if err := s.warrantyRepo.DeleteById(id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *warrantyService) validateWarranty(warranty *entity.Warranty) error {
+	// Validator Contain : Warranty Type
+	if !utils.Contains(config.WarrantyTypes, warranty.WarrantyType) {
+		return errors.New("warranty type is not valid")
+	}
+	if warranty.EndDate.Before(warranty.StartDate) {
+		return errors.New("end date can't be before the start date")
+	}
+
+	// Repo : Find Asset By Id
+	asset, err := s.assetRepo.FindById(warranty.AssetId)
+	if err != nil {
+		return err
+	}
+	if asset == nil {
+		return errors.New("asset not found")
+	}
+
+	// Repo : Find Vendor By Id
+	if warranty.VendorId != nil {
+		vendor, err := s.vendorRepo.FindById(*warranty.VendorId)
+		if err != nil {
+			return err
+		}
+		if vendor == nil {
+			return errors.New("vendor not found")
+		}
+	}
+
+	return nil
+}
+
+// Warranty Contact Is Preferred, Otherwise Fallback To The Vendor Contact
+func findAssetWarrantyStatus(warrantyRepo repository.WarrantyRepository, assetPlacementId uuid.UUID) (*entity.AssetWarrantyStatus, error) {
+	// Repo : Find Active Warranty By Asset Placement Id
+	warranty, err := warrantyRepo.FindActiveByAssetPlacementId(time.Now(), assetPlacementId)
+	if err != nil {
+		return nil, err
+	}
+	if warranty == nil {
+		return &entity.AssetWarrantyStatus{IsUnderWarranty: false}, nil
+	}
+
+	status := entity.AssetWarrantyStatus{
+		IsUnderWarranty: true,
+		WarrantyId:      &warranty.ID,
+		WarrantyType:    &warranty.WarrantyType,
+		EndDate:         &warranty.EndDate,
+		ContactName:     warranty.ContactName,
+		ContactEmail:    warranty.ContactEmail,
+		ContactPhone:    warranty.ContactPhone,
+	}
+	if warranty.Vendor != nil {
+		status.VendorName = &warranty.Vendor.VendorName
+		if status.ContactName == nil {
+			status.ContactName = warranty.Vendor.ContactName
+		}
+		if status.ContactEmail == nil {
+			status.ContactEmail = warranty.Vendor.ContactEmail
+		}
+		if status.ContactPhone == nil {
+			status.ContactPhone = warranty.Vendor.ContactPhone
+		}
+	}
+
+	return &status, nil
+}
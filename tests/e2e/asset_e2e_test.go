@@ -65,7 +65,7 @@ func TestAssetPostCreateWithValidInput(t *testing.T) {
 	assert.Equal(t, payload["asset_desc"], data["asset_desc"])
 	assert.Equal(t, payload["asset_merk"], data["asset_merk"])
 	assert.Equal(t, payload["asset_category"], data["asset_category"])
-	assert.Equal(t, payload["asset_price"], data["asset_price"])
+	assert.Equal(t, 250000.0, data["asset_price"])
 	assert.Equal(t, payload["asset_status"], data["asset_status"])
 
 	// Nullable / Optional Fields
@@ -79,7 +79,7 @@ func TestAssetPostCreateWithValidInput(t *testing.T) {
 	assert.IsType(t, "", data["asset_desc"])
 	assert.IsType(t, "", data["asset_merk"])
 	assert.IsType(t, "", data["asset_category"])
-	assert.IsType(t, "", data["asset_price"])
+	assert.IsType(t, 0.0, data["asset_price"])
 	assert.IsType(t, "", data["asset_status"])
 	assert.IsType(t, "", data["created_at"])
 	assert.IsType(t, "", data["created_by"])
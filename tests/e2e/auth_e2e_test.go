@@ -130,3 +130,34 @@ func TestAuthPostSignOutWithValidInput(t *testing.T) {
 	assert.Equal(t, "success", result["status"])
 	assert.Equal(t, "user signout", result["message"])
 }
+
+func TestAuthPostRefreshWithInvalidToken(t *testing.T) {
+	// Test Data
+	payload := map[string]string{
+		"refresh_token": "invalid-refresh-token",
+	}
+	jsonPayload, _ := json.Marshal(payload)
+
+	// Exec
+	url := "http://127.0.0.1:9000/api/v1/auths/refresh"
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonPayload))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Prepare Response Body
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var result map[string]interface{}
+	err = json.Unmarshal(body, &result)
+	assert.NoError(t, err)
+
+	// Validate Template Response
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, "failed", result["status"])
+	assert.Equal(t, "invalid refresh token", result["message"])
+}
@@ -68,12 +68,33 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 		&entity.Admin{},
 		&entity.User{},
 		&entity.Technician{},
+		&entity.AccountEmail{},
+		&entity.TechnicianScope{},
 		&entity.History{},
+		&entity.Archive{},
+		&entity.Role{},
+		&entity.RolePermission{},
+		&entity.RoleSeededPermission{},
+		&entity.AccountRole{},
+		&entity.ApiKey{},
+		&entity.ApiKeyScope{},
 		&entity.Room{},
+		&entity.AssetCategory{},
+		&entity.AssetCategoryField{},
 		&entity.Asset{},
+		&entity.AssetAttribute{},
+		&entity.AssetImage{},
+		&entity.Vendor{},
+		&entity.Warranty{},
+		&entity.AssetStatusHistory{},
 		&entity.AssetPlacement{},
+		&entity.AssetUnit{},
+		&entity.AssetTransfer{},
+		&entity.AssetMovement{},
 		&entity.AssetMaintenance{},
 		&entity.AssetFinding{},
+		&entity.AssetLoan{},
+		&entity.Migration{},
 	)
 	assert.NoError(t, err)
 
@@ -81,12 +102,33 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 		&entity.Admin{},
 		&entity.User{},
 		&entity.Technician{},
+		&entity.AccountEmail{},
+		&entity.TechnicianScope{},
 		&entity.History{},
+		&entity.Archive{},
+		&entity.Role{},
+		&entity.RolePermission{},
+		&entity.RoleSeededPermission{},
+		&entity.AccountRole{},
+		&entity.ApiKey{},
+		&entity.ApiKeyScope{},
 		&entity.Room{},
+		&entity.AssetCategory{},
+		&entity.AssetCategoryField{},
 		&entity.Asset{},
+		&entity.AssetAttribute{},
+		&entity.AssetImage{},
+		&entity.Vendor{},
+		&entity.Warranty{},
+		&entity.AssetStatusHistory{},
 		&entity.AssetPlacement{},
+		&entity.AssetUnit{},
+		&entity.AssetTransfer{},
+		&entity.AssetMovement{},
 		&entity.AssetMaintenance{},
 		&entity.AssetFinding{},
+		&entity.AssetLoan{},
+		&entity.Migration{},
 	)
 	assert.NoError(t, err)
 
@@ -141,7 +183,7 @@ func CreateTestAsset(t *testing.T, db *gorm.DB, adminID uuid.UUID) *entity.Asset
 	assetCategory := "Test Category"
 	assetStatus := "new"
 	assetMerk := "Test Merk"
-	assetPrice := "12345"
+	assetPrice := entity.Money(1234500)
 	assetImageUrl := "http://example.com/test.jpg"
 	assetDesc := "Test asset description"
 
@@ -164,6 +206,19 @@ func CreateTestAsset(t *testing.T, db *gorm.DB, adminID uuid.UUID) *entity.Asset
 	return asset
 }
 
+func CreateTestAssetCategory(t *testing.T, db *gorm.DB, categoryName string) *entity.AssetCategory {
+	assetCategory := &entity.AssetCategory{
+		ID:           uuid.New(),
+		CategoryName: categoryName,
+		CreatedAt:    time.Now(),
+	}
+
+	err := db.Create(assetCategory).Error
+	assert.NoError(t, err)
+
+	return assetCategory
+}
+
 func CreateTestRoom(t *testing.T, db *gorm.DB) *entity.Room {
 	room := &entity.Room{
 		ID:        uuid.New(),
@@ -85,3 +85,86 @@ func TestAdminRepositoryFindAllContact(t *testing.T) {
 	// Test 2: Ensure order by admin username in ascending
 	assert.Equal(t, "admin1", contacts[0].Username)
 }
+
+func TestAdminRepositoryUpdateIsActiveAndCountActive(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewAdminRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := entity.Admin{
+		Username: "admin_active",
+		Password: "hashed_password",
+		Email:    "active@gmail.com",
+	}
+	err := repo.Create(&admin)
+	assert.NoError(t, err)
+	tests.CreateTestAdmin(t, db)
+
+	// Test 1: Should count every active admin
+	total, err := repo.CountActive()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+
+	// Test 2: Should deactivate the admin
+	err = repo.UpdateIsActiveById(false, admin.ID)
+	assert.NoError(t, err)
+
+	result, err := repo.FindById(admin.ID)
+	assert.NoError(t, err)
+	assert.False(t, result.IsActive)
+	assert.NotNil(t, result.DeactivatedAt)
+
+	total, err = repo.CountActive()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	// Test 3: Should return error when admin not found
+	err = repo.UpdateIsActiveById(true, uuid.New())
+	assert.Error(t, err)
+}
+
+func TestAdminRepositoryDeleteById(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewAdminRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := entity.Admin{
+		Username: "admin_deleted",
+		Password: "hashed_password",
+		Email:    "deleted@gmail.com",
+	}
+	err := repo.Create(&admin)
+	assert.NoError(t, err)
+	lastAdmin := tests.CreateTestAdmin(t, db)
+	asset := tests.CreateTestAsset(t, db, admin.ID)
+
+	// Test 1: Should hide the deleted admin but keep the record it created
+	err = repo.DeleteById(admin.ID)
+	assert.NoError(t, err)
+
+	result, err := repo.FindById(admin.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+
+	var totalAsset int64
+	db.Model(&entity.Asset{}).Where("id = ?", asset.ID).Count(&totalAsset)
+	assert.Equal(t, int64(1), totalAsset)
+
+	// Test 2: Should still find the deleted admin by email as inactive
+	result, err = repo.FindByEmail(admin.Email)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.False(t, result.IsActive)
+	assert.NotNil(t, result.DeletedAt)
+
+	// Test 3: Should not delete or deactivate the last active admin
+	err = repo.DeleteById(lastAdmin.ID)
+	assert.EqualError(t, err, "can't remove the last active admin")
+
+	err = repo.UpdateIsActiveById(false, lastAdmin.ID)
+	assert.EqualError(t, err, "can't remove the last active admin")
+
+	// Test 4: Should return error when admin is already deleted
+	err = repo.DeleteById(admin.ID)
+	assert.Error(t, err)
+}
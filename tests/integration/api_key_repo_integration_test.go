@@ -0,0 +1,72 @@
+package repository_test
+
+import (
+	"pelita/config"
+	"pelita/entity"
+	"pelita/repository"
+	"pelita/tests"
+	"pelita/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiKeyRepositoryCreateFindAndDelete(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewApiKeyRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	apiKey := entity.ApiKey{
+		Name:      "helpdesk",
+		KeyPrefix: "pelita_abc123",
+		KeyHash:   utils.HashToken("pelita_abc123secret"),
+		ExpiredAt: time.Now().Add(24 * time.Hour),
+		CreatedBy: admin.ID,
+	}
+
+	// Test 1: Create api key with scopes should not return error
+	err := repo.Create(&apiKey, []string{config.PermissionFindingCreate, config.PermissionRoomReadAsset})
+	assert.NoError(t, err)
+
+	// Test 2: Should find api key and its scopes by the key hash
+	found, err := repo.FindByKeyHash(utils.HashToken("pelita_abc123secret"))
+	assert.NoError(t, err)
+	assert.NotNil(t, found)
+	assert.Equal(t, apiKey.ID, found.ID)
+	assert.Len(t, found.Scopes, 2)
+	assert.Nil(t, found.LastUsedAt)
+
+	// Test 3: Should return nil for unknown key
+	notFound, err := repo.FindByKeyHash(utils.HashToken("unknown"))
+	assert.NoError(t, err)
+	assert.Nil(t, notFound)
+
+	// Test 4: Should update last used at
+	err = repo.UpdateLastUsedAtById(time.Now(), apiKey.ID)
+	assert.NoError(t, err)
+
+	found, err = repo.FindByKeyHash(apiKey.KeyHash)
+	assert.NoError(t, err)
+	assert.NotNil(t, found.LastUsedAt)
+
+	// Test 5: Should revoke api key and keep it with its scopes and history
+	err = repo.CreateHistoryById("api_key_read /api/v1/assets", apiKey.ID)
+	assert.NoError(t, err)
+	err = repo.RevokeById(apiKey.ID)
+	assert.NoError(t, err)
+
+	found, err = repo.FindByKeyHash(apiKey.KeyHash)
+	assert.NoError(t, err)
+	assert.NotNil(t, found.RevokedAt)
+	assert.Len(t, found.Scopes, len(apiKey.Scopes))
+
+	var totalHistory int64
+	db.Model(&entity.History{}).Where("api_key_id = ?", apiKey.ID).Count(&totalHistory)
+	assert.Equal(t, int64(1), totalHistory)
+
+	// Test 6: Should return error when api key is already revoked
+	err = repo.RevokeById(apiKey.ID)
+	assert.Error(t, err)
+}
@@ -0,0 +1,135 @@
+package repository_test
+
+import (
+	"errors"
+	"pelita/entity"
+	"pelita/repository"
+	"pelita/tests"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetCategoryRepositoryCreateAndUpdate(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewAssetCategoryRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	asset := tests.CreateTestAsset(t, db, admin.ID)
+
+	// Test 1: Create category with its field definition
+	assetCategory := entity.AssetCategory{
+		CategoryName: "Laptop",
+		FieldDefinitions: []entity.AssetCategoryField{
+			{FieldName: "ram_gb", FieldLabel: "RAM (GB)", FieldType: "number", IsRequired: true},
+			{FieldName: "os", FieldLabel: "Operating System", FieldType: "enum", FieldOptions: []string{"Windows", "Linux"}},
+		},
+	}
+	err := repo.Create(&assetCategory)
+	assert.NoError(t, err)
+
+	found, err := repo.FindByCategoryName("laptop")
+	assert.NoError(t, err)
+	assert.NotNil(t, found)
+	assert.Len(t, found.FieldDefinitions, 2)
+	assert.Equal(t, "ram_gb", found.FieldDefinitions[0].FieldName)
+	assert.Equal(t, []string{"Windows", "Linux"}, found.FieldDefinitions[1].FieldOptions)
+	ramFieldId := found.FieldDefinitions[0].ID
+
+	err = db.Model(&entity.Asset{}).Where("id = ?", asset.ID).Update("asset_category_id", assetCategory.ID).Error
+	assert.NoError(t, err)
+	for i, value := range []string{"16", "Linux"} {
+		attribute := entity.AssetAttribute{ID: uuid.New(), AttributeValue: value, AssetId: asset.ID, AssetCategoryFieldId: found.FieldDefinitions[i].ID}
+		assert.NoError(t, db.Create(&attribute).Error)
+	}
+
+	// Test 2: Rename category, field matched by name keep its id and removed field is deleted
+	update := entity.AssetCategory{
+		CategoryName: "Notebook",
+		FieldDefinitions: []entity.AssetCategoryField{
+			{FieldName: "cpu", FieldLabel: "CPU", FieldType: "string"},
+			{FieldName: "ram_gb", FieldLabel: "Memory (GB)", FieldType: "number", IsRequired: true},
+		},
+	}
+	var checkedAssetIds []uuid.UUID
+	var checkedAttributes []entity.AssetAttributeValue
+	err = repo.UpdateById(&update, assetCategory.ID, func(parentOf map[uuid.UUID]*uuid.UUID) error {
+		assert.Contains(t, parentOf, assetCategory.ID)
+		return nil
+	}, func(assetIds []uuid.UUID, attributes []entity.AssetAttributeValue) ([]entity.AssetAttributeValue, error) {
+		checkedAssetIds, checkedAttributes = assetIds, attributes
+		return []entity.AssetAttributeValue{{AssetId: asset.ID, FieldName: "ram_gb", AttributeValue: "32"}}, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{asset.ID}, checkedAssetIds)
+	assert.Len(t, checkedAttributes, 2)
+	assert.Equal(t, []entity.AssetAttributeValue{{AssetId: asset.ID, FieldName: "os", AttributeValue: "Linux"}}, update.RemovedAttributes)
+
+	var attributes []entity.AssetAttribute
+	err = db.Where("asset_id = ?", asset.ID).Find(&attributes).Error
+	assert.NoError(t, err)
+	assert.Len(t, attributes, 1)
+	assert.Equal(t, "32", attributes[0].AttributeValue)
+
+	found, err = repo.FindById(assetCategory.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Notebook", found.CategoryName)
+	assert.Len(t, found.FieldDefinitions, 2)
+	assert.Equal(t, "cpu", found.FieldDefinitions[0].FieldName)
+	assert.Equal(t, ramFieldId, found.FieldDefinitions[1].ID)
+	assert.Equal(t, "Memory (GB)", found.FieldDefinitions[1].FieldLabel)
+
+	var renamed entity.Asset
+	err = db.First(&renamed, "id = ?", asset.ID).Error
+	assert.NoError(t, err)
+	assert.Equal(t, "Notebook", renamed.AssetCategory)
+
+	// Test 3: Attribute that no longer fit the field definition should cancel the update
+	err = repo.UpdateById(&update, assetCategory.ID, func(parentOf map[uuid.UUID]*uuid.UUID) error {
+		return nil
+	}, func(assetIds []uuid.UUID, attributes []entity.AssetAttributeValue) ([]entity.AssetAttributeValue, error) {
+		return nil, errors.New("attribute ram_gb is not valid")
+	})
+	assert.EqualError(t, err, "attribute ram_gb is not valid")
+
+	// Test 4: Category still used by asset is counted
+	total, err := repo.CountAssetById(assetCategory.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+}
+
+func TestAssetCategoryRepositoryCreateMissingFromAsset(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewAssetCategoryRepository(db)
+
+	// Setup: Prepare Free Text Category
+	admin := tests.CreateTestAdmin(t, db)
+	tests.CreateTestAssetCategory(t, db, "Printer")
+	for _, category := range []string{"Laptop", "laptop", "Laptops", " printers ", "Chair"} {
+		asset := tests.CreateTestAsset(t, db, admin.ID)
+		err := db.Model(&entity.Asset{}).Where("id = ?", asset.ID).Update("asset_category", category).Error
+		assert.NoError(t, err)
+	}
+
+	// Test 1: Plural and casing is folded, existing category is reused
+	total, err := repo.CreateMissingFromAsset()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+
+	var assets []entity.Asset
+	err = db.Find(&assets).Error
+	assert.NoError(t, err)
+	categoryTotal := map[string]int{}
+	for _, dt := range assets {
+		assert.NotNil(t, dt.AssetCategoryId)
+		categoryTotal[dt.AssetCategory]++
+	}
+	assert.Equal(t, map[string]int{"Laptop": 3, "Printer": 1, "Chair": 1}, categoryTotal)
+
+	// Test 2: Running it again create nothing
+	total, err = repo.CreateMissingFromAsset()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+}
@@ -35,7 +35,7 @@ func TestAssetFindingRepositoryCreateAndFind(t *testing.T) {
 
 	// Test 2: Should Find All Asset Finding
 	pagination := utils.Pagination{Page: 1, Limit: 4}
-	found, total, err := repo.FindAll(pagination)
+	found, total, err := repo.FindAll(pagination, utils.Filter{}, nil)
 	assert.NoError(t, err)
 	assert.True(t, total > 0)
 	var exists bool
@@ -57,11 +57,42 @@ func TestAssetFindingRepositoryCreateAndFind(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, stats)
 
-	// Test 5: Should Delete Asset Finding By Id
-	err = repo.DeleteById(finding.ID)
+	// Test 5: Soft Delete By Id should hide the asset finding
+	err = repo.SoftDeleteById(finding.ID)
 	assert.NoError(t, err)
 
-	var deleted entity.AssetFinding
-	result := db.Unscoped().First(&deleted, "id = ?", finding.ID)
+	deleted, err := repo.FindDeleted()
+	assert.NoError(t, err)
+	assert.Len(t, deleted, 1)
+	assert.Equal(t, finding.ID, deleted[0].ID)
+
+	// Test 6: Recover Deleted By Id should restore the asset finding
+	err = repo.RecoverDeletedById(finding.ID)
+	assert.NoError(t, err)
+
+	deleted, err = repo.FindDeleted()
+	assert.NoError(t, err)
+	assert.Empty(t, deleted)
+
+	// Test 7: Hard Delete By Id should not remove asset finding that is not soft deleted
+	err = repo.HardDeleteById(finding.ID, admin.ID)
+	assert.NoError(t, err)
+
+	var check entity.AssetFinding
+	result := db.First(&check, "id = ?", finding.ID)
+	assert.NoError(t, result.Error)
+
+	// Test 8: Hard Delete By Id should remove the soft deleted asset finding and archive it
+	err = repo.SoftDeleteById(finding.ID)
+	assert.NoError(t, err)
+	err = repo.HardDeleteById(finding.ID, admin.ID)
+	assert.NoError(t, err)
+
+	result = db.Unscoped().First(&check, "id = ?", finding.ID)
 	assert.Error(t, result.Error)
+
+	var archives []entity.Archive
+	err = db.Where("deleted_table = ? AND deleted_id = ?", "asset_findings", finding.ID.String()).Find(&archives).Error
+	assert.NoError(t, err)
+	assert.NotEmpty(t, archives)
 }
@@ -0,0 +1,99 @@
+package repository_test
+
+import (
+	"pelita/entity"
+	"pelita/repository"
+	"pelita/tests"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetImageRepositoryGallery(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewAssetImageRepository(db)
+	assetRepo := repository.NewAssetRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	asset := tests.CreateTestAsset(t, db, admin.ID)
+	err := db.Model(&entity.Asset{}).Where("id = ?", asset.ID).Update("asset_image_url", nil).Error
+	assert.NoError(t, err)
+
+	// Test 1: The first image of an empty gallery become the primary image
+	firstImage := []entity.AssetImage{{ImageURL: "http://example.com/first.jpg"}, {ImageURL: "http://example.com/second.jpg"}}
+	err = repo.Create(firstImage, asset.ID, admin.ID)
+	assert.NoError(t, err)
+	assert.True(t, firstImage[0].IsPrimary)
+	assert.False(t, firstImage[1].IsPrimary)
+
+	// Test 2: Next upload is appended after the last order
+	nextImage := []entity.AssetImage{{ImageURL: "http://example.com/third.jpg"}}
+	err = repo.Create(nextImage, asset.ID, admin.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, nextImage[0].ImageOrder)
+	assert.False(t, nextImage[0].IsPrimary)
+
+	found, err := assetRepo.FindById(asset.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://example.com/first.jpg", *found.AssetImageURL)
+
+	// Test 3: Reorder the gallery
+	err = repo.UpdateOrderByAssetId(asset.ID, []uuid.UUID{nextImage[0].ID, firstImage[1].ID, firstImage[0].ID})
+	assert.NoError(t, err)
+	gallery, err := repo.FindAllByAssetId(asset.ID)
+	assert.NoError(t, err)
+	assert.Len(t, gallery, 3)
+	assert.Equal(t, nextImage[0].ID, gallery[0].ID)
+	assert.Equal(t, firstImage[0].ID, gallery[2].ID)
+
+	// Test 4: Change the primary image, the asset image url follow
+	err = repo.UpdatePrimaryById(&nextImage[0])
+	assert.NoError(t, err)
+	primary, err := repo.FindById(nextImage[0].ID)
+	assert.NoError(t, err)
+	assert.True(t, primary.IsPrimary)
+	previous, err := repo.FindById(firstImage[0].ID)
+	assert.NoError(t, err)
+	assert.False(t, previous.IsPrimary)
+	found, err = assetRepo.FindById(asset.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://example.com/third.jpg", *found.AssetImageURL)
+
+	// Test 5: Deleting the primary image promote the next image in order
+	err = repo.DeleteById(primary)
+	assert.NoError(t, err)
+	promoted, err := repo.FindById(firstImage[1].ID)
+	assert.NoError(t, err)
+	assert.True(t, promoted.IsPrimary)
+	found, err = assetRepo.FindById(asset.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://example.com/second.jpg", *found.AssetImageURL)
+}
+
+func TestAssetRepositoryFindAllStoredFileByDeletedId(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewAssetImageRepository(db)
+	assetRepo := repository.NewAssetRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	asset := tests.CreateTestAsset(t, db, admin.ID)
+	thumbnailURL := "http://example.com/thumbnail.jpg"
+	err := repo.Create([]entity.AssetImage{{ImageURL: "http://example.com/image.jpg", ThumbnailURL: &thumbnailURL}}, asset.ID, admin.ID)
+	assert.NoError(t, err)
+
+	// Test 1: Asset that is not deleted should not list its file
+	files, err := assetRepo.FindAllStoredFileByDeletedId(asset.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+
+	// Test 2: Deleted asset should list the image and its thumbnail
+	err = db.Model(&entity.Asset{}).Where("id = ?", asset.ID).Update("deleted_at", time.Now()).Error
+	assert.NoError(t, err)
+	files, err = assetRepo.FindAllStoredFileByDeletedId(asset.ID)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"http://example.com/image.jpg", thumbnailURL}, files)
+}
@@ -0,0 +1,144 @@
+package repository_test
+
+import (
+	"pelita/entity"
+	"pelita/repository"
+	"pelita/tests"
+	"pelita/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetLoanRepositoryLifecycle(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewAssetLoanRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	technician := tests.CreateTestTechnician(t, db, admin.ID, "tech@test.com")
+	user := tests.CreateTestUser(t, db)
+	asset := tests.CreateTestAsset(t, db, admin.ID)
+	room := tests.CreateTestRoom(t, db)
+	placement := tests.CreateTestAssetPlacement(t, db, admin.ID, technician.ID, asset.ID, room.ID)
+
+	loan := entity.AssetLoan{
+		AssetPlacementId: placement.ID,
+		DueAt:            time.Now().Add(time.Hour),
+		BorrowerId:       user.ID,
+		BorrowerType:     "guest",
+	}
+
+	// Test 1: Create asset loan should be requested
+	err := repo.Create(&loan)
+	assert.NoError(t, err)
+	assert.Equal(t, "requested", loan.LoanStatus)
+
+	// Test 2: Check out before approval should fail
+	err = repo.CheckOutById(loan.ID, func(asset *entity.Asset) (*entity.AssetStatusHistory, error) {
+		return nil, nil
+	})
+	assert.EqualError(t, err, "asset loan is not approved")
+
+	// Test 3: Approve should reserve the placement
+	err = repo.ApproveById(admin.ID, loan.ID)
+	assert.NoError(t, err)
+
+	total, err := repo.CountActiveByAssetPlacementId(placement.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	// Test 4: Check out should resolve the transition from the locked asset and mark it as in-use
+	err = repo.CheckOutById(loan.ID, func(locked *entity.Asset) (*entity.AssetStatusHistory, error) {
+		assert.Equal(t, asset.ID, locked.ID)
+		return &entity.AssetStatusHistory{FromStatus: &locked.AssetStatus, ToStatus: "in-use", ChangedBy: admin.ID, ChangedByType: "admin"}, nil
+	})
+	assert.NoError(t, err)
+
+	var updatedAsset entity.Asset
+	_ = db.First(&updatedAsset, "id = ?", asset.ID).Error
+	assert.Equal(t, "in-use", updatedAsset.AssetStatus)
+
+	// Test 5: Checked out loan past its due date should be overdue
+	overdue, err := repo.FindAllOverdue(time.Now().Add(2 * time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, overdue, 1)
+	assert.Equal(t, loan.ID, overdue[0].ID)
+	assert.Equal(t, user.Username, overdue[0].BorrowerName)
+
+	overdue, err = repo.FindAllOverdue(time.Now())
+	assert.NoError(t, err)
+	assert.Empty(t, overdue)
+
+	// Test 6: Return should record condition and update asset status
+	returnNotes := "screen scratched"
+	inUse := "in-use"
+	err = repo.ReturnById("damaged", &returnNotes, loan.ID, func(locked *entity.Asset, totalCheckedOut int64) (*entity.AssetStatusHistory, error) {
+		assert.Equal(t, asset.ID, locked.ID)
+		assert.Equal(t, inUse, locked.AssetStatus)
+		assert.Equal(t, int64(0), totalCheckedOut)
+		return &entity.AssetStatusHistory{FromStatus: &inUse, ToStatus: "maintenance", ChangedBy: admin.ID, ChangedByType: "admin"}, nil
+	})
+	assert.NoError(t, err)
+
+	found, err := repo.FindById(loan.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "returned", found.LoanStatus)
+	assert.Equal(t, "damaged", *found.ReturnCondition)
+	assert.NotNil(t, found.ReturnedAt)
+
+	_ = db.First(&updatedAsset, "id = ?", asset.ID).Error
+	assert.Equal(t, "maintenance", updatedAsset.AssetStatus)
+
+	var totalHistory int64
+	_ = db.Model(&entity.AssetStatusHistory{}).Where("asset_id = ?", asset.ID).Count(&totalHistory).Error
+	assert.Equal(t, int64(2), totalHistory)
+
+	// Test 7: Returned loan should belong to the borrower history
+	loans, total, err := repo.FindAllByBorrowerId(utils.Pagination{Page: 1, Limit: 10}, user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, loans, 1)
+}
+
+func TestAssetLoanRepositoryApproveById(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewAssetLoanRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	technician := tests.CreateTestTechnician(t, db, admin.ID, "tech@test.com")
+	user := tests.CreateTestUser(t, db)
+	asset := tests.CreateTestAsset(t, db, admin.ID)
+	room := tests.CreateTestRoom(t, db)
+	placement := tests.CreateTestAssetPlacement(t, db, admin.ID, technician.ID, asset.ID, room.ID)
+
+	loans := make([]entity.AssetLoan, placement.AssetQty+1)
+	for i := range loans {
+		loans[i] = entity.AssetLoan{
+			AssetPlacementId: placement.ID,
+			DueAt:            time.Now().Add(time.Hour),
+			BorrowerId:       user.ID,
+			BorrowerType:     "guest",
+		}
+		assert.NoError(t, repo.Create(&loans[i]))
+	}
+
+	// Test 1: Approve should succeed while the placement still has asset left
+	for _, loan := range loans[:placement.AssetQty] {
+		assert.NoError(t, repo.ApproveById(admin.ID, loan.ID))
+	}
+
+	// Test 2: Approve should fail once every asset of the placement is reserved
+	err := repo.ApproveById(admin.ID, loans[placement.AssetQty].ID)
+	assert.EqualError(t, err, "no asset left to be loaned in this placement")
+
+	found, err := repo.FindById(loans[placement.AssetQty].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "requested", found.LoanStatus)
+
+	// Test 3: Approve an already approved loan should fail
+	err = repo.ApproveById(admin.ID, loans[0].ID)
+	assert.EqualError(t, err, "asset loan is not waiting for approval")
+}
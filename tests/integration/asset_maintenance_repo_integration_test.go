@@ -44,7 +44,7 @@ func TestAssetMaintenanceRepositoryCRUD(t *testing.T) {
 
 	// Test 2: Find All should return the maintenance
 	pagination := utils.Pagination{Page: 1, Limit: 10}
-	results, total, err := repo.FindAll(pagination)
+	results, total, err := repo.FindAll(pagination, utils.Filter{}, nil)
 	assert.NoError(t, err)
 	assert.True(t, total > 0)
 
@@ -58,7 +58,7 @@ func TestAssetMaintenanceRepositoryCRUD(t *testing.T) {
 	assert.True(t, found)
 
 	// Test 3: Find All Schedule should return results
-	schedules, err := repo.FindAllSchedule()
+	schedules, err := repo.FindAllSchedule(nil)
 	assert.NoError(t, err)
 	fmt.Println(err)
 	assert.NotEmpty(t, schedules)
@@ -72,20 +72,25 @@ func TestAssetMaintenanceRepositoryCRUD(t *testing.T) {
 	}
 	assert.True(t, scheduleFound)
 
-	// Test 4: Find By Asset Placement Id Maintenance By And Maintenance Day with overlapping time should error
+	// Test 4: Find All Schedule should return nothing for technician without any scope
+	unscopedSchedules, err := repo.FindAllSchedule(&entity.AccessScope{})
+	assert.NoError(t, err)
+	assert.Empty(t, unscopedSchedules)
+
+	// Test 5: Find By Asset Placement Id Maintenance By And Maintenance Day with overlapping time should error
 	overlapStart := entity.Time{Time: time.Date(0, 1, 1, 10, 0, 0, 0, time.UTC)}
 	overlapEnd := entity.Time{Time: time.Date(0, 1, 1, 12, 0, 0, 0, time.UTC)}
 	foundAP, err := repo.FindByAssetPlacementIdMaintenanceByAndMaintenanceDay(assetPlacement.ID, technician.ID, day, overlapStart, overlapEnd)
 	assert.Error(t, err)
 	assert.Nil(t, foundAP)
 
-	// Test 5: Find By Asset Placement Id Maintenance By Maintenance Day And Id with same time should error if id is different
+	// Test 6: Find By Asset Placement Id Maintenance By Maintenance Day And Id with same time should error if id is different
 	otherID := uuid.New()
 	foundAP2, err := repo.FindByAssetPlacementIdMaintenanceByMaintenanceDayAndId(assetPlacement.ID, technician.ID, day, start, end, otherID)
 	assert.Error(t, err)
 	assert.Nil(t, foundAP2)
 
-	// Test 6: Update By Id should change the hours
+	// Test 7: Update By Id should change the hours
 	newStart := entity.Time{Time: time.Date(0, 1, 1, 13, 0, 0, 0, time.UTC)}
 	newEnd := entity.Time{Time: time.Date(0, 1, 1, 15, 0, 0, 0, time.UTC)}
 
@@ -99,11 +104,42 @@ func TestAssetMaintenanceRepositoryCRUD(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, newStart.Time.Hour(), updated.MaintenanceHourStart.Time.Hour())
 
-	// Test 7: Delete By Id should delete maintenance
-	err = repo.DeleteById(maintenance.ID)
+	// Test 8: Soft Delete By Id should hide the maintenance
+	err = repo.SoftDeleteById(maintenance.ID)
+	assert.NoError(t, err)
+
+	deleted, err := repo.FindDeleted()
+	assert.NoError(t, err)
+	assert.Len(t, deleted, 1)
+	assert.Equal(t, maintenance.ID, deleted[0].ID)
+
+	// Test 9: Recover Deleted By Id should restore the maintenance
+	err = repo.RecoverDeletedById(maintenance.ID)
+	assert.NoError(t, err)
+
+	deleted, err = repo.FindDeleted()
+	assert.NoError(t, err)
+	assert.Empty(t, deleted)
+
+	// Test 10: Hard Delete By Id should not remove maintenance that is not soft deleted
+	err = repo.HardDeleteById(maintenance.ID, admin.ID)
 	assert.NoError(t, err)
 
 	var check entity.AssetMaintenance
-	res := db.Unscoped().First(&check, "id = ?", maintenance.ID)
+	res := db.First(&check, "id = ?", maintenance.ID)
+	assert.NoError(t, res.Error)
+
+	// Test 11: Hard Delete By Id should remove the soft deleted maintenance and archive it
+	err = repo.SoftDeleteById(maintenance.ID)
+	assert.NoError(t, err)
+	err = repo.HardDeleteById(maintenance.ID, admin.ID)
+	assert.NoError(t, err)
+
+	res = db.Unscoped().First(&check, "id = ?", maintenance.ID)
 	assert.Error(t, res.Error)
+
+	var archives []entity.Archive
+	err = db.Where("deleted_table = ? AND deleted_id = ?", "asset_maintenances", maintenance.ID.String()).Find(&archives).Error
+	assert.NoError(t, err)
+	assert.NotEmpty(t, archives)
 }
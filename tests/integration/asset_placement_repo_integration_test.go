@@ -6,6 +6,7 @@ import (
 	"pelita/tests"
 	"pelita/utils"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -33,13 +34,17 @@ func TestAssetPlacementRepositoryCreateFindUpdateDelete(t *testing.T) {
 	}
 
 	// Test 1: Create should succeed
-	err := repo.Create(assetPlacement, admin.ID)
+	err := repo.Create(assetPlacement, admin.ID, "PLT", 6)
 	assert.NoError(t, err)
 	assert.NotEqual(t, uuid.Nil, assetPlacement.ID)
 
+	var totalUnit int64
+	_ = db.Model(&entity.AssetUnit{}).Where("asset_placement_id = ?", assetPlacement.ID).Count(&totalUnit).Error
+	assert.Equal(t, int64(assetQty), totalUnit)
+
 	// Test 2: Find All should return the placement
 	pagination := utils.Pagination{Page: 1, Limit: 10}
-	results, total, err := repo.FindAll(pagination)
+	results, total, err := repo.FindAll(pagination, utils.Filter{}, nil)
 	assert.NoError(t, err)
 	assert.True(t, total > 0)
 	var found bool
@@ -66,18 +71,154 @@ func TestAssetPlacementRepositoryCreateFindUpdateDelete(t *testing.T) {
 
 	// Test 5: Update By Id should update Asset Qty
 	assetPlacement.AssetQty = 5
-	err = repo.UpdateById(assetPlacement, assetPlacement.ID)
+	err = repo.UpdateById(assetPlacement, assetPlacement.ID, "PLT", 6)
 	assert.NoError(t, err)
 
 	var updated entity.AssetPlacement
 	_ = db.First(&updated, "id = ?", assetPlacement.ID).Error
 	assert.Equal(t, 5, updated.AssetQty)
 
-	// Test 6: Delete By Id should remove the asset placement
-	err = repo.DeleteById(assetPlacement.ID)
+	_ = db.Model(&entity.AssetUnit{}).Where("asset_placement_id = ?", assetPlacement.ID).Count(&totalUnit).Error
+	assert.Equal(t, int64(5), totalUnit)
+
+	// Test 6: Update By Id should not reduce the quantity below its unit
+	assetPlacement.AssetQty = 1
+	err = repo.UpdateById(assetPlacement, assetPlacement.ID, "PLT", 6)
+	assert.EqualError(t, err, "asset qty can't be less than the total asset unit, delete or move the unit instead")
+
+	// Test 7: Soft Delete By Id should hide the asset placement
+	err = repo.SoftDeleteById(assetPlacement.ID)
+	assert.NoError(t, err)
+
+	deleted, err := repo.FindDeleted()
+	assert.NoError(t, err)
+	assert.Len(t, deleted, 1)
+	assert.Equal(t, assetPlacement.ID, deleted[0].ID)
+
+	// Test 8: Recover Deleted By Id should restore the asset placement
+	err = repo.RecoverDeletedById(assetPlacement.ID)
+	assert.NoError(t, err)
+
+	deleted, err = repo.FindDeleted()
+	assert.NoError(t, err)
+	assert.Empty(t, deleted)
+
+	// Test 9: Hard Delete By Id should not remove asset placement that is not soft deleted
+	err = repo.HardDeleteById(assetPlacement.ID, admin.ID)
 	assert.NoError(t, err)
 
 	var check entity.AssetPlacement
-	result := db.Unscoped().First(&check, "id = ?", assetPlacement.ID)
+	result := db.First(&check, "id = ?", assetPlacement.ID)
+	assert.NoError(t, result.Error)
+
+	// Test 10: Hard Delete By Id should remove the soft deleted asset placement and archive it
+	err = repo.SoftDeleteById(assetPlacement.ID)
+	assert.NoError(t, err)
+	err = repo.HardDeleteById(assetPlacement.ID, admin.ID)
+	assert.NoError(t, err)
+
+	result = db.Unscoped().First(&check, "id = ?", assetPlacement.ID)
 	assert.Error(t, result.Error)
+
+	var archives []entity.Archive
+	err = db.Where("deleted_table = ? AND deleted_id = ?", "asset_placements", assetPlacement.ID.String()).Find(&archives).Error
+	assert.NoError(t, err)
+	assert.NotEmpty(t, archives)
+}
+
+func TestAssetPlacementRepositoryFindAllWithScope(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewAssetPlacementRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	technician := tests.CreateTestTechnician(t, db, admin.ID, "tech@test.com")
+	asset := tests.CreateTestAsset(t, db, admin.ID)
+	room := tests.CreateTestRoom(t, db)
+	placement := tests.CreateTestAssetPlacement(t, db, admin.ID, technician.ID, asset.ID, room.ID)
+	pagination := utils.Pagination{Page: 1, Limit: 10}
+
+	// Test 1: Should return placement inside the department scope
+	results, total, err := repo.FindAll(pagination, utils.Filter{}, &entity.AccessScope{Departments: []string{room.RoomDept}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, results, 1)
+
+	// Test 2: Should hide placement outside the department or floor scope
+	results, total, err = repo.FindAll(pagination, utils.Filter{}, &entity.AccessScope{Departments: []string{"Finance & Risk Management"}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+	assert.Len(t, results, 0)
+
+	results, total, err = repo.FindAll(pagination, utils.Filter{}, &entity.AccessScope{Departments: []string{room.RoomDept}, Floors: []string{"13"}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+	assert.Len(t, results, 0)
+
+	// Test 3: Should hide every placement from technician without any scope
+	results, total, err = repo.FindAll(pagination, utils.Filter{}, &entity.AccessScope{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+	assert.Len(t, results, 0)
+
+	inScope, err := repo.IsInScopeById(placement.ID, &entity.AccessScope{})
+	assert.NoError(t, err)
+	assert.False(t, inScope)
+
+	// Test 4: Should only allow placement inside the scope
+	inScope, err = repo.IsInScopeById(placement.ID, &entity.AccessScope{Departments: []string{room.RoomDept}})
+	assert.NoError(t, err)
+	assert.True(t, inScope)
+}
+
+func TestAssetPlacementRepositorySoftDeleteWhileInUse(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewAssetPlacementRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	technician := tests.CreateTestTechnician(t, db, admin.ID, "tech@test.com")
+	user := tests.CreateTestUser(t, db)
+	asset := tests.CreateTestAsset(t, db, admin.ID)
+	room := tests.CreateTestRoom(t, db)
+	targetRoom := tests.CreateTestRoom(t, db)
+	placement := tests.CreateTestAssetPlacement(t, db, admin.ID, technician.ID, asset.ID, room.ID)
+
+	loan := entity.AssetLoan{
+		ID:               uuid.New(),
+		LoanStatus:       "checked-out",
+		DueAt:            time.Now().Add(time.Hour),
+		CreatedAt:        time.Now(),
+		BorrowerId:       user.ID,
+		BorrowerType:     "guest",
+		AssetPlacementId: placement.ID,
+	}
+	assert.NoError(t, db.Create(&loan).Error)
+
+	// Test 1: Soft Delete By Id should fail while the placement is still loaned out
+	err := repo.SoftDeleteById(placement.ID)
+	assert.EqualError(t, err, "asset placement still has active asset loan, return it first")
+
+	// Test 2: Soft Delete By Id should fail while the placement still has a pending transfer
+	assert.NoError(t, db.Model(&loan).Update("loan_status", "returned").Error)
+	transfer := entity.AssetTransfer{
+		ID:                uuid.New(),
+		TransferStatus:    "requested",
+		TransferQty:       1,
+		CreatedAt:         time.Now(),
+		RequestedBy:       technician.ID,
+		RequestedByType:   "technician",
+		AssetId:           asset.ID,
+		SourcePlacementId: placement.ID,
+		TargetRoomId:      targetRoom.ID,
+	}
+	assert.NoError(t, db.Create(&transfer).Error)
+
+	err = repo.SoftDeleteById(placement.ID)
+	assert.EqualError(t, err, "asset placement still has pending asset transfer, approve or reject it first")
+
+	// Test 3: Soft Delete By Id should succeed once nothing is in progress
+	assert.NoError(t, db.Model(&transfer).Update("transfer_status", "rejected").Error)
+	err = repo.SoftDeleteById(placement.ID)
+	assert.NoError(t, err)
 }
@@ -23,7 +23,7 @@ func TestAssetRepositoryCreateAndFind(t *testing.T) {
 	assetCategory := "test category"
 	assetStatus := "new"
 	assetMerk := "merk"
-	assetPrice := "10000"
+	assetPrice := entity.Money(1000000)
 	assetImageUrl := "http://example.com/image.jpg"
 
 	asset := &entity.Asset{
@@ -42,7 +42,7 @@ func TestAssetRepositoryCreateAndFind(t *testing.T) {
 
 	// Test 2: Find All should return the created asset
 	pagination := utils.Pagination{Page: 1, Limit: 4}
-	result, total, err := repo.FindAll(pagination)
+	result, total, err := repo.FindAll(pagination, utils.Filter{})
 	assert.NoError(t, err)
 	assert.True(t, total > 0)
 	assert.NotEmpty(t, result)
@@ -87,7 +87,7 @@ func TestAssetRepositorySoftAndRecoverDelete(t *testing.T) {
 
 	// Test 2: Asset should not be returned in Find All
 	pagination := utils.Pagination{Page: 1, Limit: 4}
-	assets, total, err := repo.FindAll(pagination)
+	assets, total, err := repo.FindAll(pagination, utils.Filter{})
 	assert.NoError(t, err)
 	for _, a := range assets {
 		assert.NotEqual(t, asset.ID, a.ID)
@@ -111,7 +111,7 @@ func TestAssetRepositorySoftAndRecoverDelete(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Test 5: Asset should now appear in Find All again
-	all, total, err := repo.FindAll(pagination)
+	all, total, err := repo.FindAll(pagination, utils.Filter{})
 	assert.NoError(t, err)
 	var exists bool
 	for _, a := range all {
@@ -127,11 +127,13 @@ func TestAssetRepositoryUpdateAndHardDelete(t *testing.T) {
 	db := tests.SetupTestDB(t)
 	repo := repository.NewAssetRepository(db)
 	adminId := uuid.New()
+	primaryImageURL := "https://storage.example.com/asset/primary.jpg"
 	asset := &entity.Asset{
 		ID:            uuid.New(),
 		AssetName:     "test asset",
 		AssetCategory: "test category",
 		AssetStatus:   "new",
+		AssetImageURL: &primaryImageURL,
 		CreatedAt:     time.Now(),
 		CreatedBy:     adminId,
 	}
@@ -139,24 +141,425 @@ func TestAssetRepositoryUpdateAndHardDelete(t *testing.T) {
 	err := db.Create(asset).Error
 	assert.NoError(t, err)
 
-	// Test 1: Asset should be Update By Id
+	// Test 1: Asset should be Update By Id, keeping the primary image and creator of the gallery
+	otherImageURL := "https://example.com/other.jpg"
 	asset.AssetName = "UpdatedName"
-	err = repo.UpdateById(asset, asset.ID)
+	asset.AssetImageURL = &otherImageURL
+	asset.CreatedBy = uuid.New()
+	err = repo.UpdateById(asset, nil, asset.ID)
 	assert.NoError(t, err)
 
 	var updated entity.Asset
 	_ = db.First(&updated, "id = ?", asset.ID).Error
 	assert.Equal(t, "UpdatedName", updated.AssetName)
+	assert.Equal(t, primaryImageURL, *updated.AssetImageURL)
+	assert.Equal(t, adminId, updated.CreatedBy)
+
+	asset.AssetImageURL = nil
+	err = repo.UpdateById(asset, nil, asset.ID)
+	assert.NoError(t, err)
+
+	_ = db.First(&updated, "id = ?", asset.ID).Error
+	assert.Equal(t, primaryImageURL, *updated.AssetImageURL)
 
 	// Test 2: Asset should be deleted by id
 	err = repo.SoftDeleteById(asset.ID)
 	assert.NoError(t, err)
 
 	// Test 3:  Asset should be permanentally deleted by id
-	err = repo.HardDeleteById(asset.ID)
+	err = repo.HardDeleteById(asset.ID, adminId)
 	assert.NoError(t, err)
 
 	var check entity.Asset
 	result := db.Unscoped().First(&check, "id = ?", asset.ID)
 	assert.Error(t, result.Error)
+
+	var archive entity.Archive
+	err = db.First(&archive, "record_table = ? AND record_id = ?", "assets", asset.ID.String()).Error
+	assert.NoError(t, err)
+	assert.Contains(t, archive.RecordData, "UpdatedName")
+}
+
+func TestAssetRepositoryUpdateByIdAndFindHistory(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewAssetRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	asset := &entity.Asset{
+		AssetName:     "status asset",
+		AssetCategory: "test category",
+		AssetStatus:   "procured",
+	}
+
+	// Test 1: Create should record the initial status
+	err := repo.Create(asset, admin.ID)
+	assert.NoError(t, err)
+
+	history, err := repo.FindStatusHistoryByAssetId(asset.ID)
+	assert.NoError(t, err)
+	assert.Len(t, history, 1)
+	assert.Nil(t, history[0].FromStatus)
+	assert.Equal(t, "procured", history[0].ToStatus)
+
+	// Test 2: Update with history should change the asset status and append the history
+	fromStatus := "procured"
+	reason := "delivered"
+	asset.AssetName = "delivered asset"
+	err = repo.UpdateById(asset, &entity.AssetStatusHistory{
+		FromStatus:    &fromStatus,
+		ToStatus:      "available",
+		Reason:        &reason,
+		ChangedBy:     admin.ID,
+		ChangedByType: "admin",
+	}, asset.ID)
+	assert.NoError(t, err)
+
+	found, err := repo.FindById(asset.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "available", found.AssetStatus)
+	assert.Equal(t, "delivered asset", found.AssetName)
+
+	history, err = repo.FindStatusHistoryByAssetId(asset.ID)
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, "available", history[1].ToStatus)
+	assert.Equal(t, reason, *history[1].Reason)
+
+	// Test 3: Update with history from a stale status should fail without changing the asset
+	asset.AssetName = "stale asset"
+	err = repo.UpdateById(asset, &entity.AssetStatusHistory{FromStatus: &fromStatus, ToStatus: "available", ChangedBy: admin.ID, ChangedByType: "admin"}, asset.ID)
+	assert.EqualError(t, err, "asset status has been changed, please try again")
+
+	found, err = repo.FindById(asset.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "delivered asset", found.AssetName)
+
+	// Test 4: Update of unknown asset should fail
+	err = repo.UpdateById(asset, nil, uuid.New())
+	assert.Error(t, err)
+}
+
+func TestAssetRepositoryFindValuation(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewAssetRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	technician := tests.CreateTestTechnician(t, db, admin.ID, "tech@test.com")
+	room := tests.CreateTestRoom(t, db)
+	unvaluedAsset := tests.CreateTestAsset(t, db, admin.ID)
+	asset := tests.CreateTestAsset(t, db, admin.ID)
+
+	purchaseDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	usefulLife := 4
+	err := db.Model(&entity.Asset{}).Where("id = ?", asset.ID).Updates(map[string]interface{}{
+		"purchase_date":       purchaseDate,
+		"useful_life":         usefulLife,
+		"depreciation_method": "declining-balance",
+	}).Error
+	assert.NoError(t, err)
+	tests.CreateTestAssetPlacement(t, db, admin.ID, technician.ID, asset.ID, room.ID)
+	tests.CreateTestAssetPlacement(t, db, admin.ID, technician.ID, unvaluedAsset.ID, room.ID)
+
+	// Test 1: Find All Valuated should only return asset with complete valuation
+	pagination := utils.Pagination{Page: 1, Limit: 10}
+	result, total, err := repo.FindAllValuated(pagination)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, asset.ID, result[0].ID)
+	assert.Equal(t, "IDR", result[0].AssetCurrency)
+	assert.Equal(t, entity.Money(1234500), *result[0].AssetPrice)
+
+	// Test 2: Find All Placed Valuation should carry the room and quantity of the placement
+	valuation, err := repo.FindAllPlacedValuation()
+	assert.NoError(t, err)
+	assert.Len(t, valuation, 1)
+	assert.Equal(t, asset.ID, valuation[0].ID)
+	assert.Equal(t, "declining-balance", valuation[0].DepreciationMethod)
+	assert.Equal(t, 3, valuation[0].AssetQty)
+	assert.Equal(t, room.RoomDept, valuation[0].RoomDept)
+	assert.Equal(t, room.Floor, valuation[0].Floor)
+}
+
+func TestAssetRepositoryCreateBulkAndFindExport(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewAssetRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	technician := tests.CreateTestTechnician(t, db, admin.ID, "tech@test.com")
+	room := tests.CreateTestRoom(t, db)
+	merk := "Imported Merk"
+	assetImport := []entity.AssetImport{
+		{
+			Row: 2,
+			Asset: entity.Asset{
+				AssetName:     "Imported Laptop",
+				AssetMerk:     &merk,
+				AssetCategory: "Laptop",
+				AssetCurrency: "IDR",
+				AssetStatus:   "available",
+			},
+			AssetPlacement: []entity.AssetPlacement{
+				{AssetQty: 2, RoomId: room.ID, AssetOwner: technician.ID},
+			},
+		},
+		{
+			Row: 3,
+			Asset: entity.Asset{
+				AssetName:     "Imported Monitor",
+				AssetCategory: "Monitor",
+				AssetCurrency: "IDR",
+				AssetStatus:   "procured",
+			},
+		},
+	}
+
+	// Test 1: Create Bulk should create every asset, its placement, and its unit
+	err := repo.CreateBulk(assetImport, admin.ID, "TEST", 4)
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, assetImport[0].Asset.ID)
+	assert.NotEqual(t, uuid.Nil, assetImport[0].AssetPlacement[0].ID)
+
+	var totalUnit int64
+	err = db.Model(&entity.AssetUnit{}).Where("asset_placement_id = ?", assetImport[0].AssetPlacement[0].ID).Count(&totalUnit).Error
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), totalUnit)
+
+	var totalHistory int64
+	err = db.Model(&entity.AssetStatusHistory{}).Where("asset_id IN ?", []uuid.UUID{assetImport[0].Asset.ID, assetImport[1].Asset.ID}).Count(&totalHistory).Error
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), totalHistory)
+
+	// Test 2: Find All Export should return placed and unplaced asset
+	assetExport, err := repo.FindAllExport()
+	assert.NoError(t, err)
+	assert.Len(t, assetExport, 2)
+	assert.Equal(t, "Imported Laptop", assetExport[0].AssetName)
+	assert.Equal(t, room.RoomName, *assetExport[0].RoomName)
+	assert.Equal(t, 2, *assetExport[0].AssetQty)
+	assert.Equal(t, "tech@test.com", *assetExport[0].OwnerEmail)
+	assert.Equal(t, "Imported Monitor", assetExport[1].AssetName)
+	assert.Nil(t, assetExport[1].RoomName)
+
+	// Test 3: Create Bulk should roll back every asset when one row fails
+	failedImport := []entity.AssetImport{
+		{Row: 2, Asset: entity.Asset{AssetName: "Rolled Back", AssetCategory: "Laptop", AssetCurrency: "IDR", AssetStatus: "available"}},
+		{Row: 3, Asset: entity.Asset{AssetName: "Rolled Back Placement", AssetCategory: "Laptop", AssetCurrency: "IDR", AssetStatus: "available"}, AssetPlacement: []entity.AssetPlacement{
+			{AssetQty: 1, RoomId: uuid.New(), AssetOwner: technician.ID},
+		}},
+	}
+	err = repo.CreateBulk(failedImport, admin.ID, "TEST", 4)
+	assert.Error(t, err)
+
+	var totalRolledBack int64
+	err = db.Model(&entity.Asset{}).Where("asset_name LIKE ?", "Rolled Back%").Count(&totalRolledBack).Error
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), totalRolledBack)
+}
+
+func TestAssetRepositoryFindAllWithFilter(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewAssetRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	technician := tests.CreateTestTechnician(t, db, admin.ID, "tech@test.com")
+	room := tests.CreateTestRoom(t, db)
+	placedAsset := tests.CreateTestAsset(t, db, admin.ID)
+	otherAsset := tests.CreateTestAsset(t, db, admin.ID)
+	err := db.Model(&entity.Asset{}).Where("id = ?", otherAsset.ID).Updates(map[string]interface{}{
+		"asset_name":     "Standing Desk",
+		"asset_category": "Furniture",
+		"asset_price":    500000.0,
+	}).Error
+	assert.NoError(t, err)
+	tests.CreateTestAssetPlacement(t, db, admin.ID, technician.ID, placedAsset.ID, room.ID)
+	pagination := utils.Pagination{Page: 1, Limit: 10}
+
+	// Test 1: Search should match the asset name
+	result, total, err := repo.FindAll(pagination, utils.Filter{Search: "desk"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, otherAsset.ID, result[0].ID)
+
+	// Test 2: Filter by price range and category
+	result, total, err = repo.FindAll(pagination, utils.Filter{Params: map[string]string{"price_min": "100000", "category": "Furniture"}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, otherAsset.ID, result[0].ID)
+
+	// Test 3: Filter by floor and department of the placement
+	result, total, err = repo.FindAll(pagination, utils.Filter{Params: map[string]string{"floor": room.Floor, "department": room.RoomDept}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, placedAsset.ID, result[0].ID)
+
+	// Test 4: Sort by whitelisted column
+	result, _, err = repo.FindAll(pagination, utils.Filter{SortBy: "asset_price", SortDir: "asc"})
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, placedAsset.ID, result[0].ID)
+
+	// Test 5: Unknown sort column and invalid filter value should fail
+	_, _, err = repo.FindAll(pagination, utils.Filter{SortBy: "created_by", SortDir: "asc"})
+	assert.Error(t, err)
+	_, _, err = repo.FindAll(pagination, utils.Filter{Params: map[string]string{"price_min": "cheap"}})
+	assert.Error(t, err)
+}
+
+func TestAssetRepositoryAttribute(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewAssetRepository(db)
+	categoryRepo := repository.NewAssetCategoryRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	assetCategory := entity.AssetCategory{
+		CategoryName: "Laptop",
+		FieldDefinitions: []entity.AssetCategoryField{
+			{FieldName: "ram_gb", FieldLabel: "RAM (GB)", FieldType: "number"},
+			{FieldName: "os", FieldLabel: "Operating System", FieldType: "enum", FieldOptions: []string{"Windows", "Linux"}},
+		},
+	}
+	err := categoryRepo.Create(&assetCategory)
+	assert.NoError(t, err)
+	newAsset := func(name, ram, os string) *entity.Asset {
+		asset := &entity.Asset{
+			AssetName:       name,
+			AssetCategory:   assetCategory.CategoryName,
+			AssetCategoryId: &assetCategory.ID,
+			AssetStatus:     "available",
+			Attributes:      entity.AssetAttributes{"ram_gb": ram, "os": os, "unknown": "skipped"},
+		}
+		err := repo.Create(asset, admin.ID)
+		assert.NoError(t, err)
+		return asset
+	}
+	smallLaptop := newAsset("Small Laptop", "8", "Windows")
+	bigLaptop := newAsset("Big Laptop", "32", "Linux")
+	pagination := utils.Pagination{Page: 1, Limit: 10}
+
+	// Test 1: Only attribute defined by the category is stored
+	found, err := repo.FindById(smallLaptop.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.AssetAttributes{"ram_gb": "8", "os": "Windows"}, found.Attributes)
+
+	// Test 2: Filter by attribute value and numeric range
+	result, total, err := repo.FindAll(pagination, utils.Filter{Params: map[string]string{"attr.os": "Linux"}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, bigLaptop.ID, result[0].ID)
+	result, total, err = repo.FindAll(pagination, utils.Filter{Params: map[string]string{"attr.ram_gb.min": "16"}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, bigLaptop.ID, result[0].ID)
+	_, _, err = repo.FindAll(pagination, utils.Filter{Params: map[string]string{"attr.ram_gb.max": "a lot"}})
+	assert.Error(t, err)
+
+	// Test 3: Update rewrite the attribute as a whole
+	smallLaptop.Attributes = entity.AssetAttributes{"ram_gb": "16"}
+	err = repo.UpdateById(smallLaptop, nil, smallLaptop.ID)
+	assert.NoError(t, err)
+	found, err = repo.FindById(smallLaptop.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.AssetAttributes{"ram_gb": "16"}, found.Attributes)
+
+	// Test 4: Export include the attribute
+	assetExport, err := repo.FindAllExport()
+	assert.NoError(t, err)
+	assert.Len(t, assetExport, 2)
+	for _, dt := range assetExport {
+		if dt.ID == bigLaptop.ID {
+			assert.Equal(t, "Linux", dt.Attributes["os"])
+		}
+	}
+}
+
+func TestAssetRepositoryMergeById(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewAssetRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	technician := tests.CreateTestTechnician(t, db, admin.ID, "tech@test.com")
+	sharedRoom := tests.CreateTestRoom(t, db)
+	otherRoom := tests.CreateTestRoom(t, db)
+	err := db.Model(&entity.Room{}).Where("id = ?", otherRoom.ID).Update("room_name", "Test Room B").Error
+	assert.NoError(t, err)
+	asset := tests.CreateTestAsset(t, db, admin.ID)
+	duplicate := tests.CreateTestAsset(t, db, admin.ID)
+	survivingPlacement := tests.CreateTestAssetPlacement(t, db, admin.ID, technician.ID, asset.ID, sharedRoom.ID)
+	mergedPlacement := tests.CreateTestAssetPlacement(t, db, admin.ID, technician.ID, duplicate.ID, sharedRoom.ID)
+	movedPlacement := tests.CreateTestAssetPlacement(t, db, admin.ID, technician.ID, duplicate.ID, otherRoom.ID)
+	maintenance := tests.CreateTestAssetMaintenanceWithDay(t, db, mergedPlacement.ID, admin.ID, technician.ID, "Mon")
+
+	// Setup: Both Asset Share A Category, Only The Duplicate Has A Gallery And A Serial Number
+	assetCategory := tests.CreateTestAssetCategory(t, db, "Laptop")
+	var fields []entity.AssetCategoryField
+	for i, fieldName := range []string{"serial_number", "os"} {
+		field := entity.AssetCategoryField{ID: uuid.New(), FieldName: fieldName, FieldLabel: fieldName, FieldType: "string", FieldOrder: i + 1, AssetCategoryId: assetCategory.ID}
+		assert.NoError(t, db.Create(&field).Error)
+		fields = append(fields, field)
+	}
+	err = db.Model(&entity.Asset{}).Where("id IN ?", []uuid.UUID{asset.ID, duplicate.ID}).Update("asset_category_id", assetCategory.ID).Error
+	assert.NoError(t, err)
+	attributes := []entity.AssetAttribute{
+		{ID: uuid.New(), AttributeValue: "Linux", AssetId: asset.ID, AssetCategoryFieldId: fields[1].ID},
+		{ID: uuid.New(), AttributeValue: "SN-001", AssetId: duplicate.ID, AssetCategoryFieldId: fields[0].ID},
+		{ID: uuid.New(), AttributeValue: "Windows", AssetId: duplicate.ID, AssetCategoryFieldId: fields[1].ID},
+	}
+	assert.NoError(t, db.Create(&attributes).Error)
+	images := []entity.AssetImage{
+		{ID: uuid.New(), ImageURL: "http://example.com/front.jpg", ImageOrder: 1, IsPrimary: true, CreatedAt: time.Now(), AssetId: duplicate.ID, CreatedBy: admin.ID},
+		{ID: uuid.New(), ImageURL: "http://example.com/back.jpg", ImageOrder: 2, CreatedAt: time.Now(), AssetId: duplicate.ID, CreatedBy: admin.ID},
+	}
+	assert.NoError(t, db.Create(&images).Error)
+
+	// Test 1: Merge the duplicate into the asset
+	err = repo.MergeById(duplicate.ID, asset.ID)
+	assert.NoError(t, err)
+
+	// Test 2: Placement in the same room is combined along with its maintenance
+	var placement entity.AssetPlacement
+	err = db.First(&placement, "id = ?", survivingPlacement.ID).Error
+	assert.NoError(t, err)
+	assert.Equal(t, survivingPlacement.AssetQty+mergedPlacement.AssetQty, placement.AssetQty)
+	err = db.First(&entity.AssetPlacement{}, "id = ?", mergedPlacement.ID).Error
+	assert.Error(t, err)
+	var movedMaintenance entity.AssetMaintenance
+	err = db.First(&movedMaintenance, "id = ?", maintenance.ID).Error
+	assert.NoError(t, err)
+	assert.Equal(t, survivingPlacement.ID, movedMaintenance.AssetPlacementId)
+
+	// Test 3: Placement in another room is re-pointed to the asset
+	err = db.First(&placement, "id = ?", movedPlacement.ID).Error
+	assert.NoError(t, err)
+	assert.Equal(t, asset.ID, placement.AssetId)
+
+	// Test 4: Gallery of the duplicate is moved and become the primary image of an asset without gallery
+	var movedImages []entity.AssetImage
+	err = db.Where("asset_id = ?", asset.ID).Order("image_order ASC").Find(&movedImages).Error
+	assert.NoError(t, err)
+	assert.Len(t, movedImages, 2)
+	assert.True(t, movedImages[0].IsPrimary)
+	survivor, err := repo.FindById(asset.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://example.com/front.jpg", *survivor.AssetImageURL)
+
+	// Test 5: Empty attribute is carried over, while the one already filled is kept
+	var survivingAttributes []entity.AssetAttribute
+	err = db.Where("asset_id = ?", asset.ID).Order("attribute_value ASC").Find(&survivingAttributes).Error
+	assert.NoError(t, err)
+	assert.Len(t, survivingAttributes, 2)
+	assert.Equal(t, "Linux", survivingAttributes[0].AttributeValue)
+	assert.Equal(t, "SN-001", survivingAttributes[1].AttributeValue)
+
+	// Test 6: Duplicate is soft deleted and can't be merged again
+	found, err := repo.FindById(duplicate.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, found.DeletedAt)
+	err = repo.MergeById(duplicate.ID, asset.ID)
+	assert.Error(t, err)
 }
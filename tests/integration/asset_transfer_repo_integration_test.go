@@ -0,0 +1,157 @@
+package repository_test
+
+import (
+	"pelita/entity"
+	"pelita/repository"
+	"pelita/tests"
+	"pelita/utils"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetTransferRepositoryApproveAndMovement(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewAssetTransferRepository(db)
+	movementRepo := repository.NewAssetMovementRepository(db)
+	unitRepo := repository.NewAssetUnitRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	technician := tests.CreateTestTechnician(t, db, admin.ID, "tech@test.com")
+	asset := tests.CreateTestAsset(t, db, admin.ID)
+	room := tests.CreateTestRoom(t, db)
+	otherRoom := entity.Room{ID: uuid.New(), Floor: "2", RoomName: "Other Room", RoomDept: "IT", CreatedAt: room.CreatedAt}
+	assert.NoError(t, db.Create(&otherRoom).Error)
+	source := tests.CreateTestAssetPlacement(t, db, admin.ID, technician.ID, asset.ID, room.ID)
+
+	_, err := unitRepo.CreateMissingForAllAssetPlacement("PLT", 6)
+	assert.NoError(t, err)
+
+	// Test 1: Create should store a requested transfer
+	transfer := entity.AssetTransfer{
+		TransferQty:       2,
+		RequestedBy:       technician.ID,
+		RequestedByType:   "technician",
+		AssetId:           asset.ID,
+		SourcePlacementId: source.ID,
+		TargetRoomId:      otherRoom.ID,
+	}
+	err = repo.Create(&transfer)
+	assert.NoError(t, err)
+
+	found, err := repo.FindById(transfer.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, found)
+	assert.Equal(t, "requested", found.TransferStatus)
+
+	// Test 2: Approve should create the target placement and move the quantity with its unit
+	err = repo.ApproveById(admin.ID, "admin", transfer.ID)
+	assert.NoError(t, err)
+
+	found, err = repo.FindById(transfer.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "approved", found.TransferStatus)
+	assert.NotNil(t, found.TargetPlacementId)
+
+	var updatedSource, target entity.AssetPlacement
+	_ = db.First(&updatedSource, "id = ?", source.ID).Error
+	_ = db.First(&target, "id = ?", *found.TargetPlacementId).Error
+	assert.Equal(t, source.AssetQty-2, updatedSource.AssetQty)
+	assert.Equal(t, 2, target.AssetQty)
+	assert.Equal(t, otherRoom.ID, target.RoomId)
+
+	totalTarget, _ := unitRepo.CountByAssetPlacementId(target.ID)
+	assert.Equal(t, int64(2), totalTarget)
+
+	// Test 3: Movement should be logged for the asset and both room
+	pagination := utils.Pagination{Page: 1, Limit: 10}
+	movements, total, err := movementRepo.FindAllByAssetId(pagination, asset.ID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, 2, movements[0].AssetQty)
+	assert.Equal(t, transfer.ID, *movements[0].AssetTransferId)
+
+	_, total, err = movementRepo.FindAllByRoomId(pagination, room.ID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	_, total, err = movementRepo.FindAllByRoomId(pagination, otherRoom.ID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	_, total, err = movementRepo.FindAllByAssetId(pagination, asset.ID, &entity.AccessScope{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+
+	// Test 4: Approved transfer can't be approved or rejected again
+	err = repo.ApproveById(admin.ID, "admin", transfer.ID)
+	assert.Error(t, err)
+	err = repo.RejectById(admin.ID, transfer.ID)
+	assert.Error(t, err)
+}
+
+func TestAssetTransferRepositoryApproveByIdWithLoanAndOpenReference(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewAssetTransferRepository(db)
+	unitRepo := repository.NewAssetUnitRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	technician := tests.CreateTestTechnician(t, db, admin.ID, "tech@test.com")
+	user := tests.CreateTestUser(t, db)
+	asset := tests.CreateTestAsset(t, db, admin.ID)
+	room := tests.CreateTestRoom(t, db)
+	otherRoom := entity.Room{ID: uuid.New(), Floor: "2", RoomName: "Other Room", RoomDept: "IT", CreatedAt: room.CreatedAt}
+	assert.NoError(t, db.Create(&otherRoom).Error)
+	source := tests.CreateTestAssetPlacement(t, db, admin.ID, technician.ID, asset.ID, room.ID)
+
+	_, err := unitRepo.CreateMissingForAllAssetPlacement("PLT", 6)
+	assert.NoError(t, err)
+	units, err := unitRepo.FindAllByAssetPlacementId(source.ID)
+	assert.NoError(t, err)
+
+	loan := entity.AssetLoan{ID: uuid.New(), LoanStatus: "approved", DueAt: time.Now().Add(time.Hour), CreatedAt: time.Now(), BorrowerId: user.ID, BorrowerType: "guest", AssetPlacementId: source.ID}
+	assert.NoError(t, db.Create(&loan).Error)
+
+	createTransfer := func(transferQty int) entity.AssetTransfer {
+		transfer := entity.AssetTransfer{
+			TransferQty:       transferQty,
+			RequestedBy:       technician.ID,
+			RequestedByType:   "technician",
+			AssetId:           asset.ID,
+			SourcePlacementId: source.ID,
+			TargetRoomId:      otherRoom.ID,
+		}
+		assert.NoError(t, repo.Create(&transfer))
+		return transfer
+	}
+
+	// Test 1: Loaned asset can't be transferred
+	transfer := createTransfer(3)
+	err = repo.ApproveById(admin.ID, "admin", transfer.ID)
+	assert.EqualError(t, err, "only 2 asset is available to be transferred")
+
+	// Test 2: Unit with open finding can't be moved when there isn't enough unit without it
+	findings := make([]entity.AssetFinding, 0, 2)
+	for _, unit := range units[1:] {
+		finding := entity.AssetFinding{ID: uuid.New(), FindingCategory: "broken", FindingNotes: "screen broken", CreatedAt: time.Now(), AssetPlacementId: source.ID, AssetUnitId: &unit.ID, FindingByTechnician: &technician.ID}
+		assert.NoError(t, db.Create(&finding).Error)
+		findings = append(findings, finding)
+	}
+	transfer = createTransfer(2)
+	err = repo.ApproveById(admin.ID, "admin", transfer.ID)
+	assert.Error(t, err)
+
+	// Test 3: Unit without open finding is moved first
+	err = db.Model(&entity.AssetFinding{}).Where("id = ?", findings[0].ID).Update("deleted_at", time.Now()).Error
+	assert.NoError(t, err)
+	err = repo.ApproveById(admin.ID, "admin", transfer.ID)
+	assert.NoError(t, err)
+
+	remaining, err := unitRepo.FindAllByAssetPlacementId(source.ID)
+	assert.NoError(t, err)
+	assert.Len(t, remaining, 1)
+	assert.Equal(t, units[2].ID, remaining[0].ID)
+}
@@ -0,0 +1,160 @@
+package repository_test
+
+import (
+	"pelita/entity"
+	"pelita/repository"
+	"pelita/tests"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetUnitRepositoryGenerateAndLookup(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewAssetUnitRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	technician := tests.CreateTestTechnician(t, db, admin.ID, "tech@test.com")
+	asset := tests.CreateTestAsset(t, db, admin.ID)
+	room := tests.CreateTestRoom(t, db)
+	placement := tests.CreateTestAssetPlacement(t, db, admin.ID, technician.ID, asset.ID, room.ID)
+
+	// Test 1: Explode should create a unit for every quantity
+	total, err := repo.CreateMissingForAllAssetPlacement("PLT", 6)
+	assert.NoError(t, err)
+	assert.Equal(t, placement.AssetQty, total)
+
+	units, err := repo.FindAllByAssetPlacementId(placement.ID)
+	assert.NoError(t, err)
+	assert.Len(t, units, placement.AssetQty)
+	assert.Equal(t, "PLT-000001", units[0].Tag)
+	assert.Equal(t, "good", units[0].UnitCondition)
+
+	// Test 2: Generate again should only create the missing unit
+	created, err := repo.CreateMissingByAssetPlacementId("PLT", 6, placement.AssetQty+1, placement.ID)
+	assert.NoError(t, err)
+	assert.Len(t, created, 1)
+	assert.Equal(t, "PLT-000004", created[0].Tag)
+
+	// Test 3: Lookup should resolve the tag to its unit, asset, placement, and room
+	lookup, err := repo.FindLookupByTag("PLT-000002", nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, lookup)
+	assert.Equal(t, units[1].ID, lookup.AssetUnitId)
+	assert.Equal(t, asset.ID, lookup.AssetId)
+	assert.Equal(t, placement.ID, lookup.AssetPlacementId)
+	assert.Equal(t, room.ID, lookup.RoomId)
+
+	lookup, err = repo.FindLookupByTag("PLT-999999", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, lookup)
+
+	// Test 4: Label should be found by room and by placement
+	labels, err := repo.FindAllLabelByRoomId(room.ID, nil)
+	assert.NoError(t, err)
+	assert.Len(t, labels, placement.AssetQty+1)
+
+	labels, err = repo.FindAllLabelByAssetPlacementId(uuid.New(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, labels)
+
+	// Test 5: Technician without any scope should not resolve the tag or its label
+	lookup, err = repo.FindLookupByTag("PLT-000002", &entity.AccessScope{})
+	assert.NoError(t, err)
+	assert.Nil(t, lookup)
+
+	labels, err = repo.FindAllLabelByRoomId(room.ID, &entity.AccessScope{})
+	assert.NoError(t, err)
+	assert.Empty(t, labels)
+
+	// Test 6: Update should store serial number and condition
+	serialNumber := "SN-001"
+	err = repo.UpdateById(&entity.AssetUnit{SerialNumber: &serialNumber, UnitCondition: "damaged"}, units[0].ID)
+	assert.NoError(t, err)
+
+	found, err := repo.FindBySerialNumberAndId(serialNumber, uuid.New())
+	assert.NoError(t, err)
+	assert.NotNil(t, found)
+	assert.Equal(t, "damaged", found.UnitCondition)
+}
+
+func TestAssetUnitRepositoryMoveAndDelete(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewAssetUnitRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	technician := tests.CreateTestTechnician(t, db, admin.ID, "tech@test.com")
+	asset := tests.CreateTestAsset(t, db, admin.ID)
+	room := tests.CreateTestRoom(t, db)
+	otherRoom := entity.Room{ID: uuid.New(), Floor: "2", RoomName: "Other Room", RoomDept: "IT", CreatedAt: room.CreatedAt}
+	assert.NoError(t, db.Create(&otherRoom).Error)
+	source := tests.CreateTestAssetPlacement(t, db, admin.ID, technician.ID, asset.ID, room.ID)
+	target := tests.CreateTestAssetPlacement(t, db, admin.ID, technician.ID, asset.ID, otherRoom.ID)
+
+	_, err := repo.CreateMissingForAllAssetPlacement("PLT", 6)
+	assert.NoError(t, err)
+	units, err := repo.FindAllByAssetPlacementId(source.ID)
+	assert.NoError(t, err)
+
+	// Test 1: Move should keep both quantity consistent with its unit
+	err = repo.MoveById(admin.ID, "admin", target.ID, units[0].ID)
+	assert.NoError(t, err)
+
+	var updatedSource, updatedTarget entity.AssetPlacement
+	_ = db.First(&updatedSource, "id = ?", source.ID).Error
+	_ = db.First(&updatedTarget, "id = ?", target.ID).Error
+	assert.Equal(t, source.AssetQty-1, updatedSource.AssetQty)
+	assert.Equal(t, target.AssetQty+1, updatedTarget.AssetQty)
+
+	totalSource, _ := repo.CountByAssetPlacementId(source.ID)
+	totalTarget, _ := repo.CountByAssetPlacementId(target.ID)
+	assert.Equal(t, int64(updatedSource.AssetQty), totalSource)
+	assert.Equal(t, int64(updatedTarget.AssetQty), totalTarget)
+
+	// Test 2: Move to the same placement should fail
+	err = repo.MoveById(admin.ID, "admin", target.ID, units[0].ID)
+	assert.Error(t, err)
+
+	// Test 3: Delete should reduce the quantity
+	err = repo.DeleteById(units[1].ID)
+	assert.NoError(t, err)
+
+	_ = db.First(&updatedSource, "id = ?", source.ID).Error
+	assert.Equal(t, source.AssetQty-2, updatedSource.AssetQty)
+}
+
+func TestAssetUnitRepositoryDeleteSurplusForAllAssetPlacement(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewAssetUnitRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	technician := tests.CreateTestTechnician(t, db, admin.ID, "tech@test.com")
+	asset := tests.CreateTestAsset(t, db, admin.ID)
+	room := tests.CreateTestRoom(t, db)
+	placement := tests.CreateTestAssetPlacement(t, db, admin.ID, technician.ID, asset.ID, room.ID)
+
+	_, err := repo.CreateMissingForAllAssetPlacement("PLT", 6)
+	assert.NoError(t, err)
+
+	// Test 1: Nothing is removed while the unit match the quantity
+	tags, err := repo.DeleteSurplusForAllAssetPlacement()
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+
+	// Test 2: Unit beyond a reduced quantity is removed, latest sequence first
+	err = db.Model(&entity.AssetPlacement{}).Where("id = ?", placement.ID).Update("asset_qty", 1).Error
+	assert.NoError(t, err)
+
+	tags, err = repo.DeleteSurplusForAllAssetPlacement()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"PLT-000002", "PLT-000003"}, tags)
+
+	units, err := repo.FindAllByAssetPlacementId(placement.ID)
+	assert.NoError(t, err)
+	assert.Len(t, units, 1)
+	assert.Equal(t, "PLT-000001", units[0].Tag)
+}
@@ -150,3 +150,28 @@ func TestHistoryRepository_FindMy_Guest(t *testing.T) {
 	assert.Equal(t, user.ID, *history[0].UserID)
 	assert.Equal(t, "guest", history[0].TypeUser)
 }
+
+func TestHistoryRepositoryCreate(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewHistoryRepository(db)
+
+	// Models
+	admin := tests.CreateTestAdmin(t, db)
+	history := entity.History{
+		AdminID:     &admin.ID,
+		TypeUser:    "admin",
+		TypeHistory: "login_failed",
+	}
+
+	// Query
+	err := repo.Create(&history)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, history.ID)
+
+	histories, total, err := repo.FindMy(utils.Pagination{Page: 1, Limit: 10}, admin.ID, "admin")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "login_failed", histories[0].TypeHistory)
+}
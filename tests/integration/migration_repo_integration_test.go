@@ -0,0 +1,47 @@
+package repository_test
+
+import (
+	"errors"
+	"pelita/entity"
+	"pelita/repository"
+	"pelita/tests"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestMigrationRepositoryRunOnce(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewMigrationRepository(db)
+	totalRun := 0
+
+	// Test 1: Failed migration should not be recorded
+	migrated, err := repo.RunOnce("test_migration", func(tx *gorm.DB) error {
+		totalRun++
+		return errors.New("migration failed")
+	})
+	assert.EqualError(t, err, "migration failed")
+	assert.False(t, migrated)
+
+	// Test 2: Migration should run and be recorded
+	migrated, err = repo.RunOnce("test_migration", func(tx *gorm.DB) error {
+		totalRun++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, migrated)
+
+	var migration entity.Migration
+	err = db.First(&migration, "name = ?", "test_migration").Error
+	assert.NoError(t, err)
+
+	// Test 3: Recorded migration should be skipped
+	migrated, err = repo.RunOnce("test_migration", func(tx *gorm.DB) error {
+		totalRun++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.False(t, migrated)
+	assert.Equal(t, 2, totalRun)
+}
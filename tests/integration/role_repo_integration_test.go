@@ -0,0 +1,90 @@
+package repository_test
+
+import (
+	"pelita/config"
+	"pelita/entity"
+	"pelita/repository"
+	"pelita/tests"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleRepositoryFindPermissionByAccount(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewRoleRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	technician := tests.CreateTestTechnician(t, db, admin.ID, "tech@test.com")
+	err := repo.SyncPermissionByRoleName(config.RoleTechnician, config.DefaultRolePermissions[config.RoleTechnician])
+	assert.NoError(t, err)
+
+	// Test 1: Should fallback to system role permission
+	permissions, err := repo.FindPermissionByAccount(technician.ID, config.RoleTechnician)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, config.DefaultRolePermissions[config.RoleTechnician], permissions)
+
+	// Test 2: Should use custom role permission once assigned
+	role := entity.Role{RoleName: "floor supervisor"}
+	err = repo.Create(&role, []string{config.PermissionPlacementRead, config.PermissionPlacementCreate})
+	assert.NoError(t, err)
+
+	err = repo.AssignAccountRole(&entity.AccountRole{AccountId: technician.ID, AccountType: config.RoleTechnician, RoleId: role.ID})
+	assert.NoError(t, err)
+
+	permissions, err = repo.FindPermissionByAccount(technician.ID, config.RoleTechnician)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{config.PermissionPlacementRead, config.PermissionPlacementCreate}, permissions)
+
+	// Test 3: Should replace permission on update
+	role.RoleName = "floor supervisor"
+	err = repo.UpdateById(&role, []string{config.PermissionFindingRead}, role.ID)
+	assert.NoError(t, err)
+
+	permissions, err = repo.FindPermissionByAccount(technician.ID, config.RoleTechnician)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{config.PermissionFindingRead}, permissions)
+
+	// Test 4: Should fallback to system role after the custom role is deleted
+	err = repo.DeleteById(role.ID)
+	assert.NoError(t, err)
+
+	permissions, err = repo.FindPermissionByAccount(technician.ID, config.RoleTechnician)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, config.DefaultRolePermissions[config.RoleTechnician], permissions)
+}
+
+func TestRoleRepositorySyncNewPermissionByRoleName(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewRoleRepository(db)
+
+	// Setup: Seed An Older Default Permission Set
+	err := repo.SyncNewPermissionByRoleName(config.RoleTechnician, []string{config.PermissionPlacementRead, config.PermissionFindingRead})
+	assert.NoError(t, err)
+
+	role, err := repo.FindByRoleName(config.RoleTechnician)
+	assert.NoError(t, err)
+	assert.NotNil(t, role)
+
+	// Setup: Admin Remove One Seeded Permission
+	err = db.Where("role_id = ? AND permission = ?", role.ID, config.PermissionFindingRead).Delete(&entity.RolePermission{}).Error
+	assert.NoError(t, err)
+
+	// Test 1: Should Add Permission That Was Never Seeded And Keep The Removed One Removed
+	err = repo.SyncNewPermissionByRoleName(config.RoleTechnician, []string{config.PermissionPlacementRead, config.PermissionFindingRead, config.PermissionLoanRead})
+	assert.NoError(t, err)
+
+	var permissions []string
+	err = db.Model(&entity.RolePermission{}).Where("role_id = ?", role.ID).Pluck("permission", &permissions).Error
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{config.PermissionPlacementRead, config.PermissionLoanRead}, permissions)
+
+	// Test 2: Should Give Every Permission Back On Full Sync
+	err = repo.SyncPermissionByRoleName(config.RoleTechnician, []string{config.PermissionPlacementRead, config.PermissionFindingRead, config.PermissionLoanRead})
+	assert.NoError(t, err)
+
+	err = db.Model(&entity.RolePermission{}).Where("role_id = ?", role.ID).Pluck("permission", &permissions).Error
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{config.PermissionPlacementRead, config.PermissionFindingRead, config.PermissionLoanRead}, permissions)
+}
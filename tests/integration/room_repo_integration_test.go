@@ -27,7 +27,7 @@ func TestRoomRepositoryCRUD(t *testing.T) {
 	admin := tests.CreateTestAdmin(t, db)
 	asset := tests.CreateTestAsset(t, db, admin.ID)
 	technician := tests.CreateTestTechnician(t, db, admin.ID, "tech@gmail.com")
-	tests.CreateTestAssetPlacement(t, db, admin.ID, technician.ID, asset.ID, room.ID)
+	placement := tests.CreateTestAssetPlacement(t, db, admin.ID, technician.ID, asset.ID, room.ID)
 
 	// Test 1: Should Find All Rooms
 	pagination := utils.Pagination{Page: 1, Limit: 10}
@@ -64,7 +64,7 @@ func TestRoomRepositoryCRUD(t *testing.T) {
 	assert.Equal(t, "Engineering", updated.RoomDept)
 
 	// Test 5: Should Find Room Asset By Floor And Room Name
-	assetResults, err := repo.FindRoomAssetByFloorAndRoomName(room.Floor, room.RoomName)
+	assetResults, err := repo.FindRoomAssetByFloorAndRoomName(room.Floor, room.RoomName, nil)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, assetResults)
 	exists = false
@@ -77,7 +77,7 @@ func TestRoomRepositoryCRUD(t *testing.T) {
 	assert.True(t, exists)
 
 	// Test 6: Should Find Room Asset Short By Floor And Room Name
-	shortResults, err := repo.FindRoomAssetShortByFloorAndRoomName(room.Floor, room.RoomName)
+	shortResults, err := repo.FindRoomAssetShortByFloorAndRoomName(room.Floor, room.RoomName, nil)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, shortResults)
 	exists = false
@@ -90,19 +90,90 @@ func TestRoomRepositoryCRUD(t *testing.T) {
 	assert.True(t, exists)
 
 	// Test 7: Should Return All Room Assets When Room Name is "all"
-	allAssets, err := repo.FindRoomAssetByFloorAndRoomName("1", "all")
+	allAssets, err := repo.FindRoomAssetByFloorAndRoomName("1", "all", nil)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, allAssets)
 
-	allShortAssets, err := repo.FindRoomAssetShortByFloorAndRoomName("1", "all")
+	allShortAssets, err := repo.FindRoomAssetShortByFloorAndRoomName("1", "all", nil)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, allShortAssets)
 
-	// Test 8: Should Delete Room By Id
-	err = repo.DeleteById(room.ID)
+	// Test 8: Should Only Find Room Asset Inside The Technician Scope
+	scopedAssets, err := repo.FindRoomAssetByFloorAndRoomName(room.Floor, room.RoomName, &entity.AccessScope{Departments: []string{"Engineering"}})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, scopedAssets)
+
+	_, err = repo.FindRoomAssetByFloorAndRoomName(room.Floor, room.RoomName, &entity.AccessScope{Departments: []string{"Finance"}})
+	assert.Error(t, err)
+
+	_, err = repo.FindRoomAssetShortByFloorAndRoomName(room.Floor, room.RoomName, &entity.AccessScope{})
+	assert.Error(t, err)
+
+	inScope, err := repo.IsInScopeById(room.ID, &entity.AccessScope{})
+	assert.NoError(t, err)
+	assert.False(t, inScope)
+
+	inScope, err = repo.IsInScopeById(room.ID, nil)
+	assert.NoError(t, err)
+	assert.True(t, inScope)
+
+	// Test 9: Should Not Soft Delete Room That Still Has Asset Placement
+	err = repo.SoftDeleteById(room.ID)
+	assert.Error(t, err)
+
+	// Test 10: Should Soft Delete Room After Its Asset Placement Is Deleted
+	err = repository.NewAssetPlacementRepository(db).SoftDeleteById(placement.ID)
+	assert.NoError(t, err)
+	err = repo.SoftDeleteById(room.ID)
+	assert.NoError(t, err)
+
+	found, err = repo.FindByRoomNameAndFloor("Room A", "1")
+	assert.NoError(t, err)
+	assert.Nil(t, found)
+
+	deletedRooms, err := repo.FindDeleted()
+	assert.NoError(t, err)
+	assert.Len(t, deletedRooms, 1)
+	assert.Equal(t, room.ID, deletedRooms[0].ID)
+
+	// Test 11: Should Recover Deleted Room
+	err = repo.RecoverDeletedById(room.ID)
+	assert.NoError(t, err)
+
+	found, err = repo.FindByRoomNameAndFloor("Room A", "1")
+	assert.NoError(t, err)
+	assert.NotNil(t, found)
+
+	// Test 12: Should Not Find Delete Dependency Of Room That Is Not Soft Deleted
+	dependencies, err := repo.FindDeleteDependencyById(room.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, dependencies)
+
+	// Test 13: Should Find Every Row Cascaded Along With The Room
+	err = repo.SoftDeleteById(room.ID)
+	assert.NoError(t, err)
+
+	dependencies, err = repo.FindDeleteDependencyById(room.ID)
+	assert.NoError(t, err)
+	assert.Len(t, dependencies, 1)
+	assert.Equal(t, "asset_placements", dependencies[0].RecordTable)
+	assert.Equal(t, 1, dependencies[0].Total)
+
+	// Test 14: Should Hard Delete Room By Id And Archive The Cascaded Row
+	err = repo.HardDeleteById(room.ID, admin.ID)
 	assert.NoError(t, err)
 
 	var deleted entity.Room
 	result := db.Unscoped().First(&deleted, "id = ?", room.ID)
 	assert.Error(t, result.Error)
+
+	var archives []entity.Archive
+	err = db.Where("deleted_table = ? AND deleted_id = ?", "rooms", room.ID.String()).Order("record_table").Find(&archives).Error
+	assert.NoError(t, err)
+	assert.Len(t, archives, 2)
+	assert.Equal(t, "asset_placements", archives[0].RecordTable)
+	assert.Equal(t, dependencies[0].RecordIds[0], archives[0].RecordId)
+	assert.Equal(t, "rooms", archives[1].RecordTable)
+	assert.Contains(t, archives[1].RecordData, "Room A")
+	assert.Equal(t, admin.ID, archives[1].ArchivedBy)
 }
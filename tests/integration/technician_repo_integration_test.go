@@ -99,18 +99,97 @@ func TestTechnicianRepositoryUpdateById(t *testing.T) {
 	assert.False(t, updated.TelegramIsValid)
 }
 
-func TestTechnicianRepositoryDeleteById(t *testing.T) {
+func TestTechnicianRepositorySoftDeleteRecoverHardDelete(t *testing.T) {
 	db := tests.SetupTestDB(t)
 	repo := repository.NewTechnicianRepository(db)
 	admin := tests.CreateTestAdmin(t, db)
 	tech := tests.CreateTestTechnician(t, db, admin.ID, "delete_test@example.com")
 
-	// Test 1: Should Delete technician by id
-	err := repo.DeleteById(tech.ID)
+	// Test 1: Should Soft Delete technician by id
+	err := repo.SoftDeleteById(tech.ID)
 	assert.NoError(t, err)
 
 	// Test 2: Should not find deleted technician
 	result, err := repo.FindById(tech.ID)
 	assert.NoError(t, err)
 	assert.Nil(t, result)
+
+	deleted, err := repo.FindDeleted()
+	assert.NoError(t, err)
+	assert.Len(t, deleted, 1)
+	assert.Equal(t, tech.ID, deleted[0].ID)
+
+	// Test 3: Should find deleted technician that has passed the retention
+	ids, err := repo.FindAllDeletedIdBefore(time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Contains(t, ids, tech.ID)
+
+	ids, err = repo.FindAllDeletedIdBefore(time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.NotContains(t, ids, tech.ID)
+
+	// Test 4: Should Recover deleted technician
+	err = repo.RecoverDeletedById(tech.ID)
+	assert.NoError(t, err)
+
+	result, err = repo.FindById(tech.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+
+	// Test 5: Should Hard Delete soft deleted technician and archive its history
+	history := entity.History{ID: uuid.New(), TechnicianID: &tech.ID, TypeUser: "technician", TypeHistory: "login"}
+	assert.NoError(t, db.Create(&history).Error)
+
+	err = repo.SoftDeleteById(tech.ID)
+	assert.NoError(t, err)
+	dependencies, err := repo.FindDeleteDependencyById(tech.ID)
+	assert.NoError(t, err)
+	assert.Contains(t, dependencies, entity.DeleteDependency{RecordTable: "histories", Total: 1, RecordIds: []string{history.ID.String()}})
+
+	err = repo.HardDeleteById(tech.ID, admin.ID)
+	assert.NoError(t, err)
+
+	deleted, err = repo.FindDeleted()
+	assert.NoError(t, err)
+	assert.Empty(t, deleted)
+
+	var archives []entity.Archive
+	err = db.Where("record_table = ? AND record_id = ?", "histories", history.ID.String()).Find(&archives).Error
+	assert.NoError(t, err)
+	assert.Len(t, archives, 1)
+}
+
+func TestTechnicianRepositorySoftDeleteWhileInUse(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewTechnicianRepository(db)
+	admin := tests.CreateTestAdmin(t, db)
+	tech := tests.CreateTestTechnician(t, db, admin.ID, "in_use_test@example.com")
+	asset := tests.CreateTestAsset(t, db, admin.ID)
+	room := tests.CreateTestRoom(t, db)
+	placement := tests.CreateTestAssetPlacement(t, db, admin.ID, tech.ID, asset.ID, room.ID)
+
+	// Test 1: Should not Soft Delete technician that still owns an asset placement
+	err := repo.SoftDeleteById(tech.ID)
+	assert.EqualError(t, err, "technician still owns asset placement, hand it over or delete it first")
+
+	// Test 2: Should not Soft Delete technician that still has an active asset loan
+	assert.NoError(t, db.Model(placement).Update("deleted_at", time.Now()).Error)
+	loan := entity.AssetLoan{
+		ID:               uuid.New(),
+		LoanStatus:       "approved",
+		DueAt:            time.Now().Add(time.Hour),
+		CreatedAt:        time.Now(),
+		BorrowerId:       tech.ID,
+		BorrowerType:     "technician",
+		AssetPlacementId: placement.ID,
+	}
+	assert.NoError(t, db.Create(&loan).Error)
+
+	err = repo.SoftDeleteById(tech.ID)
+	assert.EqualError(t, err, "technician still has active asset loan, return it first")
+
+	// Test 3: Should Soft Delete technician once nothing is in use
+	assert.NoError(t, db.Model(&loan).Update("loan_status", "returned").Error)
+	err = repo.SoftDeleteById(tech.ID)
+	assert.NoError(t, err)
 }
@@ -4,6 +4,7 @@ import (
 	"pelita/entity"
 	"pelita/repository"
 	"pelita/tests"
+	"strings"
 	"testing"
 	"time"
 
@@ -78,3 +79,231 @@ func TestUserRepositoryFindById(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Nil(t, result)
 }
+
+func TestUserRepositoryFindAccountByIdAndUpdatePassword(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewUserRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	technician := tests.CreateTestTechnician(t, db, admin.ID, "tech@test.com")
+
+	// Test 1: Should find account of each role by ID
+	account, err := repo.FindAccountById(admin.ID, "admin")
+	assert.NoError(t, err)
+	assert.NotNil(t, account)
+	assert.Equal(t, admin.ID, account.GetID())
+
+	account, err = repo.FindAccountById(technician.ID, "technician")
+	assert.NoError(t, err)
+	assert.NotNil(t, account)
+	assert.Equal(t, technician.ID, account.GetID())
+
+	// Test 2: Should return nil when ID exists in another role table
+	account, err = repo.FindAccountById(admin.ID, "guest")
+	assert.NoError(t, err)
+	assert.Nil(t, account)
+
+	// Test 3: Should update password on the role table
+	err = repo.UpdatePasswordById(technician.ID, "technician", "new_hashed_password")
+	assert.NoError(t, err)
+
+	var updated entity.Technician
+	_ = db.First(&updated, "id = ?", technician.ID).Error
+	assert.Equal(t, "new_hashed_password", updated.Password)
+
+	// Test 4: Should return error when account not found
+	err = repo.UpdatePasswordById(uuid.New(), "guest", "new_hashed_password")
+	assert.Error(t, err)
+}
+
+func TestUserRepositoryUpdateTelegramById(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewUserRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	technician := tests.CreateTestTechnician(t, db, admin.ID, "tech@test.com")
+
+	// Test 1: Should link telegram account and mark it as valid
+	telegramId := "55555"
+	err := repo.UpdateTelegramById(technician.ID, "technician", &telegramId, true)
+	assert.NoError(t, err)
+
+	var updated entity.Technician
+	_ = db.First(&updated, "id = ?", technician.ID).Error
+	assert.NotNil(t, updated.TelegramUserId)
+	assert.Equal(t, telegramId, *updated.TelegramUserId)
+	assert.True(t, updated.TelegramIsValid)
+
+	// Test 2: Should unlink telegram account
+	err = repo.UpdateTelegramById(technician.ID, "technician", nil, false)
+	assert.NoError(t, err)
+
+	_ = db.First(&updated, "id = ?", technician.ID).Error
+	assert.Nil(t, updated.TelegramUserId)
+	assert.False(t, updated.TelegramIsValid)
+
+	// Test 3: Should return error when account not found
+	err = repo.UpdateTelegramById(uuid.New(), "guest", &telegramId, true)
+	assert.Error(t, err)
+}
+
+func TestUserRepositoryLinkTelegramById(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewUserRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	technician := tests.CreateTestTechnician(t, db, admin.ID, "tech@test.com")
+	user := tests.CreateTestUser(t, db)
+	telegramId := "77777"
+	err := repo.UpdateTelegramById(user.ID, "guest", &telegramId, false)
+	assert.NoError(t, err)
+
+	// Test 1: Should detect telegram user id of another role table
+	isUsed, err := repo.IsTelegramUserIdUsedByOtherAccount(telegramId, technician.ID)
+	assert.NoError(t, err)
+	assert.True(t, isUsed)
+
+	isUsed, err = repo.IsTelegramUserIdUsedByOtherAccount(telegramId, user.ID)
+	assert.NoError(t, err)
+	assert.False(t, isUsed)
+
+	// Test 2: Should link telegram account and release it from the other account
+	err = repo.LinkTelegramById(technician.ID, "technician", telegramId)
+	assert.NoError(t, err)
+
+	var updatedTechnician entity.Technician
+	_ = db.First(&updatedTechnician, "id = ?", technician.ID).Error
+	assert.Equal(t, telegramId, *updatedTechnician.TelegramUserId)
+	assert.True(t, updatedTechnician.TelegramIsValid)
+
+	var updatedUser entity.User
+	_ = db.First(&updatedUser, "id = ?", user.ID).Error
+	assert.Nil(t, updatedUser.TelegramUserId)
+	assert.False(t, updatedUser.TelegramIsValid)
+
+	// Test 3: Should return error when account not found
+	err = repo.LinkTelegramById(uuid.New(), "guest", telegramId)
+	assert.Error(t, err)
+}
+
+func TestUserRepositoryIsEmailUsedByOtherAccount(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewUserRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	technician := tests.CreateTestTechnician(t, db, admin.ID, "tech@test.com")
+	user := tests.CreateTestUser(t, db)
+
+	// Test 1: Should detect email of another role table
+	isUsed, err := repo.IsEmailUsedByOtherAccount(technician.Email, user.ID)
+	assert.NoError(t, err)
+	assert.True(t, isUsed)
+
+	isUsed, err = repo.IsEmailUsedByOtherAccount(admin.Email, technician.ID)
+	assert.NoError(t, err)
+	assert.True(t, isUsed)
+
+	isUsed, err = repo.IsEmailUsedByOtherAccount(strings.ToUpper(technician.Email), user.ID)
+	assert.NoError(t, err)
+	assert.True(t, isUsed)
+
+	// Test 2: Should ignore the email of the account itself
+	isUsed, err = repo.IsEmailUsedByOtherAccount(user.Email, user.ID)
+	assert.NoError(t, err)
+	assert.False(t, isUsed)
+
+	// Test 3: Should return false for unused email
+	isUsed, err = repo.IsEmailUsedByOtherAccount("unused@test.com", user.ID)
+	assert.NoError(t, err)
+	assert.False(t, isUsed)
+}
+
+func TestUserRepositoryReserveAccountEmail(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewUserRepository(db)
+	technicianRepo := repository.NewTechnicianRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	user := &entity.User{Username: "reserved", Password: "hashed_password", Email: "Reserved@Test.com"}
+	err := repo.Create(user)
+	assert.NoError(t, err)
+
+	// Test 1: Should reject the same email in another role table regardless of its case
+	err = technicianRepo.Create(&entity.Technician{Username: "tech", Email: "reserved@test.com"}, admin.ID)
+	assert.EqualError(t, err, "email already used")
+
+	// Test 2: Should release the old email once it is changed
+	err = repo.UpdateEmailById(user.ID, "guest", "changed@test.com")
+	assert.NoError(t, err)
+
+	err = technicianRepo.Create(&entity.Technician{Username: "tech", Email: "reserved@test.com"}, admin.ID)
+	assert.NoError(t, err)
+
+	// Test 3: Should not change the email when the new email is already reserved
+	err = repo.UpdateEmailById(user.ID, "guest", "RESERVED@test.com")
+	assert.EqualError(t, err, "email already used")
+
+	var updated entity.User
+	_ = db.First(&updated, "id = ?", user.ID).Error
+	assert.Equal(t, "changed@test.com", updated.Email)
+}
+
+func TestUserRepositoryUpdateProfileAndEmailById(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewUserRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	technician := tests.CreateTestTechnician(t, db, admin.ID, "tech@test.com")
+
+	// Test 1: Should update username and telegram of the role table
+	telegramId := "77777"
+	err := repo.UpdateProfileById(technician.ID, "technician", "newtech", &telegramId, false)
+	assert.NoError(t, err)
+
+	var updated entity.Technician
+	_ = db.First(&updated, "id = ?", technician.ID).Error
+	assert.Equal(t, "newtech", updated.Username)
+	assert.Equal(t, telegramId, *updated.TelegramUserId)
+	assert.False(t, updated.TelegramIsValid)
+	assert.Equal(t, "tech@test.com", updated.Email)
+
+	// Test 2: Should update email of the role table
+	err = repo.UpdateEmailById(technician.ID, "technician", "newtech@test.com")
+	assert.NoError(t, err)
+
+	_ = db.First(&updated, "id = ?", technician.ID).Error
+	assert.Equal(t, "newtech@test.com", updated.Email)
+}
+
+func TestUserRepositoryFindAllEmailCollision(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewUserRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	tests.CreateTestTechnician(t, db, admin.ID, "tech@test.com")
+	user := tests.CreateTestUser(t, db)
+
+	// Test 1: Should return empty when every email is unique
+	collisions, err := repo.FindAllEmailCollision()
+	assert.NoError(t, err)
+	assert.Empty(t, collisions)
+
+	// Test 2: Should list every account sharing the same email
+	err = db.Model(&entity.User{}).Where("id = ?", user.ID).Update("email", admin.Email).Error
+	assert.NoError(t, err)
+
+	collisions, err = repo.FindAllEmailCollision()
+	assert.NoError(t, err)
+	assert.Len(t, collisions, 2)
+	for _, collision := range collisions {
+		assert.Equal(t, admin.Email, collision.Email)
+		assert.Contains(t, []uuid.UUID{admin.ID, user.ID}, collision.AccountId)
+	}
+}
@@ -0,0 +1,98 @@
+package repository_test
+
+import (
+	"pelita/entity"
+	"pelita/repository"
+	"pelita/tests"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarrantyRepositoryActiveAndExpiring(t *testing.T) {
+	db := tests.SetupTestDB(t)
+	repo := repository.NewWarrantyRepository(db)
+	vendorRepo := repository.NewVendorRepository(db)
+
+	// Setup: Prepare Test Data
+	admin := tests.CreateTestAdmin(t, db)
+	technician := tests.CreateTestTechnician(t, db, admin.ID, "tech@test.com")
+	asset := tests.CreateTestAsset(t, db, admin.ID)
+	room := tests.CreateTestRoom(t, db)
+	placement := tests.CreateTestAssetPlacement(t, db, admin.ID, technician.ID, asset.ID, room.ID)
+
+	contactName := "Vendor Support"
+	vendor := entity.Vendor{VendorName: "PT Vendor", ContactName: &contactName}
+	err := vendorRepo.Create(&vendor)
+	assert.NoError(t, err)
+
+	// Test 1: Asset without warranty should not be covered
+	found, err := repo.FindActiveByAssetPlacementId(time.Now(), placement.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, found)
+
+	// Test 2: Warranty covering today should be found along with its vendor
+	now := time.Now()
+	warranty := entity.Warranty{
+		WarrantyType: "warranty",
+		StartDate:    now.AddDate(-1, 0, 0),
+		EndDate:      now.AddDate(0, 0, 10),
+		AssetId:      asset.ID,
+		VendorId:     &vendor.ID,
+	}
+	err = repo.Create(&warranty)
+	assert.NoError(t, err)
+
+	found, err = repo.FindActiveByAssetPlacementId(now, placement.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, found)
+	assert.Equal(t, warranty.ID, found.ID)
+	assert.Equal(t, "PT Vendor", found.Vendor.VendorName)
+
+	found, err = repo.FindActiveByAssetPlacementId(now.AddDate(0, 0, 30), placement.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, found)
+
+	// Test 3: Warranty ending within the reminder days should fallback to the vendor contact
+	reminder, err := repo.FindAllUnremindedByEndDate(now, now.AddDate(0, 0, 30))
+	assert.NoError(t, err)
+	assert.Len(t, reminder, 1)
+	assert.Equal(t, warranty.ID, reminder[0].ID)
+	assert.Equal(t, asset.AssetName, reminder[0].AssetName)
+	assert.Equal(t, contactName, *reminder[0].ContactName)
+
+	reminder, err = repo.FindAllUnremindedByEndDate(now, now.AddDate(0, 0, 9))
+	assert.NoError(t, err)
+	assert.Len(t, reminder, 0)
+
+	// Test 4: Warranty should only be reminded once
+	err = repo.UpdateRemindedAtByIds(now, []uuid.UUID{warranty.ID})
+	assert.NoError(t, err)
+
+	reminder, err = repo.FindAllUnremindedByEndDate(now, now.AddDate(0, 0, 30))
+	assert.NoError(t, err)
+	assert.Len(t, reminder, 0)
+
+	// Test 5: Changing the end date should remind the warranty again
+	warranty.EndDate = now.AddDate(0, 0, 20)
+	err = repo.UpdateById(&warranty, warranty.ID)
+	assert.NoError(t, err)
+
+	reminder, err = repo.FindAllUnremindedByEndDate(now, now.AddDate(0, 0, 30))
+	assert.NoError(t, err)
+	assert.Len(t, reminder, 1)
+
+	// Test 6: Deleting the vendor should keep the warranty
+	err = vendorRepo.DeleteById(vendor.ID)
+	assert.NoError(t, err)
+
+	warranties, err := repo.FindAllByAssetId(asset.ID)
+	assert.NoError(t, err)
+	assert.Len(t, warranties, 1)
+	assert.Nil(t, warranties[0].VendorId)
+
+	err = repo.DeleteById(uuid.New())
+	assert.Error(t, err)
+}
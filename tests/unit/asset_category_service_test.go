@@ -0,0 +1,143 @@
+package unit
+
+import (
+	"pelita/entity"
+	"pelita/service"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetCategoryServiceUpdateByIdRecheckAttribute(t *testing.T) {
+	laptop, printer := uuid.New(), uuid.New()
+	attributes := []entity.AssetAttributeValue{
+		{AssetId: laptop, FieldName: "ram_gb", AttributeValue: "16"},
+		{AssetId: laptop, FieldName: "os", AttributeValue: "Linux"},
+		{AssetId: printer, FieldName: "os", AttributeValue: "Windows"},
+	}
+
+	tests := []struct {
+		name        string
+		fields      []entity.AssetCategoryField
+		wantErr     string
+		wantChanged []entity.AssetAttributeValue
+	}{
+		{
+			name: "field type changed to string",
+			fields: []entity.AssetCategoryField{
+				{FieldName: "ram_gb", FieldType: "string"},
+				{FieldName: "os", FieldType: "enum", FieldOptions: []string{"linux", "windows"}},
+			},
+			wantChanged: []entity.AssetAttributeValue{
+				{AssetId: laptop, FieldName: "os", AttributeValue: "linux"},
+				{AssetId: printer, FieldName: "os", AttributeValue: "windows"},
+			},
+		},
+		{
+			name: "field type changed to date",
+			fields: []entity.AssetCategoryField{
+				{FieldName: "ram_gb", FieldType: "date"},
+			},
+			wantErr: "attribute ram_gb of asset " + laptop.String() + " must be in YYYY-MM-DD format, update the asset first",
+		},
+		{
+			name: "enum option narrowed",
+			fields: []entity.AssetCategoryField{
+				{FieldName: "os", FieldType: "enum", FieldOptions: []string{"Linux"}},
+			},
+			wantErr: "attribute os of asset " + printer.String() + " must be one of Linux, update the asset first",
+		},
+		{
+			name: "required field missing on asset",
+			fields: []entity.AssetCategoryField{
+				{FieldName: "ram_gb", FieldType: "number", IsRequired: true},
+			},
+			wantErr: "field ram_gb can't be required, 1 asset has no value for it",
+		},
+		{
+			name: "field removed",
+			fields: []entity.AssetCategoryField{
+				{FieldName: "os", FieldType: "enum", FieldOptions: []string{"Linux", "Windows"}, IsRequired: true},
+			},
+			wantChanged: []entity.AssetAttributeValue{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assetCategoryRepo := &fakeAssetCategoryRepository{assetIds: []uuid.UUID{laptop, printer}, attributes: attributes}
+			assetCategoryService := service.NewAssetCategoryService(assetCategoryRepo)
+
+			err := assetCategoryService.UpdateById(&entity.AssetCategory{CategoryName: "Computer", FieldDefinitions: tt.fields}, uuid.New())
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantChanged, assetCategoryRepo.changedAttributes)
+		})
+	}
+}
+
+func TestAssetCategoryServiceUpdateByIdRecheckParent(t *testing.T) {
+	// Test Data
+	laptop := &entity.AssetCategory{ID: uuid.New(), CategoryName: "Laptop"}
+	computer := &entity.AssetCategory{ID: uuid.New(), CategoryName: "Computer"}
+	monitor := &entity.AssetCategory{ID: uuid.New(), CategoryName: "Monitor"}
+	screen := &entity.AssetCategory{ID: uuid.New(), CategoryName: "Screen"}
+	assetCategories := map[string]*entity.AssetCategory{"Laptop": laptop, "Computer": computer, "Monitor": monitor, "Screen": screen}
+
+	tests := []struct {
+		name     string
+		id       uuid.UUID
+		parentId uuid.UUID
+		parentOf map[uuid.UUID]*uuid.UUID
+		wantErr  string
+	}{
+		{
+			name:     "unchanged parent",
+			id:       laptop.ID,
+			parentId: computer.ID,
+			parentOf: map[uuid.UUID]*uuid.UUID{laptop.ID: nil, computer.ID: nil, monitor.ID: nil, screen.ID: nil},
+		},
+		{
+			name:     "concurrent re-parent create a cycle",
+			id:       computer.ID,
+			parentId: laptop.ID,
+			parentOf: map[uuid.UUID]*uuid.UUID{laptop.ID: &computer.ID, computer.ID: nil, monitor.ID: nil, screen.ID: nil},
+			wantErr:  "parent category can't be the category itself or its child",
+		},
+		{
+			name:     "cycle already saved",
+			id:       laptop.ID,
+			parentId: monitor.ID,
+			parentOf: map[uuid.UUID]*uuid.UUID{laptop.ID: nil, computer.ID: nil, monitor.ID: &screen.ID, screen.ID: &monitor.ID},
+			wantErr:  "parent category can't be the category itself or its child",
+		},
+		{
+			name:     "parent deleted meanwhile",
+			id:       laptop.ID,
+			parentId: computer.ID,
+			parentOf: map[uuid.UUID]*uuid.UUID{laptop.ID: nil, monitor.ID: nil, screen.ID: nil},
+			wantErr:  "parent category not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assetCategoryRepo := &fakeAssetCategoryRepository{assetCategories: assetCategories, parentOf: tt.parentOf}
+			assetCategoryService := service.NewAssetCategoryService(assetCategoryRepo)
+
+			// Exec
+			err := assetCategoryService.UpdateById(&entity.AssetCategory{CategoryName: "Renamed", ParentId: &tt.parentId}, tt.id)
+
+			// Test : Parent Is Checked Again Against The Category Seen Under Lock
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package unit
+
+import (
+	"pelita/entity"
+	"pelita/service"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetImportServiceImport(t *testing.T) {
+	// Test Data
+	room := &entity.Room{ID: uuid.New(), RoomName: "Lab", Floor: "1"}
+	secondRoom := &entity.Room{ID: uuid.New(), RoomName: "Office", Floor: "2"}
+	technician := &entity.Technician{ID: uuid.New(), Email: "tech@test.com"}
+	category := &entity.AssetCategory{ID: uuid.New(), CategoryName: "electronics"}
+	assets := map[uuid.UUID]*entity.Asset{}
+	assetImportService := service.NewAssetImportService(
+		&fakeAssetRepository{assets: assets},
+		&fakeRoomRepository{rooms: map[string]*entity.Room{"1|Lab": room, "2|Office": secondRoom}},
+		&fakeTechnicianRepository{technicians: map[string]*entity.Technician{technician.Email: technician}},
+		&fakeAssetCategoryRepository{assetCategories: map[string]*entity.AssetCategory{category.CategoryName: category}},
+	)
+	header := []string{"asset_name", "asset_category", "asset_status", "asset_price", "floor", "room_name", "asset_qty", "owner_email"}
+
+	// Test 1 : Row number should follow the file line even after an empty row
+	rows := [][]string{
+		header,
+		{},
+		{"Laptop", "electronics", "available", "Rp 10.000.000", "1", "Lab", "2", "tech@test.com"},
+		{"", "", "", "", "", "", "", ""},
+		{"Printer", "electronics", "in-use", "", "", "", "", ""},
+	}
+	report, err := assetImportService.Import(rows, uuid.New(), true)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.TotalRow)
+	assert.Len(t, report.Errors, 1)
+	assert.Equal(t, 5, report.Errors[0].Row)
+
+	// Test 2 : Only initial status should be accepted
+	assert.Equal(t, "asset_status", report.Errors[0].Column)
+	assert.Contains(t, report.Errors[0].Message, "procured, available")
+
+	// Test 3 : Repeated asset row should only add its placement when its asset column match
+	rows = [][]string{
+		header,
+		{"Laptop", "electronics", "available", "10000000", "1", "Lab", "2", "tech@test.com"},
+		{"laptop", "Electronics", "", "Rp 10.000.000", "2", "Office", "1", "tech@test.com"},
+	}
+	report, err = assetImportService.Import(rows, uuid.New(), true)
+	assert.NoError(t, err)
+	assert.Empty(t, report.Errors)
+	assert.Equal(t, 1, report.TotalAsset)
+	assert.Equal(t, 2, report.TotalAssetPlacement)
+
+	// Test 4 : Repeated asset row with a different asset column should be reported instead of merged
+	rows = [][]string{
+		header,
+		{"Laptop", "electronics", "available", "10000000", "1", "Lab", "2", "tech@test.com"},
+		{"Laptop", "electronics", "procured", "12000000", "2", "Office", "1", "tech@test.com"},
+	}
+	report, err = assetImportService.Import(rows, uuid.New(), true)
+	assert.NoError(t, err)
+	assert.Len(t, report.Errors, 2)
+	for _, importError := range report.Errors {
+		assert.Equal(t, 3, importError.Row)
+		assert.Contains(t, importError.Message, "row 2")
+	}
+
+	// Test 5 : Row similar to an existing asset should be reported unless it is forced
+	existing := &entity.Asset{ID: uuid.New(), AssetName: "Laptop Pro", AssetCategoryId: &category.ID}
+	assets[existing.ID] = existing
+	rows = [][]string{
+		{"asset_name", "asset_category", "asset_status", "force"},
+		{"Laptop Pro", "electronics", "available", ""},
+		{"Laptop  Pro", "electronics", "available", "true"},
+	}
+	report, err = assetImportService.Import(rows, uuid.New(), true)
+	assert.NoError(t, err)
+	assert.Len(t, report.Errors, 1)
+	assert.Equal(t, 2, report.Errors[0].Row)
+	assert.Contains(t, report.Errors[0].Message, "Laptop Pro (100%)")
+	assert.Equal(t, 1, report.TotalAsset)
+}
@@ -0,0 +1,183 @@
+package unit
+
+import (
+	"pelita/entity"
+	"pelita/service"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetLoanServiceCreate(t *testing.T) {
+	tests := []struct {
+		name        string
+		dueAt       time.Time
+		placement   bool
+		assetStatus string
+		wantErr     string
+	}{
+		{name: "available asset", dueAt: time.Now().Add(time.Hour), placement: true, assetStatus: "available"},
+		{name: "in-use asset", dueAt: time.Now().Add(time.Hour), placement: true, assetStatus: "in-use"},
+		{name: "due at in the past", dueAt: time.Now().Add(-time.Hour), placement: true, assetStatus: "available", wantErr: "due at must be in the future"},
+		{name: "due at is now", dueAt: time.Now(), placement: true, assetStatus: "available", wantErr: "due at must be in the future"},
+		{name: "unknown placement", dueAt: time.Now().Add(time.Hour), assetStatus: "available", wantErr: "asset placement not found"},
+		{name: "asset in maintenance", dueAt: time.Now().Add(time.Hour), placement: true, assetStatus: "maintenance", wantErr: "asset is maintenance and can't be loaned"},
+		{name: "retired asset", dueAt: time.Now().Add(time.Hour), placement: true, assetStatus: "retired", wantErr: "asset is retired and can't be loaned"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			placementId := uuid.New()
+			placementRepo := &fakeAssetPlacementRepository{assetPlacements: map[uuid.UUID]*entity.AssetPlacement{}}
+			if tt.placement {
+				placementRepo.assetPlacements[placementId] = &entity.AssetPlacement{ID: placementId, AssetQty: 1}
+			}
+			assetRepo := &fakeAssetRepository{assets: map[uuid.UUID]*entity.Asset{placementId: {ID: uuid.New(), AssetStatus: tt.assetStatus}}}
+			loanRepo := &fakeAssetLoanRepository{assetLoans: map[uuid.UUID]*entity.AssetLoan{}}
+			assetLoanService := service.NewAssetLoanService(loanRepo, placementRepo, assetRepo, nil)
+
+			borrowerId := uuid.New()
+			assetLoan := entity.AssetLoan{AssetPlacementId: placementId, DueAt: tt.dueAt}
+			err := assetLoanService.Create(&assetLoan, borrowerId, "guest")
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Empty(t, loanRepo.assetLoans)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "requested", assetLoan.LoanStatus)
+			assert.Equal(t, borrowerId, assetLoan.BorrowerId)
+			assert.Equal(t, "guest", assetLoan.BorrowerType)
+		})
+	}
+}
+
+func TestAssetLoanServiceApproveById(t *testing.T) {
+	tests := []struct {
+		name        string
+		loanStatus  string
+		assetQty    int
+		totalActive int64
+		wantErr     string
+	}{
+		{name: "asset left in placement", loanStatus: "requested", assetQty: 3, totalActive: 2},
+		{name: "every asset is reserved", loanStatus: "requested", assetQty: 3, totalActive: 3, wantErr: "no asset left to be loaned in this placement"},
+		{name: "empty placement", loanStatus: "requested", assetQty: 0, totalActive: 0, wantErr: "no asset left to be loaned in this placement"},
+		{name: "already approved", loanStatus: "approved", assetQty: 3, totalActive: 1, wantErr: "asset loan is approved, expected requested"},
+		{name: "already returned", loanStatus: "returned", assetQty: 3, totalActive: 0, wantErr: "asset loan is returned, expected requested"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assetLoan := &entity.AssetLoan{
+				ID:             uuid.New(),
+				LoanStatus:     tt.loanStatus,
+				AssetPlacement: entity.AssetPlacement{AssetQty: tt.assetQty},
+			}
+			loanRepo := &fakeAssetLoanRepository{assetLoans: map[uuid.UUID]*entity.AssetLoan{assetLoan.ID: assetLoan}, totalActive: tt.totalActive}
+			assetLoanService := service.NewAssetLoanService(loanRepo, nil, nil, nil)
+
+			adminId := uuid.New()
+			err := assetLoanService.ApproveById(assetLoan.ID, adminId)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Equal(t, tt.loanStatus, assetLoan.LoanStatus)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "approved", assetLoan.LoanStatus)
+			assert.Equal(t, adminId, *assetLoan.ApprovedBy)
+		})
+	}
+
+	// Test : Unknown Asset Loan
+	err := service.NewAssetLoanService(&fakeAssetLoanRepository{assetLoans: map[uuid.UUID]*entity.AssetLoan{}}, nil, nil, nil).ApproveById(uuid.New(), uuid.New())
+	assert.EqualError(t, err, "asset loan not found")
+}
+
+func TestAssetLoanServiceCheckOutById(t *testing.T) {
+	tests := []struct {
+		name        string
+		assetStatus string
+		wantErr     string
+	}{
+		{name: "available asset", assetStatus: "available"},
+		{name: "asset already in-use", assetStatus: "in-use"},
+		{name: "asset retired after approval", assetStatus: "retired", wantErr: "illegal asset status transition: retired to in-use"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assetLoan := &entity.AssetLoan{ID: uuid.New(), LoanStatus: "approved"}
+			loanRepo := &fakeAssetLoanRepository{
+				assetLoans: map[uuid.UUID]*entity.AssetLoan{assetLoan.ID: assetLoan},
+				asset:      &entity.Asset{ID: uuid.New(), AssetStatus: tt.assetStatus},
+			}
+			assetLoanService := service.NewAssetLoanService(loanRepo, nil, nil, nil)
+
+			err := assetLoanService.CheckOutById(assetLoan.ID, uuid.New(), "admin")
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Equal(t, "approved", assetLoan.LoanStatus)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "checked-out", assetLoan.LoanStatus)
+			if tt.assetStatus == "in-use" {
+				assert.Nil(t, loanRepo.history)
+				return
+			}
+			assert.Equal(t, "in-use", loanRepo.history.ToStatus)
+		})
+	}
+}
+
+func TestAssetLoanServiceReturnById(t *testing.T) {
+	tests := []struct {
+		name            string
+		returnCondition string
+		assetStatus     string
+		totalCheckedOut int64
+		wantStatus      string
+		wantErr         string
+	}{
+		{name: "last loan returned good", returnCondition: "good", assetStatus: "in-use", wantStatus: "available"},
+		{name: "other loan still checked out", returnCondition: "good", assetStatus: "in-use", totalCheckedOut: 1, wantStatus: "in-use"},
+		{name: "damaged return", returnCondition: "damaged", assetStatus: "in-use", totalCheckedOut: 1, wantStatus: "maintenance"},
+		{name: "asset already in maintenance", returnCondition: "good", assetStatus: "maintenance", wantStatus: "maintenance"},
+		{name: "damaged retired asset", returnCondition: "damaged", assetStatus: "retired", wantErr: "illegal asset status transition: retired to maintenance"},
+		{name: "unknown condition", returnCondition: "lost", assetStatus: "in-use", wantErr: "return condition is not valid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assetLoan := &entity.AssetLoan{ID: uuid.New(), LoanStatus: "checked-out"}
+			loanRepo := &fakeAssetLoanRepository{
+				assetLoans:      map[uuid.UUID]*entity.AssetLoan{assetLoan.ID: assetLoan},
+				asset:           &entity.Asset{ID: uuid.New(), AssetStatus: tt.assetStatus},
+				totalCheckedOut: tt.totalCheckedOut,
+			}
+			assetLoanService := service.NewAssetLoanService(loanRepo, nil, nil, nil)
+
+			err := assetLoanService.ReturnById(tt.returnCondition, nil, assetLoan.ID, uuid.New(), "admin")
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Equal(t, "checked-out", assetLoan.LoanStatus)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "returned", assetLoan.LoanStatus)
+			if tt.wantStatus == tt.assetStatus {
+				assert.Nil(t, loanRepo.history)
+				return
+			}
+			assert.Equal(t, tt.wantStatus, loanRepo.history.ToStatus)
+		})
+	}
+}
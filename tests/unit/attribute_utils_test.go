@@ -0,0 +1,72 @@
+package unit
+
+import (
+	"encoding/json"
+	"pelita/entity"
+	"pelita/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeAttributeValue(t *testing.T) {
+	// Test 1: Should normalize every valid value
+	cases := []struct {
+		fieldType string
+		value     string
+		options   []string
+		expected  string
+	}{
+		{"string", "  SN-001 ", nil, "SN-001"},
+		{"number", "16.0", nil, "16"},
+		{"number", "2.50", nil, "2.5"},
+		{"date", "2024-02-29", nil, "2024-02-29"},
+		{"boolean", "TRUE", nil, "true"},
+		{"boolean", "0", nil, "false"},
+		{"enum", "linux", []string{"Windows", "Linux"}, "Linux"},
+	}
+	for _, c := range cases {
+		value, err := utils.NormalizeAttributeValue(c.fieldType, c.value, c.options)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, value)
+	}
+
+	// Test 2: Should reject value that doesn't match its type
+	invalid := []struct {
+		fieldType string
+		value     string
+		options   []string
+	}{
+		{"number", "16 GB", nil},
+		{"date", "29/02/2024", nil},
+		{"date", "2023-02-29", nil},
+		{"boolean", "yes", nil},
+		{"enum", "MacOS", []string{"Windows", "Linux"}},
+		{"unknown", "value", nil},
+	}
+	for _, c := range invalid {
+		_, err := utils.NormalizeAttributeValue(c.fieldType, c.value, c.options)
+		assert.Error(t, err)
+	}
+}
+
+func TestAssetAttributesUnmarshalJSON(t *testing.T) {
+	// Test 1: Should convert JSON value into text
+	var attributes entity.AssetAttributes
+	err := json.Unmarshal([]byte(`{"ram_gb": 16, "is_touch": true, "os": "Linux", "serial": null}`), &attributes)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.AssetAttributes{"ram_gb": "16", "is_touch": "true", "os": "Linux", "serial": ""}, attributes)
+
+	// Test 2: Should keep nil when attribute is null or missing, so the current attribute is kept
+	var asset entity.Asset
+	err = json.Unmarshal([]byte(`{"asset_name": "Laptop", "attributes": null}`), &asset)
+	assert.NoError(t, err)
+	assert.Nil(t, asset.Attributes)
+	err = json.Unmarshal([]byte(`{"asset_name": "Laptop"}`), &asset)
+	assert.NoError(t, err)
+	assert.Nil(t, asset.Attributes)
+
+	// Test 3: Should reject nested value
+	err = json.Unmarshal([]byte(`{"ports": ["usb"]}`), &attributes)
+	assert.Error(t, err)
+}
@@ -0,0 +1,54 @@
+package unit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"pelita/entity"
+	"pelita/middleware"
+	"pelita/utils"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthMiddlewareApiKeyReadHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	apiKey := &entity.ApiKey{ID: uuid.New(), ExpiredAt: time.Now().Add(time.Hour)}
+	revokedAt := time.Now()
+	revokedApiKey := &entity.ApiKey{ID: uuid.New(), ExpiredAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}
+
+	tests := []struct {
+		name        string
+		method      string
+		key         string
+		wantStatus  int
+		wantHistory []string
+	}{
+		{name: "read request", method: http.MethodGet, key: "valid-key", wantStatus: http.StatusOK, wantHistory: []string{"api_key_read /api/v1/assets/:id"}},
+		{name: "write request", method: http.MethodPut, key: "valid-key", wantStatus: http.StatusOK},
+		{name: "invalid api key", method: http.MethodGet, key: "invalid-key", wantStatus: http.StatusUnauthorized},
+		{name: "revoked api key", method: http.MethodGet, key: "revoked-key", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKeyRepo := &fakeApiKeyRepository{apiKeys: map[string]*entity.ApiKey{utils.HashToken("valid-key"): apiKey, utils.HashToken("revoked-key"): revokedApiKey}}
+
+			router := gin.New()
+			router.Handle(tt.method, "/api/v1/assets/:id", middleware.AuthMiddleware(nil, apiKeyRepo), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, "/api/v1/assets/"+uuid.NewString(), nil)
+			req.Header.Set("X-API-Key", tt.key)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantHistory, apiKeyRepo.histories)
+		})
+	}
+}
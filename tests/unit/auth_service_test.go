@@ -0,0 +1,239 @@
+package unit
+
+import (
+	"pelita/config"
+	"pelita/entity"
+	"pelita/service"
+	"pelita/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthServiceLoginLockAccountAfterMaxFailedAttempt(t *testing.T) {
+	// Test Data
+	user := &entity.User{ID: uuid.New(), Username: "tester", Email: "locked@example.com"}
+	assert.NoError(t, utils.HashPassword(user, "nopass123"))
+	historyRepo := &fakeHistoryRepository{}
+	authService := service.NewAuthService(
+		&fakeUserRepository{users: map[string]*entity.User{user.Email: user}},
+		&fakeAdminRepository{},
+		&fakeTechnicianRepository{},
+		newFakeSessionRepository(),
+		nil,
+		newFakeLoginAttemptRepository(),
+		historyRepo,
+		&utils.LogNotifier{},
+		nil,
+	)
+
+	// Test 1 : Every Attempt Before The Limit Only Fail The Password
+	for i := int64(1); i < config.LoginAttempt.MaxAttemptPerEmail; i++ {
+		_, err := authService.Login(user.Email, "wrongpass", "test", "10.0.0.1")
+		assert.EqualError(t, err, "invalid password")
+	}
+
+	// Test 2 : The Attempt That Reach The Limit Lock The Account
+	_, err := authService.Login(user.Email, "wrongpass", "test", "10.0.0.1")
+	assert.EqualError(t, err, "invalid password")
+
+	_, err = authService.Login(user.Email, "nopass123", "test", "10.0.0.1")
+	assert.ErrorIs(t, err, service.ErrAccountLocked)
+
+	// Test 3 : Failed And Locked Attempt Is Recorded In History
+	var failed, locked int
+	for _, history := range historyRepo.histories {
+		switch history.TypeHistory {
+		case "login_failed":
+			failed++
+		case "account_locked":
+			locked++
+		}
+	}
+	assert.Equal(t, int(config.LoginAttempt.MaxAttemptPerEmail), failed)
+	assert.Equal(t, 1, locked)
+}
+
+func TestAuthServiceLoginSharedIPAddressOnlyLockTheFailingAccount(t *testing.T) {
+	// Test Data
+	careless := &entity.User{ID: uuid.New(), Username: "careless", Email: "careless@example.com"}
+	assert.NoError(t, utils.HashPassword(careless, "nopass123"))
+	colleague := &entity.User{ID: uuid.New(), Username: "colleague", Email: "colleague@example.com", Password: careless.Password}
+	authService := service.NewAuthService(
+		&fakeUserRepository{users: map[string]*entity.User{careless.Email: careless, colleague.Email: colleague}},
+		&fakeAdminRepository{},
+		&fakeTechnicianRepository{},
+		newFakeSessionRepository(),
+		nil,
+		newFakeLoginAttemptRepository(),
+		&fakeHistoryRepository{},
+		&utils.LogNotifier{},
+		nil,
+	)
+	officeIP := "203.0.113.10"
+
+	// Test 1 : Lock One Account Behind The Shared IP Address
+	for i := int64(0); i < config.LoginAttempt.MaxAttemptPerEmail; i++ {
+		_, _ = authService.Login(careless.Email, "wrongpass", "test", officeIP)
+	}
+	_, err := authService.Login(careless.Email, "nopass123", "test", officeIP)
+	assert.ErrorIs(t, err, service.ErrAccountLocked)
+
+	// Test 2 : Other Account Behind The Same IP Address Can Still Login
+	loginData, err := authService.Login(colleague.Email, "nopass123", "test", officeIP)
+	assert.NoError(t, err)
+	assert.NotNil(t, loginData)
+}
+
+func TestAuthServiceLoginLockIPAddressOnCredentialStuffing(t *testing.T) {
+	// Test Data
+	user := &entity.User{ID: uuid.New(), Username: "tester", Email: "victim@example.com"}
+	assert.NoError(t, utils.HashPassword(user, "nopass123"))
+	authService := service.NewAuthService(
+		&fakeUserRepository{users: map[string]*entity.User{user.Email: user}},
+		&fakeAdminRepository{},
+		&fakeTechnicianRepository{},
+		newFakeSessionRepository(),
+		nil,
+		newFakeLoginAttemptRepository(),
+		&fakeHistoryRepository{},
+		&utils.LogNotifier{},
+		nil,
+	)
+	attackerIP := "198.51.100.7"
+
+	// Test 1 : Unregistered Email Attempt Count Toward The IP Address Limit
+	for i := int64(0); i < config.LoginAttempt.MaxAttemptPerIP; i++ {
+		_, err := authService.Login(uuid.NewString()+"@example.com", "guess", "test", attackerIP)
+		assert.EqualError(t, err, "account not found")
+	}
+
+	// Test 2 : The IP Address Is Locked Even For A Registered Email
+	_, err := authService.Login(user.Email, "nopass123", "test", attackerIP)
+	assert.ErrorIs(t, err, service.ErrAccountLocked)
+
+	// Test 3 : Other IP Address Is Not Affected
+	_, err = authService.Login(user.Email, "nopass123", "test", "10.0.0.2")
+	assert.NoError(t, err)
+}
+
+func TestAuthServiceLoginLockIPAddressOnRegisteredEmailStuffing(t *testing.T) {
+	// Test Data
+	maxAttemptPerIP := config.LoginAttempt.MaxAttemptPerIP
+	config.LoginAttempt.MaxAttemptPerIP = 2 * (config.LoginAttempt.MaxAttemptPerEmail - 1)
+	t.Cleanup(func() { config.LoginAttempt.MaxAttemptPerIP = maxAttemptPerIP })
+	first := &entity.User{ID: uuid.New(), Username: "first", Email: "first@example.com"}
+	assert.NoError(t, utils.HashPassword(first, "nopass123"))
+	second := &entity.User{ID: uuid.New(), Username: "second", Email: "second@example.com", Password: first.Password}
+	authService := service.NewAuthService(
+		&fakeUserRepository{users: map[string]*entity.User{first.Email: first, second.Email: second}},
+		&fakeAdminRepository{},
+		&fakeTechnicianRepository{},
+		newFakeSessionRepository(),
+		nil,
+		newFakeLoginAttemptRepository(),
+		&fakeHistoryRepository{},
+		&utils.LogNotifier{},
+		nil,
+	)
+	attackerIP := "198.51.100.8"
+
+	// Exec
+	for _, user := range []*entity.User{first, second} {
+		for i := int64(1); i < config.LoginAttempt.MaxAttemptPerEmail; i++ {
+			_, err := authService.Login(user.Email, "guess", "test", attackerIP)
+			assert.EqualError(t, err, "invalid password")
+		}
+	}
+
+	// Test 1 : No Single Account Reach Its Limit, But The IP Address Is Locked
+	_, err := authService.Login(first.Email, "nopass123", "test", attackerIP)
+	assert.ErrorIs(t, err, service.ErrAccountLocked)
+
+	// Test 2 : The Account Can Still Login From Another IP Address
+	_, err = authService.Login(first.Email, "nopass123", "test", "10.0.0.3")
+	assert.NoError(t, err)
+}
+
+func TestAuthServiceUnlockAccount(t *testing.T) {
+	// Test Data
+	user := &entity.User{ID: uuid.New(), Username: "tester", Email: "unlock@example.com"}
+	assert.NoError(t, utils.HashPassword(user, "nopass123"))
+	authService := service.NewAuthService(
+		&fakeUserRepository{users: map[string]*entity.User{user.Email: user}},
+		&fakeAdminRepository{},
+		&fakeTechnicianRepository{},
+		newFakeSessionRepository(),
+		nil,
+		newFakeLoginAttemptRepository(),
+		&fakeHistoryRepository{},
+		&utils.LogNotifier{},
+		nil,
+	)
+	for i := int64(0); i < config.LoginAttempt.MaxAttemptPerEmail; i++ {
+		_, _ = authService.Login(user.Email, "wrongpass", "test", "10.0.0.1")
+	}
+
+	tests := []struct {
+		name    string
+		email   string
+		wantErr string
+	}{
+		{name: "unknown account", email: "nobody@example.com", wantErr: "account not found"},
+		{name: "locked account", email: user.Email},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := authService.UnlockAccount(tt.email, "")
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	// Test : Unlocked Account Can Login Again
+	_, err := authService.Login(user.Email, "nopass123", "test", "10.0.0.1")
+	assert.NoError(t, err)
+}
+
+func TestAuthServiceRefreshTokenConcurrentReuse(t *testing.T) {
+	// Test Data
+	user := &entity.User{ID: uuid.New(), Username: "tester", Email: "refresh@example.com"}
+	assert.NoError(t, utils.HashPassword(user, "nopass123"))
+	sessionRepo := newFakeSessionRepository()
+	authService := service.NewAuthService(
+		&fakeUserRepository{users: map[string]*entity.User{user.Email: user}},
+		&fakeAdminRepository{},
+		&fakeTechnicianRepository{},
+		sessionRepo,
+		nil,
+		newFakeLoginAttemptRepository(),
+		&fakeHistoryRepository{},
+		&utils.LogNotifier{},
+		nil,
+	)
+	loginData, err := authService.Login(user.Email, "nopass123", "test", "10.0.0.1")
+	assert.NoError(t, err)
+
+	// Exec
+	var concurrentData *entity.LoginData
+	var concurrentErr error
+	sessionRepo.beforeRotate = func() {
+		concurrentData, concurrentErr = authService.RefreshToken(loginData.RefreshToken)
+	}
+	_, err = authService.RefreshToken(loginData.RefreshToken)
+
+	// Test 1 : Only One Of The Concurrent Refresh Win The Swap
+	assert.NoError(t, concurrentErr)
+	assert.NotNil(t, concurrentData)
+
+	// Test 2 : The Loser Is Treated As Reuse And The Whole Session Is Revoked
+	assert.EqualError(t, err, "refresh token has already been used")
+	assert.Empty(t, sessionRepo.sessions)
+	_, err = authService.RefreshToken(concurrentData.RefreshToken)
+	assert.EqualError(t, err, "invalid refresh token")
+}
@@ -0,0 +1,54 @@
+package unit
+
+import (
+	"bytes"
+	"image/png"
+	"pelita/entity"
+	"pelita/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateQRCode(t *testing.T) {
+	// Test 1: Should return a square png image
+	image, err := utils.GenerateQRCode("PLT-000001", 256)
+	assert.NoError(t, err)
+
+	decoded, err := png.Decode(bytes.NewReader(image))
+	assert.NoError(t, err)
+	assert.Equal(t, 256, decoded.Bounds().Dx())
+	assert.Equal(t, 256, decoded.Bounds().Dy())
+}
+
+func TestGenerateCode128(t *testing.T) {
+	// Test 1: Should return a png image with the requested size
+	image, err := utils.GenerateCode128("PLT-000001", 300, 80)
+	assert.NoError(t, err)
+
+	decoded, err := png.Decode(bytes.NewReader(image))
+	assert.NoError(t, err)
+	assert.Equal(t, 300, decoded.Bounds().Dx())
+	assert.Equal(t, 80, decoded.Bounds().Dy())
+
+	// Test 2: Should widen the image when the requested width is too narrow for the content
+	image, err = utils.GenerateCode128("PLT-000001", 10, 80)
+	assert.NoError(t, err)
+
+	decoded, err = png.Decode(bytes.NewReader(image))
+	assert.NoError(t, err)
+	assert.Greater(t, decoded.Bounds().Dx(), 10)
+}
+
+func TestGeneratePDFAssetTagLabel(t *testing.T) {
+	// Test 1: Should render a pdf document for every label
+	labels := []entity.AssetTagLabel{
+		{Tag: "PLT-000001", AssetName: "Monitor", AssetCategory: "Electronic", RoomName: "Meeting", Floor: "1"},
+		{Tag: "PLT-000002", AssetName: "Monitor", AssetCategory: "Electronic", RoomName: "Meeting", Floor: "1"},
+	}
+
+	var buf bytes.Buffer
+	err := utils.GeneratePDFAssetTagLabel(labels, &buf)
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF")))
+}
@@ -0,0 +1,26 @@
+package unit
+
+import (
+	"pelita/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeCategoryKey(t *testing.T) {
+	// Test 1: Should ignore case and extra spacing
+	assert.Equal(t, "laptop", utils.NormalizeCategoryKey("Laptop"))
+	assert.Equal(t, "network switch", utils.NormalizeCategoryKey("  Network   SWITCH "))
+}
+
+func TestSingularCategoryKey(t *testing.T) {
+	// Test 1: Should return the singular candidate of a plural key
+	assert.Equal(t, []string{"laptop"}, utils.SingularCategoryKey("laptops"))
+	assert.Equal(t, []string{"battery"}, utils.SingularCategoryKey("batteries"))
+	assert.Equal(t, []string{"box", "boxe"}, utils.SingularCategoryKey("boxes"))
+	assert.Equal(t, []string{"cabl", "cable"}, utils.SingularCategoryKey("cables"))
+
+	// Test 2: Should not return any candidate for a singular key
+	assert.Nil(t, utils.SingularCategoryKey("glass"))
+	assert.Nil(t, utils.SingularCategoryKey("monitor"))
+}
@@ -0,0 +1,165 @@
+package unit
+
+import (
+	"pelita/entity"
+	"pelita/service"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteConfirmationHardDeleteById(t *testing.T) {
+	// Test Data
+	deletedAt := time.Now()
+	asset := &entity.Asset{ID: uuid.New(), AssetName: "Laptop", DeletedAt: &deletedAt}
+	assetRepo := &fakeAssetRepository{
+		assets: map[uuid.UUID]*entity.Asset{asset.ID: asset},
+		dependencies: map[uuid.UUID][]entity.DeleteDependency{
+			asset.ID: {{RecordTable: "asset_placements", Total: 2, RecordIds: []string{"b", "a"}}},
+		},
+	}
+	assetService := service.NewAssetService(assetRepo, nil, nil, nil, newFakeDeleteConfirmationRepository())
+	accountId := uuid.New()
+
+	// Exec
+	preview, err := assetService.GetDeletePreviewById(asset.ID, accountId)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, preview.ConfirmationToken)
+
+	// Test 1 : Token From The Preview Should Delete The Asset
+	err = assetService.HardDeleteById(asset.ID, accountId, preview.ConfirmationToken)
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{asset.ID}, assetRepo.hardDeleted)
+
+	// Test 2 : Token Can't Be Reused
+	err = assetService.HardDeleteById(asset.ID, accountId, preview.ConfirmationToken)
+	assert.EqualError(t, err, "invalid or expired confirmation token")
+	assert.Len(t, assetRepo.hardDeleted, 1)
+}
+
+func TestDeleteConfirmationHardDeleteByIdIgnoreRecordIdOrder(t *testing.T) {
+	// Test Data
+	deletedAt := time.Now()
+	asset := &entity.Asset{ID: uuid.New(), AssetName: "Laptop", DeletedAt: &deletedAt}
+	assetRepo := &fakeAssetRepository{
+		assets: map[uuid.UUID]*entity.Asset{asset.ID: asset},
+		dependencies: map[uuid.UUID][]entity.DeleteDependency{
+			asset.ID: {{RecordTable: "asset_placements", Total: 2, RecordIds: []string{"b", "a"}}},
+		},
+	}
+	assetService := service.NewAssetService(assetRepo, nil, nil, nil, newFakeDeleteConfirmationRepository())
+	accountId := uuid.New()
+
+	// Exec
+	preview, err := assetService.GetDeletePreviewById(asset.ID, accountId)
+	assert.NoError(t, err)
+	assetRepo.dependencies[asset.ID] = []entity.DeleteDependency{{RecordTable: "asset_placements", Total: 2, RecordIds: []string{"a", "b"}}}
+
+	// Test : Fingerprint Should Not Depend On The Order Of The Record Id
+	err = assetService.HardDeleteById(asset.ID, accountId, preview.ConfirmationToken)
+	assert.NoError(t, err)
+}
+
+func TestDeleteConfirmationHardDeleteByIdRejectInvalidToken(t *testing.T) {
+	tests := []struct {
+		name            string
+		token           func(token string) string
+		otherAccount    bool
+		otherRecord     bool
+		extraDependency []entity.DeleteDependency
+		wantErr         string
+	}{
+		{
+			name:    "missing token",
+			token:   func(token string) string { return "" },
+			wantErr: "confirmation token is required, request a delete preview first",
+		},
+		{
+			name:    "unknown token",
+			token:   func(token string) string { return "unknown" },
+			wantErr: "invalid or expired confirmation token",
+		},
+		{
+			name:         "wrong account",
+			otherAccount: true,
+			wantErr:      "invalid or expired confirmation token",
+		},
+		{
+			name:        "wrong record",
+			otherRecord: true,
+			wantErr:     "invalid or expired confirmation token",
+		},
+		{
+			name:            "changed fingerprint",
+			extraDependency: []entity.DeleteDependency{{RecordTable: "asset_images", Total: 1, RecordIds: []string{"d"}}},
+			wantErr:         "dependency has changed since the preview, request a new delete preview",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Test Data
+			deletedAt := time.Now()
+			asset := &entity.Asset{ID: uuid.New(), AssetName: "Laptop", DeletedAt: &deletedAt}
+			other := &entity.Asset{ID: uuid.New(), AssetName: "Printer", DeletedAt: &deletedAt}
+			assetRepo := &fakeAssetRepository{
+				assets: map[uuid.UUID]*entity.Asset{asset.ID: asset, other.ID: other},
+				dependencies: map[uuid.UUID][]entity.DeleteDependency{
+					asset.ID: {{RecordTable: "asset_placements", Total: 2, RecordIds: []string{"b", "a"}}},
+					other.ID: {{RecordTable: "asset_placements", Total: 1, RecordIds: []string{"c"}}},
+				},
+			}
+			assetService := service.NewAssetService(assetRepo, nil, nil, nil, newFakeDeleteConfirmationRepository())
+			accountId := uuid.New()
+
+			preview, err := assetService.GetDeletePreviewById(asset.ID, accountId)
+			assert.NoError(t, err)
+
+			token := preview.ConfirmationToken
+			if tt.token != nil {
+				token = tt.token(token)
+			}
+			if tt.otherAccount {
+				accountId = uuid.New()
+			}
+			if tt.otherRecord {
+				asset = other
+			}
+			assetRepo.dependencies[asset.ID] = append(assetRepo.dependencies[asset.ID], tt.extraDependency...)
+
+			// Exec
+			err = assetService.HardDeleteById(asset.ID, accountId, token)
+
+			// Test : Invalid Token Is Rejected Without Deleting Anything
+			assert.EqualError(t, err, tt.wantErr)
+			assert.Empty(t, assetRepo.hardDeleted)
+		})
+	}
+}
+
+func TestDeleteConfirmationHardDeleteByIdConsumeRejectedToken(t *testing.T) {
+	// Test Data
+	deletedAt := time.Now()
+	asset := &entity.Asset{ID: uuid.New(), AssetName: "Laptop", DeletedAt: &deletedAt}
+	assetRepo := &fakeAssetRepository{
+		assets: map[uuid.UUID]*entity.Asset{asset.ID: asset},
+		dependencies: map[uuid.UUID][]entity.DeleteDependency{
+			asset.ID: {{RecordTable: "asset_placements", Total: 2, RecordIds: []string{"b", "a"}}},
+		},
+	}
+	assetService := service.NewAssetService(assetRepo, nil, nil, nil, newFakeDeleteConfirmationRepository())
+	accountId := uuid.New()
+
+	// Exec
+	preview, err := assetService.GetDeletePreviewById(asset.ID, accountId)
+	assert.NoError(t, err)
+	err = assetService.HardDeleteById(asset.ID, uuid.New(), preview.ConfirmationToken)
+	assert.Error(t, err)
+
+	// Test : Token Tried By Another Account Can't Be Used By The Requester Anymore
+	err = assetService.HardDeleteById(asset.ID, accountId, preview.ConfirmationToken)
+	assert.EqualError(t, err, "invalid or expired confirmation token")
+	assert.Empty(t, assetRepo.hardDeleted)
+}
@@ -0,0 +1,51 @@
+package unit
+
+import (
+	"pelita/entity"
+	"pelita/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateBookValueStraightLine(t *testing.T) {
+	purchaseDate := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	// Test 1: Should keep the price before a full month has passed
+	value := utils.CalculateBookValue(entity.Money(1200000000), 0, purchaseDate, 4, "straight-line", time.Date(2024, 2, 14, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, entity.Money(1200000000), value)
+
+	// Test 2: Should depreciate evenly per month toward the salvage value
+	value = utils.CalculateBookValue(entity.Money(1200000000), entity.Money(240000000), purchaseDate, 4, "straight-line", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, entity.Money(720000000), value)
+
+	// Test 3: Should stop at the salvage value once the useful life is over
+	value = utils.CalculateBookValue(entity.Money(1200000000), entity.Money(240000000), purchaseDate, 4, "straight-line", time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, entity.Money(240000000), value)
+}
+
+func TestCalculateBookValueDecliningBalance(t *testing.T) {
+	purchaseDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Test 1: Should depreciate twice the straight line rate from the remaining value
+	value := utils.CalculateBookValue(entity.Money(1000000000), 0, purchaseDate, 5, "declining-balance", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, entity.Money(360000000), value)
+
+	// Test 2: Should prorate the partial year
+	value = utils.CalculateBookValue(entity.Money(1000000000), 0, purchaseDate, 5, "declining-balance", time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, entity.Money(800000000), value)
+
+	// Test 3: Should never drop below the salvage value
+	value = utils.CalculateBookValue(entity.Money(1000000000), entity.Money(300000000), purchaseDate, 5, "declining-balance", time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, entity.Money(300000000), value)
+}
+
+func TestCalculateBookValueRoundMinorUnit(t *testing.T) {
+	purchaseDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Test : Should round the depreciation to the nearest minor unit
+	value := utils.CalculateBookValue(entity.Money(1000000), 0, purchaseDate, 3, "straight-line", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, entity.Money(972222), value)
+	assert.Equal(t, "9722.22", value.String())
+}
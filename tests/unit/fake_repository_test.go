@@ -0,0 +1,446 @@
+package unit
+
+import (
+	"errors"
+	"pelita/entity"
+	"pelita/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Fake Repository Embed The Interface, So Only The Method Used By The Service Under Test Is Implemented
+
+type fakeAdminRepository struct {
+	repository.AdminRepository
+	admins map[string]*entity.Admin
+}
+
+func (r *fakeAdminRepository) FindByEmail(email string) (*entity.Admin, error) {
+	return r.admins[email], nil
+}
+
+type fakeTechnicianRepository struct {
+	repository.TechnicianRepository
+	technicians map[string]*entity.Technician
+}
+
+func (r *fakeTechnicianRepository) FindByEmail(email string) (*entity.Technician, error) {
+	return r.technicians[email], nil
+}
+
+type fakeUserRepository struct {
+	repository.UserRepository
+	users map[string]*entity.User
+}
+
+func (r *fakeUserRepository) FindByEmail(email string) (*entity.User, error) {
+	return r.users[email], nil
+}
+
+func (r *fakeUserRepository) FindAccountById(id uuid.UUID, role string) (entity.Account, error) {
+	for _, user := range r.users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *fakeUserRepository) IsEmailUsedByOtherAccount(email string, id uuid.UUID) (bool, error) {
+	user, ok := r.users[email]
+	return ok && user.ID != id, nil
+}
+
+func (r *fakeUserRepository) UpdateProfileById(id uuid.UUID, role, username string, telegramUserId *string, telegramIsValid bool) error {
+	for _, user := range r.users {
+		if user.ID == id {
+			user.Username = username
+			user.TelegramUserId = telegramUserId
+			user.TelegramIsValid = telegramIsValid
+			return nil
+		}
+	}
+
+	return errors.New("account not found")
+}
+
+func (r *fakeUserRepository) UpdateEmailById(id uuid.UUID, role, email string) error {
+	for oldEmail, user := range r.users {
+		if user.ID == id {
+			delete(r.users, oldEmail)
+			user.Email = email
+			r.users[email] = user
+			return nil
+		}
+	}
+
+	return errors.New("account not found")
+}
+
+func (r *fakeUserRepository) LinkTelegramById(id uuid.UUID, role, telegramUserId string) error {
+	for _, user := range r.users {
+		if user.ID == id {
+			user.TelegramUserId = &telegramUserId
+			user.TelegramIsValid = true
+			return nil
+		}
+	}
+
+	return errors.New("account not found")
+}
+
+type fakeHistoryRepository struct {
+	repository.HistoryRepository
+	histories []entity.History
+}
+
+func (r *fakeHistoryRepository) Create(history *entity.History) error {
+	r.histories = append(r.histories, *history)
+	return nil
+}
+
+type fakeSessionRepository struct {
+	repository.SessionRepository
+	sessions      []entity.Session
+	refreshTokens map[string]string
+	beforeRotate  func()
+}
+
+func newFakeSessionRepository() *fakeSessionRepository {
+	return &fakeSessionRepository{refreshTokens: map[string]string{}}
+}
+
+func (r *fakeSessionRepository) Create(session *entity.Session, refreshTokenHash string, ttl time.Duration) error {
+	r.sessions = append(r.sessions, *session)
+	r.refreshTokens[refreshTokenHash] = session.ID.String()
+	return nil
+}
+
+func (r *fakeSessionRepository) FindByRefreshTokenHash(refreshTokenHash string) (*entity.Session, bool, error) {
+	val, ok := r.refreshTokens[refreshTokenHash]
+	if !ok {
+		return nil, false, nil
+	}
+	for _, session := range r.sessions {
+		if session.ID.String() == strings.TrimPrefix(val, "rotated:") {
+			return &session, strings.HasPrefix(val, "rotated:"), nil
+		}
+	}
+	return nil, false, nil
+}
+
+func (r *fakeSessionRepository) Rotate(session *entity.Session, oldRefreshTokenHash, newRefreshTokenHash string, ttl time.Duration) (bool, error) {
+	// Concurrent Refresh Is Run Between The Lookup And The Swap
+	if beforeRotate := r.beforeRotate; beforeRotate != nil {
+		r.beforeRotate = nil
+		beforeRotate()
+	}
+	if r.refreshTokens[oldRefreshTokenHash] != session.ID.String() {
+		return false, nil
+	}
+	r.refreshTokens[oldRefreshTokenHash] = "rotated:" + session.ID.String()
+	r.refreshTokens[newRefreshTokenHash] = session.ID.String()
+	return true, nil
+}
+
+func (r *fakeSessionRepository) DeleteById(id uuid.UUID) error {
+	for i, session := range r.sessions {
+		if session.ID == id {
+			r.sessions = append(r.sessions[:i], r.sessions[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("session not found")
+}
+
+func (r *fakeSessionRepository) DeleteByAccountId(accountId uuid.UUID) error {
+	return nil
+}
+
+func (r *fakeSessionRepository) BlacklistAccountById(accountId uuid.UUID, ttl time.Duration) error {
+	return nil
+}
+
+type fakeLoginAttemptRepository struct {
+	attempts map[string]int64
+	locks    map[string]time.Duration
+}
+
+func newFakeLoginAttemptRepository() *fakeLoginAttemptRepository {
+	return &fakeLoginAttemptRepository{attempts: map[string]int64{}, locks: map[string]time.Duration{}}
+}
+
+func (r *fakeLoginAttemptRepository) IncrementFailed(target string, window time.Duration) (int64, error) {
+	r.attempts[target]++
+	return r.attempts[target], nil
+}
+
+func (r *fakeLoginAttemptRepository) FindLockDuration(target string) (time.Duration, error) {
+	return r.locks[target], nil
+}
+
+func (r *fakeLoginAttemptRepository) Lock(target string, baseDuration, maxDuration time.Duration) (time.Duration, error) {
+	r.locks[target] = baseDuration
+	delete(r.attempts, target)
+	return baseDuration, nil
+}
+
+func (r *fakeLoginAttemptRepository) Reset(target string) error {
+	delete(r.attempts, target)
+	return nil
+}
+
+func (r *fakeLoginAttemptRepository) Unlock(target string) error {
+	delete(r.attempts, target)
+	delete(r.locks, target)
+	return nil
+}
+
+type fakeTelegramLinkRepository struct {
+	links map[string]entity.TelegramLink
+}
+
+func newFakeTelegramLinkRepository() *fakeTelegramLinkRepository {
+	return &fakeTelegramLinkRepository{links: map[string]entity.TelegramLink{}}
+}
+
+func (r *fakeTelegramLinkRepository) Create(telegramLink *entity.TelegramLink, code string, ttl time.Duration) error {
+	r.links[code] = *telegramLink
+	return nil
+}
+
+func (r *fakeTelegramLinkRepository) ConsumeByCode(code string) (*entity.TelegramLink, error) {
+	telegramLink, ok := r.links[code]
+	if !ok {
+		return nil, nil
+	}
+	delete(r.links, code)
+
+	return &telegramLink, nil
+}
+
+type fakeEmailChangeRepository struct {
+	emailChanges map[string]entity.EmailChange
+}
+
+func newFakeEmailChangeRepository() *fakeEmailChangeRepository {
+	return &fakeEmailChangeRepository{emailChanges: map[string]entity.EmailChange{}}
+}
+
+func (r *fakeEmailChangeRepository) Create(emailChange *entity.EmailChange, tokenHash string, ttl time.Duration) error {
+	r.emailChanges[tokenHash] = *emailChange
+	return nil
+}
+
+func (r *fakeEmailChangeRepository) ConsumeByTokenHash(tokenHash string) (*entity.EmailChange, error) {
+	emailChange, ok := r.emailChanges[tokenHash]
+	if !ok {
+		return nil, nil
+	}
+	delete(r.emailChanges, tokenHash)
+
+	return &emailChange, nil
+}
+
+// Fake Mailer Record Every Email Instead Of Sending It
+type fakeMailer struct {
+	emails []fakeEmail
+}
+
+type fakeEmail struct {
+	to   string
+	body string
+}
+
+func (m *fakeMailer) Send(to, subject, body string) error {
+	m.emails = append(m.emails, fakeEmail{to: to, body: body})
+	return nil
+}
+
+type fakeApiKeyRepository struct {
+	repository.ApiKeyRepository
+	apiKeys   map[string]*entity.ApiKey
+	histories []string
+}
+
+func (r *fakeApiKeyRepository) FindByKeyHash(keyHash string) (*entity.ApiKey, error) {
+	return r.apiKeys[keyHash], nil
+}
+
+func (r *fakeApiKeyRepository) UpdateLastUsedAtById(lastUsedAt time.Time, id uuid.UUID) error {
+	return nil
+}
+
+func (r *fakeApiKeyRepository) CreateHistoryById(typeHistory string, id uuid.UUID) error {
+	r.histories = append(r.histories, typeHistory)
+	return nil
+}
+
+type fakeAssetRepository struct {
+	repository.AssetRepository
+	assets       map[uuid.UUID]*entity.Asset
+	dependencies map[uuid.UUID][]entity.DeleteDependency
+	hardDeleted  []uuid.UUID
+}
+
+func (r *fakeAssetRepository) FindById(id uuid.UUID) (*entity.Asset, error) {
+	return r.assets[id], nil
+}
+
+func (r *fakeAssetRepository) FindByAssetPlacementId(assetPlacementId uuid.UUID) (*entity.Asset, error) {
+	return r.assets[assetPlacementId], nil
+}
+
+func (r *fakeAssetRepository) FindAllByAssetCategoryId(assetCategoryId uuid.UUID) ([]entity.Asset, error) {
+	assets := []entity.Asset{}
+	for _, dt := range r.assets {
+		if dt.AssetCategoryId != nil && *dt.AssetCategoryId == assetCategoryId {
+			assets = append(assets, *dt)
+		}
+	}
+	return assets, nil
+}
+
+func (r *fakeAssetRepository) FindDeleteDependencyById(id uuid.UUID) ([]entity.DeleteDependency, error) {
+	return r.dependencies[id], nil
+}
+
+func (r *fakeAssetRepository) FindAllStoredFileByDeletedId(id uuid.UUID) ([]string, error) {
+	return nil, nil
+}
+
+func (r *fakeAssetRepository) HardDeleteById(id, archivedBy uuid.UUID) error {
+	r.hardDeleted = append(r.hardDeleted, id)
+	return nil
+}
+
+type fakeDeleteConfirmationRepository struct {
+	repository.DeleteConfirmationRepository
+	deleteConfirmations map[string]entity.DeleteConfirmation
+}
+
+func newFakeDeleteConfirmationRepository() *fakeDeleteConfirmationRepository {
+	return &fakeDeleteConfirmationRepository{deleteConfirmations: map[string]entity.DeleteConfirmation{}}
+}
+
+func (r *fakeDeleteConfirmationRepository) Create(deleteConfirmation *entity.DeleteConfirmation, tokenHash string, ttl time.Duration) error {
+	r.deleteConfirmations[tokenHash] = *deleteConfirmation
+	return nil
+}
+
+func (r *fakeDeleteConfirmationRepository) ConsumeByTokenHash(tokenHash string) (*entity.DeleteConfirmation, error) {
+	deleteConfirmation, ok := r.deleteConfirmations[tokenHash]
+	if !ok {
+		return nil, nil
+	}
+	delete(r.deleteConfirmations, tokenHash)
+	return &deleteConfirmation, nil
+}
+
+type fakeRoomRepository struct {
+	repository.RoomRepository
+	rooms map[string]*entity.Room
+}
+
+func (r *fakeRoomRepository) FindByRoomNameAndFloor(roomName, floor string) (*entity.Room, error) {
+	return r.rooms[floor+"|"+roomName], nil
+}
+
+type fakeAssetCategoryRepository struct {
+	repository.AssetCategoryRepository
+	assetCategories   map[string]*entity.AssetCategory
+	assetIds          []uuid.UUID
+	attributes        []entity.AssetAttributeValue
+	changedAttributes []entity.AssetAttributeValue
+	parentOf          map[uuid.UUID]*uuid.UUID // Parent Seen Under Lock, May Differ From The Category Read Before
+}
+
+func (r *fakeAssetCategoryRepository) FindAll() ([]entity.AssetCategory, error) {
+	assetCategories := []entity.AssetCategory{}
+	for _, assetCategory := range r.assetCategories {
+		assetCategories = append(assetCategories, *assetCategory)
+	}
+	return assetCategories, nil
+}
+
+func (r *fakeAssetCategoryRepository) FindByCategoryName(categoryName string) (*entity.AssetCategory, error) {
+	return r.assetCategories[categoryName], nil
+}
+
+func (r *fakeAssetCategoryRepository) FindByCategoryNameAndId(categoryName string, id uuid.UUID) (*entity.AssetCategory, error) {
+	return nil, nil
+}
+
+func (r *fakeAssetCategoryRepository) UpdateById(assetCategory *entity.AssetCategory, id uuid.UUID, validateParent func(parentOf map[uuid.UUID]*uuid.UUID) error, validateAttribute func(assetIds []uuid.UUID, attributes []entity.AssetAttributeValue) ([]entity.AssetAttributeValue, error)) error {
+	if err := validateParent(r.parentOf); err != nil {
+		return err
+	}
+	changed, err := validateAttribute(r.assetIds, r.attributes)
+	if err != nil {
+		return err
+	}
+	r.changedAttributes = changed
+	return nil
+}
+
+type fakeAssetPlacementRepository struct {
+	repository.AssetPlacementRepository
+	assetPlacements map[uuid.UUID]*entity.AssetPlacement
+}
+
+func (r *fakeAssetPlacementRepository) FindById(id uuid.UUID) (*entity.AssetPlacement, error) {
+	return r.assetPlacements[id], nil
+}
+
+type fakeAssetLoanRepository struct {
+	repository.AssetLoanRepository
+	assetLoans      map[uuid.UUID]*entity.AssetLoan
+	totalActive     int64
+	asset           *entity.Asset
+	totalCheckedOut int64
+	history         *entity.AssetStatusHistory
+}
+
+func (r *fakeAssetLoanRepository) FindById(id uuid.UUID) (*entity.AssetLoan, error) {
+	return r.assetLoans[id], nil
+}
+
+func (r *fakeAssetLoanRepository) CountActiveByAssetPlacementId(assetPlacementId uuid.UUID) (int64, error) {
+	return r.totalActive, nil
+}
+
+func (r *fakeAssetLoanRepository) Create(assetLoan *entity.AssetLoan) error {
+	assetLoan.ID = uuid.New()
+	assetLoan.LoanStatus = "requested"
+	r.assetLoans[assetLoan.ID] = assetLoan
+	return nil
+}
+
+func (r *fakeAssetLoanRepository) ApproveById(adminId, id uuid.UUID) error {
+	r.assetLoans[id].LoanStatus = "approved"
+	r.assetLoans[id].ApprovedBy = &adminId
+	return nil
+}
+
+func (r *fakeAssetLoanRepository) CheckOutById(id uuid.UUID, resolveHistory func(asset *entity.Asset) (*entity.AssetStatusHistory, error)) error {
+	history, err := resolveHistory(r.asset)
+	if err != nil {
+		return err
+	}
+	r.assetLoans[id].LoanStatus = "checked-out"
+	r.history = history
+	return nil
+}
+
+func (r *fakeAssetLoanRepository) ReturnById(returnCondition string, returnNotes *string, id uuid.UUID, resolveHistory func(asset *entity.Asset, totalCheckedOut int64) (*entity.AssetStatusHistory, error)) error {
+	history, err := resolveHistory(r.asset, r.totalCheckedOut)
+	if err != nil {
+		return err
+	}
+	r.assetLoans[id].LoanStatus = "returned"
+	r.history = history
+	return nil
+}
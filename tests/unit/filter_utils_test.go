@@ -0,0 +1,31 @@
+package unit
+
+import (
+	"net/http/httptest"
+	"pelita/utils"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Test 1: Should split search, sort, and filter param
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/assets?page=2&limit=5&search=%20laptop%20&sort_by=asset_price&sort_dir=ASC&category=Laptop&floor=", nil)
+	filter := utils.GetFilter(c)
+	assert.Equal(t, "laptop", filter.Search)
+	assert.Equal(t, "asset_price", filter.SortBy)
+	assert.Equal(t, "asc", filter.SortDir)
+	assert.Equal(t, map[string]string{"category": "Laptop"}, filter.Params, "pagination, search, sort, and empty param should not be a filter")
+
+	// Test 2: Should default sort direction to desc
+	c, _ = gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/assets", nil)
+	filter = utils.GetFilter(c)
+	assert.Equal(t, "", filter.SortBy)
+	assert.Equal(t, "desc", filter.SortDir)
+	assert.Empty(t, filter.Params)
+}
@@ -0,0 +1,98 @@
+package unit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"pelita/config"
+	"pelita/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// JPEG With An APP1 Exif Segment Holding Only The Orientation Tag
+func buildJPEGWithOrientation(t *testing.T, width, height int, orientation uint16) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	var buf bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&buf, img, nil))
+	data := buf.Bytes()
+
+	tiff := []byte{'M', 'M', 0, 42, 0, 0, 0, 8, 0, 1}
+	entry := make([]byte, 12)
+	binary.BigEndian.PutUint16(entry[0:], 0x0112)
+	binary.BigEndian.PutUint16(entry[2:], 3)
+	binary.BigEndian.PutUint32(entry[4:], 1)
+	binary.BigEndian.PutUint16(entry[8:], orientation)
+	tiff = append(tiff, entry...)
+	tiff = append(tiff, 0, 0, 0, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	result := append([]byte{}, data[:2]...)
+	result = append(result, app1...)
+	return append(result, data[2:]...)
+}
+
+func TestProcessImageJPEG(t *testing.T) {
+	data := buildJPEGWithOrientation(t, 800, 400, 6)
+
+	// Test 1: Should rotate by the orientation and drop the EXIF
+	result, err := utils.ProcessImage(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, "jpg", result.FileExt)
+	assert.False(t, bytes.Contains(result.Image, []byte("Exif")), "exif should be stripped")
+
+	img, err := jpeg.Decode(bytes.NewReader(result.Image))
+	assert.NoError(t, err)
+	assert.Equal(t, 400, img.Bounds().Dx())
+	assert.Equal(t, 800, img.Bounds().Dy())
+
+	// Test 2: Should scale the thumbnail down to the longest side
+	thumbnail, err := jpeg.Decode(bytes.NewReader(result.Thumbnail))
+	assert.NoError(t, err)
+	assert.Equal(t, config.ConfigFile.ThumbnailSize/2, thumbnail.Bounds().Dx())
+	assert.Equal(t, config.ConfigFile.ThumbnailSize, thumbnail.Bounds().Dy())
+}
+
+func TestProcessImagePNG(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 100, 50))
+	img.Set(0, 0, color.NRGBA{R: 255, A: 128})
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+
+	// Test 1: Should keep png and not upscale a small thumbnail
+	result, err := utils.ProcessImage(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "png", result.FileExt)
+	thumbnail, err := png.Decode(bytes.NewReader(result.Thumbnail))
+	assert.NoError(t, err)
+	assert.Equal(t, 100, thumbnail.Bounds().Dx())
+
+	// Test 2: Should reject a file that is not an image
+	_, err = utils.ProcessImage(bytes.NewReader([]byte("not an image")))
+	assert.Error(t, err)
+}
+
+func TestProcessImageRejectHugeResolution(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+
+	// Header Is Rewritten To Declare 100000 x 100000 Pixel, The Pixel Data Is Never Reached
+	data := buf.Bytes()
+	binary.BigEndian.PutUint32(data[16:], 100000)
+	binary.BigEndian.PutUint32(data[20:], 100000)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+
+	// Test : Should reject the image from its header
+	_, err := utils.ProcessImage(bytes.NewReader(data))
+	assert.ErrorContains(t, err, "image resolution must be at most")
+}
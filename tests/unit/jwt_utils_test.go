@@ -54,3 +54,55 @@ func TestGenerateAndValidateToken(t *testing.T) {
 	// Test 3: Parsed User ID from token should be same with raw
 	assert.Equal(t, userID, parsedUserID, "parsed user ID should match original user ID")
 }
+
+func TestGenerateSessionToken(t *testing.T) {
+	// Test Data
+	userID := uuid.New()
+	sessionID := uuid.New()
+	role := "technician"
+
+	// Exec
+	token, err := utils.GenerateSessionToken(userID, role, sessionID)
+
+	// Test 1 : Not returned an error and not empty value
+	assert.NoError(t, err, "token generation should not return error")
+	assert.NotEmpty(t, token, "token should not be empty")
+
+	// Test 2: Token should be valid and carry the same user id
+	parsedUserID, err := middleware.ValidateToken(token)
+	assert.NoError(t, err, "token should be valid")
+	assert.Equal(t, userID, parsedUserID, "parsed user ID should match original user ID")
+}
+
+func TestGenerateRefreshTokenAndHashToken(t *testing.T) {
+	// Exec
+	token1, err := utils.GenerateRefreshToken()
+	assert.NoError(t, err, "refresh token generation should not return error")
+	token2, err := utils.GenerateRefreshToken()
+	assert.NoError(t, err, "refresh token generation should not return error")
+
+	// Test 1 : Refresh token should be 64 hex characters and unique
+	assert.Len(t, token1, 64, "refresh token should be 64 characters")
+	assert.NotEqual(t, token1, token2, "refresh token should be unique")
+
+	// Test 2 : Hash should be deterministic and different from raw token
+	assert.Equal(t, utils.HashToken(token1), utils.HashToken(token1), "hash should be deterministic")
+	assert.NotEqual(t, token1, utils.HashToken(token1), "hash should not equal raw token")
+	assert.NotEqual(t, utils.HashToken(token1), utils.HashToken(token2), "different token should have different hash")
+}
+
+func TestGeneratePasswordHash(t *testing.T) {
+	// Test Data
+	rawPassword := "nopass123"
+
+	// Exec
+	hashedPassword, err := utils.GeneratePasswordHash(rawPassword)
+
+	// Test 1 : Not returned an error and have different char after hash
+	assert.NoError(t, err, "hashing password should not return an error")
+	assert.NotEqual(t, rawPassword, hashedPassword, "hashed password should not equal raw password")
+
+	// Test 2 : Hashed password should match original
+	err = bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(rawPassword))
+	assert.NoError(t, err, "hashed password should match original password")
+}
@@ -0,0 +1,70 @@
+package unit
+
+import (
+	"encoding/json"
+	"pelita/entity"
+	"pelita/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    entity.Money
+		wantErr bool
+	}{
+		{value: "1500000", want: 150000000},
+		{value: "1500000.5", want: 150000050},
+		{value: "1500000.50", want: 150000050},
+		{value: "1.000.000", want: 100000000},
+		{value: "1,000,000", want: 100000000},
+		{value: "1.000", want: 100000},
+		{value: "Rp 10000", want: 1000000},
+		{value: "Rp. 10.000,-", want: 1000000},
+		{value: "IDR 2.500.000", want: 250000000},
+		{value: "$ 10,000.50", want: 1000050},
+		{value: "10.000,50", want: 1000050},
+		{value: "12,5", want: 1250},
+		{value: "250000 IDR", want: 25000000},
+		{value: "-1.000", want: -100000},
+		{value: "", wantErr: true},
+		{value: "abc", wantErr: true},
+		{value: "12a3", wantErr: true},
+		{value: "1.0000", wantErr: true},
+		{value: "1.2.3", wantErr: true},
+		{value: "10,000.505", wantErr: true},
+		{value: "1.000,00.5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			money, err := utils.ParseMoney(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, money)
+		})
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	// Test 1: Should be written as a decimal number with 2 fraction digit
+	body, err := json.Marshal(map[string]entity.Money{"asset_price": 150000050})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"asset_price": 1500000.50}`, string(body))
+
+	// Test 2: Should read a number without losing the minor unit
+	var req entity.RequestUpdateAssetById
+	err = json.Unmarshal([]byte(`{"asset_price": 0.29, "salvage_value": "10"}`), &req)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.Money(29), req.AssetPrice)
+	assert.Equal(t, entity.Money(1000), req.SalvageValue)
+
+	// Test 3: Should reject more than 2 fraction digit
+	err = json.Unmarshal([]byte(`{"asset_price": 0.295}`), &req)
+	assert.Error(t, err)
+}
@@ -0,0 +1,109 @@
+package unit
+
+import (
+	"pelita/routes"
+	"pelita/scheduler"
+	"pelita/service"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron"
+	"github.com/stretchr/testify/assert"
+)
+
+// Fake Service, Only Record Which Table Is Purged
+type fakePurgeAssetService struct {
+	service.AssetService
+	purged *[]string
+}
+
+func (s fakePurgeAssetService) PurgeDeleted(before time.Time) (int, error) {
+	*s.purged = append(*s.purged, "asset")
+	return 0, nil
+}
+
+type fakePurgeRoomService struct {
+	service.RoomService
+	purged *[]string
+}
+
+func (s fakePurgeRoomService) PurgeDeleted(before time.Time) (int, error) {
+	*s.purged = append(*s.purged, "room")
+	return 0, nil
+}
+
+type fakePurgeTechnicianService struct {
+	service.TechnicianService
+	purged *[]string
+}
+
+func (s fakePurgeTechnicianService) PurgeDeleted(before time.Time) (int, error) {
+	*s.purged = append(*s.purged, "technician")
+	return 0, nil
+}
+
+type fakePurgeAssetPlacementService struct {
+	service.AssetPlacementService
+	purged *[]string
+}
+
+func (s fakePurgeAssetPlacementService) PurgeDeleted(before time.Time) (int, error) {
+	*s.purged = append(*s.purged, "asset placement")
+	return 0, nil
+}
+
+type fakePurgeAssetMaintenanceService struct {
+	service.AssetMaintenanceService
+	purged *[]string
+}
+
+func (s fakePurgeAssetMaintenanceService) PurgeDeleted(before time.Time) (int, error) {
+	*s.purged = append(*s.purged, "asset maintenance")
+	return 0, nil
+}
+
+type fakePurgeAssetFindingService struct {
+	service.AssetFindingService
+	purged *[]string
+}
+
+func (s fakePurgeAssetFindingService) PurgeDeleted(before time.Time) (int, error) {
+	*s.purged = append(*s.purged, "asset finding")
+	return 0, nil
+}
+
+func TestSchedulerPurgeSoftDeleted(t *testing.T) {
+	// Test Data
+	var purged []string
+	purgeScheduler := scheduler.NewPurgeScheduler(
+		fakePurgeAssetService{purged: &purged},
+		fakePurgeRoomService{purged: &purged},
+		fakePurgeTechnicianService{purged: &purged},
+		fakePurgeAssetPlacementService{purged: &purged},
+		fakePurgeAssetMaintenanceService{purged: &purged},
+		fakePurgeAssetFindingService{purged: &purged},
+	)
+	c := cron.New()
+
+	// Exec
+	routes.Scheduler(c, &scheduler.AssetMaintenanceScheduler{}, &scheduler.AssetLoanScheduler{}, &scheduler.WarrantyScheduler{}, purgeScheduler)
+
+	// Test 1 : Every Job Is Scheduled
+	entries := c.Entries()
+	assert.Len(t, entries, 5)
+
+	// Test 2 : Purge Is Scheduled Every Day At 00:30 AM
+	midnight := time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)
+	var purgeEntry *cron.Entry
+	for _, entry := range entries {
+		if entry.Schedule.Next(midnight).Equal(midnight.Add(30 * time.Minute)) {
+			purgeEntry = entry
+		}
+	}
+	assert.NotNil(t, purgeEntry)
+	assert.Equal(t, midnight.AddDate(0, 0, 1).Add(30*time.Minute), purgeEntry.Schedule.Next(midnight.Add(30*time.Minute)))
+
+	// Test 3 : Scheduled Job Purge Every Table, Child Table First
+	purgeEntry.Job.Run()
+	assert.Equal(t, []string{"asset finding", "asset maintenance", "asset placement", "technician", "room", "asset"}, purged)
+}
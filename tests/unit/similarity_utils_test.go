@@ -0,0 +1,34 @@
+package unit
+
+import (
+	"pelita/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSimilarityText(t *testing.T) {
+	// Test 1: Should ignore case, punctuation, and extra spacing
+	assert.Equal(t, "thinkpad x1 carbon", utils.NormalizeSimilarityText("  ThinkPad-X1   Carbon! "))
+	assert.Equal(t, "", utils.NormalizeSimilarityText(" - "))
+}
+
+func TestLevenshtein(t *testing.T) {
+	// Test 1: Should count insertion, deletion, and substitution
+	assert.Equal(t, 0, utils.Levenshtein("laptop", "laptop"))
+	assert.Equal(t, 3, utils.Levenshtein("kitten", "sitting"))
+	assert.Equal(t, 6, utils.Levenshtein("", "laptop"))
+	assert.Equal(t, 1, utils.Levenshtein("kursi", "kurs"))
+
+	// Test 2: Should count a multibyte character once
+	assert.Equal(t, 1, utils.Levenshtein("café", "cafe"))
+}
+
+func TestSimilarity(t *testing.T) {
+	// Test 1: Should be identical after normalization
+	assert.Equal(t, 1.0, utils.Similarity("ThinkPad X1", "thinkpad-x1"))
+
+	// Test 2: Should be based on the longest text
+	assert.InDelta(t, 0.9, utils.Similarity("ThinkPad X1", "ThinkPad X2"), 0.01)
+	assert.Less(t, utils.Similarity("Office Chair", "Projector"), 0.5)
+}
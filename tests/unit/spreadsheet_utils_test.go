@@ -0,0 +1,63 @@
+package unit
+
+import (
+	"bytes"
+	"pelita/utils"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadSpreadsheetCSV(t *testing.T) {
+	// Test 1: Should trim every cell, keep inner empty row and skip trailing empty row
+	input := "asset_name,asset_category\n\n Laptop , electronics\n,\n"
+	rows, err := utils.ReadSpreadsheet(strings.NewReader(input), "csv")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"asset_name", "asset_category"}, {}, {"Laptop", "electronics"}}, rows)
+
+	// Test 2: Should reject unknown file type
+	_, err = utils.ReadSpreadsheet(strings.NewReader(input), "pdf")
+	assert.Error(t, err)
+}
+
+func TestWriteAndReadSpreadsheet(t *testing.T) {
+	rows := [][]string{
+		{"asset_name", "asset_price"},
+		{"Laptop", "12500000.00"},
+		{"Chair, Ergonomic", ""},
+	}
+
+	for _, fileExt := range []string{"csv", "xlsx"} {
+		// Test 1: Written file should be read back as the same row
+		var buf bytes.Buffer
+		err := utils.WriteSpreadsheet(&buf, fileExt, rows)
+		assert.NoError(t, err, fileExt)
+
+		result, err := utils.ReadSpreadsheet(&buf, fileExt)
+		assert.NoError(t, err, fileExt)
+		assert.Len(t, result, 3, fileExt)
+		assert.Equal(t, rows[1], result[1], fileExt)
+		assert.Equal(t, "Chair, Ergonomic", result[2][0], fileExt)
+	}
+}
+
+func TestWriteSpreadsheetCSVEscapeFormula(t *testing.T) {
+	rows := [][]string{
+		{"asset_name", "asset_desc"},
+		{"=HYPERLINK(\"http://evil\")", "@SUM(A1)"},
+		{"+62 812", "-1"},
+	}
+
+	// Test 1: Cell starting with a formula character should be written behind a quote
+	var buf bytes.Buffer
+	err := utils.WriteSpreadsheet(&buf, "csv", rows)
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), `"'=HYPERLINK(""http://evil"")",'@SUM(A1)`)
+	assert.Contains(t, buf.String(), "'+62 812,'-1")
+
+	// Test 2: Escaped cell should be read back as the original value
+	result, err := utils.ReadSpreadsheet(&buf, "csv")
+	assert.NoError(t, err)
+	assert.Equal(t, rows, result)
+}
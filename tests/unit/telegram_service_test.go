@@ -0,0 +1,230 @@
+package unit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"pelita/controller"
+	"pelita/entity"
+	"pelita/service"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTelegramBotToken = "123456:TEST"
+
+// Fake Telegram Bot API, Record Every Message Sent By The Bot
+type fakeTelegramAPI struct {
+	mutex    sync.Mutex
+	messages []string
+}
+
+func (api *fakeTelegramAPI) sentMessages() []string {
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+
+	return append([]string{}, api.messages...)
+}
+
+func newFakeTelegramAPI(t *testing.T) *fakeTelegramAPI {
+	api := &fakeTelegramAPI{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case fmt.Sprintf("/bot%s/getMe", testTelegramBotToken):
+			fmt.Fprint(w, `{"ok":true,"result":{"id":1,"first_name":"Pelita","username":"pelita_bot"}}`)
+		case fmt.Sprintf("/bot%s/sendMessage", testTelegramBotToken):
+			api.mutex.Lock()
+			api.messages = append(api.messages, r.FormValue("text"))
+			api.mutex.Unlock()
+			fmt.Fprintf(w, `{"ok":true,"result":{"message_id":1,"date":0,"chat":{"id":%s,"type":"private"}}}`, r.FormValue("chat_id"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"ok":false,"error_code":404,"description":"Not Found"}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	// Bot Request Is Rewritten To The Fake Telegram API
+	t.Setenv("TELEGRAM_API_URL", server.URL)
+	t.Setenv("TELEGRAM_BOT_TOKEN", testTelegramBotToken)
+
+	return api
+}
+
+func newTelegramUpdate(telegramUserId int, text string) tgbotapi.Update {
+	return tgbotapi.Update{
+		Message: &tgbotapi.Message{
+			From: &tgbotapi.User{ID: telegramUserId},
+			Chat: &tgbotapi.Chat{ID: int64(telegramUserId), Type: "private"},
+			Text: text,
+		},
+	}
+}
+
+func TestTelegramServiceLinkHandshake(t *testing.T) {
+	// Test Data
+	user := &entity.User{ID: uuid.New(), Username: "tester", Email: "telegram@example.com"}
+	telegramLinkRepo := newFakeTelegramLinkRepository()
+	historyRepo := &fakeHistoryRepository{}
+	api := newFakeTelegramAPI(t)
+	telegramService := service.NewTelegramService(&fakeUserRepository{users: map[string]*entity.User{user.Email: user}}, telegramLinkRepo, historyRepo)
+
+	// Test 1 : Link Code Is Generated For The Account
+	linkCode, err := telegramService.CreateLinkCode(user.ID, "guest")
+	assert.NoError(t, err)
+	assert.Equal(t, "/link "+linkCode.Code, linkCode.Command)
+
+	// Test 2 : Sending The Command To The Bot Link And Verify The Telegram Account
+	err = telegramService.HandleUpdate(newTelegramUpdate(987654, linkCode.Command))
+	assert.NoError(t, err)
+	assert.NotNil(t, user.TelegramUserId)
+	assert.Equal(t, "987654", *user.TelegramUserId)
+	assert.True(t, user.TelegramIsValid)
+	assert.Len(t, historyRepo.histories, 1)
+	assert.Equal(t, "link_telegram", historyRepo.histories[0].TypeHistory)
+
+	// Test 3 : Link Code Can Only Be Used Once
+	err = telegramService.HandleUpdate(newTelegramUpdate(111111, linkCode.Command))
+	assert.NoError(t, err)
+	assert.Equal(t, "987654", *user.TelegramUserId)
+
+	messages := api.sentMessages()
+	assert.Len(t, messages, 2)
+	assert.Contains(t, messages[0], "has been linked")
+	assert.Contains(t, messages[1], "invalid or has expired")
+
+	// Test 4 : Unknown Account Can't Generate Link Code
+	_, err = telegramService.CreateLinkCode(uuid.New(), "guest")
+	assert.EqualError(t, err, "account not found")
+}
+
+func TestTelegramServiceHandleUpdateParseLinkCode(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		wantLinked bool
+		wantReply  string
+	}{
+		{name: "link command", text: "/link ABCD1234", wantLinked: true, wantReply: "has been linked"},
+		{name: "start deep link", text: "/start ABCD1234", wantLinked: true, wantReply: "has been linked"},
+		{name: "code only", text: "ABCD1234", wantLinked: true, wantReply: "has been linked"},
+		{name: "lowercase code", text: "/link abcd1234", wantLinked: true, wantReply: "has been linked"},
+		{name: "extra space", text: "  /link   ABCD1234  ", wantLinked: true, wantReply: "has been linked"},
+		{name: "command without code", text: "/link", wantReply: "Send `/link <code>`"},
+		{name: "too many word", text: "/link ABCD 1234", wantReply: "Send `/link <code>`"},
+		{name: "empty text", text: "", wantReply: "Send `/link <code>`"},
+		{name: "unknown code", text: "/link FFFF0000", wantReply: "invalid or has expired"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &entity.User{ID: uuid.New(), Username: "tester", Email: "telegram@example.com"}
+			telegramLinkRepo := newFakeTelegramLinkRepository()
+			historyRepo := &fakeHistoryRepository{}
+			api := newFakeTelegramAPI(t)
+			telegramService := service.NewTelegramService(&fakeUserRepository{users: map[string]*entity.User{user.Email: user}}, telegramLinkRepo, historyRepo)
+			telegramLinkRepo.links["ABCD1234"] = entity.TelegramLink{AccountId: user.ID, Role: "guest"}
+
+			err := telegramService.HandleUpdate(newTelegramUpdate(987654, tt.text))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantLinked, user.TelegramIsValid)
+
+			messages := api.sentMessages()
+			assert.Len(t, messages, 1)
+			assert.Contains(t, messages[0], tt.wantReply)
+		})
+	}
+}
+
+func TestTelegramServiceHandleUpdateIgnoreNonMessage(t *testing.T) {
+	// Test Data
+	user := &entity.User{ID: uuid.New(), Username: "tester", Email: "telegram@example.com"}
+	telegramLinkRepo := newFakeTelegramLinkRepository()
+	historyRepo := &fakeHistoryRepository{}
+	api := newFakeTelegramAPI(t)
+	telegramService := service.NewTelegramService(&fakeUserRepository{users: map[string]*entity.User{user.Email: user}}, telegramLinkRepo, historyRepo)
+
+	// Test : Update Without Message (Such As Edited Message Or Callback) Is Ignored Without Reply
+	err := telegramService.HandleUpdate(tgbotapi.Update{UpdateID: 1})
+	assert.NoError(t, err)
+	assert.Empty(t, api.sentMessages())
+}
+
+func TestTelegramServiceHandleUpdateIgnoreGroupChat(t *testing.T) {
+	// Test Data
+	user := &entity.User{ID: uuid.New(), Username: "tester", Email: "telegram@example.com"}
+	telegramLinkRepo := newFakeTelegramLinkRepository()
+	historyRepo := &fakeHistoryRepository{}
+	api := newFakeTelegramAPI(t)
+	telegramService := service.NewTelegramService(&fakeUserRepository{users: map[string]*entity.User{user.Email: user}}, telegramLinkRepo, historyRepo)
+	telegramLinkRepo.links["ABCD1234"] = entity.TelegramLink{AccountId: user.ID, Role: "guest"}
+	update := newTelegramUpdate(987654, "/link ABCD1234")
+	update.Message.Chat = &tgbotapi.Chat{ID: -100123, Type: "group"}
+
+	// Exec
+	err := telegramService.HandleUpdate(update)
+
+	// Test 1 : Link Code Posted In A Group Is Neither Consumed Nor Replied To
+	assert.NoError(t, err)
+	assert.False(t, user.TelegramIsValid)
+	assert.Contains(t, telegramLinkRepo.links, "ABCD1234")
+	assert.Empty(t, api.sentMessages())
+}
+
+func TestTelegramControllerWebhook(t *testing.T) {
+	// Test Data
+	gin.SetMode(gin.TestMode)
+	user := &entity.User{ID: uuid.New(), Username: "tester", Email: "telegram@example.com"}
+	telegramLinkRepo := newFakeTelegramLinkRepository()
+	historyRepo := &fakeHistoryRepository{}
+	api := newFakeTelegramAPI(t)
+	telegramService := service.NewTelegramService(&fakeUserRepository{users: map[string]*entity.User{user.Email: user}}, telegramLinkRepo, historyRepo)
+	telegramLinkRepo.links["ABCD1234"] = entity.TelegramLink{AccountId: user.ID, Role: "guest"}
+
+	router := gin.New()
+	router.POST("/api/v1/telegram/webhook", controller.NewTelegramController(telegramService).Webhook)
+
+	update, err := json.Marshal(newTelegramUpdate(987654, "/link ABCD1234"))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		secret     string
+		header     string
+		body       []byte
+		wantStatus int
+	}{
+		{name: "secret is not configured", secret: "", header: "", body: update, wantStatus: http.StatusUnauthorized},
+		{name: "wrong secret token", secret: "s3cret", header: "wrong", body: update, wantStatus: http.StatusUnauthorized},
+		{name: "invalid update body", secret: "s3cret", header: "s3cret", body: []byte("{"), wantStatus: http.StatusBadRequest},
+		{name: "valid update", secret: "s3cret", header: "s3cret", body: update, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TELEGRAM_WEBHOOK_SECRET", tt.secret)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/telegram/webhook", bytes.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Telegram-Bot-Api-Secret-Token", tt.header)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+
+	// Test : Only The Valid Update Reach The Service And Link The Account
+	assert.True(t, user.TelegramIsValid)
+	messages := api.sentMessages()
+	assert.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "has been linked")
+}
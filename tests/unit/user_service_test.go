@@ -0,0 +1,60 @@
+package unit
+
+import (
+	"pelita/entity"
+	"pelita/service"
+	"regexp"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserServiceUpdateMyProfileEmailChange(t *testing.T) {
+	telegramUserId := "123456"
+	user := &entity.User{ID: uuid.New(), Username: "tester", Email: "old@example.com", TelegramUserId: &telegramUserId, TelegramIsValid: true}
+	other := &entity.User{ID: uuid.New(), Username: "other", Email: "taken@example.com"}
+	mailer := &fakeMailer{}
+	historyRepo := &fakeHistoryRepository{}
+	userService := service.NewUserService(
+		&fakeUserRepository{users: map[string]*entity.User{user.Email: user, other.Email: other}},
+		newFakeSessionRepository(),
+		newFakeEmailChangeRepository(),
+		historyRepo,
+		mailer,
+		nil,
+	)
+
+	// Test 1: Email Already Used By Another Account Is Rejected Without Sending Any Token
+	_, err := userService.UpdateMyProfile(user.ID, "guest", &entity.RequestPutUpdateProfile{Username: "tester", Email: other.Email, TelegramUserId: &telegramUserId})
+	assert.EqualError(t, err, "email already been used")
+	assert.Empty(t, mailer.emails)
+
+	// Test 2: Confirmation Token Is Sent To The New Email, Not To The Current Contact
+	result, err := userService.UpdateMyProfile(user.ID, "guest", &entity.RequestPutUpdateProfile{Username: "tester", Email: "new@example.com", TelegramUserId: &telegramUserId})
+	assert.NoError(t, err)
+	assert.True(t, result.EmailConfirmationRequired)
+	assert.Equal(t, "new@example.com", *result.PendingEmail)
+	assert.Len(t, mailer.emails, 1)
+	assert.Equal(t, "new@example.com", mailer.emails[0].to)
+
+	// Test 3: Email Is Not Swapped Before The Token Is Confirmed
+	assert.Equal(t, "old@example.com", user.Email)
+
+	// Test 4: Wrong Token Can't Confirm The Email
+	err = userService.ConfirmEmail("wrong-token")
+	assert.EqualError(t, err, "invalid or expired confirmation token")
+	assert.Equal(t, "old@example.com", user.Email)
+
+	// Test 5: Token From The New Email Swap The Email
+	token := regexp.MustCompile(`: (\S+)`).FindStringSubmatch(mailer.emails[0].body)
+	assert.Len(t, token, 2)
+	err = userService.ConfirmEmail(token[1])
+	assert.NoError(t, err)
+	assert.Equal(t, "new@example.com", user.Email)
+	assert.Len(t, historyRepo.histories, 1)
+
+	// Test 6: Token Can Only Be Used Once
+	err = userService.ConfirmEmail(token[1])
+	assert.EqualError(t, err, "invalid or expired confirmation token")
+}
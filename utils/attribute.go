@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Attribute Value Is Stored As Text, So Every Type Is Normalized Into A Single Comparable Form
+func NormalizeAttributeValue(fieldType, value string, options []string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch fieldType {
+	case "string":
+		if len(value) > 255 {
+			return "", errors.New("must be at most 255 characters")
+		}
+		return value, nil
+	case "number":
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", errors.New("must be a number")
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	case "date":
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return "", errors.New("must be in YYYY-MM-DD format")
+		}
+		return date.Format("2006-01-02"), nil
+	case "boolean":
+		boolean, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", errors.New("must be true or false")
+		}
+		return strconv.FormatBool(boolean), nil
+	case "enum":
+		// Option Is Matched Regardless Of Its Case, But Stored As Defined
+		for _, option := range options {
+			if strings.EqualFold(option, value) {
+				return option, nil
+			}
+		}
+		return "", fmt.Errorf("must be one of %s", strings.Join(options, ", "))
+	default:
+		return "", fmt.Errorf("has unknown type %s", fieldType)
+	}
+}
@@ -34,3 +34,31 @@ func GetCurrentRole(c *gin.Context) (string, error) {
 
 	return role, nil
 }
+
+func GetCurrentScopes(c *gin.Context) ([]string, error) {
+	scopesVal, exists := c.Get("scopes")
+	if !exists {
+		return nil, errors.New("scopes not found in context")
+	}
+
+	scopes, ok := scopesVal.([]string)
+	if !ok {
+		return nil, errors.New("invalid scopes format in context")
+	}
+
+	return scopes, nil
+}
+
+func GetCurrentSessionID(c *gin.Context) (uuid.UUID, error) {
+	sessionIDVal, exists := c.Get("sessionID")
+	if !exists {
+		return uuid.UUID{}, errors.New("session id not found in context")
+	}
+
+	sessionID, err := uuid.Parse(sessionIDVal.(string))
+	if err != nil {
+		return uuid.UUID{}, errors.New("session id is not a valid UUID")
+	}
+
+	return sessionID, nil
+}
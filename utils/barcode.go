@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/png"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+)
+
+func GenerateQRCode(content string, size int) ([]byte, error) {
+	code, err := qr.Encode(content, qr.M, qr.Auto)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeBarcodePNG(code, size, size)
+}
+
+func GenerateCode128(content string, width, height int) ([]byte, error) {
+	code, err := code128.Encode(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeBarcodePNG(code, width, height)
+}
+
+func encodeBarcodePNG(code barcode.Barcode, width, height int) ([]byte, error) {
+	// Barcode Can't Be Scaled Below Its Module Count
+	if bounds := code.Bounds(); width < bounds.Dx() {
+		width = bounds.Dx()
+	}
+
+	scaled, err := barcode.Scale(code, width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Barcode Use 16-Bit Color Model Which Is Not Supported By PDF, Convert To 8-Bit Grayscale
+	gray := image.NewGray(scaled.Bounds())
+	draw.Draw(gray, gray.Bounds(), scaled, scaled.Bounds().Min, draw.Src)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, gray); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,22 @@
+package utils
+
+import "strings"
+
+// Category Name Is Compared Regardless Of Its Case And Spacing
+func NormalizeCategoryKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// Singular Form A Plural Category Key Might Come From, Such As "laptop" For "laptops"
+func SingularCategoryKey(key string) []string {
+	switch {
+	case strings.HasSuffix(key, "ies"):
+		return []string{strings.TrimSuffix(key, "ies") + "y"}
+	case strings.HasSuffix(key, "es"):
+		return []string{strings.TrimSuffix(key, "es"), strings.TrimSuffix(key, "s")}
+	case strings.HasSuffix(key, "s") && !strings.HasSuffix(key, "ss"):
+		return []string{strings.TrimSuffix(key, "s")}
+	default:
+		return nil
+	}
+}
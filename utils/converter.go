@@ -41,3 +41,10 @@ func Capitalize(s string) string {
 	runes[0] = unicode.ToUpper(runes[0])
 	return string(runes)
 }
+
+func OptionalCell(val *string) string {
+	if val != nil {
+		return *val
+	}
+	return ""
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"math"
+	"pelita/entity"
+	"time"
+)
+
+// Book Value Of An Asset At The Given Date. Depreciation Is Counted Per Elapsed Month, And The Value Never Drop Below Its Salvage Value
+func CalculateBookValue(price, salvageValue entity.Money, purchaseDate time.Time, usefulLife int, method string, at time.Time) entity.Money {
+	if usefulLife <= 0 || price <= salvageValue {
+		return price
+	}
+
+	// Elapsed Month Since Purchase
+	elapsed := (at.Year()-purchaseDate.Year())*12 + int(at.Month()) - int(purchaseDate.Month())
+	if at.Day() < purchaseDate.Day() {
+		elapsed--
+	}
+	if elapsed <= 0 {
+		return price
+	}
+	lifeMonth := usefulLife * 12
+	if elapsed >= lifeMonth {
+		return salvageValue
+	}
+
+	var bookValue entity.Money
+	switch method {
+	case "declining-balance":
+		// Double Declining Balance, Each Year Depreciate Twice The Straight Line Rate From The Remaining Value
+		rate := 2 / float64(usefulLife)
+		value := price.Float64()
+		for year := 0; year < elapsed/12; year++ {
+			value -= value * rate
+		}
+		value -= value * rate * float64(elapsed%12) / 12
+		bookValue = entity.MoneyFromFloat(value)
+	default:
+		// Minor Unit Is Divided Once, So Only The Last Minor Unit Is Rounded
+		bookValue = price - entity.Money(math.Round(float64(price-salvageValue)*float64(elapsed)/float64(lifeMonth)))
+	}
+
+	if bookValue < salvageValue {
+		return salvageValue
+	}
+
+	return bookValue
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Query Param That Is Not A Filter Field
+var filterReservedParams = []string{"page", "limit", "search", "sort_by", "sort_dir"}
+
+type Filter struct {
+	Search  string
+	Params  map[string]string
+	SortBy  string
+	SortDir string
+}
+
+func GetFilter(c *gin.Context) Filter {
+	filter := Filter{
+		Search:  strings.TrimSpace(c.Query("search")),
+		Params:  map[string]string{},
+		SortBy:  strings.TrimSpace(c.Query("sort_by")),
+		SortDir: strings.ToLower(strings.TrimSpace(c.DefaultQuery("sort_dir", "desc"))),
+	}
+
+	// Every Other Query Param Is Passed Along, Unknown Param Is Ignored By The Repository
+	for key, values := range c.Request.URL.Query() {
+		if Contains(filterReservedParams, key) || len(values) == 0 {
+			continue
+		}
+		if value := strings.TrimSpace(values[0]); value != "" {
+			filter.Params[key] = value
+		}
+	}
+
+	return filter
+}
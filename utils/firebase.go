@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -16,17 +17,26 @@ import (
 )
 
 func UploadFile(user_id uuid.UUID, ctx string, file *multipart.FileHeader, fileExt string) (string, error) {
-	firebase, err := config.InitFirebase()
-	if err != nil {
-		return "", fmt.Errorf("failed to initialize Firebase: %w", err)
-	}
-	bucket := firebase.StorageClient.Bucket(os.Getenv("FIREBASE_BUCKET_NAME"))
 	fileReader, err := file.Open()
 	if err != nil {
 		return "", fmt.Errorf("failed to open file: %w", err)
 	}
 	defer fileReader.Close()
 
+	return uploadObject(user_id, ctx, fileReader, fileExt)
+}
+
+func UploadBytes(user_id uuid.UUID, ctx string, data []byte, fileExt string) (string, error) {
+	return uploadObject(user_id, ctx, bytes.NewReader(data), fileExt)
+}
+
+func uploadObject(user_id uuid.UUID, ctx string, fileReader io.Reader, fileExt string) (string, error) {
+	firebase, err := config.InitFirebase()
+	if err != nil {
+		return "", fmt.Errorf("failed to initialize Firebase: %w", err)
+	}
+	bucket := firebase.StorageClient.Bucket(os.Getenv("FIREBASE_BUCKET_NAME"))
+
 	id := uuid.New().String()
 	objectName := fmt.Sprintf("%s/%s/%s", ctx, user_id, id+"."+fileExt)
 
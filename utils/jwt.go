@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"pelita/config"
 	"pelita/entity"
 	"time"
@@ -15,23 +18,69 @@ func GenerateToken(userId uuid.UUID, role string) (string, error) {
 		"user_id": userId.String(),
 		"role":    role,
 		"exp":     time.Now().Add(config.GetJWTExpirationDuration()).Unix(),
-		"iat":     time.Now().Unix(),
+		"iat":     issuedAt(),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
 	return token.SignedString(config.GetJWTSecret())
 }
 
+func GenerateSessionToken(userId uuid.UUID, role string, sessionId uuid.UUID) (string, error) {
+	claims := jwt.MapClaims{
+		"user_id":    userId.String(),
+		"role":       role,
+		"session_id": sessionId.String(),
+		"exp":        time.Now().Add(config.GetJWTExpirationDuration()).Unix(),
+		"iat":        issuedAt(),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	return token.SignedString(config.GetJWTSecret())
+}
+
+// Issued At In Second With Millisecond Precision, So A Token Issued Right Before A Revocation Within The Same Second Is Still Revoked
+func issuedAt() float64 {
+	return float64(time.Now().UnixMilli()) / 1000
+}
+
+func GenerateRandomToken(length int) (string, error) {
+	bytes := make([]byte, length)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
+
+func GenerateRefreshToken() (string, error) {
+	return GenerateRandomToken(32)
+}
+
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(hash[:])
+}
+
 func HashPassword(u *entity.User, password string) error {
-	hashedPass, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPass, err := GeneratePasswordHash(password)
 	if err != nil {
 		return err
 	}
-	u.Password = string(hashedPass)
+	u.Password = hashedPass
 
 	return nil
 }
 
+func GeneratePasswordHash(password string) (string, error) {
+	hashedPass, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hashedPass), nil
+}
+
 func CheckPassword(account entity.Account, password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(account.GetPassword()), []byte(password))
 }
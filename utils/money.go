@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"errors"
+	"pelita/entity"
+	"regexp"
+	"strings"
+)
+
+var (
+	moneyPrefixPattern = regexp.MustCompile(`(?i)^(rp\.?|idr|usd|eur|sgd|jpy|\$|€|¥)\s*`)
+	moneySuffixPattern = regexp.MustCompile(`(?i)\s*(idr|usd|eur|sgd|jpy)$`)
+	moneyNumberPattern = regexp.MustCompile(`^-?[0-9][0-9.,]*$`)
+	moneyGroupPattern  = regexp.MustCompile(`^[0-9]{1,3}(\.[0-9]{3})*$`)
+)
+
+// Money Written By Hand Is Normalized, Such As "Rp 10.000,-", "1.000.000", "10,000.50", Or "10.000,50"
+func ParseMoney(value string) (entity.Money, error) {
+	// Currency Symbol And Trailing Dash Are Dropped
+	value = strings.TrimSpace(value)
+	value = strings.TrimSuffix(strings.TrimSuffix(value, ",-"), ".-")
+	value = moneySuffixPattern.ReplaceAllString(moneyPrefixPattern.ReplaceAllString(value, ""), "")
+	if !moneyNumberPattern.MatchString(value) {
+		return 0, errors.New("money must be a number")
+	}
+	sign := ""
+	if strings.HasPrefix(value, "-") {
+		sign = "-"
+		value = value[1:]
+	}
+
+	// Last Separator Is The Decimal Separator, Unless It Only Appear Once Followed By 3 Digit Or Appear More Than Once
+	integer, fraction := value, ""
+	lastDot, lastComma := strings.LastIndex(value, "."), strings.LastIndex(value, ",")
+	last := lastDot
+	if lastComma > lastDot {
+		last = lastComma
+	}
+	if last >= 0 {
+		separator := value[last : last+1]
+		isDecimal := lastDot >= 0 && lastComma >= 0
+		if !isDecimal && strings.Count(value, separator) == 1 && len(value)-last-1 != 3 {
+			isDecimal = true
+		}
+		if isDecimal {
+			integer, fraction = value[:last], value[last+1:]
+		}
+	}
+
+	// Thousand Separator Must Group Every 3 Digit
+	integer = strings.ReplaceAll(integer, ",", ".")
+	if strings.Contains(integer, ".") {
+		if !moneyGroupPattern.MatchString(integer) {
+			return 0, errors.New("money thousand separator is not valid")
+		}
+		integer = strings.ReplaceAll(integer, ".", "")
+	}
+	if strings.ContainsAny(fraction, ".,") {
+		return 0, errors.New("money decimal separator is not valid")
+	}
+
+	canonical := sign + integer
+	if fraction != "" {
+		canonical += "." + fraction
+	}
+
+	return entity.ParseMoney(canonical)
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"pelita/entity"
+	"strconv"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// Notifier Interface
+type Notifier interface {
+	Send(recipient entity.AccountContact, message string) error
+}
+
+// Telegram Notifier
+type TelegramNotifier struct {
+	BotToken string
+}
+
+func (n *TelegramNotifier) Send(recipient entity.AccountContact, message string) error {
+	if recipient.TelegramUserId == nil || !recipient.TelegramIsValid {
+		return errors.New("account does not have a valid telegram account")
+	}
+
+	telegramID, err := strconv.ParseInt(*recipient.TelegramUserId, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid telegram ID: %w", err)
+	}
+
+	bot, err := NewTelegramBot(n.BotToken)
+	if err != nil {
+		return fmt.Errorf("failed to connect to telegram bot: %w", err)
+	}
+
+	msg := tgbotapi.NewMessage(telegramID, message)
+	msg.ParseMode = "Markdown"
+
+	if _, err := bot.Send(msg); err != nil {
+		return fmt.Errorf("failed to send telegram message: %w", err)
+	}
+
+	return nil
+}
+
+// Log Notifier (Local Sink)
+type LogNotifier struct{}
+
+func (n *LogNotifier) Send(recipient entity.AccountContact, message string) error {
+	log.Printf("Notification to %s (%s): %s\n", recipient.Username, recipient.Email, message)
+
+	return nil
+}
+
+func NewNotifier() Notifier {
+	switch os.Getenv("NOTIFIER_DRIVER") {
+	case "telegram":
+		return &TelegramNotifier{BotToken: os.Getenv("TELEGRAM_BOT_TOKEN")}
+	default:
+		return &LogNotifier{}
+	}
+}
@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"pelita/entity"
 
 	"github.com/jung-kurt/gofpdf"
@@ -49,3 +51,63 @@ func GeneratePDFAssetFindingReport(c []entity.AssetFindingReport, filename strin
 
 	return pdf.OutputFileAndClose(filename)
 }
+
+func GeneratePDFAssetTagLabel(labels []entity.AssetTagLabel, w io.Writer) error {
+	const (
+		labelCol    = 3
+		labelRow    = 8
+		labelWidth  = 63.0
+		labelHeight = 33.0
+		marginLeft  = 10.5
+		marginTop   = 16.5
+	)
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("PELITA - Asset Tag", false)
+	pdf.SetAutoPageBreak(false, 0)
+
+	for i, label := range labels {
+		// Set Page
+		position := i % (labelCol * labelRow)
+		if position == 0 {
+			pdf.AddPage()
+		}
+		x := marginLeft + float64(position%labelCol)*labelWidth
+		y := marginTop + float64(position/labelCol)*labelHeight
+
+		// Set Code Image
+		qrCode, err := GenerateQRCode(label.Tag, 256)
+		if err != nil {
+			return err
+		}
+		barcode, err := GenerateCode128(label.Tag, 300, 60)
+		if err != nil {
+			return err
+		}
+		imageOptions := gofpdf.ImageOptions{ImageType: "PNG"}
+		pdf.RegisterImageOptionsReader("qr-"+label.Tag, imageOptions, bytes.NewReader(qrCode))
+		pdf.RegisterImageOptionsReader("barcode-"+label.Tag, imageOptions, bytes.NewReader(barcode))
+
+		// Set Label
+		pdf.SetDrawColor(200, 200, 200)
+		pdf.Rect(x, y, labelWidth, labelHeight, "D")
+		pdf.ImageOptions("qr-"+label.Tag, x+2, y+2, 24, 24, false, imageOptions, 0, "")
+		pdf.SetXY(x+27, y+3)
+		pdf.SetFont("Arial", "B", 9)
+		pdf.CellFormat(34, 5, label.Tag, "", 2, "L", false, 0, "")
+		pdf.SetFont("Arial", "", 7)
+		pdf.CellFormat(34, 4, label.AssetName, "", 2, "L", false, 0, "")
+		pdf.CellFormat(34, 4, label.AssetCategory, "", 2, "L", false, 0, "")
+		pdf.CellFormat(34, 4, fmt.Sprintf("Floor %s - %s", label.Floor, label.RoomName), "", 2, "L", false, 0, "")
+		pdf.ImageOptions("barcode-"+label.Tag, x+27, y+21, 34, 8, false, imageOptions, 0, "")
+		pdf.SetFont("Arial", "I", 6)
+		pdf.SetXY(x+2, y+27)
+		pdf.CellFormat(24, 4, "PELITA", "", 0, "C", false, 0, "")
+	}
+
+	if err := pdf.Error(); err != nil {
+		return err
+	}
+
+	return pdf.Output(w)
+}
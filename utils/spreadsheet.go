@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Read Every Row Of A CSV Or The First Sheet Of An XLSX, Empty Row Is Kept So The Index Still Match The Line Number
+func ReadSpreadsheet(r io.Reader, fileExt string) ([][]string, error) {
+	var rows [][]string
+	switch fileExt {
+	case "csv":
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			// CSV Reader Skip Blank Line, Put It Back As An Empty Row
+			line, _ := reader.FieldPos(0)
+			for len(rows) < line-1 {
+				rows = append(rows, []string{})
+			}
+			for i := range record {
+				record[i] = unescapeSpreadsheetCell(record[i])
+			}
+			rows = append(rows, record)
+		}
+	case "xlsx":
+		file, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		records, err := file.GetRows(file.GetSheetName(0))
+		if err != nil {
+			return nil, err
+		}
+		rows = records
+	default:
+		return nil, errors.New("file must be csv or xlsx")
+	}
+
+	// Trim Cell And Drop Trailing Empty Row
+	for _, row := range rows {
+		for i := range row {
+			row[i] = strings.TrimSpace(row[i])
+		}
+	}
+	for len(rows) > 0 && IsEmptySpreadsheetRow(rows[len(rows)-1]) {
+		rows = rows[:len(rows)-1]
+	}
+
+	return rows, nil
+}
+
+func IsEmptySpreadsheetRow(row []string) bool {
+	for _, cell := range row {
+		if cell != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Cell Starting With = + - @ Is Run As A Formula By Spreadsheet App, So It Is Written Behind A Quote
+func escapeSpreadsheetCell(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@", rune(cell[0])) {
+		return "'" + cell
+	}
+
+	return cell
+}
+
+func unescapeSpreadsheetCell(cell string) string {
+	if len(cell) > 1 && cell[0] == '\'' && strings.ContainsRune("=+-@", rune(cell[1])) {
+		return cell[1:]
+	}
+
+	return cell
+}
+
+func WriteSpreadsheet(w io.Writer, fileExt string, rows [][]string) error {
+	switch fileExt {
+	case "csv":
+		writer := csv.NewWriter(w)
+		for _, row := range rows {
+			escaped := make([]string, len(row))
+			for i := range row {
+				escaped[i] = escapeSpreadsheetCell(row[i])
+			}
+			if err := writer.Write(escaped); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+
+		return writer.Error()
+	case "xlsx":
+		file := excelize.NewFile()
+		defer file.Close()
+
+		sheet := file.GetSheetName(0)
+		for i, row := range rows {
+			cell, err := excelize.CoordinatesToCellName(1, i+1)
+			if err != nil {
+				return err
+			}
+
+			values := make([]interface{}, len(row))
+			for j := range row {
+				values[j] = row[j]
+			}
+			if err := file.SetSheetRow(sheet, cell, &values); err != nil {
+				return err
+			}
+		}
+
+		return file.Write(w)
+	default:
+		return errors.New("file must be csv or xlsx")
+	}
+}
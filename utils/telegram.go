@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// Rewrite Telegram Bot API Request To Another Host (Such As Fake Telegram API Server)
+type telegramTransport struct {
+	target *url.URL
+}
+
+func (t *telegramTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	req.Host = t.target.Host
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func NewTelegramBot(token string) (*tgbotapi.BotAPI, error) {
+	// Use Official Telegram API When TELEGRAM_API_URL Is Not Set
+	apiURL := os.Getenv("TELEGRAM_API_URL")
+	if apiURL == "" {
+		return tgbotapi.NewBotAPI(token)
+	}
+
+	target, err := url.Parse(apiURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return tgbotapi.NewBotAPIWithClient(token, &http.Client{Transport: &telegramTransport{target: target}})
+}